@@ -0,0 +1,358 @@
+// Package calver parses and orders calendar versions such as 2024.03.15 or
+// 24.3.2 against a user-supplied layout, following the conventions of
+// https://calver.org.
+package calver
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+// Segment identifies the meaning of one numeric part of a calendar
+// version.
+type Segment int
+
+const (
+	FullYear  Segment = iota // YYYY: 2006, 2016, 2106
+	ShortYear                // YY: 6, 16, 106 (years since 2000)
+	PadYear                  // 0Y: 06, 16, 106
+	Month                    // MM: 1 ... 12
+	PadMonth                 // 0M: 01 ... 12
+	Week                     // WW: 1 ... 53
+	PadWeek                  // 0W: 01 ... 53
+	Day                      // DD: 1 ... 31
+	PadDay                   // 0D: 01 ... 31
+	Major                    // MAJOR: any non-negative integer
+	Minor                    // MINOR
+	Micro                    // MICRO
+)
+
+var segmentTokens = [...]string{"YYYY", "YY", "0Y", "MM", "0M", "WW", "0W", "DD", "0D", "MAJOR", "MINOR", "MICRO"}
+
+func (s Segment) String() string { return segmentTokens[s] }
+
+// Layout is a compiled calendar version pattern such as "YYYY.0M.0D" or
+// "YY.MM.MICRO". Tokens are separated by literal characters.
+type Layout struct {
+	raw      string
+	segments []Segment
+	seps     []string // seps[i] precedes segments[i]; seps[0] is a literal prefix
+	suffix   string   // literal text after the last segment
+}
+
+// ParseLayout compiles a layout made of the tokens YYYY, YY, 0Y, MM, 0M,
+// WW, 0W, DD, 0D, MAJOR, MINOR and MICRO joined by literal separators.
+func ParseLayout(layout string) (*Layout, error) {
+	l := &Layout{raw: layout}
+	lit := ""
+	for rest := layout; rest != ""; {
+		seg, n := matchToken(rest)
+		if n == 0 {
+			lit += rest[:1]
+			rest = rest[1:]
+			continue
+		}
+		if len(l.segments) > 0 && lit == "" {
+			return nil, fmt.Errorf("calver: layout %q: tokens %s and %s need a separator", layout, l.segments[len(l.segments)-1], seg)
+		}
+		l.seps = append(l.seps, lit)
+		l.segments = append(l.segments, seg)
+		lit = ""
+		rest = rest[n:]
+	}
+	if len(l.segments) == 0 {
+		return nil, fmt.Errorf("calver: layout %q has no tokens", layout)
+	}
+	l.suffix = lit
+	return l, nil
+}
+
+// MustParseLayout is like ParseLayout but panics on error.
+func MustParseLayout(layout string) *Layout {
+	l, err := ParseLayout(layout)
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+func matchToken(s string) (Segment, int) {
+	best, bestLen := Segment(0), 0
+	for i, tok := range segmentTokens {
+		if strings.HasPrefix(s, tok) && len(tok) > bestLen {
+			best, bestLen = Segment(i), len(tok)
+		}
+	}
+	return best, bestLen
+}
+
+// String returns the layout as written.
+func (l *Layout) String() string { return l.raw }
+
+// Segments returns the segment kinds of l in order.
+func (l *Layout) Segments() []Segment {
+	return append([]Segment(nil), l.segments...)
+}
+
+// Version is a calendar version parsed against a Layout.
+type Version struct {
+	layout *Layout
+	values []uint64 // one per layout segment, as written (short years not expanded)
+	// Modifier is an optional trailing tag such as "dev" or "rc.1",
+	// separated from the version by "-" or ".". A version with a modifier
+	// precedes the same version without one.
+	Modifier string
+}
+
+// Parse parses s against l.
+func (l *Layout) Parse(s string) (Version, error) {
+	v := Version{layout: l, values: make([]uint64, len(l.segments))}
+	rest := s
+	for i, seg := range l.segments {
+		if !strings.HasPrefix(rest, l.seps[i]) {
+			return Version{}, fmt.Errorf("calver: %q does not match layout %q: expected %q", s, l.raw, l.seps[i])
+		}
+		rest = rest[len(l.seps[i]):]
+		n := 0
+		for n < len(rest) && rest[n] >= '0' && rest[n] <= '9' {
+			n++
+		}
+		digits := rest[:n]
+		if err := checkDigits(seg, digits); err != nil {
+			return Version{}, fmt.Errorf("calver: %q: %s: %v", s, seg, err)
+		}
+		val, err := strconv.ParseUint(digits, 10, 64)
+		if err != nil {
+			return Version{}, fmt.Errorf("calver: %q: %s %q out of range", s, seg, digits)
+		}
+		v.values[i] = val
+		rest = rest[n:]
+	}
+	if !strings.HasPrefix(rest, l.suffix) {
+		return Version{}, fmt.Errorf("calver: %q does not match layout %q: expected %q", s, l.raw, l.suffix)
+	}
+	rest = rest[len(l.suffix):]
+	if rest != "" {
+		if rest[0] != '-' && rest[0] != '.' || len(rest) == 1 {
+			return Version{}, fmt.Errorf("calver: %q does not match layout %q: unexpected %q", s, l.raw, rest)
+		}
+		v.Modifier = rest[1:]
+		if _, err := semver.Parse("0.0.0-" + v.Modifier); err != nil {
+			return Version{}, fmt.Errorf("calver: %q: invalid modifier %q", s, v.Modifier)
+		}
+	}
+	if err := v.validate(); err != nil {
+		return Version{}, fmt.Errorf("calver: %q: %v", s, err)
+	}
+	return v, nil
+}
+
+// checkDigits enforces the width rules of seg on its digits.
+func checkDigits(seg Segment, digits string) error {
+	padded := seg == PadYear || seg == PadMonth || seg == PadWeek || seg == PadDay
+	switch {
+	case digits == "":
+		return fmt.Errorf("missing digits")
+	case seg == FullYear && len(digits) != 4:
+		return fmt.Errorf("want four digits, got %q", digits)
+	case seg == PadYear && len(digits) < 2:
+		return fmt.Errorf("want at least two digits, got %q", digits)
+	case padded && seg != PadYear && len(digits) != 2:
+		return fmt.Errorf("want two digits, got %q", digits)
+	case padded && len(digits) > 2 && digits[0] == '0',
+		!padded && seg != FullYear && len(digits) > 1 && digits[0] == '0':
+		return fmt.Errorf("unexpected leading zero in %q", digits)
+	}
+	return nil
+}
+
+// validate checks calendar ranges, including that a given day exists in
+// its month.
+func (v Version) validate() error {
+	year, month, day := -1, -1, -1
+	for i, seg := range v.layout.segments {
+		val := v.values[i]
+		switch seg {
+		case FullYear, ShortYear, PadYear:
+			year = int(v.year(i))
+		case Month, PadMonth:
+			if val < 1 || val > 12 {
+				return fmt.Errorf("month %d out of range", val)
+			}
+			month = int(val)
+		case Week, PadWeek:
+			if val < 1 || val > 53 {
+				return fmt.Errorf("week %d out of range", val)
+			}
+		case Day, PadDay:
+			if val < 1 || val > 31 {
+				return fmt.Errorf("day %d out of range", val)
+			}
+			day = int(val)
+		}
+	}
+	if year >= 0 && month > 0 && day > 0 {
+		t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+		if t.Day() != day {
+			return fmt.Errorf("%04d-%02d-%02d is not a valid date", year, month, day)
+		}
+	}
+	return nil
+}
+
+// year returns the full year held by segment i.
+func (v Version) year(i int) uint64 {
+	if v.layout.segments[i] == FullYear {
+		return v.values[i]
+	}
+	return 2000 + v.values[i]
+}
+
+// Layout returns the layout v was parsed with.
+func (v Version) Layout() *Layout { return v.layout }
+
+// Value returns the numeric value of the first segment of the given kind
+// and whether the layout has such a segment. Short years are returned as
+// written; use Time for the calendar date.
+func (v Version) Value(seg Segment) (uint64, bool) {
+	for i, s := range v.layout.segments {
+		if s == seg {
+			return v.values[i], true
+		}
+	}
+	return 0, false
+}
+
+// Time returns the start of the calendar period named by v: the given
+// day, the Monday of the given ISO week, the first of the month or the
+// first of the year. ok is false if the layout has no year.
+func (v Version) Time() (t time.Time, ok bool) {
+	year, month, day, week := -1, 1, 1, 0
+	for i, seg := range v.layout.segments {
+		switch seg {
+		case FullYear, ShortYear, PadYear:
+			year = int(v.year(i))
+		case Month, PadMonth:
+			month = int(v.values[i])
+		case Day, PadDay:
+			day = int(v.values[i])
+		case Week, PadWeek:
+			week = int(v.values[i])
+		}
+	}
+	if year < 0 {
+		return time.Time{}, false
+	}
+	if week > 0 {
+		// ISO week 1 contains January 4th.
+		jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+		monday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+		return monday.AddDate(0, 0, 7*(week-1)), true
+	}
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC), true
+}
+
+// Compare returns -1, 0 or 1 as v orders before, equal to or after o.
+// Segments are compared in layout order, with short years expanded, and a
+// version with a modifier precedes the same version without one.
+// Versions with different layouts are compared segment by segment as far
+// as their layouts agree, which is only meaningful for related layouts.
+func (v Version) Compare(o Version) int {
+	n := min(len(v.values), len(o.values))
+	for i := 0; i < n; i++ {
+		a, b := v.sortValue(i), o.sortValue(i)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+	}
+	switch {
+	case len(v.values) < len(o.values):
+		return -1
+	case len(v.values) > len(o.values):
+		return 1
+	}
+	return semver.Version{Prerelease: v.Modifier}.Compare(semver.Version{Prerelease: o.Modifier})
+}
+
+func (v Version) sortValue(i int) uint64 {
+	switch v.layout.segments[i] {
+	case ShortYear, PadYear:
+		return v.year(i)
+	}
+	return v.values[i]
+}
+
+// String formats v according to its layout, joining any modifier with
+// "-".
+func (v Version) String() string {
+	if v.layout == nil {
+		return ""
+	}
+	var b strings.Builder
+	for i, seg := range v.layout.segments {
+		b.WriteString(v.layout.seps[i])
+		switch seg {
+		case PadYear, PadMonth, PadWeek, PadDay:
+			fmt.Fprintf(&b, "%02d", v.values[i])
+		default:
+			fmt.Fprintf(&b, "%d", v.values[i])
+		}
+	}
+	b.WriteString(v.layout.suffix)
+	if v.Modifier != "" {
+		b.WriteString("-" + v.Modifier)
+	}
+	return b.String()
+}
+
+// Sort orders vs in ascending order.
+func Sort(vs []Version) {
+	slices.SortStableFunc(vs, Version.Compare)
+}
+
+// ToSemver converts v to a semantic version by mapping the first three
+// segments, as written, to MAJOR.MINOR.PATCH (missing segments are zero)
+// and the modifier to the pre-release. Layouts with more than three
+// segments cannot be converted. A full year stays a four-digit major
+// version, so 2024.3.1 becomes 2024.3.1.
+func (v Version) ToSemver() (semver.Version, error) {
+	if len(v.values) > 3 {
+		return semver.Version{}, fmt.Errorf("calver: %s: layout %q has more than three segments", v, v.layout.raw)
+	}
+	var nums [3]uint64
+	copy(nums[:], v.values)
+	return semver.Version{Major: nums[0], Minor: nums[1], Patch: nums[2], Prerelease: v.Modifier}, nil
+}
+
+// FromSemver interprets sv as a calendar version in layout l, the inverse
+// of ToSemver. It fails if sv has components the layout cannot hold or
+// values the layout rejects, such as month 13.
+func (l *Layout) FromSemver(sv semver.Version) (Version, error) {
+	if len(l.segments) > 3 {
+		return Version{}, fmt.Errorf("calver: layout %q has more than three segments", l.raw)
+	}
+	nums := []uint64{sv.Major, sv.Minor, sv.Patch}
+	for i := len(l.segments); i < 3; i++ {
+		if nums[i] != 0 {
+			return Version{}, fmt.Errorf("calver: %s does not fit layout %q", sv, l.raw)
+		}
+	}
+	v := Version{layout: l, values: nums[:len(l.segments)], Modifier: sv.Prerelease}
+	for i, seg := range l.segments {
+		if seg == FullYear && (v.values[i] < 1000 || v.values[i] > 9999) {
+			return Version{}, fmt.Errorf("calver: %s: %d is not a four-digit year", sv, v.values[i])
+		}
+	}
+	if err := v.validate(); err != nil {
+		return Version{}, fmt.Errorf("calver: %s: %v", sv, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,209 @@
+package calver
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+func TestParseLayout(t *testing.T) {
+	tests := []struct {
+		layout string
+		want   []Segment
+	}{
+		{"YYYY.0M.0D", []Segment{FullYear, PadMonth, PadDay}},
+		{"YY.MM.MICRO", []Segment{ShortYear, Month, Micro}},
+		{"v0Y.0W", []Segment{PadYear, PadWeek}},
+		{"YYYY-MM-DD_MAJOR", []Segment{FullYear, Month, Day, Major}},
+		{"release-YYYY.MINOR.final", []Segment{FullYear, Minor}},
+	}
+	for _, tt := range tests {
+		l, err := ParseLayout(tt.layout)
+		if err != nil || !slices.Equal(l.Segments(), tt.want) || l.String() != tt.layout {
+			t.Errorf("ParseLayout(%q) = %v, %v; want %v", tt.layout, l.Segments(), err, tt.want)
+		}
+	}
+	for _, s := range []string{"", "release", "YYYYMM", "YY0M"} {
+		if l, err := ParseLayout(s); err == nil {
+			t.Errorf("ParseLayout(%q) = %v, want an error", s, l.Segments())
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		layout, in string
+		ok         bool
+	}{
+		{"YYYY.0M.0D", "2024.03.15", true},
+		{"YYYY.0M.0D", "2024.3.15", false},  // 0M needs two digits
+		{"YYYY.MM.DD", "2024.3.15", true},   // MM takes one
+		{"YYYY.MM.DD", "2024.03.15", false}, // and no leading zero
+		{"YYYY.MM.DD", "24.3.15", false},    // YYYY needs four digits
+		{"YY.MM.MICRO", "24.3.0", true},
+		{"YY.MM.MICRO", "24.3.01", false}, // MICRO has no leading zero
+		{"0Y.0M", "06.01", true},
+		{"0Y.0M", "6.01", false},            // 0Y needs two digits
+		{"0Y.0M", "106.01", true},           // and may have three
+		{"0Y.0M", "006.01", false},          // but not a padding zero beyond two
+		{"YYYY.0M.0D", "2024.02.29", true},  // leap day
+		{"YYYY.0M.0D", "2023.02.29", false}, // not a leap year
+		{"YY.0M.0D", "23.02.29", false},     // short years expand to 2023
+		{"YYYY.0M.0D", "2024.04.31", false},
+		{"YYYY.0M.0D", "2024.13.01", false},
+		{"YYYY.0M.0D", "2024.00.01", false},
+		{"YYYY.0W", "2024.53", true},
+		{"YYYY.0W", "2024.54", false},
+		{"YYYY.MINOR", "2024.1-dev", true},
+		{"YYYY.MINOR", "2024.1.rc.1", true},
+		{"YYYY.MINOR", "2024.1-", false}, // empty modifier
+		{"YYYY.MINOR", "2024.1+b", false},
+		{"YYYY.MINOR", "2024.1-rc..1", false}, // invalid modifier
+		{"YYYY.MINOR", "2024", false},         // missing segment
+		{"vYYYY.MINOR", "2024.1", false},      // missing prefix
+		{"YYYY.MAJOR", "2024.99999999999999999999", false},
+	}
+	for _, tt := range tests {
+		v, err := MustParseLayout(tt.layout).Parse(tt.in)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: Parse(%q) = %v, %v; want ok %v", tt.layout, tt.in, v, err, tt.ok)
+			continue
+		}
+		if err == nil && v.String() != tt.in && v.String() != stringWithDash(tt.in, v.Modifier) {
+			t.Errorf("%s: Parse(%q).String() = %q", tt.layout, tt.in, v)
+		}
+	}
+}
+
+// stringWithDash rewrites a "." before the modifier as the "-" String
+// joins modifiers with.
+func stringWithDash(s, mod string) string {
+	if mod == "" {
+		return s
+	}
+	return s[:len(s)-len(mod)-1] + "-" + mod
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		layout string
+		// ordered is in ascending order, each version after the previous.
+		ordered []string
+	}{
+		{"YYYY.0M.0D", []string{"2023.12.31", "2024.01.01-rc.1", "2024.01.01", "2024.01.02"}},
+		{"YY.MM.MICRO", []string{"9.12.0", "24.1.0-dev", "24.1.0-rc.1", "24.1.0", "24.1.1", "24.10.0", "100.1.0"}},
+		{"0Y.0M", []string{"06.01", "16.01", "106.01"}},
+	}
+	for _, tt := range tests {
+		l := MustParseLayout(tt.layout)
+		vs := make([]Version, len(tt.ordered))
+		for i, s := range tt.ordered {
+			v, err := l.Parse(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			vs[i] = v
+		}
+		for i := range vs {
+			for j := range vs {
+				want := 0
+				switch {
+				case i < j:
+					want = -1
+				case i > j:
+					want = 1
+				}
+				if got := vs[i].Compare(vs[j]); got != want {
+					t.Errorf("%s: Compare(%s, %s) = %d, want %d", tt.layout, vs[i], vs[j], got, want)
+				}
+			}
+		}
+		sorted := slices.Clone(vs)
+		slices.Reverse(sorted)
+		Sort(sorted)
+		for i := range sorted {
+			if sorted[i].String() != tt.ordered[i] {
+				t.Errorf("%s: Sort put %s at %d, want %s", tt.layout, sorted[i], i, tt.ordered[i])
+			}
+		}
+	}
+}
+
+func TestTime(t *testing.T) {
+	tests := []struct {
+		layout, in string
+		want       time.Time
+	}{
+		{"YYYY.0M.0D", "2024.03.15", time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{"YY.0M", "24.03", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"YYYY.MICRO", "2024.7", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"YYYY.0W", "2024.01", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"YYYY.0W", "2021.01", time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		v, err := MustParseLayout(tt.layout).Parse(tt.in)
+		if err != nil {
+			t.Errorf("%s: Parse(%q): %v", tt.layout, tt.in, err)
+			continue
+		}
+		if got, ok := v.Time(); !ok || !got.Equal(tt.want) {
+			t.Errorf("%s: %s.Time() = %v, %v; want %v", tt.layout, tt.in, got, ok, tt.want)
+		}
+	}
+	v, _ := MustParseLayout("MAJOR.MINOR").Parse("1.2")
+	if _, ok := v.Time(); ok {
+		t.Error("Time of a layout without a year succeeded")
+	}
+	v, _ = MustParseLayout("YY.MM").Parse("24.3")
+	if y, ok := v.Value(ShortYear); !ok || y != 24 {
+		t.Errorf("Value(ShortYear) = %d, %v; want 24 as written", y, ok)
+	}
+}
+
+func TestSemver(t *testing.T) {
+	tests := []struct {
+		layout, in, semver string
+	}{
+		{"YYYY.0M.0D", "2024.03.15", "2024.3.15"},
+		{"YY.MM.MICRO", "24.3.1-rc.1", "24.3.1-rc.1"},
+		{"YYYY.MINOR", "2024.2", "2024.2.0"},
+		{"YY.0M", "24.03-dev", "24.3.0-dev"},
+	}
+	for _, tt := range tests {
+		l := MustParseLayout(tt.layout)
+		v, err := l.Parse(tt.in)
+		if err != nil {
+			t.Errorf("%s: Parse(%q): %v", tt.layout, tt.in, err)
+			continue
+		}
+		sv, err := v.ToSemver()
+		if err != nil || sv.String() != tt.semver {
+			t.Errorf("%s: ToSemver(%s) = %v, %v; want %s", tt.layout, tt.in, sv, err, tt.semver)
+			continue
+		}
+		back, err := l.FromSemver(sv)
+		if err != nil || back.Compare(v) != 0 || back.String() != tt.in {
+			t.Errorf("%s: FromSemver(%s) = %s, %v; want %s", tt.layout, sv, back, err, tt.in)
+		}
+	}
+	errs := []struct {
+		layout, semver string
+	}{
+		{"YYYY.0M.0D", "2024.13.1"},
+		{"YYYY.0M.0D", "2023.2.29"},
+		{"YYYY.MINOR", "2024.1.1"},
+		{"YYYY.MINOR", "24.1.0"},
+		{"YYYY.MM.DD.MICRO", "2024.1.1"},
+	}
+	for _, tt := range errs {
+		if v, err := MustParseLayout(tt.layout).FromSemver(semver.MustParse(tt.semver)); err == nil {
+			t.Errorf("%s: FromSemver(%s) = %s, want an error", tt.layout, tt.semver, v)
+		}
+	}
+	v, _ := MustParseLayout("YYYY.MM.DD.MICRO").Parse("2024.1.1.0")
+	if sv, err := v.ToSemver(); err == nil {
+		t.Errorf("ToSemver(%s) = %v, want an error", v, sv)
+	}
+}
@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/sbom"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+var auditCmd = &command{
+	name:    "audit",
+	args:    "-policy FILE [SBOM|-] | releases [-allow ANOMALIES] [FILE|-]",
+	summary: "check a CycloneDX or SPDX JSON SBOM (or stdin) against allowed ranges, or a project's versions for missing patches, orphan pre-releases and duplicate releases; exit 1 on violations",
+	fields: []field{
+		{"name", "string"}, {"version", "string"}, {"purl", "string"}, {"rule", "string"},
+		{"constraint", "string"}, {"reason", "string"}, {"error", "string"},
+	},
+	run: runAudit,
+}
+
+// auditReleasesCmd is the releases subcommand of audit, with fields of
+// its own.
+var auditReleasesCmd = &command{
+	name: "audit releases",
+	args: "[-allow ANOMALIES] [FILE|-]",
+	fields: []field{
+		{"series", "string"}, {"anomaly", "string"}, {"version", "string"}, {"related", "array"}, {"message", "string"},
+	},
+	run: runAuditReleases,
+}
+
+func runAudit(e *env, c *command, args []string) int {
+	if len(args) > 0 && args[0] == "releases" {
+		return runAuditReleases(e, auditReleasesCmd, args[1:])
+	}
+	fs := c.flags(e)
+	policyFile := fs.String("policy", "", "JSON policy `FILE` of allowed ranges per component")
+	format := outputFlag(fs)
+	if e.parse(c, fs, args) != nil {
+		return exitError
+	}
+	if *policyFile == "" || fs.NArg() > 1 {
+		return e.badUsage(c, "want a policy file and at most one SBOM")
+	}
+	out, err := c.output(*format)
+	if err != nil {
+		return e.badUsage(c, "%v", err)
+	}
+	data, err := os.ReadFile(*policyFile)
+	if err != nil {
+		return e.fail(c, err)
+	}
+	policy, err := sbom.ParsePolicy(data)
+	if err != nil {
+		return e.fail(c, err)
+	}
+	name := "-"
+	if fs.NArg() == 1 {
+		name = fs.Arg(0)
+	}
+	r, err := e.open(name)
+	if err != nil {
+		return e.fail(c, err)
+	}
+	data, err = io.ReadAll(r)
+	r.Close()
+	if err != nil {
+		return e.fail(c, err)
+	}
+	components, _, err := sbom.Parse(data)
+	if err != nil {
+		return e.fail(c, err)
+	}
+	report := sbom.Audit(components, policy)
+	code := exitOK
+	if len(report.Violations) > 0 {
+		code = exitFalse
+	}
+	if !out.text() {
+		// Structured formats list the violations; the text output is the
+		// full report.
+		for _, v := range report.Violations {
+			out.add(v.Name, v.Version, v.PURL, v.Rule, v.Constraint, v.Reason, v.Error)
+		}
+		return e.write(c, out, code)
+	}
+	enc := json.NewEncoder(e.stdout)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(report); err != nil {
+		return e.fail(c, err)
+	}
+	return code
+}
+
+// runAuditReleases reconstructs the release timeline of the versions
+// listed one per line, in any order, and reports its anomalies. The text
+// output shows each series before the anomalies.
+func runAuditReleases(e *env, c *command, args []string) int {
+	fs := c.flags(e)
+	allow := fs.String("allow", "", "comma-separated `ANOMALIES` to report without failing: missing-patch, orphan-prerelease or duplicate-release")
+	format := outputFlag(fs)
+	if e.parse(c, fs, args) != nil {
+		return exitError
+	}
+	if fs.NArg() > 1 {
+		return e.badUsage(c, "want at most one file")
+	}
+	out, err := c.output(*format)
+	if err != nil {
+		return e.badUsage(c, "%v", err)
+	}
+	allowed := map[semver.Anomaly]bool{}
+	for _, name := range splitList(*allow) {
+		a, err := semver.ParseAnomaly(name)
+		if err != nil {
+			return e.badUsage(c, "-allow: unknown anomaly %q", name)
+		}
+		allowed[a] = true
+	}
+	name := "-"
+	if fs.NArg() == 1 {
+		name = fs.Arg(0)
+	}
+	r, err := e.open(name)
+	if err != nil {
+		return e.fail(c, err)
+	}
+	lines, err := readLines(r)
+	r.Close()
+	if err != nil {
+		return e.fail(c, err)
+	}
+	vs := make([]semver.Version, len(lines))
+	for i, l := range lines {
+		if vs[i], err = semver.ParseTolerant(l); err != nil {
+			return e.fail(c, fmt.Errorf("line %d: %v", i+1, err))
+		}
+	}
+	t := semver.ReconstructTimeline(vs)
+	if out.text() {
+		for _, s := range t.Series {
+			names := make([]string, len(s.Versions))
+			for i, v := range s.Versions {
+				names[i] = v.String()
+			}
+			fmt.Fprintf(e.stdout, "%s: %s\n", s.Series, strings.Join(names, " "))
+		}
+	}
+	code := exitOK
+	for _, f := range t.Findings {
+		if !allowed[f.Anomaly] {
+			code = exitFalse
+		}
+		if !out.text() {
+			related := make([]string, len(f.Related))
+			for i, v := range f.Related {
+				related[i] = v.String()
+			}
+			out.add(f.Series, f.Anomaly.String(), f.Version.String(), related, f.Message)
+			continue
+		}
+		fmt.Fprintf(e.stdout, "%s: %s: %s\n", f.Series, f.Anomaly, f.Message)
+	}
+	if !out.text() {
+		return e.write(c, out, code)
+	}
+	return code
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+var bumpCmd = &command{
+	name:    "bump",
+	args:    "[-file FILE] major|minor|patch|prerelease VERSION|-",
+	summary: "print VERSION (or each -file version) with the given component incremented",
+	fields:  []field{{"component", "string"}, {"version", "string"}, {"next", "string"}},
+	run:     runBump,
+}
+
+func runBump(e *env, c *command, args []string) int {
+	fs := c.flags(e)
+	file := fs.String("file", "", "read versions from `FILE`, one per line; - is stdin")
+	format := outputFlag(fs)
+	if e.parse(c, fs, args) != nil {
+		return exitError
+	}
+	if fs.NArg() == 2 && fs.Arg(1) == "-" {
+		*file = "-"
+	} else if *file != "" && fs.NArg() != 1 {
+		return e.badUsage(c, "want a component with -file")
+	} else if *file == "" && fs.NArg() != 2 {
+		return e.badUsage(c, "want a component and a version")
+	}
+	out, err := c.output(*format)
+	if err != nil {
+		return e.badUsage(c, "%v", err)
+	}
+	var inc func(semver.Version) semver.Version
+	switch fs.Arg(0) {
+	case "major":
+		inc = semver.Version.IncMajor
+	case "minor":
+		inc = semver.Version.IncMinor
+	case "patch":
+		inc = semver.Version.IncPatch
+	case "prerelease":
+		inc = semver.Version.IncPrerelease
+	default:
+		return e.badUsage(c, "unknown component %q", fs.Arg(0))
+	}
+	if *file != "" {
+		// The per-line results are printed as "VERSION NEXT".
+		lines, bad, err := e.batch(c, *file, "", 1, func(f []string) error {
+			v, err := semver.ParseTolerant(f[0])
+			if err != nil {
+				return err
+			}
+			next := inc(v)
+			if out.text() {
+				fmt.Fprintln(e.stdout, f[0], next)
+			} else {
+				out.add(fs.Arg(0), v.String(), next.String())
+			}
+			return nil
+		})
+		if err != nil {
+			return e.fail(c, err)
+		}
+		return e.endBatch(c, out, lines, bad, fmt.Sprintf("%d bumped", lines-bad), exitOK)
+	}
+	v, err := semver.ParseTolerant(fs.Arg(1))
+	if err != nil {
+		return e.fail(c, err)
+	}
+	next := inc(v)
+	if !out.text() {
+		out.add(fs.Arg(0), v.String(), next.String())
+		return e.write(c, out, exitOK)
+	}
+	fmt.Fprintln(e.stdout, next)
+	return exitOK
+}
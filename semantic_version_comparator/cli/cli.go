@@ -0,0 +1,215 @@
+// Package cli implements the semver command. It is a package, rather
+// than part of the command itself, so that programs which register their
+// own version schemes can offer them through -scheme:
+//
+//	func init() {
+//		scheme.Register("internal", internalScheme{})
+//	}
+//
+//	func main() {
+//		cli.Main()
+//	}
+package cli
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/httpretry"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/scheme"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+const (
+	exitOK    = 0
+	exitFalse = 1
+	exitError = 3
+)
+
+// env carries the standard streams so commands can be run in isolation.
+type env struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	// config holds the defaults from the configuration file, if any.
+	config *config
+}
+
+// netClient is used by the commands that query GitHub or the Go proxy.
+// It retries rate-limited and failed requests, which CI runs sharing an
+// address hit routinely.
+var netClient = &http.Client{Transport: &httpretry.Transport{}}
+
+type command struct {
+	name    string
+	args    string
+	summary string
+	// fields lists the columns of the command's -output json, csv and
+	// table formats.
+	fields []field
+	run    func(e *env, cmd *command, args []string) int
+}
+
+var commands []*command
+
+func init() {
+	commands = []*command{
+		compareCmd,
+		sortCmd,
+		validateCmd,
+		inspectCmd,
+		bumpCmd,
+		satisfiesCmd,
+		filterCmd,
+		tuiCmd,
+		gitLatestCmd,
+		nextCmd,
+		ghLatestCmd,
+		auditCmd,
+		upgradeReportCmd,
+		diffListsCmd,
+		gomodLintCmd,
+		imageScanCmd,
+		policyCmd,
+		checkHistoryCmd,
+		hookCmd,
+		serveCmd,
+	}
+}
+
+// Main runs the command named by os.Args[1] with the remaining arguments
+// and exits with its status.
+func Main() {
+	os.Exit(Run(os.Stdin, os.Stdout, os.Stderr, os.Args[1:]))
+}
+
+// Run runs the command named by args[0] with the standard streams stdin,
+// stdout and stderr, and returns its exit status.
+func Run(stdin io.Reader, stdout, stderr io.Writer, args []string) int {
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Fprintf(stderr, "semver: %v\n", err)
+		return exitError
+	}
+	return run(&env{stdin: stdin, stdout: stdout, stderr: stderr, config: cfg}, args)
+}
+
+func run(e *env, args []string) int {
+	if len(args) == 0 {
+		usage(e.stderr)
+		return exitError
+	}
+	name := args[0]
+	if name == "help" || name == "-h" || name == "-help" || name == "--help" {
+		usage(e.stdout)
+		return exitOK
+	}
+	for _, c := range commands {
+		if c.name == name {
+			return c.run(e, c, args[1:])
+		}
+	}
+	fmt.Fprintf(e.stderr, "semver: unknown command %q\n", name)
+	usage(e.stderr)
+	return exitError
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: semver <command> [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-44s %s\n", c.name+" "+c.args, c.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Every command accepts -output text|json|csv|table. The json, csv and")
+	fmt.Fprintln(w, "table formats list one record per result; JSON output is an array of")
+	fmt.Fprintln(w, "objects whose fields are shown by \"semver COMMAND -h\".")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Defaults for -scheme, -dialect, -output and -label-order, the strictness")
+	fmt.Fprintln(w, "of parsing and the v-prefix of tags, and named constraint aliases, are read from")
+	fmt.Fprintln(w, ".semverrc or semver.yaml in the working directory, or from")
+	fmt.Fprintln(w, "semver/config.yaml in $XDG_CONFIG_HOME; SEMVER_CONFIG names another file.")
+}
+
+// flags returns a flag set for c that writes its usage to e.stderr.
+func (c *command) flags(e *env) *flag.FlagSet {
+	fs := flag.NewFlagSet(c.name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(e.stderr, "usage: semver %s %s\n", c.name, c.args)
+		fs.PrintDefaults()
+		if len(c.fields) > 0 {
+			fmt.Fprintf(e.stderr, "\nwith -output json, prints %s\n", schema(c.fields))
+		}
+	}
+	return fs
+}
+
+// output returns the output of c for the format given to -output.
+func (c *command) output(format string) (*output, error) {
+	return newOutput(format, c.fields)
+}
+
+// write renders out to e.stdout and returns code, or exitError if writing
+// fails.
+func (e *env) write(c *command, out *output, code int) int {
+	if err := out.write(e.stdout); err != nil {
+		return e.fail(c, err)
+	}
+	return code
+}
+
+// schemeFlag registers the -scheme flag on fs.
+func schemeFlag(fs *flag.FlagSet) *string {
+	return fs.String("scheme", "semver", "version scheme: "+strings.Join(scheme.Names(), ", "))
+}
+
+// labelOrderFlag registers the -label-order flag on fs.
+func labelOrderFlag(fs *flag.FlagSet) *string {
+	return fs.String("label-order", "", "order pre-release labels by maturity, such as `\"dev < alpha < beta < rc\"`")
+}
+
+// labelComparator returns a tolerant comparator ordering pre-release
+// labels by order, the value of -label-order, or nil if order is empty.
+func labelComparator(order string) (*semver.Comparator, error) {
+	if order == "" {
+		return nil, nil
+	}
+	o, err := semver.ParseLabelOrder(order)
+	if err != nil {
+		return nil, err
+	}
+	return semver.NewComparator(semver.WithLenient(), semver.WithLabelOrder(o)), nil
+}
+
+// fail reports err for command c and returns exitError.
+func (e *env) fail(c *command, err error) int {
+	fmt.Fprintf(e.stderr, "semver %s: %s\n", c.name, strings.TrimPrefix(err.Error(), "semver: "))
+	return exitError
+}
+
+// badUsage reports a usage error for command c and returns exitError.
+func (e *env) badUsage(c *command, format string, a ...any) int {
+	fmt.Fprintf(e.stderr, "semver %s: %s\n", c.name, fmt.Sprintf(format, a...))
+	fmt.Fprintf(e.stderr, "usage: semver %s %s\n", c.name, c.args)
+	return exitError
+}
+
+// readLines returns the non-blank lines of r with surrounding whitespace
+// removed.
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		if line := strings.TrimSpace(sc.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, sc.Err()
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/scheme"
+)
+
+var compareCmd = &command{
+	name:    "compare",
+	args:    "[-scheme NAME] [-label-order ORDER] A B | [-file FILE] [-delim D] [-]",
+	summary: "print -1, 0 or 1; exit 1 if A < B, 0 if equal, 2 if A > B (0 for -file)",
+	fields:  []field{{"a", "string"}, {"b", "string"}, {"result", "number"}},
+	run:     runCompare,
+}
+
+func runCompare(e *env, c *command, args []string) int {
+	fs := c.flags(e)
+	schemeName := schemeFlag(fs)
+	labelOrder := labelOrderFlag(fs)
+	file, delim := inputFlags(fs)
+	format := outputFlag(fs)
+	if e.parse(c, fs, args) != nil {
+		return exitError
+	}
+	if stdinArg(fs.Args()) {
+		*file = "-"
+	} else if *file != "" && fs.NArg() != 0 {
+		return e.badUsage(c, "-file takes no version arguments")
+	} else if *file == "" && fs.NArg() != 2 {
+		return e.badUsage(c, "want two versions, got %d", fs.NArg())
+	}
+	out, err := c.output(*format)
+	if err != nil {
+		return e.badUsage(c, "%v", err)
+	}
+	sch, err := scheme.Lookup(*schemeName)
+	if err != nil {
+		return e.fail(c, err)
+	}
+	compare := sch.Compare
+	if labels, err := labelComparator(*labelOrder); err != nil {
+		return e.badUsage(c, "-label-order: %v", strings.TrimPrefix(err.Error(), "semver: "))
+	} else if labels != nil {
+		if sch != scheme.Semver {
+			return e.badUsage(c, "-label-order only supports the semver scheme")
+		}
+		compare = labels.Compare
+	}
+	if *file != "" {
+		// Each line holds a pair; the per-line results are printed as
+		// "A B RESULT".
+		var counts [3]int
+		lines, bad, err := e.batch(c, *file, *delim, 2, func(f []string) error {
+			n, err := compare(f[0], f[1])
+			if err != nil {
+				return err
+			}
+			counts[n+1]++
+			if out.text() {
+				fmt.Fprintln(e.stdout, f[0], f[1], n)
+			} else {
+				out.add(f[0], f[1], n)
+			}
+			return nil
+		})
+		if err != nil {
+			return e.fail(c, err)
+		}
+		return e.endBatch(c, out, lines, bad,
+			fmt.Sprintf("%d less, %d equal, %d greater", counts[0], counts[1], counts[2]), exitOK)
+	}
+	n, err := compare(fs.Arg(0), fs.Arg(1))
+	if err != nil {
+		return e.fail(c, err)
+	}
+	code := exitOK
+	switch n {
+	case -1:
+		code = 1
+	case 1:
+		code = 2
+	}
+	if !out.text() {
+		out.add(fs.Arg(0), fs.Arg(1), n)
+		return e.write(c, out, code)
+	}
+	fmt.Fprintln(e.stdout, n)
+	return code
+}
@@ -0,0 +1,228 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/internal/yaml"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+// configFiles are the names of the configuration file looked for in the
+// working directory, in order of preference.
+var configFiles = []string{".semverrc", "semver.yaml"}
+
+// config holds the defaults of a configuration file, such as
+//
+//	scheme: semver
+//	dialect: npm
+//	strictness: loose  # or strict
+//	v-prefix: require  # or forbid
+//	output: json
+//	prerelease-order: [dev, alpha, beta, rc]
+//	aliases:
+//	  supported: ">=1.4 <2"
+//
+// Its settings replace the defaults of the flags they name, so flags
+// given on the command line still win, and aliases may be given wherever
+// a command takes a constraint: "semver satisfies 1.5.0 supported".
+type config struct {
+	settings []setting
+	aliases  map[string]string
+}
+
+// setting is a flag default set by the configuration file.
+type setting struct {
+	key   string // the configuration key, for errors
+	flag  string
+	value string
+	// commands lists the commands whose flag is meant; nil means every
+	// command that has the flag.
+	commands []string
+}
+
+// loadConfig reads semver/config.yaml in the user's configuration
+// directory, $XDG_CONFIG_HOME or ~/.config on Linux, then the first of
+// configFiles in the working directory, whose keys and aliases override
+// the user's. If SEMVER_CONFIG is set it names the only file read, so
+// scripts can pin their configuration, or ignore any with /dev/null. A
+// nil config means no file was found.
+func loadConfig() (*config, error) {
+	if name := os.Getenv("SEMVER_CONFIG"); name != "" {
+		return readConfig(nil, name, true)
+	}
+	var cfg *config
+	if dir, err := os.UserConfigDir(); err == nil {
+		if cfg, err = readConfig(cfg, filepath.Join(dir, "semver", "config.yaml"), false); err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range configFiles {
+		if _, err := os.Stat(name); err == nil {
+			return readConfig(cfg, name, true)
+		}
+	}
+	return cfg, nil
+}
+
+// readConfig reads the configuration file name over base. A missing file
+// is an error only if must is set.
+func readConfig(base *config, name string, must bool) (*config, error) {
+	data, err := os.ReadFile(name)
+	if errors.Is(err, os.ErrNotExist) && !must {
+		return base, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config: %v", err)
+	}
+	cfg, err := parseConfig(base, string(data))
+	if err != nil {
+		return nil, fmt.Errorf("config %s: %v", name, err)
+	}
+	return cfg, nil
+}
+
+// parseConfig parses the YAML configuration s over base.
+func parseConfig(base *config, s string) (*config, error) {
+	tree, err := yaml.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	doc, ok := tree.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("want a mapping of settings")
+	}
+	cfg := &config{aliases: map[string]string{}}
+	if base != nil {
+		cfg.settings = slices.Clone(base.settings)
+		for name, c := range base.aliases {
+			cfg.aliases[name] = c
+		}
+	}
+	set := func(st setting) {
+		cfg.settings = slices.DeleteFunc(cfg.settings, func(old setting) bool { return old.key == st.key })
+		cfg.settings = append(cfg.settings, st)
+	}
+	keys := make([]string, 0, len(doc))
+	for k := range doc {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		if key == "aliases" {
+			aliases, ok := doc[key].(map[string]any)
+			if !ok && doc[key] != nil {
+				return nil, fmt.Errorf("aliases: want a mapping of names to constraints")
+			}
+			for name, v := range aliases {
+				c, ok := v.(string)
+				if !ok || !aliasName(name) {
+					return nil, fmt.Errorf("aliases: want NAME: CONSTRAINT with NAME a word such as supported, not %q", name)
+				}
+				cfg.aliases[name] = c
+			}
+			continue
+		}
+		value, ok := doc[key].(string)
+		if list, isList := doc[key].([]any); isList && key == "prerelease-order" {
+			// A list of labels, least mature first.
+			labels := make([]string, len(list))
+			for i, l := range list {
+				labels[i], _ = l.(string)
+			}
+			value, ok = strings.Join(labels, " < "), true
+		}
+		if !ok {
+			return nil, fmt.Errorf("%s: want a single value", key)
+		}
+		switch key {
+		case "output":
+			if _, err := newOutput(value, nil); err != nil {
+				return nil, fmt.Errorf("output: %v", strings.TrimPrefix(err.Error(), "-output "))
+			}
+			set(setting{key: key, flag: key, value: value})
+		case "scheme", "dialect":
+			set(setting{key: key, flag: key, value: value})
+		case "strictness":
+			switch value {
+			case "strict":
+				set(setting{key: key, flag: "strict", value: "true", commands: []string{"gomod-lint", "hook"}})
+			case "loose":
+				set(setting{key: key, flag: "loose", value: "true", commands: []string{"validate"}})
+			default:
+				return nil, fmt.Errorf("strictness must be strict or loose, not %q", value)
+			}
+		case "prerelease-order":
+			if _, err := semver.ParseLabelOrder(value); err != nil {
+				return nil, fmt.Errorf("prerelease-order: %v", strings.TrimPrefix(err.Error(), "semver: "))
+			}
+			set(setting{key: key, flag: "label-order", value: value, commands: []string{"sort", "compare"}})
+		case "v-prefix":
+			switch value {
+			case "require":
+				set(setting{key: key, flag: "v", value: "true", commands: []string{"hook"}})
+			case "forbid":
+				set(setting{key: key, flag: "v", value: "false", commands: []string{"hook"}})
+			default:
+				return nil, fmt.Errorf("v-prefix must be require or forbid, not %q", value)
+			}
+		default:
+			return nil, fmt.Errorf("unknown setting %q", key)
+		}
+	}
+	return cfg, nil
+}
+
+// aliasName reports whether name can be an alias: a word starting with a
+// letter, and not the wildcard "x", so that it cannot be read as a
+// constraint.
+func aliasName(name string) bool {
+	if name == "" || name == "x" || name == "X" || !isLetter(name[0]) {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if c := name[i]; !isLetter(c) && (c < '0' || c > '9') && c != '-' && c != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// parse applies the configured defaults to the flags of c in fs and then
+// parses args, so that command-line flags override the configuration.
+func (e *env) parse(c *command, fs *flag.FlagSet, args []string) error {
+	if e.config != nil {
+		for _, st := range e.config.settings {
+			f := fs.Lookup(st.flag)
+			if f == nil || st.commands != nil && !slices.Contains(st.commands, c.name) {
+				continue
+			}
+			if err := f.Value.Set(st.value); err != nil {
+				fmt.Fprintf(e.stderr, "semver %s: config %s: %v\n", c.name, st.key, err)
+				return err
+			}
+			f.DefValue = st.value
+		}
+	}
+	return fs.Parse(args)
+}
+
+// constraint returns the constraint that s names if it is a configured
+// alias, and s otherwise.
+func (e *env) constraint(s string) string {
+	if e.config != nil {
+		if c, ok := e.config.aliases[strings.TrimSpace(s)]; ok {
+			return c
+		}
+	}
+	return s
+}
@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+var diffListsCmd = &command{
+	name:    "diff-lists",
+	args:    "[-level major|minor] OLD NEW",
+	summary: "report versions added and removed, and series upgraded or downgraded, between two version lists; exit 1 if they differ",
+	fields: []field{
+		{"change", "string"}, {"series", "string"}, {"from", "string"}, {"version", "string"}, {"bump", "string"},
+	},
+	run: runDiffLists,
+}
+
+func runDiffLists(e *env, c *command, args []string) int {
+	fs := c.flags(e)
+	levelName := fs.String("level", "minor", "release series compared for upgrades: major or minor")
+	format := outputFlag(fs)
+	if e.parse(c, fs, args) != nil {
+		return exitError
+	}
+	if fs.NArg() != 2 {
+		return e.badUsage(c, "want an old and a new list")
+	}
+	out, err := c.output(*format)
+	if err != nil {
+		return e.badUsage(c, "%v", err)
+	}
+	var level semver.CompareMode
+	switch *levelName {
+	case "major":
+		level = semver.CompareMajor
+	case "minor":
+		level = semver.CompareMajorMinor
+	default:
+		return e.badUsage(c, "-level must be major or minor, not %q", *levelName)
+	}
+	old, err := e.readVersionList(c, fs.Arg(0))
+	if err != nil {
+		return e.fail(c, err)
+	}
+	new, err := e.readVersionList(c, fs.Arg(1))
+	if err != nil {
+		return e.fail(c, err)
+	}
+	entries := semver.DiffLists(old, new, level)
+	code := exitOK
+	if len(entries) > 0 {
+		code = exitFalse
+	}
+	for _, en := range entries {
+		from := ""
+		if en.HasFrom {
+			from = en.From.String()
+		}
+		if !out.text() {
+			out.add(en.Change.String(), en.Series, from, en.Version.String(), en.Bump.String())
+			continue
+		}
+		switch {
+		case en.Change == semver.ListUpgraded || en.Change == semver.ListDowngraded:
+			fmt.Fprintf(e.stdout, "%v %s: %s -> %s (%v)\n", en.Change, en.Series, en.From, en.Version, en.Bump)
+		case en.HasFrom:
+			fmt.Fprintf(e.stdout, "%v %s (%v after %s)\n", en.Change, en.Version, en.Bump, en.From)
+		default:
+			fmt.Fprintf(e.stdout, "%v %s\n", en.Change, en.Version)
+		}
+	}
+	if !out.text() {
+		return e.write(c, out, code)
+	}
+	return code
+}
+
+// readVersionList reads the versions of file, one per line, such as the
+// output of "git tag". Lines that are not versions are skipped and
+// counted on e.stderr.
+func (e *env) readVersionList(c *command, file string) ([]semver.Version, error) {
+	r, err := e.open(file)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := readLines(r)
+	r.Close()
+	if err != nil {
+		return nil, err
+	}
+	vs := make([]semver.Version, 0, len(lines))
+	for _, l := range lines {
+		if v, err := semver.ParseTolerant(l); err == nil {
+			vs = append(vs, v)
+		}
+	}
+	if skipped := len(lines) - len(vs); skipped > 0 {
+		fmt.Fprintf(e.stderr, "semver %s: %s: skipped %d lines that are not versions\n", c.name, file, skipped)
+	}
+	return vs, nil
+}
@@ -0,0 +1,241 @@
+package cli
+
+import (
+	"bufio"
+	"container/heap"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+// sortLine is a version line together with its parsed form.
+type sortLine struct {
+	s string
+	v semver.Version
+}
+
+// mergeFanIn bounds the number of chunk files merged, and so held open,
+// at once.
+const mergeFanIn = 32
+
+// externalSort sorts the version lines of r into w using at most
+// chunkSize lines of memory at a time. Sorted chunks are spilled to
+// temporary files in tmpDir and combined with k-way merges of at most
+// mergeFanIn files. dir is 1 for ascending and -1 for descending order;
+// descending order is the exact reverse of ascending, as slices.Reverse
+// gives for the in-memory sort, so lines of equal precedence come out in
+// reverse input order.
+func externalSort(r io.Reader, w io.Writer, tmpDir string, chunkSize, dir int) error {
+	cmp := func(a, b sortLine) int { return a.v.Compare(b.v) }
+	var all []*os.File
+	defer func() {
+		for _, f := range all {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+	// levels[k] holds, oldest first, the files each merged from
+	// mergeFanIn files of levels[k-1]; levels[0] holds spilled chunks.
+	// Every file of a level holds input older than those of lower
+	// levels.
+	var levels [][]*os.File
+	add := func(f *os.File) error {
+		for k := 0; ; k++ {
+			if k == len(levels) {
+				levels = append(levels, nil)
+			}
+			levels[k] = append(levels[k], f)
+			if len(levels[k]) < mergeFanIn {
+				return nil
+			}
+			var err error
+			if f, err = mergeToTemp(tmpDir, levels[k], cmp, dir, &all); err != nil {
+				return err
+			}
+			levels[k] = levels[k][:0]
+		}
+	}
+	chunk := make([]sortLine, 0, chunkSize)
+	sc := bufio.NewScanner(r)
+	lineNo := 0
+	for {
+		more := sc.Scan()
+		if more {
+			lineNo++
+			s := strings.TrimSpace(sc.Text())
+			if s == "" {
+				continue
+			}
+			v, err := semver.ParseTolerant(s)
+			if err != nil {
+				return fmt.Errorf("line %d: %v", lineNo, err)
+			}
+			chunk = append(chunk, sortLine{s, v})
+			if len(chunk) < chunkSize {
+				continue
+			}
+		}
+		if err := sc.Err(); err != nil {
+			return err
+		}
+		slices.SortStableFunc(chunk, cmp)
+		if dir < 0 {
+			slices.Reverse(chunk)
+		}
+		if !more && len(all) == 0 {
+			// Everything fit in a single chunk.
+			return writeLines(w, chunk)
+		}
+		if len(chunk) > 0 {
+			f, err := spill(tmpDir, chunk)
+			if f != nil {
+				all = append(all, f)
+			}
+			if err != nil {
+				return err
+			}
+			if err := add(f); err != nil {
+				return err
+			}
+			chunk = chunk[:0]
+		}
+		if !more {
+			break
+		}
+	}
+	var chunks []*os.File
+	for k := len(levels) - 1; k >= 0; k-- {
+		chunks = append(chunks, levels[k]...)
+	}
+	for len(chunks) > mergeFanIn {
+		var next []*os.File
+		for i := 0; i < len(chunks); i += mergeFanIn {
+			f, err := mergeToTemp(tmpDir, chunks[i:min(i+mergeFanIn, len(chunks))], cmp, dir, &all)
+			if err != nil {
+				return err
+			}
+			next = append(next, f)
+		}
+		chunks = next
+	}
+	return mergeChunks(chunks, w, cmp, dir)
+}
+
+// mergeToTemp merges chunks into a new temporary file, closes them and
+// rewinds the new file for reading. The file is added to all, for
+// cleanup.
+func mergeToTemp(tmpDir string, chunks []*os.File, cmp func(a, b sortLine) int, dir int, all *[]*os.File) (*os.File, error) {
+	f, err := os.CreateTemp(tmpDir, "semver-sort-*")
+	if err != nil {
+		return nil, err
+	}
+	os.Remove(f.Name())
+	*all = append(*all, f)
+	if err := mergeChunks(chunks, f, cmp, dir); err != nil {
+		return nil, err
+	}
+	for _, c := range chunks {
+		c.Close()
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func writeLines(w io.Writer, lines []sortLine) error {
+	bw := bufio.NewWriter(w)
+	for _, l := range lines {
+		bw.WriteString(l.s)
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+// spill writes a sorted chunk to a new temporary file and rewinds it for
+// reading.
+func spill(tmpDir string, chunk []sortLine) (*os.File, error) {
+	f, err := os.CreateTemp(tmpDir, "semver-sort-*")
+	if err != nil {
+		return nil, err
+	}
+	// Unlink the file straight away where the OS allows it, so it is
+	// reclaimed even if the process is killed (for example by SIGPIPE when
+	// the output is piped into head). Elsewhere the deferred cleanup in
+	// externalSort removes it.
+	os.Remove(f.Name())
+	if err := writeLines(f, chunk); err != nil {
+		return f, err
+	}
+	_, err = f.Seek(0, io.SeekStart)
+	return f, err
+}
+
+// mergeChunks merges chunk files, each sorted in the order dir selects,
+// into w. Lines of equal precedence are emitted in chunk order, or in
+// reverse chunk order if dir is -1, which keeps the overall sort stable
+// or its exact reverse.
+func mergeChunks(chunks []*os.File, w io.Writer, cmp func(a, b sortLine) int, dir int) error {
+	h := &mergeHeap{cmp: cmp, dir: dir}
+	for i, f := range chunks {
+		sc := bufio.NewScanner(f)
+		if err := h.push(i, sc); err != nil {
+			return err
+		}
+	}
+	bw := bufio.NewWriter(w)
+	for h.Len() > 0 {
+		top := h.items[0]
+		bw.WriteString(top.line.s)
+		bw.WriteByte('\n')
+		heap.Pop(h)
+		if err := h.push(top.chunk, top.sc); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+type mergeItem struct {
+	line  sortLine
+	chunk int
+	sc    *bufio.Scanner
+}
+
+type mergeHeap struct {
+	items []mergeItem
+	cmp   func(a, b sortLine) int
+	dir   int
+}
+
+// push reads the next line of chunk from sc and adds it to the heap.
+func (h *mergeHeap) push(chunk int, sc *bufio.Scanner) error {
+	if !sc.Scan() {
+		return sc.Err()
+	}
+	v, err := semver.ParseTolerant(sc.Text())
+	if err != nil {
+		return err
+	}
+	heap.Push(h, mergeItem{sortLine{sc.Text(), v}, chunk, sc})
+	return nil
+}
+
+func (h *mergeHeap) Len() int { return len(h.items) }
+func (h *mergeHeap) Less(i, j int) bool {
+	if c := h.dir * h.cmp(h.items[i].line, h.items[j].line); c != 0 {
+		return c < 0
+	}
+	return h.dir*h.items[i].chunk < h.dir*h.items[j].chunk
+}
+func (h *mergeHeap) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *mergeHeap) Push(x any)    { h.items = append(h.items, x.(mergeItem)) }
+func (h *mergeHeap) Pop() any {
+	it := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return it
+}
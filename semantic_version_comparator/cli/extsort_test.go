@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// sortInput returns n version lines with many of equal precedence, told
+// apart by prefix and build metadata.
+func sortInput(n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		prefix := ""
+		if i%2 == 0 {
+			prefix = "v"
+		}
+		fmt.Fprintf(&b, "%s1.%d.0+%d\n", prefix, (i*7)%5, i)
+	}
+	return b.String()
+}
+
+func TestSortStreamMatchesMemory(t *testing.T) {
+	t.Setenv("SEMVER_CONFIG", "/dev/null")
+	for _, n := range []int{1, 10, 100, 3000} {
+		input := sortInput(n)
+		for _, reverse := range []bool{false, true} {
+			args := []string{"sort"}
+			if reverse {
+				args = append(args, "-r")
+			}
+			var want, stderr bytes.Buffer
+			if code := Run(strings.NewReader(input), &want, &stderr, args); code != exitOK {
+				t.Fatalf("%v: exit %d: %s", args, code, stderr.String())
+			}
+			// Chunk sizes of 1 and 3 need more than mergeFanIn chunks
+			// for the larger inputs, and so several merge passes.
+			for _, size := range []int{1, 3, 64, n} {
+				stream := append(args[:len(args):len(args)], "--stream", "-chunk-size", strconv.Itoa(size), "-tmpdir", t.TempDir())
+				var got bytes.Buffer
+				if code := Run(strings.NewReader(input), &got, &stderr, stream); code != exitOK {
+					t.Fatalf("%v: exit %d: %s", stream, code, stderr.String())
+				}
+				if got.String() != want.String() {
+					t.Errorf("%v on %d lines differs from the in-memory sort", stream, n)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+var filterCmd = &command{
+	name:    "filter",
+	args:    "[-invert] [-latest] [-dialect NAME] [-include-prerelease] [-file FILE] CONSTRAINT",
+	summary: "print the versions read from -file or stdin that satisfy CONSTRAINT; exit 1 if none does",
+	fields:  []field{{"version", "string"}},
+	run:     runFilter,
+}
+
+func runFilter(e *env, c *command, args []string) int {
+	fs := c.flags(e)
+	invert := fs.Bool("invert", false, "print the versions that do not satisfy CONSTRAINT instead")
+	latest := fs.Bool("latest", false, "print only the highest of the selected versions")
+	dialectName := fs.String("dialect", "npm", "constraint syntax: npm, ruby, nuget, terraform or helm")
+	includePre := fs.Bool("include-prerelease", false, "let pre-releases satisfy ranges by precedence alone")
+	file := fs.String("file", "-", "read versions from `FILE`, one per line; - is stdin")
+	format := outputFlag(fs)
+	// Flags may follow the constraint, as in "git tag | semver filter
+	// '^1' --latest".
+	var positional []string
+	for rest := args; ; {
+		if e.parse(c, fs, rest) != nil {
+			return exitError
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		rest = fs.Args()[1:]
+	}
+	if len(positional) != 1 {
+		return e.badUsage(c, "want a constraint")
+	}
+	out, err := c.output(*format)
+	if err != nil {
+		return e.badUsage(c, "%v", err)
+	}
+	dialect, err := semver.ParseDialect(*dialectName)
+	if err != nil {
+		return e.fail(c, err)
+	}
+	opts := []semver.ConstraintOption{semver.WithDialect(dialect)}
+	if *includePre {
+		opts = append(opts, semver.IncludePrerelease())
+	}
+	con, err := semver.ParseConstraint(e.constraint(positional[0]), opts...)
+	if err != nil {
+		return e.fail(c, err)
+	}
+	in, err := e.open(*file)
+	if err != nil {
+		return e.fail(c, err)
+	}
+	defer in.Close()
+	// Lines that are not versions, such as other tags in "git tag"
+	// output, are skipped whether or not -invert is given. Selected lines
+	// are printed as they were written, as soon as they are read, unless
+	// -latest holds them back.
+	var best string
+	var bestV semver.Version
+	found := false
+	sc := bufio.NewScanner(in)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		v, err := semver.ParseTolerant(line)
+		if err != nil || con.Check(v) == *invert {
+			continue
+		}
+		switch {
+		case *latest:
+			if !found || v.Compare(bestV) > 0 {
+				best, bestV = line, v
+			}
+		case out.text():
+			fmt.Fprintln(e.stdout, line)
+		default:
+			out.add(line)
+		}
+		found = true
+	}
+	if err := sc.Err(); err != nil {
+		return e.fail(c, err)
+	}
+	code := exitOK
+	if !found {
+		code = exitFalse
+	}
+	if *latest && found {
+		if out.text() {
+			fmt.Fprintln(e.stdout, best)
+		} else {
+			out.add(best)
+		}
+	}
+	if !out.text() {
+		return e.write(c, out, code)
+	}
+	return code
+}
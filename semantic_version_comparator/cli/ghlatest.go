@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/ghrelease"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+var ghLatestCmd = &command{
+	name:    "gh-latest",
+	args:    "[-prerelease] [-installed VERSION] OWNER/REPO",
+	summary: "print the newest GitHub release; exit 1 if -installed is outdated",
+	fields: []field{
+		{"tag", "string"}, {"version", "string"}, {"installed", "string"}, {"outdated", "boolean"},
+	},
+	run: runGHLatest,
+}
+
+func runGHLatest(e *env, c *command, args []string) int {
+	fs := c.flags(e)
+	pre := fs.Bool("prerelease", false, "include pre-releases")
+	installed := fs.String("installed", "", "report whether `VERSION` is older than the newest release")
+	format := outputFlag(fs)
+	if e.parse(c, fs, args) != nil {
+		return exitError
+	}
+	if fs.NArg() != 1 {
+		return e.badUsage(c, "want a repository")
+	}
+	out, err := c.output(*format)
+	if err != nil {
+		return e.badUsage(c, "%v", err)
+	}
+	// GITHUB_TOKEN raises the API rate limit and grants access to private
+	// repositories.
+	client := &ghrelease.Client{Token: os.Getenv("GITHUB_TOKEN"), HTTPClient: netClient}
+	ctx := context.Background()
+	if *installed == "" {
+		r, err := client.Latest(ctx, fs.Arg(0), *pre)
+		if err != nil {
+			return e.fail(c, err)
+		}
+		if !out.text() {
+			out.add(r.TagName, r.Version.String(), "", false)
+			return e.write(c, out, exitOK)
+		}
+		fmt.Fprintln(e.stdout, r.TagName)
+		return exitOK
+	}
+	v, err := semver.ParseTolerant(*installed)
+	if err != nil {
+		return e.fail(c, err)
+	}
+	r, outdated, err := client.Outdated(ctx, fs.Arg(0), v, *pre)
+	if err != nil {
+		return e.fail(c, err)
+	}
+	code := exitOK
+	if outdated {
+		code = exitFalse
+	}
+	if !out.text() {
+		out.add(r.TagName, r.Version.String(), v.String(), outdated)
+		return e.write(c, out, code)
+	}
+	if outdated {
+		fmt.Fprintln(e.stdout, r.TagName, "outdated")
+	} else {
+		fmt.Fprintln(e.stdout, r.TagName, "up-to-date")
+	}
+	return code
+}
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/gittag"
+)
+
+var gitLatestCmd = &command{
+	name:    "git-latest",
+	args:    "[-prefix P] [-prerelease] [REPO]",
+	summary: "print the highest release tag of a git repository or remote",
+	fields:  []field{{"tag", "string"}, {"version", "string"}},
+	run:     runGitLatest,
+}
+
+func runGitLatest(e *env, c *command, args []string) int {
+	fs := c.flags(e)
+	prefix := fs.String("prefix", "", "only consider tags starting with `P`, such as api/")
+	pre := fs.Bool("prerelease", false, "include pre-release tags")
+	format := outputFlag(fs)
+	if e.parse(c, fs, args) != nil {
+		return exitError
+	}
+	out, err := c.output(*format)
+	if err != nil {
+		return e.badUsage(c, "%v", err)
+	}
+	repo := "."
+	switch fs.NArg() {
+	case 0:
+	case 1:
+		repo = fs.Arg(0)
+	default:
+		return e.badUsage(c, "want at most one repository")
+	}
+	tags, err := gittag.List(context.Background(), repo)
+	if err != nil {
+		return e.fail(c, err)
+	}
+	t, ok := gittag.Latest(tags, *prefix, *pre)
+	if !ok {
+		e.fail(c, errors.New("no release tags found"))
+		if !out.text() {
+			return e.write(c, out, exitFalse)
+		}
+		return exitFalse
+	}
+	if !out.text() {
+		out.add(t.Name, t.Version.String())
+		return e.write(c, out, exitOK)
+	}
+	fmt.Fprintln(e.stdout, t.Name)
+	return exitOK
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/goproxy"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/manifest"
+)
+
+var gomodLintCmd = &command{
+	name:    "gomod-lint",
+	args:    "[-offline] [-strict] [GOMOD|-]",
+	summary: "check the versions in a go.mod (default ./go.mod); exit 1 on errors, or warnings with -strict",
+	fields: []field{
+		{"line", "number"}, {"directive", "string"}, {"path", "string"}, {"version", "string"},
+		{"severity", "string"}, {"message", "string"},
+	},
+	run: runGoModLint,
+}
+
+func runGoModLint(e *env, c *command, args []string) int {
+	fs := c.flags(e)
+	offline := fs.Bool("offline", false, "do not ask the module proxy (GOPROXY) about retracted versions")
+	strict := fs.Bool("strict", false, "also exit 1 on warnings")
+	format := outputFlag(fs)
+	if e.parse(c, fs, args) != nil {
+		return exitError
+	}
+	if fs.NArg() > 1 {
+		return e.badUsage(c, "want at most one go.mod")
+	}
+	out, err := c.output(*format)
+	if err != nil {
+		return e.badUsage(c, "%v", err)
+	}
+	name := "go.mod"
+	if fs.NArg() == 1 {
+		name = fs.Arg(0)
+	}
+	r, err := e.open(name)
+	if err != nil {
+		return e.fail(c, err)
+	}
+	data, err := io.ReadAll(r)
+	r.Close()
+	if err != nil {
+		return e.fail(c, err)
+	}
+	f, err := manifest.ParseGoMod(data)
+	if err != nil {
+		return e.fail(c, err)
+	}
+	findings := manifest.Lint(f)
+	if !*offline {
+		retracted, err := manifest.CheckRetracted(context.Background(), &goproxy.Client{HTTPClient: netClient}, f)
+		if err != nil {
+			return e.fail(c, err)
+		}
+		findings = append(findings, retracted...)
+		slices.SortStableFunc(findings, func(a, b manifest.Finding) int { return cmp.Compare(a.Line, b.Line) })
+	}
+	code := exitOK
+	for _, fd := range findings {
+		severity := "error"
+		if fd.Warning {
+			severity = "warning"
+		}
+		if !fd.Warning || *strict {
+			code = exitFalse
+		}
+		if !out.text() {
+			out.add(fd.Line, fd.Directive, fd.Path, fd.Version, severity, fd.Message)
+			continue
+		}
+		fmt.Fprintf(e.stdout, "%s:%d: %s: %s %s %s: %s\n", name, fd.Line, severity, fd.Directive, fd.Path, fd.Version, fd.Message)
+	}
+	if !out.text() {
+		return e.write(c, out, code)
+	}
+	return code
+}
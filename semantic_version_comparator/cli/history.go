@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+var checkHistoryCmd = &command{
+	name:    "check-history",
+	args:    "[-allow PROBLEMS] [FILE|-]",
+	summary: "check versions listed in publication order for downgrades, skipped majors, re-releases and late pre-releases; exit 1 on any",
+	fields: []field{
+		{"line", "number"}, {"version", "string"}, {"problem", "string"}, {"previous", "string"}, {"message", "string"},
+	},
+	run: runCheckHistory,
+}
+
+func runCheckHistory(e *env, c *command, args []string) int {
+	fs := c.flags(e)
+	allow := fs.String("allow", "", "comma-separated `PROBLEMS` to report without failing: downgrade, skipped-major, rereleased or late-prerelease")
+	format := outputFlag(fs)
+	if e.parse(c, fs, args) != nil {
+		return exitError
+	}
+	if fs.NArg() > 1 {
+		return e.badUsage(c, "want at most one file")
+	}
+	out, err := c.output(*format)
+	if err != nil {
+		return e.badUsage(c, "%v", err)
+	}
+	allowed := map[semver.Problem]bool{}
+	for _, name := range splitList(*allow) {
+		p, err := semver.ParseProblem(name)
+		if err != nil {
+			return e.badUsage(c, "-allow: unknown problem %q", name)
+		}
+		allowed[p] = true
+	}
+	name := "-"
+	if fs.NArg() == 1 {
+		name = fs.Arg(0)
+	}
+	r, err := e.open(name)
+	if err != nil {
+		return e.fail(c, err)
+	}
+	lines, err := readLines(r)
+	r.Close()
+	if err != nil {
+		return e.fail(c, err)
+	}
+	history := make([]semver.Version, len(lines))
+	for i, l := range lines {
+		if history[i], err = semver.ParseTolerant(l); err != nil {
+			return e.fail(c, fmt.Errorf("line %d: %v", i+1, err))
+		}
+	}
+	code := exitOK
+	for _, v := range semver.CheckHistory(history) {
+		if !allowed[v.Problem] {
+			code = exitFalse
+		}
+		if !out.text() {
+			out.add(v.Index+1, lines[v.Index], v.Problem.String(), v.Previous.Original(), v.Message)
+			continue
+		}
+		fmt.Fprintf(e.stdout, "%d: %s: %s\n", v.Index+1, v.Problem, v.Message)
+	}
+	if !out.text() {
+		return e.write(c, out, code)
+	}
+	return code
+}
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/gittag"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+var hookCmd = &command{
+	name:    "hook",
+	args:    "[-repo DIR] [-prefix P] [-v=false] [-allow-lower|-allow-backports] [-allow-delete] [-allow-move] [-strict]",
+	summary: "check the tag updates a git pre-receive hook reads on stdin; exit 1 to reject the push",
+	run:     runHook,
+}
+
+func runHook(e *env, c *command, args []string) int {
+	fs := c.flags(e)
+	repo := fs.String("repo", ".", "repository `DIR` holding the existing tags")
+	prefix := fs.String("prefix", "", "release tags start with `P`, such as api/")
+	withV := fs.Bool("v", true, "release tags must start with a \"v\"; with -v=false they must not")
+	allowLower := fs.Bool("allow-lower", false, "accept release tags below the highest existing one")
+	allowBackports := fs.Bool("allow-backports", false, "accept release tags above the highest of their major.minor series")
+	allowDelete := fs.Bool("allow-delete", false, "accept deleting release tags")
+	allowMove := fs.Bool("allow-move", false, "accept moving release tags")
+	strict := fs.Bool("strict", false, "reject tags that are not release tags")
+	if e.parse(c, fs, args) != nil {
+		return exitError
+	}
+	if fs.NArg() != 0 {
+		return e.badUsage(c, "unexpected arguments")
+	}
+	p := gittag.Policy{
+		Prefix:         *prefix,
+		VPrefix:        semver.NoPrefix,
+		AllowLower:     *allowLower,
+		AllowBackports: *allowBackports,
+		AllowDelete:    *allowDelete,
+		AllowMove:      *allowMove,
+		Strict:         *strict,
+	}
+	if *withV {
+		p.VPrefix = semver.VPrefix
+	}
+	updates, err := gittag.ParseRefUpdates(e.stdin)
+	if err != nil {
+		return e.fail(c, err)
+	}
+	existing, err := gittag.List(context.Background(), *repo)
+	if err != nil {
+		return e.fail(c, err)
+	}
+	rejected := p.Check(existing, updates)
+	for _, r := range rejected {
+		fmt.Fprintf(e.stderr, "semver %s: rejected %v\n", c.name, r)
+	}
+	if len(rejected) > 0 {
+		return exitFalse
+	}
+	return exitOK
+}
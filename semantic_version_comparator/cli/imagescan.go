@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/imagescan"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+var imageScanCmd = &command{
+	name:    "image-scan",
+	args:    "[-constraint [IMAGE=]RANGE]... [PATH...]",
+	summary: "report latest, non-version and out-of-range image tags in Dockerfiles and YAML; exit 1 on findings",
+	fields: []field{
+		{"file", "string"}, {"line", "number"}, {"image", "string"}, {"repository", "string"},
+		{"tag", "string"}, {"version", "string"}, {"problem", "string"}, {"message", "string"},
+	},
+	run: runImageScan,
+}
+
+// listFlag is a flag that may be repeated, collecting every value.
+type listFlag []string
+
+func (l *listFlag) String() string { return strings.Join(*l, ", ") }
+
+func (l *listFlag) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
+
+func runImageScan(e *env, c *command, args []string) int {
+	fs := c.flags(e)
+	var constraints listFlag
+	fs.Var(&constraints, "constraint", "allowed `[IMAGE=]RANGE` for images named IMAGE, or for all images; may be repeated")
+	format := outputFlag(fs)
+	if e.parse(c, fs, args) != nil {
+		return exitError
+	}
+	out, err := c.output(*format)
+	if err != nil {
+		return e.badUsage(c, "%v", err)
+	}
+	var rules []imagescan.Rule
+	for _, s := range constraints {
+		r, err := e.parseImageRule(s)
+		if err != nil {
+			return e.badUsage(c, "-constraint %q: %v", s, err)
+		}
+		rules = append(rules, r)
+	}
+	paths := fs.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	var imgs []imagescan.Image
+	for _, p := range paths {
+		found, err := imagescan.Walk(p)
+		if err != nil {
+			return e.fail(c, err)
+		}
+		imgs = append(imgs, found...)
+	}
+	report := imagescan.Check(imgs, rules)
+	code := exitOK
+	if len(report.Findings) > 0 {
+		code = exitFalse
+	}
+	if !out.text() {
+		for _, f := range report.Findings {
+			out.add(f.File, f.Line, f.Ref, f.Repository, f.Tag, f.Version, f.Problem, f.Message)
+		}
+		return e.write(c, out, code)
+	}
+	enc := json.NewEncoder(e.stdout)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(report); err != nil {
+		return e.fail(c, err)
+	}
+	return code
+}
+
+// parseImageRule parses "[IMAGE=]RANGE". The "=" only separates an image
+// if what precedes it is not part of a range, so ">=1.25" applies to
+// every image and "nginx=>=1.25" to nginx. RANGE may be an alias.
+func (e *env) parseImageRule(s string) (imagescan.Rule, error) {
+	var r imagescan.Rule
+	rng := s
+	if i := strings.IndexByte(s, '='); i > 0 && !strings.ContainsAny(s[:i], "<>!~^ ") {
+		r.Image, rng = s[:i], s[i+1:]
+	}
+	con, err := semver.ParseConstraint(e.constraint(rng))
+	if err != nil {
+		return r, fmt.Errorf("%v", strings.TrimPrefix(err.Error(), "semver: "))
+	}
+	r.Constraint = con
+	return r, nil
+}
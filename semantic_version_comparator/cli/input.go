@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// inputFlags registers the -file and -delim flags of the commands that
+// process one record per line.
+func inputFlags(fs *flag.FlagSet) (file, delim *string) {
+	file = fs.String("file", "", "read records from `FILE`, one per line; - is stdin")
+	delim = fs.String("delim", "", "field delimiter of -file records (default whitespace)")
+	return file, delim
+}
+
+// stdinArg reports whether args is the single argument "-", which asks
+// for records to be read from stdin as with -file -.
+func stdinArg(args []string) bool {
+	return len(args) == 1 && args[0] == "-"
+}
+
+// open returns the reader for name, which is stdin for "-".
+func (e *env) open(name string) (io.ReadCloser, error) {
+	if name == "-" {
+		return io.NopCloser(e.stdin), nil
+	}
+	return os.Open(name)
+}
+
+// batch reads the records of file and calls fn with the n fields of each
+// line. Blank lines and lines starting with "#" are skipped. Fields are
+// separated by delim, or by whitespace if delim is empty, in which case
+// the last field takes the rest of the line so that it may contain spaces,
+// as constraints such as ">=1.0.0 <2.0.0" do. A line with the wrong
+// number of fields, or for which fn fails, is reported on e.stderr with
+// its line number and counted in bad.
+func (e *env) batch(c *command, file, delim string, n int, fn func(fields []string) error) (lines, bad int, err error) {
+	r, err := e.open(file)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer r.Close()
+	sc := bufio.NewScanner(r)
+	for num := 1; sc.Scan(); num++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines++
+		fields := splitRecord(line, delim, n)
+		if len(fields) != n {
+			err = fmt.Errorf("want %d fields, got %d", n, len(fields))
+		} else {
+			err = fn(fields)
+		}
+		if err != nil {
+			fmt.Fprintf(e.stderr, "semver %s: %s:%d: %s\n", c.name, file, num, strings.TrimPrefix(err.Error(), "semver: "))
+			bad++
+		}
+	}
+	return lines, bad, sc.Err()
+}
+
+func splitRecord(line, delim string, n int) []string {
+	if delim != "" {
+		fields := strings.Split(line, delim)
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		return fields
+	}
+	fields := strings.Fields(line)
+	if len(fields) > n {
+		// Re-split so that the last field keeps its inner spacing.
+		rest := line
+		for i := 0; i < n-1; i++ {
+			rest = strings.TrimSpace(rest)
+			rest = rest[len(fields[i]):]
+		}
+		fields = append(fields[:n-1], strings.TrimSpace(rest))
+	}
+	return fields
+}
+
+// endBatch writes the structured output of a batch run, if any, and
+// prints its totals to e.stderr, keeping stdout for the per-line results.
+// It returns exitError if a line failed and code otherwise.
+func (e *env) endBatch(c *command, out *output, lines, bad int, counts string, code int) int {
+	if !out.text() {
+		if err := out.write(e.stdout); err != nil {
+			return e.fail(c, err)
+		}
+	}
+	fmt.Fprintf(e.stderr, "semver %s: %d lines: %s, %d errors\n", c.name, lines, counts, bad)
+	if bad > 0 {
+		return exitError
+	}
+	return code
+}
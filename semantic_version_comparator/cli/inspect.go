@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+var inspectCmd = &command{
+	name:    "inspect",
+	args:    "[-constraint RANGE]... [-dialect NAME] [-include-prerelease] [-output FORMAT] VERSION...|-",
+	summary: "describe versions as JSON: components, normalized form, channel, stability, next versions and the constraints they satisfy; exit 1 if any is invalid or unsatisfying",
+	fields: []field{
+		{"version", "string"}, {"valid", "boolean"}, {"error", "string"}, {"suggestion", "string"},
+		{"normalized", "string"}, {"strict", "boolean"},
+		{"epoch", "number"}, {"major", "number"}, {"minor", "number"}, {"patch", "number"},
+		{"prerelease", "array"}, {"build", "array"},
+		{"channel", "string"}, {"stability", "string"},
+		{"next", "object"}, {"constraints", "array"},
+	},
+	run: runInspect,
+}
+
+// inspectCheck is the check of a version against one -constraint, in
+// the constraints field of inspect.
+type inspectCheck struct {
+	Constraint string `json:"constraint"`
+	Satisfied  bool   `json:"satisfied"`
+	Reason     string `json:"reason"`
+}
+
+func runInspect(e *env, c *command, args []string) int {
+	fs := c.flags(e)
+	var constraints listFlag
+	fs.Var(&constraints, "constraint", "report whether each version satisfies `RANGE`; may be repeated")
+	dialectName := fs.String("dialect", "npm", "constraint syntax: npm, ruby, nuget, terraform or helm")
+	includePre := fs.Bool("include-prerelease", false, "let pre-releases satisfy ranges by precedence alone")
+	format := fs.String("output", formatJSON, "output `FORMAT`: text, json, csv or table")
+	if e.parse(c, fs, args) != nil {
+		return exitError
+	}
+	if fs.NArg() == 0 {
+		return e.badUsage(c, "want a version")
+	}
+	out, err := c.output(*format)
+	if err != nil {
+		return e.badUsage(c, "%v", err)
+	}
+	dialect, err := semver.ParseDialect(*dialectName)
+	if err != nil {
+		return e.fail(c, err)
+	}
+	opts := []semver.ConstraintOption{semver.WithDialect(dialect)}
+	if *includePre {
+		opts = append(opts, semver.IncludePrerelease())
+	}
+	cons := make([]semver.Constraint, len(constraints))
+	for i, s := range constraints {
+		if cons[i], err = semver.ParseConstraint(e.constraint(s), opts...); err != nil {
+			return e.fail(c, err)
+		}
+	}
+	versions := fs.Args()
+	if stdinArg(versions) {
+		if versions, err = readLines(io.NopCloser(e.stdin)); err != nil {
+			return e.fail(c, err)
+		}
+	}
+
+	code := exitOK
+	for _, s := range versions {
+		v, err := semver.ParseTolerant(s)
+		if err != nil {
+			code = exitFalse
+			hint := ""
+			if sg, ok := semver.Suggest(s); ok {
+				hint = sg.Version.String()
+			}
+			msg := strings.TrimPrefix(err.Error(), "semver: ")
+			if out.text() {
+				fmt.Fprintf(e.stdout, "%s\n  error        %s\n", s, msg)
+				if hint != "" {
+					fmt.Fprintf(e.stdout, "  suggestion   %s\n", hint)
+				}
+				continue
+			}
+			out.add(s, false, msg, hint, "", false, 0, 0, 0, 0, []string{}, []string{}, "", "", map[string]string{}, []inspectCheck{})
+			continue
+		}
+		_, strictErr := semver.ParseStrict(s)
+		next := map[string]string{
+			"major":      v.IncMajor().String(),
+			"minor":      v.IncMinor().String(),
+			"patch":      v.IncPatch().String(),
+			"prerelease": v.IncPrerelease().String(),
+		}
+		checks := make([]inspectCheck, len(cons))
+		for i, con := range cons {
+			d := con.CheckDetail(v)
+			checks[i] = inspectCheck{Constraint: constraints[i], Satisfied: d.Satisfied, Reason: d.String()}
+			if !d.Satisfied {
+				code = exitFalse
+			}
+		}
+		if !out.text() {
+			out.add(s, true, "", "", v.String(), strictErr == nil, v.Epoch, v.Major, v.Minor, v.Patch,
+				identifiers(v.Prerelease), identifiers(v.Build), v.Channel().String(), stability(v), next, checks)
+			continue
+		}
+		fmt.Fprintf(e.stdout, "%s\n", s)
+		fmt.Fprintf(e.stdout, "  normalized   %s\n", v)
+		if strictErr != nil {
+			fmt.Fprintf(e.stdout, "  strict       %s\n", strings.TrimPrefix(strictErr.Error(), "semver: "))
+		}
+		fmt.Fprintf(e.stdout, "  core         %d.%d.%d", v.Major, v.Minor, v.Patch)
+		if v.Epoch != 0 {
+			fmt.Fprintf(e.stdout, " (epoch %d)", v.Epoch)
+		}
+		fmt.Fprintln(e.stdout)
+		if v.Prerelease != "" {
+			fmt.Fprintf(e.stdout, "  pre-release  %s\n", v.Prerelease)
+		}
+		if v.Build != "" {
+			fmt.Fprintf(e.stdout, "  build        %s\n", v.Build)
+		}
+		fmt.Fprintf(e.stdout, "  channel      %v (%s)\n", v.Channel(), stability(v))
+		fmt.Fprintf(e.stdout, "  next         major %s, minor %s, patch %s, pre-release %s\n",
+			next["major"], next["minor"], next["patch"], next["prerelease"])
+		for _, ch := range checks {
+			fmt.Fprintf(e.stdout, "  %-12s %s\n", ch.Constraint, ch.Reason)
+		}
+	}
+	if !out.text() {
+		return e.write(c, out, code)
+	}
+	return code
+}
+
+// identifiers splits a pre-release or build metadata string into its
+// identifiers, with none for an empty string.
+func identifiers(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+	return strings.Split(s, ".")
+}
+
+// stability describes the promise v makes under SemVer: a pre-release
+// may be unstable, a 0.y.z release is in initial development, where
+// anything may change, and any other release is stable.
+func stability(v semver.Version) string {
+	switch {
+	case v.Prerelease != "":
+		return "pre-release"
+	case v.Major == 0:
+		return "initial-development"
+	}
+	return "stable"
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/conventional"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/gittag"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+var nextCmd = &command{
+	name:    "next",
+	args:    "[-prefix P] [REPO]",
+	summary: "print the next version implied by conventional commits since the latest tag",
+	fields:  []field{{"tag", "string"}, {"bump", "string"}, {"version", "string"}},
+	run:     runNext,
+}
+
+func runNext(e *env, c *command, args []string) int {
+	fs := c.flags(e)
+	prefix := fs.String("prefix", "", "only consider tags starting with `P`, such as api/")
+	verbose := fs.Bool("v", false, "also print the latest tag (- if none) and the bump, as TAG BUMP VERSION")
+	format := outputFlag(fs)
+	if e.parse(c, fs, args) != nil {
+		return exitError
+	}
+	out, err := c.output(*format)
+	if err != nil {
+		return e.badUsage(c, "%v", err)
+	}
+	repo := "."
+	switch fs.NArg() {
+	case 0:
+	case 1:
+		repo = fs.Arg(0)
+	default:
+		return e.badUsage(c, "want at most one repository")
+	}
+	ctx := context.Background()
+	tags, err := gittag.List(ctx, repo)
+	if err != nil {
+		return e.fail(c, err)
+	}
+	// Without a release tag every commit counts, starting from 0.0.0.
+	var base semver.Version
+	since := ""
+	if t, ok := gittag.Latest(tags, *prefix, false); ok {
+		base, since = t.Version, t.Name
+	}
+	msgs, err := gittag.Messages(ctx, repo, since)
+	if err != nil {
+		return e.fail(c, err)
+	}
+	change := conventional.Bump(msgs)
+	next := conventional.Next(base, change)
+	code := exitOK
+	if change == semver.DiffNone {
+		code = exitFalse
+	}
+	if !out.text() {
+		// The tag is empty, rather than "-", when there is none.
+		out.add(since, change.String(), next.String())
+		return e.write(c, out, code)
+	}
+	if *verbose {
+		tag := since
+		if tag == "" {
+			tag = "-"
+		}
+		fmt.Fprintln(e.stdout, tag, change, next)
+	} else {
+		fmt.Fprintln(e.stdout, next)
+	}
+	return code
+}
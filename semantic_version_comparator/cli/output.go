@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+)
+
+// Output formats accepted by -output. text is each command's own plain
+// output; the others render the command's result as records whose fields
+// are listed in the command help.
+const (
+	formatText  = "text"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+	formatTable = "table"
+)
+
+// field is a named, typed column of a command's structured output.
+type field struct {
+	name string
+	typ  string // JSON type: string, number, boolean, array or object
+}
+
+// outputFlag registers the -output flag on fs.
+func outputFlag(fs *flag.FlagSet) *string {
+	return fs.String("output", formatText, "output `FORMAT`: text, json, csv or table")
+}
+
+// output collects the records of a command for a structured format.
+type output struct {
+	format string
+	fields []field
+	rows   [][]any
+}
+
+// newOutput returns the output for format, which must be one of the
+// names accepted by -output, for a command with the given fields.
+func newOutput(format string, fields []field) (*output, error) {
+	switch format {
+	case formatText, formatJSON, formatCSV, formatTable:
+	default:
+		return nil, fmt.Errorf("-output must be text, json, csv or table, not %q", format)
+	}
+	return &output{format: format, fields: fields}, nil
+}
+
+// text reports whether the command should print its plain output.
+func (o *output) text() bool {
+	return o.format == formatText
+}
+
+// add records a row; values are given in field order.
+func (o *output) add(values ...any) {
+	o.rows = append(o.rows, values)
+}
+
+// write renders the recorded rows. JSON output is an array with one
+// object per row, keyed by field name, so that its shape does not depend
+// on the number of results.
+func (o *output) write(w io.Writer) error {
+	switch o.format {
+	case formatJSON:
+		var buf bytes.Buffer
+		buf.WriteString("[")
+		for i, row := range o.rows {
+			if i > 0 {
+				buf.WriteString(",")
+			}
+			buf.WriteString("\n  {")
+			for j, f := range o.fields {
+				if j > 0 {
+					buf.WriteString(", ")
+				}
+				if err := marshal(&buf, f.name); err != nil {
+					return err
+				}
+				buf.WriteString(": ")
+				if err := marshal(&buf, row[j]); err != nil {
+					return err
+				}
+			}
+			buf.WriteString("}")
+		}
+		if len(o.rows) > 0 {
+			buf.WriteString("\n")
+		}
+		buf.WriteString("]\n")
+		_, err := w.Write(buf.Bytes())
+		return err
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		cw.Write(o.names())
+		for _, row := range o.rows {
+			cw.Write(o.strings(row))
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.ToUpper(strings.Join(o.names(), "\t")))
+	for _, row := range o.rows {
+		fmt.Fprintln(tw, strings.Join(o.strings(row), "\t"))
+	}
+	return tw.Flush()
+}
+
+// marshal appends the JSON encoding of v to buf, leaving characters such
+// as "<" and ">" unescaped.
+func marshal(buf *bytes.Buffer, v any) error {
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	buf.Truncate(buf.Len() - 1) // Encode adds a newline
+	return nil
+}
+
+func (o *output) names() []string {
+	names := make([]string, len(o.fields))
+	for i, f := range o.fields {
+		names[i] = f.name
+	}
+	return names
+}
+
+// strings formats row for CSV and table output, writing arrays and
+// objects as JSON.
+func (o *output) strings(row []any) []string {
+	s := make([]string, len(row))
+	for i, v := range row {
+		s[i] = fmt.Sprint(v)
+		if k := reflect.ValueOf(v).Kind(); k == reflect.Slice || k == reflect.Map {
+			var buf bytes.Buffer
+			if marshal(&buf, v) == nil {
+				s[i] = buf.String()
+			}
+		}
+	}
+	return s
+}
+
+// schema describes the JSON output of a command with the given fields.
+func schema(fields []field) string {
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = fmt.Sprintf("%q: %s", f.name, f.typ)
+	}
+	return "[{" + strings.Join(parts, ", ") + "}, ...]"
+}
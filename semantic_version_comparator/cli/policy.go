@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/policy"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+var policyCmd = &command{
+	name:    "policy",
+	args:    "check -policy FILE [-env NAME] COMPONENT VERSION | [-file FILE] [-delim D] [-]",
+	summary: "print allow or deny and the deciding rule; exit 1 if any version is denied",
+	fields: []field{
+		{"component", "string"}, {"version", "string"}, {"allowed", "boolean"}, {"rule", "string"}, {"reason", "string"},
+	},
+	run: runPolicy,
+}
+
+func runPolicy(e *env, c *command, args []string) int {
+	if len(args) == 0 || args[0] != "check" {
+		return e.badUsage(c, "want the check subcommand")
+	}
+	fs := c.flags(e)
+	policyFile := fs.String("policy", "", "JSON or YAML policy `FILE`")
+	envName := fs.String("env", "", "evaluate rules limited to environment `NAME`")
+	file, delim := inputFlags(fs)
+	format := outputFlag(fs)
+	if e.parse(c, fs, args[1:]) != nil {
+		return exitError
+	}
+	if stdinArg(fs.Args()) {
+		*file = "-"
+	}
+	switch {
+	case *policyFile == "":
+		return e.badUsage(c, "want a policy file")
+	case *file != "" && fs.NArg() != 0 && !stdinArg(fs.Args()):
+		return e.badUsage(c, "-file takes no component or version arguments")
+	case *file == "" && fs.NArg() != 2:
+		return e.badUsage(c, "want a component and a version")
+	}
+	out, err := c.output(*format)
+	if err != nil {
+		return e.badUsage(c, "%v", err)
+	}
+	p, err := policy.ParseFile(*policyFile)
+	if err != nil {
+		return e.fail(c, err)
+	}
+	if *file != "" {
+		// Each line holds a component and a version; the per-line results
+		// are printed as "COMPONENT VERSION DECISION".
+		allowed, denied := 0, 0
+		lines, bad, err := e.batch(c, *file, *delim, 2, func(f []string) error {
+			v, err := semver.ParseTolerant(f[1])
+			if err != nil {
+				return err
+			}
+			d := p.EvaluateIn(*envName, f[0], v)
+			if d.Allowed {
+				allowed++
+			} else {
+				denied++
+			}
+			if out.text() {
+				fmt.Fprintln(e.stdout, f[0], f[1], decision(d))
+			} else {
+				out.add(f[0], v.String(), d.Allowed, d.Rule, d.Reason)
+			}
+			return nil
+		})
+		if err != nil {
+			return e.fail(c, err)
+		}
+		code := exitOK
+		if denied > 0 {
+			code = exitFalse
+		}
+		return e.endBatch(c, out, lines, bad, fmt.Sprintf("%d allowed, %d denied", allowed, denied), code)
+	}
+	v, err := semver.ParseTolerant(fs.Arg(1))
+	if err != nil {
+		return e.fail(c, err)
+	}
+	d := p.EvaluateIn(*envName, fs.Arg(0), v)
+	code := exitOK
+	if !d.Allowed {
+		code = exitFalse
+	}
+	if !out.text() {
+		out.add(fs.Arg(0), v.String(), d.Allowed, d.Rule, d.Reason)
+		return e.write(c, out, code)
+	}
+	fmt.Fprintln(e.stdout, decision(d))
+	return code
+}
+
+// decision formats d as "allow [RULE]" or "deny RULE: REASON".
+func decision(d policy.Decision) string {
+	switch {
+	case !d.Allowed:
+		return fmt.Sprintf("deny %s: %s", d.Rule, d.Reason)
+	case d.Rule == "":
+		return "allow"
+	}
+	return "allow " + d.Rule
+}
@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/scheme"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+var satisfiesCmd = &command{
+	name:    "satisfies",
+	args:    "[-scheme NAME | -dialect NAME [-include-prerelease] [-explain]] VERSION CONSTRAINT | [-file FILE] [-delim D] [-]",
+	summary: "print true or false; exit 1 if VERSION (or any -file record) does not satisfy CONSTRAINT",
+	fields:  []field{{"version", "string"}, {"constraint", "string"}, {"satisfies", "boolean"}},
+	run:     runSatisfies,
+}
+
+func runSatisfies(e *env, c *command, args []string) int {
+	fs := c.flags(e)
+	dialectName := fs.String("dialect", "npm", "constraint syntax: npm, ruby, nuget, terraform or helm")
+	includePre := fs.Bool("include-prerelease", false, "let pre-releases satisfy ranges by precedence alone")
+	explain := fs.Bool("explain", false, "print to stderr which comparators VERSION passed and failed")
+	schemeName := schemeFlag(fs)
+	file, delim := inputFlags(fs)
+	format := outputFlag(fs)
+	if e.parse(c, fs, args) != nil {
+		return exitError
+	}
+	if stdinArg(fs.Args()) {
+		*file = "-"
+	} else if *file != "" && fs.NArg() != 0 {
+		return e.badUsage(c, "-file takes no version or constraint arguments")
+	} else if *file == "" && fs.NArg() != 2 {
+		return e.badUsage(c, "want a version and a constraint")
+	}
+	if *explain && *file != "" {
+		return e.badUsage(c, "-explain takes a single version and constraint")
+	}
+	out, err := c.output(*format)
+	if err != nil {
+		return e.badUsage(c, "%v", err)
+	}
+	var check func(version, constraint string) (bool, error)
+	if *schemeName != "semver" {
+		// Other schemes have no dialects; their constraints are plain
+		// comparator lists.
+		set := false
+		fs.Visit(func(f *flag.Flag) {
+			set = set || f.Name == "dialect" || f.Name == "include-prerelease" || f.Name == "explain"
+		})
+		if set {
+			return e.badUsage(c, "-dialect, -include-prerelease and -explain only apply to the semver scheme")
+		}
+		sch, err := scheme.Lookup(*schemeName)
+		if err != nil {
+			return e.fail(c, err)
+		}
+		check = func(version, constraint string) (bool, error) {
+			con, err := scheme.ParseConstraint(sch, e.constraint(constraint))
+			if err != nil {
+				return false, err
+			}
+			return con.Check(version)
+		}
+	} else {
+		dialect, err := semver.ParseDialect(*dialectName)
+		if err != nil {
+			return e.fail(c, err)
+		}
+		opts := []semver.ConstraintOption{semver.WithDialect(dialect)}
+		if *includePre {
+			opts = append(opts, semver.IncludePrerelease())
+		}
+		check = func(version, constraint string) (bool, error) {
+			v, err := semver.ParseTolerant(version)
+			if err != nil {
+				return false, err
+			}
+			con, err := semver.ParseConstraint(e.constraint(constraint), opts...)
+			if err != nil {
+				return false, err
+			}
+			if *explain {
+				fmt.Fprintln(e.stderr, con.CheckDetail(v))
+			}
+			return con.Check(v), nil
+		}
+	}
+	if *file != "" {
+		// Each line holds a version and a constraint, which may contain
+		// spaces unless -delim is given; the per-line results are printed
+		// as "VERSION CONSTRAINT RESULT".
+		satisfied, unsatisfied := 0, 0
+		lines, bad, err := e.batch(c, *file, *delim, 2, func(f []string) error {
+			ok, err := check(f[0], f[1])
+			if err != nil {
+				return err
+			}
+			if ok {
+				satisfied++
+			} else {
+				unsatisfied++
+			}
+			if out.text() {
+				fmt.Fprintln(e.stdout, f[0], f[1], ok)
+			} else {
+				out.add(f[0], f[1], ok)
+			}
+			return nil
+		})
+		if err != nil {
+			return e.fail(c, err)
+		}
+		code := exitOK
+		if unsatisfied > 0 {
+			code = exitFalse
+		}
+		return e.endBatch(c, out, lines, bad,
+			fmt.Sprintf("%d satisfied, %d not satisfied", satisfied, unsatisfied), code)
+	}
+	ok, err := check(fs.Arg(0), fs.Arg(1))
+	if err != nil {
+		return e.fail(c, err)
+	}
+	code := exitOK
+	if !ok {
+		code = exitFalse
+	}
+	if !out.text() {
+		out.add(fs.Arg(0), fs.Arg(1), ok)
+		return e.write(c, out, code)
+	}
+	fmt.Fprintln(e.stdout, ok)
+	return code
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/service"
+)
+
+var serveCmd = &command{
+	name:    "serve",
+	args:    "[-addr ADDR] [-read-timeout D] [-write-timeout D] [-idle-timeout D]",
+	summary: "serve compare, sort and satisfies as a JSON HTTP API, with Prometheus metrics at /metrics, until interrupted",
+	run:     runServe,
+}
+
+func runServe(e *env, c *command, args []string) int {
+	fs := c.flags(e)
+	addr := fs.String("addr", "localhost:8080", "listen on `ADDR`")
+	readTimeout := fs.Duration("read-timeout", 10*time.Second, "maximum duration for reading a request")
+	writeTimeout := fs.Duration("write-timeout", 10*time.Second, "maximum duration for writing a response")
+	idleTimeout := fs.Duration("idle-timeout", 60*time.Second, "maximum time to keep idle connections open")
+	if e.parse(c, fs, args) != nil {
+		return exitError
+	}
+	if fs.NArg() != 0 {
+		return e.badUsage(c, "unexpected arguments")
+	}
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return e.fail(c, err)
+	}
+	srv := &http.Server{
+		Handler:           service.Handler(),
+		ReadHeaderTimeout: *readTimeout,
+		ReadTimeout:       *readTimeout,
+		WriteTimeout:      *writeTimeout,
+		IdleTimeout:       *idleTimeout,
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	errc := make(chan error, 1)
+	go func() { errc <- srv.Serve(ln) }()
+	fmt.Fprintf(e.stderr, "semver serve: listening on http://%s\n", ln.Addr())
+	select {
+	case err = <-errc:
+	case <-ctx.Done():
+		// Let in-flight requests finish before exiting.
+		shutdown, cancel := context.WithTimeout(context.Background(), *writeTimeout)
+		defer cancel()
+		err = srv.Shutdown(shutdown)
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return e.fail(c, err)
+	}
+	return exitOK
+}
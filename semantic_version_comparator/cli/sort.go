@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/scheme"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+var sortCmd = &command{
+	name:    "sort",
+	args:    "[-r] [-scheme NAME] [-label-order ORDER] [-invalid POLICY] [--stream [-chunk-size N] [-tmpdir DIR]] [-file FILE|-]",
+	summary: "sort versions read from -file or stdin, one per line",
+	fields:  []field{{"version", "string"}},
+	run:     runSort,
+}
+
+func runSort(e *env, c *command, args []string) int {
+	fs := c.flags(e)
+	reverse := fs.Bool("r", false, "sort in descending order")
+	schemeName := schemeFlag(fs)
+	labelOrder := labelOrderFlag(fs)
+	invalid := fs.String("invalid", "error", "handling of lines that are not versions: error, first or last")
+	stream := fs.Bool("stream", false, "use an external merge sort for inputs larger than memory")
+	chunkSize := fs.Int("chunk-size", 1000000, "lines held in memory per sorted run with --stream")
+	tmpDir := fs.String("tmpdir", "", "directory for --stream temporary files (default system temp dir)")
+	file := fs.String("file", "-", "read versions from `FILE`; - is stdin")
+	format := outputFlag(fs)
+	if e.parse(c, fs, args) != nil {
+		return exitError
+	}
+	if fs.NArg() != 0 && !stdinArg(fs.Args()) {
+		return e.badUsage(c, "unexpected arguments")
+	}
+	out, err := c.output(*format)
+	if err != nil {
+		return e.badUsage(c, "%v", err)
+	}
+	if *stream && !out.text() {
+		return e.badUsage(c, "--stream only supports -output text")
+	}
+	sch, err := scheme.Lookup(*schemeName)
+	if err != nil {
+		return e.fail(c, err)
+	}
+	if *stream && sch != scheme.Semver {
+		return e.badUsage(c, "--stream only supports the semver scheme")
+	}
+	var policy semver.InvalidPolicy
+	switch *invalid {
+	case "error":
+		policy = semver.InvalidError
+	case "first":
+		policy = semver.InvalidFirst
+	case "last":
+		policy = semver.InvalidLast
+	default:
+		return e.badUsage(c, "-invalid must be error, first or last")
+	}
+	if policy != semver.InvalidError && (*stream || sch != scheme.Semver) {
+		return e.badUsage(c, "-invalid only supports the semver scheme without --stream")
+	}
+	if *reverse {
+		// Keep invalid lines where asked once the output is reversed.
+		switch policy {
+		case semver.InvalidFirst:
+			policy = semver.InvalidLast
+		case semver.InvalidLast:
+			policy = semver.InvalidFirst
+		}
+	}
+	labels, err := labelComparator(*labelOrder)
+	if err != nil {
+		return e.badUsage(c, "-label-order: %v", strings.TrimPrefix(err.Error(), "semver: "))
+	}
+	if labels != nil && (*stream || sch != scheme.Semver || policy != semver.InvalidError) {
+		return e.badUsage(c, "-label-order only supports the semver scheme without --stream or -invalid")
+	}
+	if *stream && *chunkSize < 1 {
+		return e.badUsage(c, "-chunk-size must be positive")
+	}
+	in, err := e.open(*file)
+	if err != nil {
+		return e.fail(c, err)
+	}
+	defer in.Close()
+	if *stream {
+		dir := 1
+		if *reverse {
+			dir = -1
+		}
+		if err := externalSort(in, e.stdout, *tmpDir, *chunkSize, dir); err != nil {
+			return e.fail(c, err)
+		}
+		return exitOK
+	}
+	lines, err := readLines(in)
+	if err != nil {
+		return e.fail(c, err)
+	}
+	if labels != nil {
+		err = labels.Sort(lines)
+	} else if sch == scheme.Semver {
+		// Parse each line once rather than on every comparison.
+		err = semver.SortMixed(lines, policy)
+	} else {
+		err = scheme.Sort(sch, lines)
+	}
+	if err != nil {
+		return e.fail(c, err)
+	}
+	if *reverse {
+		slices.Reverse(lines)
+	}
+	if !out.text() {
+		for _, l := range lines {
+			out.add(l)
+		}
+		return e.write(c, out, exitOK)
+	}
+	for _, l := range lines {
+		fmt.Fprintln(e.stdout, l)
+	}
+	return exitOK
+}
@@ -0,0 +1,440 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/ghrelease"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/gittag"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/registry"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+var tuiCmd = &command{
+	name:    "tui",
+	args:    "[-dialect NAME] [-include-prerelease] [-page N] [-git REPO | -github OWNER/REPO | -image REF | -file FILE|-]",
+	summary: "explore a list of versions interactively: filter it, apply constraints and inspect why a version is or is not selected",
+	run:     runTUI,
+}
+
+// tuiHelp lists the commands of the tui prompt.
+const tuiHelp = `commands:
+  /TEXT        show only versions containing the letters of TEXT in order; / alone clears
+  c RANGE      check every version against the constraint RANGE; c alone clears
+  N            inspect the version numbered N: its parts and the constraint check
+  m            show only the versions that satisfy the constraint, or all again
+  n, p         next and previous page
+  ?            show this help
+  q            quit`
+
+func runTUI(e *env, c *command, args []string) int {
+	fs := c.flags(e)
+	dialectName := fs.String("dialect", "npm", "constraint syntax: npm, ruby, nuget, terraform or helm")
+	includePre := fs.Bool("include-prerelease", false, "let pre-releases satisfy ranges by precedence alone")
+	pageSize := fs.Int("page", 20, "versions shown per page")
+	repo := fs.String("git", "", "load the tags of the git repository or remote `REPO`")
+	gh := fs.String("github", "", "load the tags of the GitHub repository `OWNER/REPO`")
+	image := fs.String("image", "", "load the tags of the container image repository `REF`, such as ghcr.io/owner/app")
+	file := fs.String("file", "-", "load versions from `FILE`, one per line; - is stdin")
+	if e.parse(c, fs, args) != nil {
+		return exitError
+	}
+	if fs.NArg() != 0 && !stdinArg(fs.Args()) {
+		return e.badUsage(c, "unexpected arguments")
+	}
+	if *pageSize < 1 {
+		return e.badUsage(c, "-page must be positive")
+	}
+	sources := 0
+	for _, s := range []string{*repo, *gh, *image} {
+		if s != "" {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return e.badUsage(c, "give at most one of -git, -github and -image")
+	}
+	dialect, err := semver.ParseDialect(*dialectName)
+	if err != nil {
+		return e.fail(c, err)
+	}
+	opts := []semver.ConstraintOption{semver.WithDialect(dialect)}
+	if *includePre {
+		opts = append(opts, semver.IncludePrerelease())
+	}
+
+	ctx := context.Background()
+	var lines []string
+	var from string
+	commands := e.stdin
+	switch {
+	case *repo != "":
+		lines, err = gittag.List(ctx, *repo)
+		from = "tags of " + *repo
+	case *gh != "":
+		client := &ghrelease.Client{Token: os.Getenv("GITHUB_TOKEN"), HTTPClient: netClient}
+		lines, err = client.Tags(ctx, *gh)
+		from = "tags of github.com/" + *gh
+	case *image != "":
+		lines, err = (&registry.Client{HTTPClient: netClient}).Tags(ctx, *image)
+		from = "tags of " + *image
+	default:
+		var in io.ReadCloser
+		if in, err = e.open(*file); err != nil {
+			break
+		}
+		lines, err = readLines(in)
+		in.Close()
+		from = *file
+		if *file == "-" {
+			// The versions use up stdin, so commands come from the
+			// terminal.
+			from = "stdin"
+			tty, ttyErr := os.Open("/dev/tty")
+			if ttyErr != nil {
+				return e.badUsage(c, "reading versions from stdin needs a terminal for commands; use -file")
+			}
+			defer tty.Close()
+			commands = tty
+		}
+	}
+	if err != nil {
+		return e.fail(c, err)
+	}
+
+	s := newTUISession(lines, opts, *pageSize)
+	s.from = from
+	s.ansi = isTerminal(e.stdout)
+	s.run(e, commands)
+	return exitOK
+}
+
+// isTerminal reports whether w is a terminal, for clearing the screen
+// between views.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// tuiEntry is one line of the loaded list.
+type tuiEntry struct {
+	line string
+	v    semver.Version
+	err  error // the parse error, if line is not a version
+}
+
+// tuiSession is the state of an interactive session: the loaded
+// versions, newest first with lines that are not versions last, and the
+// filter, constraint and page applied to them.
+type tuiSession struct {
+	entries  []tuiEntry
+	opts     []semver.ConstraintOption
+	pageSize int
+	from     string
+	ansi     bool
+
+	filter   string
+	con      *semver.Constraint
+	matching bool // show only the versions that satisfy con
+	page     int
+	view     []int  // indexes into entries of the versions shown
+	panel    string // the output of the last command
+}
+
+func newTUISession(lines []string, opts []semver.ConstraintOption, pageSize int) *tuiSession {
+	s := &tuiSession{opts: opts, pageSize: pageSize}
+	for _, l := range lines {
+		l = strings.TrimSpace(l)
+		if l == "" {
+			continue
+		}
+		v, err := semver.ParseTolerant(l)
+		s.entries = append(s.entries, tuiEntry{line: l, v: v, err: err})
+	}
+	slices.SortStableFunc(s.entries, func(a, b tuiEntry) int {
+		switch {
+		case a.err != nil && b.err != nil:
+			return strings.Compare(a.line, b.line)
+		case a.err != nil:
+			return 1
+		case b.err != nil:
+			return -1
+		}
+		return b.v.Compare(a.v)
+	})
+	s.update()
+	return s
+}
+
+// run reads commands from r until "q" or the end of input, showing the
+// session after each.
+func (s *tuiSession) run(e *env, r io.Reader) {
+	sc := bufio.NewScanner(r)
+	for {
+		s.render(e.stdout)
+		if !sc.Scan() {
+			fmt.Fprintln(e.stdout)
+			return
+		}
+		if !s.do(strings.TrimSpace(sc.Text())) {
+			return
+		}
+	}
+}
+
+// do runs the command cmd and reports whether the session goes on.
+func (s *tuiSession) do(cmd string) bool {
+	s.panel = ""
+	switch {
+	case cmd == "":
+	case cmd == "q" || cmd == "quit":
+		return false
+	case cmd == "?" || cmd == "help":
+		s.panel = tuiHelp
+	case cmd == "n":
+		if (s.page+1)*s.pageSize < len(s.view) {
+			s.page++
+		}
+	case cmd == "p":
+		if s.page > 0 {
+			s.page--
+		}
+	case cmd == "m":
+		if s.con == nil {
+			s.panel = "no constraint; set one with c RANGE"
+			break
+		}
+		s.matching = !s.matching
+		s.update()
+	case strings.HasPrefix(cmd, "/"):
+		s.filter = strings.TrimSpace(cmd[1:])
+		s.update()
+	case cmd == "c" || strings.HasPrefix(cmd, "c "):
+		text := strings.TrimSpace(cmd[1:])
+		if text == "" {
+			s.con, s.matching = nil, false
+			s.update()
+			break
+		}
+		con, err := semver.ParseConstraint(text, s.opts...)
+		if err != nil {
+			s.panel = "error: " + strings.TrimPrefix(err.Error(), "semver: ")
+			break
+		}
+		s.con = &con
+		s.update()
+	default:
+		n, err := strconv.Atoi(cmd)
+		if err != nil {
+			s.panel = fmt.Sprintf("unknown command %q; ? shows the commands", cmd)
+			break
+		}
+		if n < 1 || n > len(s.view) {
+			s.panel = fmt.Sprintf("no version numbered %d", n)
+			break
+		}
+		s.panel = s.inspect(s.entries[s.view[n-1]])
+	}
+	return true
+}
+
+// update recomputes the versions shown after a change of the filter or
+// constraint, returning to the first page.
+func (s *tuiSession) update() {
+	s.view = s.view[:0]
+	for i, en := range s.entries {
+		if !fuzzyMatch(en.line, s.filter) {
+			continue
+		}
+		if s.matching && (en.err != nil || !s.con.Check(en.v)) {
+			continue
+		}
+		s.view = append(s.view, i)
+	}
+	s.page = 0
+}
+
+// fuzzyMatch reports whether the letters of pattern appear in s in order,
+// ignoring case, so "12rc" matches "v1.2.0-rc.1".
+func fuzzyMatch(s, pattern string) bool {
+	s, pattern = strings.ToLower(s), strings.ToLower(pattern)
+	for _, r := range pattern {
+		i := strings.IndexRune(s, r)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(string(r)):]
+	}
+	return true
+}
+
+// selected returns the index into entries of the highest version that
+// satisfies the constraint, the one automation resolving it would pick,
+// or -1.
+func (s *tuiSession) selected() int {
+	if s.con == nil {
+		return -1
+	}
+	for i, en := range s.entries {
+		if en.err == nil && s.con.Check(en.v) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *tuiSession) render(w io.Writer) {
+	if s.ansi {
+		fmt.Fprint(w, "\x1b[H\x1b[2J")
+	}
+	valid := 0
+	for _, en := range s.entries {
+		if en.err == nil {
+			valid++
+		}
+	}
+	fmt.Fprintf(w, "semver tui: %d versions from %s", valid, s.from)
+	if invalid := len(s.entries) - valid; invalid > 0 {
+		fmt.Fprintf(w, ", %d lines that are not versions", invalid)
+	}
+	fmt.Fprintln(w)
+	sel := s.selected()
+	var status []string
+	if s.filter != "" {
+		status = append(status, fmt.Sprintf("filter %q", s.filter))
+	}
+	if s.con != nil {
+		n := 0
+		for _, en := range s.entries {
+			if en.err == nil && s.con.Check(en.v) {
+				n++
+			}
+		}
+		c := fmt.Sprintf("constraint %s: %d satisfy", s.con, n)
+		if sel >= 0 {
+			c += ", selected " + s.entries[sel].line
+		}
+		if s.matching {
+			c += ", showing only those"
+		}
+		status = append(status, c)
+	}
+	if len(status) > 0 {
+		fmt.Fprintln(w, strings.Join(status, "; "))
+	}
+	fmt.Fprintln(w)
+
+	start := s.page * s.pageSize
+	end := min(start+s.pageSize, len(s.view))
+	if len(s.view) == 0 {
+		fmt.Fprintln(w, "  no versions match")
+	}
+	width := 0
+	for _, i := range s.view[start:end] {
+		width = max(width, len(s.entries[i].line))
+	}
+	for k := start; k < end; k++ {
+		i := s.view[k]
+		en := s.entries[i]
+		mark, note := " ", ""
+		switch {
+		case en.err != nil:
+			mark, note = "!", "not a version"
+		case s.con != nil && s.con.Check(en.v):
+			mark = s.color("✓", "32")
+			if i == sel {
+				note = "<- selected"
+			}
+		case s.con != nil:
+			mark, note = s.color("✗", "31"), rejection(s.con.CheckDetail(en.v))
+		}
+		row := fmt.Sprintf("%4d %s %-*s  %s", k+1, mark, width, en.line, note)
+		fmt.Fprintln(w, strings.TrimRight(row, " "))
+	}
+	if pages := (len(s.view) + s.pageSize - 1) / s.pageSize; pages > 1 {
+		fmt.Fprintf(w, "\npage %d of %d\n", s.page+1, pages)
+	}
+	if s.panel != "" {
+		fmt.Fprintf(w, "\n%s\n", s.panel)
+	}
+	fmt.Fprint(w, "\n/TEXT filter, c RANGE constraint, N inspect, m matching, n/p page, ? help, q quit\n> ")
+}
+
+// color wraps text in the ANSI color code when writing to a terminal.
+func (s *tuiSession) color(text, code string) string {
+	if !s.ansi {
+		return text
+	}
+	return "\x1b[" + code + "m" + text + "\x1b[0m"
+}
+
+// rejection summarizes why d rejects its version, from the first
+// alternative of the constraint.
+func rejection(d semver.Detail) string {
+	if len(d.Clauses) == 0 {
+		return "matches no version"
+	}
+	r := d.Clauses[0]
+	if r.PrereleaseExcluded {
+		return "pre-release excluded"
+	}
+	var failed []string
+	for _, c := range r.Comparators {
+		if !c.Passed {
+			failed = append(failed, c.String())
+		}
+	}
+	note := "fails " + strings.Join(failed, " ")
+	if len(d.Clauses) > 1 {
+		note += fmt.Sprintf(" (and %d more alternatives)", len(d.Clauses)-1)
+	}
+	return note
+}
+
+// inspect describes how en parses and, with a constraint, how it is
+// checked.
+func (s *tuiSession) inspect(en tuiEntry) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n", en.line)
+	if en.err != nil {
+		fmt.Fprintf(&b, "  error       %s\n", strings.TrimPrefix(en.err.Error(), "semver: "))
+		if sg, ok := semver.Suggest(en.line); ok {
+			fmt.Fprintf(&b, "  suggestion  %s (%s)\n", sg, sg.Reason)
+		}
+		return strings.TrimRight(b.String(), "\n")
+	}
+	v := en.v
+	fmt.Fprintf(&b, "  version     %s\n", v)
+	if v.Epoch != 0 {
+		fmt.Fprintf(&b, "  epoch       %d\n", v.Epoch)
+	}
+	fmt.Fprintf(&b, "  core        major %d, minor %d, patch %d\n", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		fmt.Fprintf(&b, "  pre-release %s (channel %v)\n", v.Prerelease, v.Channel())
+	} else {
+		fmt.Fprintln(&b, "  pre-release none (a release)")
+	}
+	if v.Build != "" {
+		fmt.Fprintf(&b, "  build       %s (ignored for precedence)\n", v.Build)
+	}
+	if _, err := semver.ParseStrict(en.line); err != nil {
+		fmt.Fprintf(&b, "  strict      %s\n", strings.TrimPrefix(err.Error(), "semver: "))
+	}
+	if s.con != nil {
+		fmt.Fprintf(&b, "  constraint  %s\n", s.con.CheckDetail(v))
+		if sel := s.selected(); sel >= 0 && s.entries[sel].line != en.line {
+			fmt.Fprintf(&b, "  selected    %s is the highest version that satisfies %s\n", s.entries[sel].line, s.con)
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/manifest"
+)
+
+var upgradeReportCmd = &command{
+	name:    "upgrade-report",
+	args:    "[-format go|npm|pip] [-fail-on KINDS] [-allow NAMES] OLD NEW",
+	summary: "classify dependency changes between two manifests; exit 1 on any -fail-on kind",
+	fields: []field{
+		{"name", "string"}, {"from", "string"}, {"to", "string"}, {"kind", "string"}, {"breaking", "boolean"},
+	},
+	run: runUpgradeReport,
+}
+
+func runUpgradeReport(e *env, c *command, args []string) int {
+	fs := c.flags(e)
+	formatName := fs.String("format", "", "manifest `FORMAT`: go, npm or pip (default from the file names)")
+	failOn := fs.String("fail-on", "", "comma-separated change `KINDS` that fail the report: added, removed, downgrade, patch, minor, major, changed or breaking")
+	allow := fs.String("allow", "", "comma-separated dependency `NAMES` whose changes never fail the report")
+	format := outputFlag(fs)
+	if e.parse(c, fs, args) != nil {
+		return exitError
+	}
+	if fs.NArg() != 2 {
+		return e.badUsage(c, "want an old and a new manifest")
+	}
+	out, err := c.output(*format)
+	if err != nil {
+		return e.badUsage(c, "%v", err)
+	}
+	failing := map[string]bool{}
+	for _, k := range splitList(*failOn) {
+		if _, err := manifest.ParseKind(k); err != nil && k != "breaking" {
+			return e.badUsage(c, "-fail-on: unknown change kind %q", k)
+		}
+		failing[k] = true
+	}
+	allowed := map[string]bool{}
+	for _, name := range splitList(*allow) {
+		allowed[name] = true
+	}
+	var mf manifest.Format
+	if *formatName != "" {
+		if mf, err = manifest.ParseFormat(*formatName); err != nil {
+			return e.badUsage(c, "-format must be go, npm or pip, not %q", *formatName)
+		}
+	} else {
+		a, errA := manifest.Detect(fs.Arg(0))
+		b, errB := manifest.Detect(fs.Arg(1))
+		switch {
+		case errA != nil:
+			return e.fail(c, fmt.Errorf("%v; use -format", errA))
+		case errB != nil:
+			return e.fail(c, fmt.Errorf("%v; use -format", errB))
+		case a != b:
+			return e.fail(c, fmt.Errorf("manifests are of different formats, %v and %v", a, b))
+		}
+		mf = a
+	}
+	var deps [2][]manifest.Dependency
+	for i := range deps {
+		r, err := e.open(fs.Arg(i))
+		if err != nil {
+			return e.fail(c, err)
+		}
+		data, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			return e.fail(c, err)
+		}
+		if deps[i], err = manifest.Parse(mf, data); err != nil {
+			return e.fail(c, fmt.Errorf("%s: %v", fs.Arg(i), err))
+		}
+	}
+	code := exitOK
+	for _, ch := range manifest.Diff(mf, deps[0], deps[1]) {
+		if !allowed[ch.Name] && (failing[ch.Kind.String()] || ch.Breaking && failing["breaking"]) {
+			code = exitFalse
+		}
+		if !out.text() {
+			out.add(ch.Name, ch.From, ch.To, ch.Kind.String(), ch.Breaking)
+			continue
+		}
+		from, to := ch.From, ch.To
+		if from == "" {
+			from = "-"
+		}
+		if to == "" {
+			to = "-"
+		}
+		line := fmt.Sprintf("%s %s -> %s %s", ch.Name, from, to, ch.Kind)
+		if ch.Breaking {
+			line += " breaking"
+		}
+		fmt.Fprintln(e.stdout, line)
+	}
+	if !out.text() {
+		return e.write(c, out, code)
+	}
+	return code
+}
+
+// splitList splits a comma-separated flag value, dropping empty items.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
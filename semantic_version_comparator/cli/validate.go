@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+var validateCmd = &command{
+	name:    "validate",
+	args:    "[-loose] [-file FILE] [VERSION...|-]",
+	summary: "check versions (from arguments, -file or stdin); exit 1 if any is invalid",
+	fields:  []field{{"version", "string"}, {"valid", "boolean"}, {"error", "string"}, {"suggestion", "string"}},
+	run:     runValidate,
+}
+
+func runValidate(e *env, c *command, args []string) int {
+	fs := c.flags(e)
+	loose := fs.Bool("loose", false, "accept versions that ParseTolerant can coerce")
+	file := fs.String("file", "", "read versions from `FILE`, one per line, and print a summary; - is stdin")
+	format := outputFlag(fs)
+	if e.parse(c, fs, args) != nil {
+		return exitError
+	}
+	out, err := c.output(*format)
+	if err != nil {
+		return e.badUsage(c, "%v", err)
+	}
+	versions := fs.Args()
+	if stdinArg(versions) {
+		*file = "-"
+	} else if *file != "" && len(versions) > 0 {
+		return e.badUsage(c, "-file takes no version arguments")
+	}
+	if *file != "" || len(versions) == 0 {
+		r := io.NopCloser(e.stdin)
+		if *file != "" {
+			if r, err = e.open(*file); err != nil {
+				return e.fail(c, err)
+			}
+		}
+		versions, err = readLines(r)
+		r.Close()
+		if err != nil {
+			return e.fail(c, err)
+		}
+	}
+	parse := semver.ParseStrict
+	if *loose {
+		parse = semver.ParseTolerant
+	}
+	code, invalid := exitOK, 0
+	for _, s := range versions {
+		_, err := parse(s)
+		if err != nil {
+			code = exitFalse
+			invalid++
+		}
+		var msg, hint string
+		if err != nil {
+			msg = err.Error()
+			if sg, ok := semver.Suggest(s); ok {
+				hint = sg.Version.String()
+			}
+		}
+		switch {
+		case !out.text():
+			out.add(s, err == nil, msg, hint)
+		case hint != "":
+			fmt.Fprintf(e.stderr, "%s; did you mean %s?\n", msg, hint)
+		case err != nil:
+			fmt.Fprintln(e.stderr, msg)
+		}
+	}
+	if !out.text() {
+		code = e.write(c, out, code)
+	}
+	if *file != "" {
+		fmt.Fprintf(e.stderr, "semver %s: %d lines: %d valid, %d invalid\n", c.name, len(versions), len(versions)-invalid, invalid)
+	}
+	return code
+}
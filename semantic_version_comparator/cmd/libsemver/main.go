@@ -0,0 +1,98 @@
+// Command libsemver is a C shared library exposing version comparison,
+// validation and constraint checks, so that programs in other languages
+// link against this implementation rather than re-implementing
+// pre-release precedence. Build it with:
+//
+//	go build -buildmode=c-shared -o libsemver.so ./cmd/libsemver
+//
+// which also writes the header libsemver.h. Functions that fail store a
+// message in *err, if err is not NULL; the caller releases it with
+// semver_free.
+package main
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/service"
+)
+
+func main() {}
+
+// semver_compare returns -1, 0 or 1 as a orders before, equal to or after
+// b in the named scheme (NULL or "" means "semver"), or -2 on error.
+//
+//export semver_compare
+func semver_compare(a, b, scheme *C.char, err **C.char) C.int {
+	resp, e := service.Compare(service.CompareRequest{A: goString(a), B: goString(b), Scheme: goString(scheme)})
+	if e != nil {
+		setError(err, e)
+		return -2
+	}
+	return C.int(resp.Result)
+}
+
+// semver_validate returns 1 if version is a valid semantic version and 0
+// otherwise, with the reason in *err. If loose is non-zero, versions such
+// as "v1.2" that can be coerced are accepted.
+//
+//export semver_validate
+func semver_validate(version *C.char, loose C.int, err **C.char) C.int {
+	parse := semver.ParseStrict
+	if loose != 0 {
+		parse = semver.ParseTolerant
+	}
+	if _, e := parse(goString(version)); e != nil {
+		setError(err, e)
+		return 0
+	}
+	return 1
+}
+
+// semver_satisfies returns 1 if version satisfies constraint in the
+// given dialect (NULL or "" means "npm"), 0 if it does not and -1 on
+// error. If include_prerelease is non-zero, pre-releases satisfy ranges
+// by precedence alone.
+//
+//export semver_satisfies
+func semver_satisfies(version, constraint, dialect *C.char, include_prerelease C.int, err **C.char) C.int {
+	resp, e := service.Satisfies(service.SatisfiesRequest{
+		Version:           goString(version),
+		Constraint:        goString(constraint),
+		Dialect:           goString(dialect),
+		IncludePrerelease: include_prerelease != 0,
+	})
+	if e != nil {
+		setError(err, e)
+		return -1
+	}
+	if resp.Satisfies {
+		return 1
+	}
+	return 0
+}
+
+// semver_free releases a string returned by this library.
+//
+//export semver_free
+func semver_free(p *C.char) {
+	C.free(unsafe.Pointer(p))
+}
+
+func goString(s *C.char) string {
+	if s == nil {
+		return ""
+	}
+	return C.GoString(s)
+}
+
+func setError(dst **C.char, err error) {
+	if dst != nil {
+		*dst = C.CString(err.Error())
+	}
+}
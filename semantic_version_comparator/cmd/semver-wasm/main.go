@@ -0,0 +1,121 @@
+//go:build js && wasm
+
+// Command semver-wasm exposes compare, satisfies and sort to JavaScript
+// when built for WebAssembly, so that browsers order versions exactly as
+// the semver command does. It installs a global semverWasm object whose
+// functions return {result} on success and {error} on failure; see
+// examples/wasm for the wrapper that turns the latter into exceptions.
+//
+// Build it with:
+//
+//	GOOS=js GOARCH=wasm go build -o examples/wasm/semver.wasm ./cmd/semver-wasm
+//	cp "$(go env GOROOT)/lib/wasm/wasm_exec.js" examples/wasm/
+package main
+
+import (
+	"fmt"
+	"syscall/js"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/service"
+)
+
+func main() {
+	js.Global().Set("semverWasm", js.ValueOf(map[string]any{
+		"compare":   js.FuncOf(compare),
+		"satisfies": js.FuncOf(satisfies),
+		"sort":      js.FuncOf(sortVersions),
+	}))
+	// Keep the functions alive for the lifetime of the page.
+	select {}
+}
+
+// compare(a, b, scheme?) returns -1, 0 or 1.
+func compare(_ js.Value, args []js.Value) any {
+	if len(args) < 2 {
+		return failure(fmt.Errorf("compare: want two versions"))
+	}
+	resp, err := service.Compare(service.CompareRequest{
+		A:      args[0].String(),
+		B:      args[1].String(),
+		Scheme: option(args, 2, ""),
+	})
+	if err != nil {
+		return failure(err)
+	}
+	return success(resp.Result)
+}
+
+// satisfies(version, constraint, {dialect, includePrerelease}?) returns a
+// boolean.
+func satisfies(_ js.Value, args []js.Value) any {
+	if len(args) < 2 {
+		return failure(fmt.Errorf("satisfies: want a version and a constraint"))
+	}
+	resp, err := service.Satisfies(service.SatisfiesRequest{
+		Version:           args[0].String(),
+		Constraint:        args[1].String(),
+		Dialect:           option(args, 2, "dialect"),
+		IncludePrerelease: flag(args, 2, "includePrerelease"),
+	})
+	if err != nil {
+		return failure(err)
+	}
+	return success(resp.Satisfies)
+}
+
+// sort(versions, {scheme, reverse}?) returns a new sorted array.
+func sortVersions(_ js.Value, args []js.Value) any {
+	if len(args) < 1 || args[0].Type() != js.TypeObject || !js.Global().Get("Array").Call("isArray", args[0]).Bool() {
+		return failure(fmt.Errorf("sort: want an array of versions"))
+	}
+	vs := make([]string, args[0].Length())
+	for i := range vs {
+		vs[i] = args[0].Index(i).String()
+	}
+	resp, err := service.Sort(service.SortRequest{
+		Versions: vs,
+		Scheme:   option(args, 1, "scheme"),
+		Reverse:  flag(args, 1, "reverse"),
+	})
+	if err != nil {
+		return failure(err)
+	}
+	out := make([]any, len(resp.Versions))
+	for i, v := range resp.Versions {
+		out[i] = v
+	}
+	return success(out)
+}
+
+// option returns the string argument i or, if key is not empty, the
+// property key of the options object at i. It returns "" if either is
+// missing.
+func option(args []js.Value, i int, key string) string {
+	if i >= len(args) {
+		return ""
+	}
+	v := args[i]
+	if key != "" {
+		if v.Type() != js.TypeObject {
+			return ""
+		}
+		v = v.Get(key)
+	}
+	if v.Type() != js.TypeString {
+		return ""
+	}
+	return v.String()
+}
+
+// flag reports whether property key of the options object at i is truthy.
+func flag(args []js.Value, i int, key string) bool {
+	return i < len(args) && args[i].Type() == js.TypeObject && args[i].Get(key).Truthy()
+}
+
+func success(v any) any {
+	return map[string]any{"result": v}
+}
+
+func failure(err error) any {
+	return map[string]any{"error": err.Error()}
+}
@@ -0,0 +1,16 @@
+// Command semver compares, sorts, validates and bumps semantic versions.
+//
+// Exit codes are designed for shell scripts: 0 means success (or "equal"
+// for compare), 1 means a negative answer (less-than for compare, an
+// invalid version, an unsatisfied constraint), 2 is greater-than for
+// compare and 3 reports a usage or input error.
+//
+// Every command accepts -output json, csv or table in place of its plain
+// output, for consumption by other tools.
+package main
+
+import "github.com/ketiyohannes/sample-dataset/semantic_version_comparator/cli"
+
+func main() {
+	cli.Main()
+}
@@ -0,0 +1,107 @@
+// Package conventional classifies commit messages that follow the
+// Conventional Commits 1.0.0 specification and derives the semantic
+// version bump they call for.
+package conventional
+
+import (
+	"strings"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+// Commit is a parsed conventional commit message.
+type Commit struct {
+	// Type is the lower-cased commit type, such as "feat" or "fix".
+	Type  string
+	Scope string
+	// Breaking is set by a "!" before the colon or by a BREAKING CHANGE
+	// footer.
+	Breaking    bool
+	Description string
+	// Body is everything after the header, including footers.
+	Body string
+}
+
+// Parse parses a commit message whose header has the form
+// "type(scope)!: description". ok is false if the header does not follow
+// the convention.
+func Parse(message string) (c Commit, ok bool) {
+	header, body, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	prefix, desc, found := strings.Cut(header, ":")
+	if !found || !strings.HasPrefix(desc, " ") || strings.TrimSpace(desc) == "" {
+		return Commit{}, false
+	}
+	if strings.HasSuffix(prefix, "!") {
+		c.Breaking = true
+		prefix = prefix[:len(prefix)-1]
+	}
+	if i := strings.IndexByte(prefix, '('); i >= 0 {
+		if !strings.HasSuffix(prefix, ")") {
+			return Commit{}, false
+		}
+		c.Scope = prefix[i+1 : len(prefix)-1]
+		prefix = prefix[:i]
+		if c.Scope == "" {
+			return Commit{}, false
+		}
+	}
+	if prefix == "" {
+		return Commit{}, false
+	}
+	for i := 0; i < len(prefix); i++ {
+		if ch := prefix[i]; !(ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9' || ch == '-') {
+			return Commit{}, false
+		}
+	}
+	c.Type = strings.ToLower(prefix)
+	c.Description = strings.TrimSpace(desc)
+	c.Body = strings.TrimSpace(body)
+	for _, line := range strings.Split(c.Body, "\n") {
+		if strings.HasPrefix(line, "BREAKING CHANGE:") || strings.HasPrefix(line, "BREAKING-CHANGE:") {
+			c.Breaking = true
+		}
+	}
+	return c, true
+}
+
+// Bump returns the release the commit calls for: DiffMajor for a breaking
+// change, DiffMinor for a feature, DiffPatch for a fix and DiffNone for
+// any other type.
+func (c Commit) Bump() semver.Change {
+	switch {
+	case c.Breaking:
+		return semver.DiffMajor
+	case c.Type == "feat":
+		return semver.DiffMinor
+	case c.Type == "fix":
+		return semver.DiffPatch
+	}
+	return semver.DiffNone
+}
+
+// Bump returns the most significant bump called for by messages.
+// Messages that are not conventional commits are ignored.
+func Bump(messages []string) semver.Change {
+	change := semver.DiffNone
+	for _, m := range messages {
+		if c, ok := Parse(m); ok {
+			change = max(change, c.Bump())
+		}
+	}
+	return change
+}
+
+// Next returns the version that follows v after a release with the given
+// change: IncMajor for DiffMajor, IncMinor for DiffMinor, IncPatch for
+// DiffPatch. Any other change returns v unchanged.
+func Next(v semver.Version, change semver.Change) semver.Version {
+	switch change {
+	case semver.DiffMajor:
+		return v.IncMajor()
+	case semver.DiffMinor:
+		return v.IncMinor()
+	case semver.DiffPatch:
+		return v.IncPatch()
+	}
+	return v
+}
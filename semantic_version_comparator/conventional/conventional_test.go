@@ -0,0 +1,79 @@
+package conventional
+
+import (
+	"testing"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		message string
+		want    Commit
+		ok      bool
+	}{
+		{"feat: add a flag", Commit{Type: "feat", Description: "add a flag"}, true},
+		{"fix(parser): handle v", Commit{Type: "fix", Scope: "parser", Description: "handle v"}, true},
+		{"Feat!: drop Go 1.20", Commit{Type: "feat", Breaking: true, Description: "drop Go 1.20"}, true},
+		{"refactor(api)!: rename", Commit{Type: "refactor", Scope: "api", Breaking: true, Description: "rename"}, true},
+		{
+			"fix: x\n\nlonger text\n\nBREAKING CHANGE: y is gone",
+			Commit{Type: "fix", Breaking: true, Description: "x", Body: "longer text\n\nBREAKING CHANGE: y is gone"},
+			true,
+		},
+		{"chore: x\n\nBREAKING-CHANGE: z", Commit{Type: "chore", Breaking: true, Description: "x", Body: "BREAKING-CHANGE: z"}, true},
+		{"docs: mention BREAKING CHANGE: inline", Commit{Type: "docs", Description: "mention BREAKING CHANGE: inline"}, true},
+		{"Update README", Commit{}, false},
+		{"feat:no space", Commit{}, false},
+		{"feat: ", Commit{}, false},
+		{"feat(): empty scope", Commit{}, false},
+		{"feat(api: unclosed", Commit{}, false},
+		{": no type", Commit{}, false},
+		{"feat fix: spaces", Commit{}, false},
+		{"Merge branch 'main': x", Commit{}, false},
+	}
+	for _, tt := range tests {
+		c, ok := Parse(tt.message)
+		if ok != tt.ok || c != tt.want {
+			t.Errorf("Parse(%q) = %+v, %v; want %+v, %v", tt.message, c, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestBump(t *testing.T) {
+	tests := []struct {
+		messages []string
+		want     semver.Change
+	}{
+		{nil, semver.DiffNone},
+		{[]string{"docs: x", "Update README"}, semver.DiffNone},
+		{[]string{"docs: x", "fix: y"}, semver.DiffPatch},
+		{[]string{"fix: y", "feat: z", "fix: w"}, semver.DiffMinor},
+		{[]string{"feat: z", "chore!: drop support"}, semver.DiffMajor},
+		{[]string{"fix: y\n\nBREAKING CHANGE: z"}, semver.DiffMajor},
+	}
+	for _, tt := range tests {
+		if got := Bump(tt.messages); got != tt.want {
+			t.Errorf("Bump(%q) = %v, want %v", tt.messages, got, tt.want)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		v      string
+		change semver.Change
+		want   string
+	}{
+		{"1.2.3", semver.DiffMajor, "2.0.0"},
+		{"1.2.3", semver.DiffMinor, "1.3.0"},
+		{"1.2.3", semver.DiffPatch, "1.2.4"},
+		{"1.2.3", semver.DiffNone, "1.2.3"},
+		{"1.2.3-rc.1", semver.DiffPatch, "1.2.3"},
+	}
+	for _, tt := range tests {
+		if got := Next(semver.MustParse(tt.v), tt.change); got.String() != tt.want {
+			t.Errorf("Next(%s, %v) = %s, want %s", tt.v, tt.change, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+/*
+ * Example use of libsemver. Build the library and this program with:
+ *
+ *   go build -buildmode=c-shared -o examples/c/libsemver.so ./cmd/libsemver
+ *   cc -o examples/c/example examples/c/example.c -Iexamples/c -Lexamples/c -lsemver
+ *   LD_LIBRARY_PATH=examples/c examples/c/example
+ */
+#include <stdio.h>
+
+#include "libsemver.h"
+
+int main(void) {
+	char *err = NULL;
+
+	printf("compare(1.0.0-rc.1, 1.0.0) = %d\n", semver_compare("1.0.0-rc.1", "1.0.0", NULL, &err));
+	printf("satisfies(1.2.0-beta.1, ^1.0.0) = %d\n", semver_satisfies("1.2.0-beta.1", "^1.0.0", NULL, 0, &err));
+
+	if (!semver_validate("1.2", 0, &err)) {
+		printf("validate(1.2): %s\n", err);
+		semver_free(err);
+	}
+	return 0;
+}
@@ -0,0 +1,36 @@
+// semver.js wraps the semver-wasm module. Load wasm_exec.js first, then:
+//
+//   import { load } from "./semver.js";
+//   const semver = await load("semver.wasm");
+//   semver.compare("1.0.0-rc.1", "1.0.0");            // -1
+//   semver.satisfies("1.2.0", "^1.0.0");              // true
+//   semver.sort(["2.0.0", "1.0.0"], { reverse: true }); // ["2.0.0", "1.0.0"]
+//
+// Invalid versions and constraints throw an Error with the message the
+// semver command would print.
+
+function unwrap(r) {
+  if (r.error !== undefined) {
+    throw new Error(r.error);
+  }
+  return r.result;
+}
+
+export async function load(url = "semver.wasm") {
+  const go = new Go();
+  const { instance } = await WebAssembly.instantiateStreaming(fetch(url), go.importObject);
+  // run resolves only when the Go program exits, which it never does.
+  go.run(instance);
+  const api = globalThis.semverWasm;
+  return {
+    // compare returns -1, 0 or 1; scheme is "semver" (the default),
+    // "pep440", "maven" or another name accepted by -scheme.
+    compare: (a, b, scheme = "") => unwrap(api.compare(a, b, scheme)),
+    // satisfies reports whether version satisfies constraint. Options:
+    // dialect ("npm", "ruby", "nuget", "terraform" or "helm") and
+    // includePrerelease.
+    satisfies: (version, constraint, options = {}) => unwrap(api.satisfies(version, constraint, options)),
+    // sort returns a sorted copy of versions. Options: scheme and reverse.
+    sort: (versions, options = {}) => unwrap(api.sort(versions, options)),
+  };
+}
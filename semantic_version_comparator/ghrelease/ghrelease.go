@@ -0,0 +1,220 @@
+// Package ghrelease finds the newest release of a GitHub repository, for
+// self-update and outdated-dependency checks.
+package ghrelease
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+// DefaultBaseURL is the GitHub REST API endpoint used when
+// Client.BaseURL is empty.
+const DefaultBaseURL = "https://api.github.com"
+
+// ErrNoRelease is returned when a repository has no release or tag that
+// names a qualifying version.
+var ErrNoRelease = errors.New("ghrelease: no release found")
+
+// Client queries the GitHub REST API. The zero value is ready to use for
+// public repositories, subject to GitHub's unauthenticated rate limit.
+type Client struct {
+	// Token, if set, is sent as a bearer token.
+	Token string
+	// BaseURL is the API root, for GitHub Enterprise Server; empty means
+	// DefaultBaseURL.
+	BaseURL string
+	// HTTPClient is used for requests; nil means http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// Release is a GitHub release whose tag names a semantic version.
+type Release struct {
+	TagName     string
+	Name        string
+	Draft       bool
+	Prerelease  bool
+	PublishedAt time.Time
+	URL         string
+	Version     semver.Version
+}
+
+// Releases returns the releases of repo ("owner/name") whose tags parse
+// with semver.ParseTolerant, in the order GitHub lists them. Drafts are
+// included, and are only visible with a token that can push to repo.
+func (c *Client) Releases(ctx context.Context, repo string) ([]Release, error) {
+	var raw []struct {
+		TagName     string    `json:"tag_name"`
+		Name        string    `json:"name"`
+		Draft       bool      `json:"draft"`
+		Prerelease  bool      `json:"prerelease"`
+		PublishedAt time.Time `json:"published_at"`
+		HTMLURL     string    `json:"html_url"`
+	}
+	if err := c.getAll(ctx, repo, "releases", &raw); err != nil {
+		return nil, err
+	}
+	var rs []Release
+	for _, r := range raw {
+		v, err := semver.ParseTolerant(r.TagName)
+		if err != nil {
+			continue
+		}
+		rs = append(rs, Release{
+			TagName:     r.TagName,
+			Name:        r.Name,
+			Draft:       r.Draft,
+			Prerelease:  r.Prerelease,
+			PublishedAt: r.PublishedAt,
+			URL:         r.HTMLURL,
+			Version:     v,
+		})
+	}
+	return rs, nil
+}
+
+// Tags returns the tag names of repo.
+func (c *Client) Tags(ctx context.Context, repo string) ([]string, error) {
+	var raw []struct {
+		Name string `json:"name"`
+	}
+	if err := c.getAll(ctx, repo, "tags", &raw); err != nil {
+		return nil, err
+	}
+	names := make([]string, len(raw))
+	for i, t := range raw {
+		names[i] = t.Name
+	}
+	return names, nil
+}
+
+// Latest returns the release of repo with the highest version. Drafts are
+// always skipped; releases marked as pre-releases on GitHub or carrying a
+// SemVer pre-release are skipped unless includePrerelease is set. If the
+// repository publishes no releases, its tags are used instead, with only
+// TagName and Version set. It returns ErrNoRelease if nothing qualifies.
+func (c *Client) Latest(ctx context.Context, repo string, includePrerelease bool) (Release, error) {
+	rs, err := c.Releases(ctx, repo)
+	if err != nil {
+		return Release{}, err
+	}
+	var best Release
+	found := false
+	for _, r := range rs {
+		if r.Draft || !includePrerelease && (r.Prerelease || r.Version.Prerelease != "") {
+			continue
+		}
+		if !found || r.Version.Compare(best.Version) > 0 {
+			best, found = r, true
+		}
+	}
+	if found {
+		return best, nil
+	}
+	if len(rs) > 0 {
+		return Release{}, fmt.Errorf("%w in %s", ErrNoRelease, repo)
+	}
+	tags, err := c.Tags(ctx, repo)
+	if err != nil {
+		return Release{}, err
+	}
+	for _, name := range tags {
+		v, err := semver.ParseTolerant(name)
+		if err != nil || !includePrerelease && v.Prerelease != "" {
+			continue
+		}
+		if !found || v.Compare(best.Version) > 0 {
+			best, found = Release{TagName: name, Version: v}, true
+		}
+	}
+	if !found {
+		return Release{}, fmt.Errorf("%w in %s", ErrNoRelease, repo)
+	}
+	return best, nil
+}
+
+// Outdated returns the latest release of repo, as chosen by Latest, and
+// whether installed has lower precedence than it.
+func (c *Client) Outdated(ctx context.Context, repo string, installed semver.Version, includePrerelease bool) (latest Release, outdated bool, err error) {
+	latest, err = c.Latest(ctx, repo, includePrerelease)
+	if err != nil {
+		return Release{}, false, err
+	}
+	return latest, installed.Compare(latest.Version) < 0, nil
+}
+
+// getAll fetches every page of the list endpoint /repos/{repo}/{what}
+// into dst, which must point to a slice. The token is only sent to the
+// host of the first page.
+func (c *Client) getAll(ctx context.Context, repo, what string, dst any) error {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return fmt.Errorf("ghrelease: repository %q is not of the form owner/name", repo)
+	}
+	base := c.BaseURL
+	if base == "" {
+		base = DefaultBaseURL
+	}
+	next := strings.TrimSuffix(base, "/") + "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(name) + "/" + what + "?per_page=100"
+	start, err := url.Parse(next)
+	if err != nil {
+		return fmt.Errorf("ghrelease: %v", err)
+	}
+	var all []json.RawMessage
+	for next != "" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, next, nil)
+		if err != nil {
+			return fmt.Errorf("ghrelease: %v", err)
+		}
+		req.Header.Set("Accept", "application/vnd.github+json")
+		if c.Token != "" && strings.EqualFold(req.URL.Scheme, start.Scheme) && strings.EqualFold(req.URL.Host, start.Host) {
+			req.Header.Set("Authorization", "Bearer "+c.Token)
+		}
+		hc := c.HTTPClient
+		if hc == nil {
+			hc = http.DefaultClient
+		}
+		resp, err := hc.Do(req)
+		if err != nil {
+			return fmt.Errorf("ghrelease: %v", err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("ghrelease: reading %s of %s: %v", what, repo, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("ghrelease: listing %s of %s: %s", what, repo, resp.Status)
+		}
+		var page []json.RawMessage
+		if err := json.Unmarshal(body, &page); err != nil {
+			return fmt.Errorf("ghrelease: listing %s of %s: %v", what, repo, err)
+		}
+		all = append(all, page...)
+		next = nextLink(resp.Header.Get("Link"))
+	}
+	joined, err := json.Marshal(all)
+	if err != nil {
+		return fmt.Errorf("ghrelease: %v", err)
+	}
+	return json.Unmarshal(joined, dst)
+}
+
+// nextLink returns the rel="next" URL of a Link header, or "".
+func nextLink(link string) string {
+	for _, part := range strings.Split(link, ",") {
+		target, attrs, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if strings.Contains(attrs, `rel="next"`) {
+			return strings.Trim(strings.TrimSpace(target), "<>")
+		}
+	}
+	return ""
+}
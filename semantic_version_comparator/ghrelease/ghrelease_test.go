@@ -0,0 +1,183 @@
+package ghrelease
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+// server serves the releases and tags of the repositories in repos,
+// keyed by "owner/name", splitting each list into pages of two items.
+// Requests without the token are answered with 401.
+func server(t *testing.T, token string, repos map[string][2][]string) *httptest.Server {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token || r.Header.Get("Accept") != "application/vnd.github+json" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		rest, ok := strings.CutPrefix(r.URL.Path, "/repos/")
+		i := strings.LastIndexByte(rest, '/')
+		lists, found := repos[rest[:max(i, 0)]]
+		if !ok || i < 0 || !found {
+			http.NotFound(w, r)
+			return
+		}
+		var items []string
+		switch rest[i+1:] {
+		case "releases":
+			items = lists[0]
+		case "tags":
+			items = lists[1]
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		page := 0
+		if p := r.URL.Query().Get("page"); p != "" {
+			page = int(p[0] - '0')
+		}
+		end := min(2*page+2, len(items))
+		if end < len(items) {
+			w.Header().Set("Link", `<`+srv.URL+r.URL.Path+`?per_page=100&page=`+string(rune('0'+page+1))+`>; rel="next"`)
+		}
+		w.Write([]byte("[" + strings.Join(items[min(2*page, end):end], ",") + "]"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func release(tag string, draft, pre bool) string {
+	b := func(v bool) string {
+		if v {
+			return "true"
+		}
+		return "false"
+	}
+	return `{"tag_name":"` + tag + `","name":"` + tag + `","draft":` + b(draft) + `,"prerelease":` + b(pre) +
+		`,"published_at":"2024-01-02T03:04:05Z","html_url":"https://github.com/o/r/releases/` + tag + `"}`
+}
+
+func tag(name string) string { return `{"name":"` + name + `"}` }
+
+var repos = map[string][2][]string{
+	"o/r": {{
+		release("v1.0.0", false, false),
+		release("v1.2.0", false, false),
+		release("nightly", false, false),
+		release("v2.0.0", true, false),
+		release("v1.3.0", false, true),
+		release("v1.4.0-rc.1", false, false),
+	}, nil},
+	"o/tags-only": {nil, {tag("v0.1.0"), tag("v0.2.0"), tag("latest"), tag("v0.3.0-beta")}},
+	"o/empty":     {nil, {tag("latest")}},
+	"o/drafts":    {{release("v1.0.0", true, false)}, nil},
+}
+
+func TestReleases(t *testing.T) {
+	srv := server(t, "tok", repos)
+	c := &Client{Token: "tok", BaseURL: srv.URL}
+	rs, err := c.Releases(context.Background(), "o/r")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, r := range rs {
+		got = append(got, r.TagName)
+	}
+	if want := []string{"v1.0.0", "v1.2.0", "v2.0.0", "v1.3.0", "v1.4.0-rc.1"}; !slices.Equal(got, want) {
+		t.Errorf("Releases = %q, want %q", got, want)
+	}
+	if r := rs[2]; !r.Draft || r.Version.String() != "2.0.0" || r.URL == "" || r.PublishedAt.Year() != 2024 {
+		t.Errorf("Releases[2] = %+v", r)
+	}
+	tags, err := c.Tags(context.Background(), "o/tags-only")
+	if err != nil || len(tags) != 4 {
+		t.Errorf("Tags = %q, %v; want 4 tags", tags, err)
+	}
+}
+
+func TestLatest(t *testing.T) {
+	srv := server(t, "tok", repos)
+	c := &Client{Token: "tok", BaseURL: srv.URL + "/"}
+	tests := []struct {
+		repo    string
+		include bool
+		want    string // "" for ErrNoRelease
+	}{
+		{"o/r", false, "v1.2.0"},
+		{"o/r", true, "v1.4.0-rc.1"},
+		{"o/tags-only", false, "v0.2.0"},
+		{"o/tags-only", true, "v0.3.0-beta"},
+		{"o/empty", true, ""},
+		{"o/drafts", true, ""},
+	}
+	for _, tt := range tests {
+		got, err := c.Latest(context.Background(), tt.repo, tt.include)
+		if tt.want == "" {
+			if !errors.Is(err, ErrNoRelease) {
+				t.Errorf("Latest(%s, %v) = %q, %v; want ErrNoRelease", tt.repo, tt.include, got.TagName, err)
+			}
+			continue
+		}
+		if err != nil || got.TagName != tt.want {
+			t.Errorf("Latest(%s, %v) = %q, %v; want %q", tt.repo, tt.include, got.TagName, err, tt.want)
+		}
+	}
+	for _, tt := range []struct {
+		installed string
+		want      bool
+	}{{"1.1.0", true}, {"1.2.0", false}, {"1.3.0", false}} {
+		latest, outdated, err := c.Outdated(context.Background(), "o/r", semver.MustParse(tt.installed), false)
+		if err != nil || outdated != tt.want || latest.TagName != "v1.2.0" {
+			t.Errorf("Outdated(%s) = %q, %v, %v; want v1.2.0, %v", tt.installed, latest.TagName, outdated, err, tt.want)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	srv := server(t, "tok", repos)
+	tests := []struct {
+		name string
+		c    *Client
+		repo string
+	}{
+		{"bad repository", &Client{Token: "tok", BaseURL: srv.URL}, "o/r/x"},
+		{"no owner", &Client{Token: "tok", BaseURL: srv.URL}, "/r"},
+		{"missing", &Client{Token: "tok", BaseURL: srv.URL}, "o/missing"},
+		{"unauthorized", &Client{BaseURL: srv.URL}, "o/r"},
+	}
+	for _, tt := range tests {
+		if _, err := tt.c.Releases(context.Background(), tt.repo); err == nil || !strings.HasPrefix(err.Error(), "ghrelease: ") {
+			t.Errorf("%s: Releases error = %v", tt.name, err)
+		}
+	}
+}
+
+func TestTagsPagination(t *testing.T) {
+	var otherAuth string
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		otherAuth = r.Header.Get("Authorization")
+		w.Write([]byte("[" + tag("v2.0.0") + "]"))
+	}))
+	defer other.Close()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", `<`+other.URL+`/tags?page=2>; rel="next"`)
+		w.Write([]byte("[" + tag("v1.0.0") + "]"))
+	}))
+	defer srv.Close()
+	c := &Client{Token: "tok", BaseURL: srv.URL}
+	tags, err := c.Tags(context.Background(), "o/r")
+	if err != nil || !slices.Equal(tags, []string{"v1.0.0", "v2.0.0"}) {
+		t.Fatalf("Tags = %q, %v", tags, err)
+	}
+	if otherAuth != "" {
+		t.Errorf("the other host received Authorization %q", otherAuth)
+	}
+}
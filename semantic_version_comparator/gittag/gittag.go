@@ -0,0 +1,125 @@
+// Package gittag finds release versions among the tags of a git
+// repository. It runs the git command, which must be on PATH, so it works
+// with any repository and remote git itself can reach.
+package gittag
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+// Tag is a git tag that names a semantic version.
+type Tag struct {
+	Name    string
+	Version semver.Version
+}
+
+// List returns the tag names of repo, which is either a local repository
+// directory or a remote URL. Local tags are listed with "git tag"; remote
+// ones with "git ls-remote", without cloning.
+func List(ctx context.Context, repo string) ([]string, error) {
+	var args []string
+	if fi, err := os.Stat(repo); err == nil && fi.IsDir() {
+		args = []string{"-C", repo, "tag", "--list"}
+	} else {
+		args = []string{"ls-remote", "--tags", "--refs", "--", repo}
+	}
+	out, err := runGit(ctx, "listing tags of "+repo, args...)
+	if err != nil {
+		return nil, err
+	}
+	var tags []string
+	for _, line := range strings.Split(out, "\n") {
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
+		// ls-remote prints "<sha>\trefs/tags/<name>".
+		if _, ref, ok := strings.Cut(line, "\t"); ok {
+			line = strings.TrimPrefix(ref, "refs/tags/")
+		}
+		tags = append(tags, line)
+	}
+	return tags, nil
+}
+
+// Releases returns the tags that consist of prefix followed by a valid
+// semantic version, such as "api/v1.2.0" for the prefix "api/". A "v"
+// after the prefix is optional. The result is sorted in ascending
+// precedence order; tags of equal precedence are ordered by name.
+func Releases(tags []string, prefix string) []Tag {
+	var out []Tag
+	for _, name := range tags {
+		rest, ok := strings.CutPrefix(name, prefix)
+		if !ok {
+			continue
+		}
+		v, err := semver.Parse(rest)
+		if err != nil {
+			continue
+		}
+		out = append(out, Tag{Name: name, Version: v})
+	}
+	slices.SortFunc(out, func(a, b Tag) int {
+		if c := a.Version.Compare(b.Version); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return out
+}
+
+// Latest returns the release tag with the highest precedence among tags,
+// as selected by Releases. Pre-releases are skipped unless
+// includePrerelease is set. ok is false if no tag qualifies.
+func Latest(tags []string, prefix string, includePrerelease bool) (t Tag, ok bool) {
+	rs := Releases(tags, prefix)
+	for i := len(rs) - 1; i >= 0; i-- {
+		if includePrerelease || rs[i].Version.Prerelease == "" {
+			return rs[i], true
+		}
+	}
+	return Tag{}, false
+}
+
+// Messages returns the full messages of the commits reachable from HEAD
+// but not from the tag since, newest first, in the local repository repo.
+// If since is empty it returns every commit message.
+func Messages(ctx context.Context, repo, since string) ([]string, error) {
+	args := []string{"-C", repo, "log", "--format=%B%x00"}
+	if since != "" {
+		args = append(args, "refs/tags/"+since+"..HEAD")
+	}
+	out, err := runGit(ctx, "reading log of "+repo, args...)
+	if err != nil {
+		return nil, err
+	}
+	var msgs []string
+	for _, m := range strings.Split(out, "\x00") {
+		if m = strings.TrimSpace(m); m != "" {
+			msgs = append(msgs, m)
+		}
+	}
+	return msgs, nil
+}
+
+// runGit runs git with args and returns its standard output. Failures
+// are reported as errors mentioning action and git's own message.
+func runGit(ctx context.Context, action string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("gittag: %s: %v: %s", action, err, msg)
+		}
+		return "", fmt.Errorf("gittag: %s: %v", action, err)
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,128 @@
+package gittag
+
+import (
+	"context"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var tags = []string{"v1.0.0", "v1.2.0", "1.10.0", "v2.0.0-rc.1", "latest", "api/v0.3.0", "api/v0.4.0-beta", "v1.2", "V1.1.0"}
+
+func names(ts []Tag) string {
+	s := make([]string, len(ts))
+	for i, t := range ts {
+		s[i] = t.Name
+	}
+	return strings.Join(s, " ")
+}
+
+func TestReleases(t *testing.T) {
+	tests := []struct {
+		prefix string
+		want   string
+	}{
+		{"", "v1.0.0 V1.1.0 v1.2.0 1.10.0 v2.0.0-rc.1"},
+		{"api/", "api/v0.3.0 api/v0.4.0-beta"},
+		{"web/", ""},
+	}
+	for _, tt := range tests {
+		if got := names(Releases(tags, tt.prefix)); got != tt.want {
+			t.Errorf("Releases(%q) = %q, want %q", tt.prefix, got, tt.want)
+		}
+	}
+	if got := names(Releases([]string{"v1.0.0", "1.0.0", "v1.0.0+b"}, "")); got != "1.0.0 v1.0.0 v1.0.0+b" {
+		t.Errorf("Releases of equal versions = %q, want them ordered by name", got)
+	}
+}
+
+func TestLatest(t *testing.T) {
+	tests := []struct {
+		prefix  string
+		include bool
+		want    string // "" if there is none
+	}{
+		{"", false, "1.10.0"},
+		{"", true, "v2.0.0-rc.1"},
+		{"api/", false, "api/v0.3.0"},
+		{"api/", true, "api/v0.4.0-beta"},
+		{"web/", true, ""},
+	}
+	for _, tt := range tests {
+		got, ok := Latest(tags, tt.prefix, tt.include)
+		if ok != (tt.want != "") || got.Name != tt.want {
+			t.Errorf("Latest(%q, %v) = %q, %v; want %q", tt.prefix, tt.include, got.Name, ok, tt.want)
+		}
+	}
+	if _, ok := Latest([]string{"v1.0.0-rc.1"}, "", false); ok {
+		t.Error("Latest found a release among pre-releases only")
+	}
+}
+
+// gitRepo returns a new repository in a temporary directory, skipping
+// the test if git is not installed.
+func gitRepo(t *testing.T) (dir string, git func(args ...string)) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	dir = t.TempDir()
+	git = func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(cmd.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com",
+			"GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com", "GIT_CONFIG_GLOBAL=/dev/null")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+	git("init", "-q")
+	return dir, git
+}
+
+func TestList(t *testing.T) {
+	dir, git := gitRepo(t)
+	git("commit", "-q", "--allow-empty", "-m", "first")
+	for _, tag := range []string{"v1.0.0", "v1.1.0", "api/v0.1.0"} {
+		git("tag", tag)
+	}
+	// A file URL is listed as a remote, with ls-remote.
+	for _, repo := range []string{dir, "file://" + dir} {
+		got, err := List(context.Background(), repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		slices.Sort(got)
+		if want := []string{"api/v0.1.0", "v1.0.0", "v1.1.0"}; !slices.Equal(got, want) {
+			t.Errorf("List(%s) = %q, want %q", repo, got, want)
+		}
+	}
+	if _, err := List(context.Background(), t.TempDir()); err == nil || !strings.HasPrefix(err.Error(), "gittag: ") {
+		t.Errorf("List of a directory that is not a repository: error = %v", err)
+	}
+}
+
+func TestMessages(t *testing.T) {
+	dir, git := gitRepo(t)
+	git("commit", "-q", "--allow-empty", "-m", "feat: first")
+	git("tag", "v1.0.0")
+	git("commit", "-q", "--allow-empty", "-m", "fix: second\n\nwith a body")
+	git("commit", "-q", "--allow-empty", "-m", "docs: third")
+	tests := []struct {
+		since string
+		want  []string
+	}{
+		{"v1.0.0", []string{"docs: third", "fix: second\n\nwith a body"}},
+		{"", []string{"docs: third", "fix: second\n\nwith a body", "feat: first"}},
+	}
+	for _, tt := range tests {
+		got, err := Messages(context.Background(), dir, tt.since)
+		if err != nil || !slices.Equal(got, tt.want) {
+			t.Errorf("Messages(%q) = %q, %v; want %q", tt.since, got, err, tt.want)
+		}
+	}
+	if _, err := Messages(context.Background(), dir, "v9.9.9"); err == nil {
+		t.Error("Messages since a missing tag succeeded")
+	}
+}
@@ -0,0 +1,176 @@
+package gittag
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+// RefUpdate is one line of the input of a git pre-receive or update
+// hook: a ref and the object names it moves between.
+type RefUpdate struct {
+	Old, New string
+	Ref      string
+}
+
+// Tag returns the tag name of a ref under refs/tags/.
+func (u RefUpdate) Tag() (name string, ok bool) {
+	return strings.CutPrefix(u.Ref, "refs/tags/")
+}
+
+// Creating reports whether u creates its ref, and Deleting whether it
+// deletes it; git marks the missing side with an all-zero object name.
+func (u RefUpdate) Creating() bool { return isZeroOID(u.Old) }
+
+// Deleting reports whether u deletes its ref.
+func (u RefUpdate) Deleting() bool { return isZeroOID(u.New) }
+
+func isZeroOID(s string) bool {
+	return s != "" && strings.Trim(s, "0") == ""
+}
+
+// ParseRefUpdates reads the "<old> <new> <ref>" lines that git passes to
+// a pre-receive hook on standard input.
+func ParseRefUpdates(r io.Reader) ([]RefUpdate, error) {
+	var us []RefUpdate
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		f := strings.Fields(line)
+		if len(f) != 3 {
+			return nil, fmt.Errorf("gittag: ref update line %d: want \"<old> <new> <ref>\", got %q", n, line)
+		}
+		us = append(us, RefUpdate{Old: f[0], New: f[1], Ref: f[2]})
+	}
+	return us, sc.Err()
+}
+
+// Policy decides which tag pushes a git server accepts. Release tags are
+// those that consist of Prefix followed by something that starts like a
+// version, an optional "v" and a digit; other tags are accepted unless
+// Strict is set.
+type Policy struct {
+	// Prefix selects the release tags of one module of a repository,
+	// such as "api/".
+	Prefix string
+	// VPrefix selects the canonical spelling of release tags: with a
+	// leading "v", as in v1.2.3, or without one.
+	VPrefix semver.Prefix
+	// AllowLower accepts new release tags below the highest existing
+	// one. AllowBackports accepts them only if they exceed the highest
+	// tag of their own major.minor series, so that 1.4.3 may follow
+	// 2.0.0 once 1.4.2 exists.
+	AllowLower, AllowBackports bool
+	// AllowDelete accepts deleting release tags, and AllowMove pointing
+	// them at another object.
+	AllowDelete, AllowMove bool
+	// Strict rejects tags that are not release tags.
+	Strict bool
+}
+
+// Rejection is a ref update refused by a Policy.
+type Rejection struct {
+	Ref    string
+	Reason string
+}
+
+func (r Rejection) Error() string {
+	return r.Ref + ": " + r.Reason
+}
+
+// Check returns the updates that p refuses, given the tag names that
+// exist before the push. New release tags must be canonical and, unless
+// allowed otherwise, higher than every existing release tag; tags created
+// by the same push count as existing for the ones after them.
+func (p Policy) Check(existing []string, updates []RefUpdate) []Rejection {
+	var rejected []Rejection
+	var known []semver.Version
+	for _, t := range Releases(existing, p.Prefix) {
+		known = append(known, t.Version)
+	}
+	for _, u := range updates {
+		name, ok := u.Tag()
+		if !ok {
+			continue
+		}
+		reject := func(format string, args ...any) {
+			rejected = append(rejected, Rejection{Ref: u.Ref, Reason: fmt.Sprintf(format, args...)})
+		}
+		rest, hasPrefix := strings.CutPrefix(name, p.Prefix)
+		if !hasPrefix || !looksLikeVersion(rest) {
+			if p.Strict && !u.Deleting() {
+				reject("not a release tag: want %s", p.releaseForm())
+			}
+			continue
+		}
+		switch {
+		case u.Deleting():
+			if !p.AllowDelete {
+				reject("release tags cannot be deleted")
+			}
+			continue
+		case !u.Creating():
+			if !p.AllowMove {
+				reject("release tags cannot be moved")
+			}
+			continue
+		}
+		want, err := semver.Canonical(rest, p.VPrefix)
+		if err != nil {
+			reject("%v", strings.TrimPrefix(err.Error(), "semver: "))
+			continue
+		}
+		if rest != want {
+			reject("not canonical: want %s%s", p.Prefix, want)
+			continue
+		}
+		v, _ := semver.ParseTolerant(rest)
+		if top, ok := p.ceiling(known, v); ok && v.Compare(top) <= 0 {
+			reject("%s is not higher than existing release %s%s", v, p.Prefix, top.Original())
+			continue
+		}
+		known = append(known, v)
+	}
+	return rejected
+}
+
+// ceiling returns the version that a new release tag v must exceed.
+func (p Policy) ceiling(known []semver.Version, v semver.Version) (semver.Version, bool) {
+	if p.AllowLower {
+		return semver.Version{}, false
+	}
+	var top semver.Version
+	found := false
+	for _, k := range known {
+		if p.AllowBackports && (k.Epoch != v.Epoch || k.Major != v.Major || k.Minor != v.Minor) {
+			continue
+		}
+		if !found || k.Compare(top) > 0 {
+			top, found = k, true
+		}
+	}
+	return top, found
+}
+
+// looksLikeVersion reports whether s starts with digits, after an
+// optional "v".
+func looksLikeVersion(s string) bool {
+	if s != "" && (s[0] == 'v' || s[0] == 'V') {
+		s = s[1:]
+	}
+	return s != "" && s[0] >= '0' && s[0] <= '9'
+}
+
+// releaseForm describes the names of release tags under p.
+func (p Policy) releaseForm() string {
+	if p.Prefix == "" {
+		return "a version"
+	}
+	return fmt.Sprintf("%q followed by a version", p.Prefix)
+}
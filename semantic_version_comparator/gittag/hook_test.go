@@ -0,0 +1,72 @@
+package gittag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+const (
+	zero = "0000000000000000000000000000000000000000"
+	oidA = "1111111111111111111111111111111111111111"
+	oidB = "2222222222222222222222222222222222222222"
+)
+
+func TestParseRefUpdates(t *testing.T) {
+	in := zero + " " + oidA + " refs/tags/v1.0.0\n\n" + oidA + " " + zero + " refs/heads/main\n"
+	us, err := ParseRefUpdates(strings.NewReader(in))
+	if err != nil || len(us) != 2 {
+		t.Fatalf("ParseRefUpdates = %v, %v; want 2 updates", us, err)
+	}
+	if name, ok := us[0].Tag(); !ok || name != "v1.0.0" || !us[0].Creating() || us[0].Deleting() {
+		t.Errorf("us[0] = %+v: Tag %q, %v, Creating %v, Deleting %v", us[0], name, ok, us[0].Creating(), us[0].Deleting())
+	}
+	if _, ok := us[1].Tag(); ok || us[1].Creating() || !us[1].Deleting() {
+		t.Errorf("us[1] = %+v: want a branch deletion", us[1])
+	}
+	if _, err := ParseRefUpdates(strings.NewReader(oidA + " refs/tags/v1.0.0\n")); err == nil {
+		t.Error("ParseRefUpdates of a line with two fields succeeded")
+	}
+}
+
+func TestPolicyCheck(t *testing.T) {
+	existing := []string{"v1.4.2", "v2.0.0", "docs", "api/v0.1.0"}
+	create := func(tag string) RefUpdate { return RefUpdate{Old: zero, New: oidA, Ref: "refs/tags/" + tag} }
+	tests := []struct {
+		name    string
+		policy  Policy
+		updates []RefUpdate
+		want    []string // rejected refs
+	}{
+		{"higher", Policy{VPrefix: semver.VPrefix}, []RefUpdate{create("v2.0.1"), create("v2.1.0")}, nil},
+		{"lower", Policy{VPrefix: semver.VPrefix}, []RefUpdate{create("v1.4.3")}, []string{"v1.4.3"}},
+		{"equal", Policy{VPrefix: semver.VPrefix}, []RefUpdate{create("v2.0.0+build")}, []string{"v2.0.0+build"}},
+		{"same push", Policy{VPrefix: semver.VPrefix}, []RefUpdate{create("v2.2.0"), create("v2.1.0")}, []string{"v2.1.0"}},
+		{"allow lower", Policy{VPrefix: semver.VPrefix, AllowLower: true}, []RefUpdate{create("v1.0.1")}, nil},
+		{"backport", Policy{VPrefix: semver.VPrefix, AllowBackports: true}, []RefUpdate{create("v1.4.3"), create("v1.4.1"), create("v1.3.0")}, []string{"v1.4.1"}},
+		{"not canonical", Policy{VPrefix: semver.VPrefix}, []RefUpdate{create("2.1.0"), create("v2.1")}, []string{"2.1.0", "v2.1"}},
+		{"no v", Policy{}, []RefUpdate{create("2.1.0"), create("v2.2.0")}, []string{"v2.2.0"}},
+		{"invalid", Policy{VPrefix: semver.VPrefix}, []RefUpdate{create("v2.x")}, []string{"v2.x"}},
+		{"prefix", Policy{Prefix: "api/", VPrefix: semver.VPrefix}, []RefUpdate{create("api/v0.2.0"), create("api/v0.0.9"), create("v0.0.1")}, []string{"api/v0.0.9"}},
+		{"other tags", Policy{VPrefix: semver.VPrefix}, []RefUpdate{create("nightly")}, nil},
+		{"strict", Policy{VPrefix: semver.VPrefix, Strict: true}, []RefUpdate{create("nightly"), {Old: oidA, New: zero, Ref: "refs/tags/docs"}}, []string{"nightly"}},
+		{"delete", Policy{VPrefix: semver.VPrefix}, []RefUpdate{{Old: oidA, New: zero, Ref: "refs/tags/v2.0.0"}}, []string{"v2.0.0"}},
+		{"allow delete", Policy{VPrefix: semver.VPrefix, AllowDelete: true}, []RefUpdate{{Old: oidA, New: zero, Ref: "refs/tags/v2.0.0"}}, nil},
+		{"move", Policy{VPrefix: semver.VPrefix}, []RefUpdate{{Old: oidA, New: oidB, Ref: "refs/tags/v2.0.0"}}, []string{"v2.0.0"}},
+		{"allow move", Policy{VPrefix: semver.VPrefix, AllowMove: true}, []RefUpdate{{Old: oidA, New: oidB, Ref: "refs/tags/v2.0.0"}}, nil},
+		{"branches", Policy{VPrefix: semver.VPrefix, Strict: true}, []RefUpdate{{Old: oidA, New: oidB, Ref: "refs/heads/main"}}, nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, r := range tt.policy.Check(existing, tt.updates) {
+			if r.Reason == "" || !strings.HasPrefix(r.Error(), r.Ref+": ") {
+				t.Errorf("%s: rejection %+v has no reason", tt.name, r)
+			}
+			got = append(got, strings.TrimPrefix(r.Ref, "refs/tags/"))
+		}
+		if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+			t.Errorf("%s: Check rejected %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -1,3 +1,3 @@
-module semantic_version_comparator
+module github.com/ketiyohannes/sample-dataset/semantic_version_comparator
 
-go 1.21
\ No newline at end of file
+go 1.21
@@ -0,0 +1,286 @@
+// Package goproxy lists and resolves Go module versions through a module
+// proxy speaking the GOPROXY protocol, such as proxy.golang.org.
+package goproxy
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+// DefaultProxy is the proxy used when neither Client.Proxy nor the
+// GOPROXY environment variable is set.
+const DefaultProxy = "https://proxy.golang.org"
+
+// ErrNotFound is returned when the proxy does not know the module or
+// version.
+var ErrNotFound = errors.New("goproxy: not found")
+
+// Client queries a Go module proxy. The zero value is ready to use.
+type Client struct {
+	// Proxy is the base URL of the proxy. If empty, the first URL in
+	// GOPROXY is used, or DefaultProxy if GOPROXY is unset. A GOPROXY
+	// of "off", or that lists "direct" before any URL, is an error:
+	// the client cannot fetch from version control, and falling back
+	// to the public proxy would leak private module paths.
+	Proxy string
+	// HTTPClient is used for requests; nil means http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// Info is the metadata the proxy reports for a version.
+type Info struct {
+	Version semver.Version
+	Time    time.Time
+}
+
+// List returns the tagged versions of module known to the proxy, in
+// ascending order. Pseudo-versions are not listed by proxies; use Latest
+// for modules without tags.
+func (c *Client) List(ctx context.Context, module string) ([]semver.Version, error) {
+	body, err := c.get(ctx, module, "@v/list")
+	if err != nil {
+		return nil, err
+	}
+	var vs []semver.Version
+	sc := bufio.NewScanner(strings.NewReader(string(body)))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		v, err := semver.Parse(line)
+		if err != nil {
+			return nil, fmt.Errorf("goproxy: %s: invalid version %q in list", module, line)
+		}
+		vs = append(vs, v)
+	}
+	semver.Sort(vs)
+	return vs, nil
+}
+
+// Latest returns the version the proxy reports as the latest for module,
+// which may be a pseudo-version if the module has no tags.
+func (c *Client) Latest(ctx context.Context, module string) (Info, error) {
+	return c.info(ctx, module, "@latest")
+}
+
+// Stat returns the metadata of one version of module.
+func (c *Client) Stat(ctx context.Context, module string, v semver.Version) (Info, error) {
+	escaped, err := EscapeVersion("v" + v.String())
+	if err != nil {
+		return Info{}, err
+	}
+	return c.info(ctx, module, "@v/"+escaped+".info")
+}
+
+func (c *Client) info(ctx context.Context, module, endpoint string) (Info, error) {
+	body, err := c.get(ctx, module, endpoint)
+	if err != nil {
+		return Info{}, err
+	}
+	var raw struct {
+		Version string
+		Time    time.Time
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return Info{}, fmt.Errorf("goproxy: %s/%s: %v", module, endpoint, err)
+	}
+	v, err := semver.Parse(raw.Version)
+	if err != nil {
+		return Info{}, fmt.Errorf("goproxy: %s/%s: %v", module, endpoint, err)
+	}
+	return Info{Version: v, Time: raw.Time}, nil
+}
+
+// GoMod returns the go.mod file of one version of module.
+func (c *Client) GoMod(ctx context.Context, module string, v semver.Version) ([]byte, error) {
+	escaped, err := EscapeVersion("v" + v.String())
+	if err != nil {
+		return nil, err
+	}
+	return c.get(ctx, module, "@v/"+escaped+".mod")
+}
+
+// Retractions returns the versions of module retracted by the go.mod
+// file of its latest version, which is where the go command looks for
+// retract directives.
+func (c *Client) Retractions(ctx context.Context, module string) ([]Retraction, error) {
+	vs, err := c.versions(ctx, module)
+	if err != nil {
+		return nil, err
+	}
+	return c.retractions(ctx, module, latest(vs))
+}
+
+// latest returns the version whose go.mod holds the retractions of a
+// module: the highest one, ignoring +incompatible versions unless there
+// is nothing else, since those predate the module's go.mod and cannot
+// carry its directives.
+func latest(vs []semver.Version) semver.Version {
+	for i := len(vs) - 1; i >= 0; i-- {
+		if !vs[i].IsIncompatible() {
+			return vs[i]
+		}
+	}
+	return vs[len(vs)-1]
+}
+
+// versions returns the tagged versions of module or, if it has none, the
+// pseudo-version reported by @latest.
+func (c *Client) versions(ctx context.Context, module string) ([]semver.Version, error) {
+	vs, err := c.List(ctx, module)
+	if err != nil || len(vs) > 0 {
+		return vs, err
+	}
+	info, err := c.Latest(ctx, module)
+	if err != nil {
+		return nil, err
+	}
+	return []semver.Version{info.Version}, nil
+}
+
+func (c *Client) retractions(ctx context.Context, module string, latest semver.Version) ([]Retraction, error) {
+	mod, err := c.GoMod(ctx, module, latest)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRetractions(mod)
+}
+
+// Resolve returns the highest version of module that satisfies con and
+// is not retracted, unless con was parsed with
+// semver.AllowStatus(semver.Retracted). Like the go command it prefers
+// tagged versions and falls back to the pseudo-version reported by
+// @latest when the module has no tags. Pseudo-versions are pre-releases, so under the npm
+// pre-release rule only constraints parsed with semver.IncludePrerelease
+// admit them. Resolve returns ErrNotFound if no version qualifies.
+func (c *Client) Resolve(ctx context.Context, module string, con semver.Constraint) (semver.Version, error) {
+	vs, err := c.versions(ctx, module)
+	if err != nil {
+		return semver.Version{}, err
+	}
+	rs, err := c.retractions(ctx, module, latest(vs))
+	if err != nil {
+		return semver.Version{}, err
+	}
+	for i, v := range vs {
+		if Retracted(rs, v) {
+			vs[i] = v.WithStatus(semver.Retracted)
+		}
+	}
+	v, ok := semver.MaxSatisfying(vs, con)
+	if !ok {
+		return semver.Version{}, fmt.Errorf("%w: no version of %s satisfies %q", ErrNotFound, module, con)
+	}
+	return v, nil
+}
+
+// get fetches endpoint, relative to the escaped module path, from the
+// proxy.
+func (c *Client) get(ctx context.Context, module, endpoint string) ([]byte, error) {
+	escaped, err := EscapePath(module)
+	if err != nil {
+		return nil, err
+	}
+	proxy, err := c.proxy()
+	if err != nil {
+		return nil, err
+	}
+	u := strings.TrimSuffix(proxy, "/") + "/" + escaped + "/" + endpoint
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("goproxy: %v", err)
+	}
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("goproxy: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("goproxy: reading %s: %v", u, err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return nil, fmt.Errorf("%w: %s/%s", ErrNotFound, module, endpoint)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("goproxy: GET %s: %s", u, resp.Status)
+	}
+	return body, nil
+}
+
+// proxy returns the base URL of the proxy to query, as documented on
+// Client.Proxy.
+func (c *Client) proxy() (string, error) {
+	if c.Proxy != "" {
+		return c.Proxy, nil
+	}
+	env := os.Getenv("GOPROXY")
+	if env == "" {
+		return DefaultProxy, nil
+	}
+	for _, p := range strings.FieldsFunc(env, func(r rune) bool { return r == ',' || r == '|' }) {
+		switch p = strings.TrimSpace(p); p {
+		case "off":
+			return "", fmt.Errorf("goproxy: module lookups disabled by GOPROXY=%s", env)
+		case "direct":
+			return "", fmt.Errorf("goproxy: GOPROXY=%s asks for direct version control access, which is not supported; set a proxy URL", env)
+		case "":
+		default:
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("goproxy: GOPROXY=%s names no proxy", env)
+}
+
+// EscapePath escapes a module path for use in proxy URLs: each upper-case
+// letter is replaced by "!" and its lower-case form, so that paths remain
+// distinct on case-insensitive file systems.
+func EscapePath(path string) (string, error) {
+	if path == "" || strings.HasPrefix(path, "/") || strings.HasSuffix(path, "/") {
+		return "", fmt.Errorf("goproxy: invalid module path %q", path)
+	}
+	return escape(path, "module path", "-._~/+")
+}
+
+// EscapeVersion escapes a version for use in proxy URLs as EscapePath
+// does a module path, so that v1.0.0-RC1 is requested as v1.0.0-!r!c1.
+func EscapeVersion(v string) (string, error) {
+	if v == "" {
+		return "", fmt.Errorf("goproxy: invalid version %q", v)
+	}
+	return escape(v, "version", "-._~+")
+}
+
+// escape replaces the upper-case letters of s by "!" and their
+// lower-case forms, and rejects characters other than letters, digits
+// and those in allowed. what names s in errors.
+func escape(s, what, allowed string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c >= 'A' && c <= 'Z':
+			b.WriteByte('!')
+			b.WriteByte(c + 'a' - 'A')
+		case c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || strings.IndexByte(allowed, c) >= 0:
+			b.WriteByte(c)
+		default:
+			return "", fmt.Errorf("goproxy: invalid character %q in %s %q", c, what, s)
+		}
+	}
+	return b.String(), nil
+}
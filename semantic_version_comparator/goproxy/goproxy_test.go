@@ -0,0 +1,98 @@
+package goproxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		in, path, version string
+	}{
+		{"github.com/Azure/azure-sdk", "github.com/!azure/azure-sdk", ""},
+		{"v1.0.0-RC1", "", "v1.0.0-!r!c1"},
+		{"v1.2.3+incompatible", "", "v1.2.3+incompatible"},
+		{"v1.0.0-beta.1", "", "v1.0.0-beta.1"},
+	}
+	for _, tt := range tests {
+		if tt.path != "" {
+			if got, err := EscapePath(tt.in); err != nil || got != tt.path {
+				t.Errorf("EscapePath(%q) = %q, %v; want %q", tt.in, got, err, tt.path)
+			}
+		}
+		if tt.version != "" {
+			if got, err := EscapeVersion(tt.in); err != nil || got != tt.version {
+				t.Errorf("EscapeVersion(%q) = %q, %v; want %q", tt.in, got, err, tt.version)
+			}
+		}
+	}
+	for _, bad := range []string{"", "v1.0.0/x", "v1!0"} {
+		if _, err := EscapeVersion(bad); err == nil {
+			t.Errorf("EscapeVersion(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestStatEscapesVersion(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch {
+		case strings.HasSuffix(r.URL.Path, ".info"):
+			w.Write([]byte(`{"Version":"v1.0.0-RC1","Time":"2024-01-02T03:04:05Z"}`))
+		case strings.HasSuffix(r.URL.Path, ".mod"):
+			w.Write([]byte("module example.com/M\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	c := &Client{Proxy: srv.URL}
+	v := semver.MustParse("v1.0.0-RC1")
+	if _, err := c.Stat(context.Background(), "example.com/M", v); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GoMod(context.Background(), "example.com/M", v); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"/example.com/!m/@v/v1.0.0-!r!c1.info", "/example.com/!m/@v/v1.0.0-!r!c1.mod"}
+	if strings.Join(paths, " ") != strings.Join(want, " ") {
+		t.Errorf("requested %q, want %q", paths, want)
+	}
+}
+
+func TestProxy(t *testing.T) {
+	tests := []struct {
+		goproxy string
+		want    string // "" for an error
+	}{
+		{"", DefaultProxy},
+		{"https://proxy.example.com", "https://proxy.example.com"},
+		{"https://proxy.example.com,direct", "https://proxy.example.com"},
+		{"https://a.example.com|https://b.example.com", "https://a.example.com"},
+		{"off", ""},
+		{"direct", ""},
+		{"direct,https://proxy.example.com", ""},
+		{"https://proxy.example.com,off", "https://proxy.example.com"},
+		{",", ""},
+	}
+	for _, tt := range tests {
+		t.Setenv("GOPROXY", tt.goproxy)
+		got, err := (&Client{}).proxy()
+		switch {
+		case tt.want == "" && err == nil:
+			t.Errorf("GOPROXY=%q: proxy() = %q, want error", tt.goproxy, got)
+		case tt.want != "" && (err != nil || got != tt.want):
+			t.Errorf("GOPROXY=%q: proxy() = %q, %v; want %q", tt.goproxy, got, err, tt.want)
+		}
+	}
+	t.Setenv("GOPROXY", "off")
+	if got, err := (&Client{Proxy: "https://mine.example.com"}).proxy(); err != nil || got != "https://mine.example.com" {
+		t.Errorf("Client.Proxy with GOPROXY=off: proxy() = %q, %v", got, err)
+	}
+}
@@ -0,0 +1,122 @@
+package goproxy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+// Retraction is a version or closed interval of versions withdrawn by a
+// retract directive in a go.mod file.
+type Retraction struct {
+	Low, High semver.Version
+	// Rationale is the comment attached to the directive, if any.
+	Rationale string
+}
+
+// Contains reports whether r retracts v.
+func (r Retraction) Contains(v semver.Version) bool {
+	return v.Compare(r.Low) >= 0 && v.Compare(r.High) <= 0
+}
+
+// Retracted reports whether any of rs retracts v.
+func Retracted(rs []Retraction, v semver.Version) bool {
+	for _, r := range rs {
+		if r.Contains(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseRetractions returns the retract directives of a go.mod file, both
+// single-line ("retract v1.0.0", "retract [v1.0.0, v1.1.0]") and in
+// "retract ( ... )" blocks. Other directives are ignored.
+func ParseRetractions(gomod []byte) ([]Retraction, error) {
+	var rs []Retraction
+	inBlock := false
+	pending := "" // comment lines preceding a directive
+	for n, line := range strings.Split(string(gomod), "\n") {
+		code, comment, _ := strings.Cut(line, "//")
+		code, comment = strings.TrimSpace(code), strings.TrimSpace(comment)
+		switch {
+		case code == "" && comment != "":
+			if pending != "" {
+				pending += " "
+			}
+			pending += comment
+			continue
+		case inBlock && code == ")":
+			inBlock = false
+		case inBlock:
+			r, err := parseRetraction(code)
+			if err != nil {
+				return nil, fmt.Errorf("goproxy: go.mod:%d: %v", n+1, err)
+			}
+			r.Rationale = rationale(pending, comment)
+			rs = append(rs, r)
+		case code == "retract (":
+			inBlock = true
+		case strings.HasPrefix(code, "retract ") || strings.HasPrefix(code, "retract\t"):
+			r, err := parseRetraction(strings.TrimSpace(code[len("retract"):]))
+			if err != nil {
+				return nil, fmt.Errorf("goproxy: go.mod:%d: %v", n+1, err)
+			}
+			r.Rationale = rationale(pending, comment)
+			rs = append(rs, r)
+		}
+		pending = ""
+	}
+	if inBlock {
+		return nil, fmt.Errorf("goproxy: go.mod: unterminated retract block")
+	}
+	return rs, nil
+}
+
+// rationale prefers a trailing comment over the comment lines above.
+func rationale(above, trailing string) string {
+	if trailing != "" {
+		return trailing
+	}
+	return above
+}
+
+func parseRetraction(spec string) (Retraction, error) {
+	if strings.HasPrefix(spec, "[") {
+		if !strings.HasSuffix(spec, "]") {
+			return Retraction{}, fmt.Errorf("invalid retract interval %q", spec)
+		}
+		lo, hi, ok := strings.Cut(spec[1:len(spec)-1], ",")
+		if !ok {
+			return Retraction{}, fmt.Errorf("invalid retract interval %q", spec)
+		}
+		low, err := parseGoVersion(lo)
+		if err != nil {
+			return Retraction{}, err
+		}
+		high, err := parseGoVersion(hi)
+		if err != nil {
+			return Retraction{}, err
+		}
+		if low.Compare(high) > 0 {
+			return Retraction{}, fmt.Errorf("retract interval %q is empty", spec)
+		}
+		return Retraction{Low: low, High: high}, nil
+	}
+	v, err := parseGoVersion(spec)
+	if err != nil {
+		return Retraction{}, err
+	}
+	return Retraction{Low: v, High: v}, nil
+}
+
+// parseGoVersion parses a version as the go command writes it: with a
+// mandatory "v" prefix.
+func parseGoVersion(s string) (semver.Version, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "v") {
+		return semver.Version{}, fmt.Errorf("invalid version %q: missing v prefix", s)
+	}
+	return semver.Parse(s)
+}
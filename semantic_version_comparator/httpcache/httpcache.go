@@ -0,0 +1,284 @@
+// Package httpcache is a caching http.RoundTripper for the registry, Go
+// proxy and GitHub clients, so that repeated "what is the latest
+// version?" queries neither hammer upstream services nor use up their
+// rate limits:
+//
+//	hc := httpcache.NewClient(httpcache.NewMemory(0), 5*time.Minute)
+//	proxy := &goproxy.Client{HTTPClient: hc}
+//	gh := &ghrelease.Client{HTTPClient: hc}
+//
+// Responses younger than the TTL are served from the cache. Older ones
+// are revalidated with If-None-Match and If-Modified-Since, which GitHub
+// does not count against the rate limit when it answers 304 Not
+// Modified.
+package httpcache
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Entry is a cached response.
+type Entry struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+	// Stored is when the response was last fetched or revalidated.
+	Stored time.Time `json:"stored"`
+}
+
+// Cache stores responses by key. Implementations must be safe for
+// concurrent use.
+type Cache interface {
+	Get(key string) (Entry, bool)
+	Set(key string, e Entry)
+}
+
+// DefaultCapacity is the number of entries a Memory holds when its
+// capacity is not set.
+const DefaultCapacity = 1024
+
+// Memory is an in-memory Cache holding a bounded number of entries: once
+// full, it evicts the least recently used one. Stale entries are kept
+// until then, since their validators still save a full response. Its
+// zero value is empty, holds DefaultCapacity entries and is ready to use.
+type Memory struct {
+	capacity int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     list.List // of *memoryEntry, most recently used first
+}
+
+type memoryEntry struct {
+	key string
+	e   Entry
+}
+
+// NewMemory returns an empty in-memory cache holding at most capacity
+// entries; zero or less means DefaultCapacity.
+func NewMemory(capacity int) *Memory {
+	return &Memory{capacity: capacity}
+}
+
+// Get returns the entry stored under key.
+func (m *Memory) Get(key string) (Entry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	el, ok := m.entries[key]
+	if !ok {
+		return Entry{}, false
+	}
+	m.lru.MoveToFront(el)
+	return el.Value.(*memoryEntry).e, true
+}
+
+// Set stores e under key, evicting the least recently used entry if the
+// cache is full.
+func (m *Memory) Set(key string, e Entry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if el, ok := m.entries[key]; ok {
+		el.Value.(*memoryEntry).e = e
+		m.lru.MoveToFront(el)
+		return
+	}
+	if m.entries == nil {
+		m.entries = make(map[string]*list.Element)
+	}
+	m.entries[key] = m.lru.PushFront(&memoryEntry{key, e})
+	capacity := m.capacity
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	if m.lru.Len() > capacity {
+		oldest := m.lru.Back()
+		m.lru.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryEntry).key)
+	}
+}
+
+// Len returns the number of cached entries.
+func (m *Memory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lru.Len()
+}
+
+// Disk is a Cache keeping one JSON file per entry in a directory, so that
+// entries and their ETags survive restarts, as for a CLI run from cron.
+// Entries that cannot be read are treated as missing.
+type Disk struct {
+	Dir string
+}
+
+func (d Disk) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get returns the entry stored under key.
+func (d Disk) Get(key string) (Entry, bool) {
+	data, err := os.ReadFile(d.path(key))
+	if err != nil {
+		return Entry{}, false
+	}
+	var e Entry
+	if json.Unmarshal(data, &e) != nil {
+		return Entry{}, false
+	}
+	return e, true
+}
+
+// Set stores e under key. Errors are ignored: a cache that cannot be
+// written only costs requests. The file is replaced atomically, so
+// concurrent processes never read a partial entry.
+func (d Disk) Set(key string, e Entry) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(d.Dir, 0o755); err != nil {
+		return
+	}
+	f, err := os.CreateTemp(d.Dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), d.path(key))
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+}
+
+// Clear removes the entries of d, leaving other files in its directory
+// alone.
+func (d Disk) Clear() error {
+	files, err := filepath.Glob(filepath.Join(d.Dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if err := os.Remove(f); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Transport is an http.RoundTripper that caches successful GET
+// responses in Cache. Other requests pass through unchanged.
+type Transport struct {
+	// Base makes the actual requests; nil means http.DefaultTransport.
+	Base http.RoundTripper
+	// Cache stores the responses.
+	Cache Cache
+	// TTL is how long a response is served without asking upstream. Zero
+	// revalidates every request, which with ETags still saves bandwidth
+	// and rate limit. For OCI registries keep it below the lifetime of
+	// their bearer tokens, which are cached too: five minutes on Docker
+	// Hub.
+	TTL time.Duration
+	// Now returns the current time; nil means time.Now.
+	Now func() time.Time
+}
+
+// NewClient returns an http.Client whose Transport caches in c for ttl.
+func NewClient(c Cache, ttl time.Duration) *http.Client {
+	return &http.Client{Transport: &Transport{Cache: c, TTL: ttl}}
+}
+
+// RoundTrip serves req from the cache when it can, revalidates stale
+// entries, and stores 200 responses. The Authorization header is part of
+// the key, so responses are never shared between credentials.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		return base.RoundTrip(req)
+	}
+	key := cacheKey(req)
+	now := time.Now
+	if t.Now != nil {
+		now = t.Now
+	}
+	e, cached := t.Cache.Get(key)
+	if cached && now().Sub(e.Stored) < t.TTL {
+		return e.response(req), nil
+	}
+	out := req
+	if cached {
+		out = req.Clone(req.Context())
+		if etag := e.Header.Get("ETag"); etag != "" {
+			out.Header.Set("If-None-Match", etag)
+		}
+		if lm := e.Header.Get("Last-Modified"); lm != "" {
+			out.Header.Set("If-Modified-Since", lm)
+		}
+	}
+	resp, err := base.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case cached && resp.StatusCode == http.StatusNotModified:
+		resp.Body.Close()
+		e.Stored = now()
+		t.Cache.Set(key, e)
+		return e.response(req), nil
+	case resp.StatusCode != http.StatusOK || resp.Header.Get("Cache-Control") == "no-store":
+		return resp, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	e = Entry{Status: resp.StatusCode, Header: resp.Header.Clone(), Body: body, Stored: now()}
+	t.Cache.Set(key, e)
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// cacheKey identifies the response to req: its URL, the headers that
+// select a representation, and a digest of its credentials.
+func cacheKey(req *http.Request) string {
+	h := sha256.New()
+	for _, name := range []string{"Authorization", "Accept"} {
+		io.WriteString(h, name+": "+req.Header.Get(name)+"\n")
+	}
+	return req.URL.String() + " " + hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// response rebuilds the cached response for req.
+func (e Entry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        strconv.Itoa(e.Status) + " " + http.StatusText(e.Status),
+		StatusCode:    e.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,181 @@
+package httpcache
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestMemory(t *testing.T) {
+	m := NewMemory(2)
+	m.Set("a", Entry{Status: 200, Body: []byte("a")})
+	m.Set("b", Entry{Status: 200, Body: []byte("b")})
+	if _, ok := m.Get("a"); !ok {
+		t.Fatal("Get(a) missed")
+	}
+	m.Set("c", Entry{Status: 200, Body: []byte("c")})
+	if _, ok := m.Get("b"); ok {
+		t.Error("Get(b) hit after b became the least recently used entry of a full cache")
+	}
+	for _, key := range []string{"a", "c"} {
+		if e, ok := m.Get(key); !ok || string(e.Body) != key {
+			t.Errorf("Get(%s) = %q, %v; want %q", key, e.Body, ok, key)
+		}
+	}
+	m.Set("c", Entry{Status: 200, Body: []byte("c2")})
+	if e, _ := m.Get("c"); string(e.Body) != "c2" || m.Len() != 2 {
+		t.Errorf("after replacing c: Get(c) = %q, Len = %d; want c2, 2", e.Body, m.Len())
+	}
+	var zero Memory
+	for i := 0; i < DefaultCapacity+10; i++ {
+		zero.Set(strconv.Itoa(i), Entry{})
+	}
+	if zero.Len() != DefaultCapacity {
+		t.Errorf("zero Memory holds %d entries, want %d", zero.Len(), DefaultCapacity)
+	}
+}
+
+func TestDisk(t *testing.T) {
+	d := Disk{Dir: filepath.Join(t.TempDir(), "cache")}
+	want := Entry{
+		Status: 200,
+		Header: http.Header{"Etag": {`"v1"`}},
+		Body:   []byte("body"),
+		Stored: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	d.Set("key", want)
+	got, ok := d.Get("key")
+	if !ok || got.Status != want.Status || string(got.Body) != string(want.Body) ||
+		got.Header.Get("ETag") != `"v1"` || !got.Stored.Equal(want.Stored) {
+		t.Errorf("Get = %+v, %v; want %+v", got, ok, want)
+	}
+	if _, ok := d.Get("other"); ok {
+		t.Error("Get of a missing key hit")
+	}
+	if err := os.WriteFile(d.path("corrupt"), []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := d.Get("corrupt"); ok {
+		t.Error("Get of a corrupt entry hit")
+	}
+	keep := filepath.Join(d.Dir, "keep.txt")
+	if err := os.WriteFile(keep, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := d.Get("key"); ok {
+		t.Error("Get hit after Clear")
+	}
+	if _, err := os.Stat(keep); err != nil {
+		t.Errorf("Clear removed a file that is not an entry: %v", err)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		cache Cache
+	}{
+		{"memory", NewMemory(0)},
+		{"disk", Disk{Dir: t.TempDir()}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			testTransport(t, tt.cache)
+		})
+	}
+}
+
+func testTransport(t *testing.T, cache Cache) {
+	const lastModified = "Tue, 02 Jan 2024 03:04:05 GMT"
+	etag, body := `"v1"`, "one"
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/etag":
+			if r.Header.Get("If-None-Match") == etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", etag)
+		case "/modified":
+			if r.Header.Get("If-Modified-Since") == lastModified {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("Last-Modified", lastModified)
+		case "/no-store":
+			w.Header().Set("Cache-Control", "no-store")
+		case "/missing":
+			http.NotFound(w, r)
+			return
+		}
+		io.WriteString(w, body)
+	}))
+	defer srv.Close()
+
+	now := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	client := &http.Client{Transport: &Transport{Cache: cache, TTL: time.Minute, Now: func() time.Time { return now }}}
+	get := func(path string) (int, string) {
+		t.Helper()
+		resp, err := client.Get(srv.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode, string(b)
+	}
+
+	steps := []struct {
+		desc     string
+		path     string
+		advance  time.Duration
+		change   bool // serve a new body under a new ETag first
+		status   int
+		body     string
+		requests int // total requests upstream after the step
+	}{
+		{"first fetch", "/etag", 0, false, 200, "one", 1},
+		{"fresh entry", "/etag", 30 * time.Second, false, 200, "one", 1},
+		{"stale entry revalidated by ETag", "/etag", time.Minute, false, 200, "one", 2},
+		{"revalidation renews freshness", "/etag", 30 * time.Second, false, 200, "one", 2},
+		{"changed upstream", "/etag", time.Minute, true, 200, "two", 3},
+		{"first fetch by date", "/modified", 0, false, 200, "two", 4},
+		{"stale entry revalidated by Last-Modified", "/modified", time.Minute, false, 200, "two", 5},
+		{"no-store", "/no-store", 0, false, 200, "two", 6},
+		{"no-store is not cached", "/no-store", 0, false, 200, "two", 7},
+		{"errors are not cached", "/missing", 0, false, 404, "404 page not found\n", 8},
+		{"errors are not cached", "/missing", 0, false, 404, "404 page not found\n", 9},
+	}
+	for _, s := range steps {
+		now = now.Add(s.advance)
+		if s.change {
+			etag, body = `"v2"`, "two"
+		}
+		status, got := get(s.path)
+		if status != s.status || got != s.body || requests != s.requests {
+			t.Errorf("%s: GET %s = %d %q after %d requests upstream; want %d %q after %d",
+				s.desc, s.path, status, got, requests, s.status, s.body, s.requests)
+		}
+	}
+
+	resp, err := client.Post(srv.URL+"/etag", "text/plain", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if requests != 10 {
+		t.Errorf("POST was answered from the cache")
+	}
+}
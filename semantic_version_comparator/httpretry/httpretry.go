@@ -0,0 +1,211 @@
+// Package httpretry is an http.RoundTripper that retries rate-limited and
+// failed requests with exponential backoff and spaces requests to each
+// host, for running the registry, Go proxy and GitHub clients in CI at
+// scale:
+//
+//	hc := &http.Client{Transport: &httpretry.Transport{PerHost: 5, Burst: 10}}
+//	gh := &ghrelease.Client{Token: token, HTTPClient: hc}
+//
+// It composes with httpcache: put the cache outside, so that cache hits
+// skip the limiter, with httpcache.Transport{Base: &httpretry.Transport{}}.
+package httpretry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Defaults for the zero fields of Transport.
+const (
+	DefaultMaxRetries = 4
+	DefaultMinBackoff = 500 * time.Millisecond
+	DefaultMaxBackoff = 30 * time.Second
+	DefaultMaxWait    = 2 * time.Minute
+)
+
+// Transport retries requests that fail with 429 Too Many Requests, a
+// 5xx status of 500, 502, 503 or 504, GitHub's rate-limited 403, or a
+// network error, and limits the request rate per host. Only requests
+// that can be replayed are retried: those without a body, or with
+// GetBody set, as http.NewRequest does for common body types.
+type Transport struct {
+	// Base makes the actual requests; nil means http.DefaultTransport.
+	Base http.RoundTripper
+	// MaxRetries is the number of retries after the first attempt; zero
+	// means DefaultMaxRetries and a negative value disables retries.
+	MaxRetries int
+	// MinBackoff and MaxBackoff bound the exponential backoff between
+	// attempts, which is randomized to spread out concurrent clients.
+	// Zero means DefaultMinBackoff and DefaultMaxBackoff.
+	MinBackoff, MaxBackoff time.Duration
+	// MaxWait caps the wait a server may request with Retry-After or
+	// X-RateLimit-Reset; longer waits fail the request instead. Zero
+	// means DefaultMaxWait.
+	MaxWait time.Duration
+	// PerHost, if positive, is the number of requests per second allowed
+	// to each host, with bursts of up to Burst requests (at least 1).
+	PerHost float64
+	Burst   int
+
+	mu   sync.Mutex
+	next map[string]time.Time // theoretical arrival time per host
+}
+
+// RoundTrip sends req, waiting for the host's rate limit and retrying
+// as described on Transport. Waits end early when req's context is done.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	retries := t.MaxRetries
+	if retries == 0 {
+		retries = DefaultMaxRetries
+	}
+	if retries < 0 {
+		retries = 0
+	}
+	// A body that cannot be replayed is consumed by the first attempt.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		retries = 0
+	}
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		if err := sleep(ctx, t.reserve(req.URL.Host)); err != nil {
+			return nil, err
+		}
+		out := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			out = req.Clone(ctx)
+			out.Body = body
+		}
+		resp, err := base.RoundTrip(out)
+		if attempt == retries || !retryable(resp, err) || ctx.Err() != nil {
+			return resp, err
+		}
+		wait := t.backoff(attempt)
+		if resp != nil {
+			if d, ok := serverWait(resp); ok {
+				if d > t.maxWait() {
+					return resp, nil
+				}
+				wait = d
+			}
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryable reports whether the outcome of an attempt is worth retrying.
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	case http.StatusForbidden:
+		// GitHub reports an exhausted rate limit as 403.
+		return resp.Header.Get("X-RateLimit-Remaining") == "0"
+	}
+	return false
+}
+
+// serverWait returns the wait a response asks for, from Retry-After in
+// seconds or as a date, or from GitHub's X-RateLimit-Reset once the limit
+// is exhausted.
+func serverWait(resp *http.Response) (time.Duration, bool) {
+	if ra := resp.Header.Get("Retry-After"); ra != "" {
+		if n, err := strconv.Atoi(ra); err == nil && n >= 0 {
+			return time.Duration(n) * time.Second, true
+		}
+		if at, err := http.ParseTime(ra); err == nil {
+			return max(time.Until(at), 0), true
+		}
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if n, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			return max(time.Until(time.Unix(n, 0)), 0), true
+		}
+	}
+	return 0, false
+}
+
+// backoff returns a random wait of up to MinBackoff·2^attempt, capped at
+// MaxBackoff.
+func (t *Transport) backoff(attempt int) time.Duration {
+	lo, hi := t.MinBackoff, t.MaxBackoff
+	if lo <= 0 {
+		lo = DefaultMinBackoff
+	}
+	if hi <= 0 {
+		hi = DefaultMaxBackoff
+	}
+	d := lo
+	for i := 0; i < attempt && d < hi; i++ {
+		d *= 2
+	}
+	d = min(d, hi)
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+func (t *Transport) maxWait() time.Duration {
+	if t.MaxWait > 0 {
+		return t.MaxWait
+	}
+	return DefaultMaxWait
+}
+
+// reserve takes the next request slot of host and returns how long to
+// wait for it. It uses the generic cell rate algorithm: each request
+// pushes the host's theoretical arrival time one interval further, and a
+// request may go once it is no more than Burst-1 intervals ahead.
+func (t *Transport) reserve(host string) time.Duration {
+	if t.PerHost <= 0 {
+		return 0
+	}
+	interval := time.Duration(float64(time.Second) / t.PerHost)
+	burst := max(t.Burst, 1)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.next == nil {
+		t.next = make(map[string]time.Time)
+	}
+	now := time.Now()
+	tat := t.next[host]
+	if tat.Before(now) {
+		tat = now
+	}
+	t.next[host] = tat.Add(interval)
+	return max(tat.Add(-time.Duration(burst-1)*interval).Sub(now), 0)
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,72 @@
+package httpretry
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// countingTransport answers every request with 503 and counts attempts,
+// reading each body to the end as a real transport would.
+type countingTransport struct{ attempts int }
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.attempts++
+	if req.Body != nil {
+		io.Copy(io.Discard, req.Body)
+		req.Body.Close()
+	}
+	return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody, Request: req}, nil
+}
+
+func TestRoundTripRetries(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxRetries int
+		body       func() *http.Request
+		want       int
+	}{
+		{"default without body", 0, func() *http.Request {
+			req, _ := http.NewRequest("GET", "http://example.com/", nil)
+			return req
+		}, DefaultMaxRetries + 1},
+		{"default with replayable body", 0, func() *http.Request {
+			req, _ := http.NewRequest("POST", "http://example.com/", strings.NewReader("data"))
+			return req
+		}, DefaultMaxRetries + 1},
+		{"default with one-shot body", 0, func() *http.Request {
+			req, _ := http.NewRequest("POST", "http://example.com/", io.NopCloser(strings.NewReader("data")))
+			req.GetBody = nil
+			return req
+		}, 1},
+		{"explicit with one-shot body", 2, func() *http.Request {
+			req, _ := http.NewRequest("POST", "http://example.com/", io.NopCloser(strings.NewReader("data")))
+			req.GetBody = nil
+			return req
+		}, 1},
+		{"disabled", -1, func() *http.Request {
+			req, _ := http.NewRequest("GET", "http://example.com/", nil)
+			return req
+		}, 1},
+		{"two retries", 2, func() *http.Request {
+			req, _ := http.NewRequest("GET", "http://example.com/", nil)
+			return req
+		}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := &countingTransport{}
+			tr := &Transport{Base: base, MaxRetries: tt.maxRetries, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+			resp, err := tr.RoundTrip(tt.body())
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if base.attempts != tt.want {
+				t.Errorf("attempts = %d, want %d", base.attempts, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package imagescan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+// Problems reported for an image.
+const (
+	ProblemLatest     = "latest"
+	ProblemNotSemver  = "not-semver"
+	ProblemNotAllowed = "not-allowed"
+)
+
+// Rule limits the versions of the images it names to a constraint.
+type Rule struct {
+	// Image is a repository such as "nginx" or "ghcr.io/org/app". It
+	// matches the repository itself and any repository ending in
+	// "/"+Image, so "nginx" also matches "docker.io/library/nginx". An
+	// empty Image matches every image.
+	Image      string
+	Constraint semver.Constraint
+}
+
+func (r Rule) matches(repository string) bool {
+	return r.Image == "" || repository == r.Image || strings.HasSuffix(repository, "/"+r.Image)
+}
+
+// Finding is an image with a problem.
+type Finding struct {
+	Image
+	// Version is the tag read as a version, if it is one.
+	Version string `json:"version,omitempty"`
+	Problem string `json:"problem"`
+	Message string `json:"message"`
+}
+
+// Report is the result of a scan.
+type Report struct {
+	Images   int       `json:"images"`
+	Findings []Finding `json:"findings"`
+}
+
+// Check reports the problems of imgs, in order:
+//
+//   - an image without a tag or digest, or tagged "latest", is
+//     ProblemLatest;
+//   - a tag that semver.ParseTolerant rejects, such as "alpine3.19", is
+//     ProblemNotSemver, unless the image is pinned by digest;
+//   - a version outside the constraint of a rule matching the image is
+//     ProblemNotAllowed. The first matching rule applies.
+//
+// Tag suffixes that do not name a release channel, such as the "-alpine"
+// of "1.25-alpine", are image variants rather than pre-releases and are
+// ignored when checking constraints, so 1.25-alpine satisfies ">=1.25".
+func Check(imgs []Image, rules []Rule) Report {
+	r := Report{Images: len(imgs), Findings: []Finding{}}
+	for _, img := range imgs {
+		f, ok := check(img, rules)
+		if !ok {
+			r.Findings = append(r.Findings, f)
+		}
+	}
+	return r
+}
+
+func check(img Image, rules []Rule) (Finding, bool) {
+	f := Finding{Image: img}
+	switch {
+	case img.Tag == "" && img.Digest == "":
+		f.Problem, f.Message = ProblemLatest, "no tag, so the image floats on latest"
+		return f, false
+	case img.Tag == "latest":
+		f.Problem, f.Message = ProblemLatest, "tagged latest"
+		return f, false
+	case img.Tag == "":
+		return f, true
+	}
+	v, err := semver.ParseTolerant(img.Tag)
+	if err != nil {
+		if img.Digest != "" {
+			return f, true
+		}
+		f.Problem, f.Message = ProblemNotSemver, fmt.Sprintf("tag %q is not a version", img.Tag)
+		return f, false
+	}
+	f.Version = v.String()
+	if v.Channel() == semver.ChannelUnknown {
+		v.Prerelease = ""
+	}
+	for _, rule := range rules {
+		if !rule.matches(img.Repository) {
+			continue
+		}
+		if rule.Constraint.Check(v) {
+			return f, true
+		}
+		f.Problem, f.Message = ProblemNotAllowed, fmt.Sprintf("%s does not satisfy %s", v, rule.Constraint)
+		return f, false
+	}
+	return f, true
+}
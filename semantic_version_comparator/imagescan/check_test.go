@@ -0,0 +1,62 @@
+package imagescan
+
+import (
+	"testing"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+func TestCheck(t *testing.T) {
+	rules := []Rule{
+		{Image: "nginx", Constraint: semver.MustParseConstraint(">=1.25")},
+		{Image: "ghcr.io/org/app", Constraint: semver.MustParseConstraint("^2.0.0")},
+		{Constraint: semver.MustParseConstraint("<10.0.0")},
+	}
+	tests := []struct {
+		ref     string
+		problem string // "" if the image passes
+		version string
+	}{
+		{"nginx", ProblemLatest, ""},
+		{"nginx:latest", ProblemLatest, ""},
+		{"nginx@sha256:ab", "", ""},
+		{"nginx:1.25-alpine", "", "1.25.0-alpine"},
+		{"docker.io/library/nginx:1.24", ProblemNotAllowed, "1.24.0"},
+		{"mynginx:1.24", "", "1.24.0"},
+		{"nginx:1.26.0-rc.1", ProblemNotAllowed, "1.26.0-rc.1"},
+		{"alpine:3.19", "", "3.19.0"},
+		{"alpine:edge", ProblemNotSemver, ""},
+		{"alpine:edge@sha256:ab", "", ""},
+		{"ghcr.io/org/app:v2.3.1", "", "2.3.1"},
+		{"ghcr.io/org/app:v3.0.0", ProblemNotAllowed, "3.0.0"},
+		{"postgres:16", ProblemNotAllowed, "16.0.0"},
+	}
+	imgs := make([]Image, len(tests))
+	for i, tt := range tests {
+		imgs[i] = newImage("compose.yaml", i+1, tt.ref)
+	}
+	r := Check(imgs, rules)
+	if r.Images != len(tests) {
+		t.Errorf("Images = %d; want %d", r.Images, len(tests))
+	}
+	fs := r.Findings
+	for _, tt := range tests {
+		if tt.problem == "" {
+			continue
+		}
+		if len(fs) == 0 || fs[0].Ref != tt.ref {
+			t.Errorf("Check found no problem with %s; want %s", tt.ref, tt.problem)
+			continue
+		}
+		if f := fs[0]; f.Problem != tt.problem || f.Version != tt.version || f.Message == "" {
+			t.Errorf("Check(%s) = %s %q (version %q); want %s (version %q)", tt.ref, f.Problem, f.Message, f.Version, tt.problem, tt.version)
+		}
+		fs = fs[1:]
+	}
+	for _, f := range fs {
+		t.Errorf("Check(%s) = %s %q; want no problem", f.Ref, f.Problem, f.Message)
+	}
+	if r := Check(nil, rules); r.Findings == nil {
+		t.Error("Check(nil) returned nil Findings; want an empty list")
+	}
+}
@@ -0,0 +1,145 @@
+// Package imagescan finds the container images referenced by Dockerfiles,
+// docker-compose files and Kubernetes manifests and checks their tags:
+// images left on "latest", tags that are not versions and versions that
+// fall outside an allowed range.
+package imagescan
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Image is an image reference found in a file.
+type Image struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+	// Ref is the reference as written, such as "nginx:1.25-alpine" or
+	// "ghcr.io/org/app@sha256:...".
+	Ref        string `json:"image"`
+	Repository string `json:"repository"`
+	Tag        string `json:"tag,omitempty"`
+	Digest     string `json:"digest,omitempty"`
+}
+
+// ParseReference splits an image reference into its repository, tag and
+// digest: "registry:5000/app:1.2@sha256:ab" has repository
+// "registry:5000/app", tag "1.2" and digest "sha256:ab". The tag and
+// digest are empty if not given.
+func ParseReference(ref string) (repository, tag, digest string) {
+	repository, digest, _ = strings.Cut(ref, "@")
+	// A colon after the last slash starts the tag; one before it belongs
+	// to a registry port.
+	if i := strings.LastIndexByte(repository, ':'); i > strings.LastIndexByte(repository, '/') {
+		repository, tag = repository[:i], repository[i+1:]
+	}
+	return repository, tag, digest
+}
+
+func newImage(file string, line int, ref string) Image {
+	repo, tag, digest := ParseReference(ref)
+	return Image{File: file, Line: line, Ref: ref, Repository: repo, Tag: tag, Digest: digest}
+}
+
+// ScanDockerfile returns the images of the FROM instructions of a
+// Dockerfile. References to earlier build stages, "scratch" and images
+// named through build arguments such as "$BASE" are skipped.
+func ScanDockerfile(file string, data []byte) []Image {
+	var imgs []Image
+	stages := map[string]bool{}
+	for n, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || !strings.EqualFold(fields[0], "FROM") {
+			continue
+		}
+		args := fields[1:]
+		for len(args) > 0 && strings.HasPrefix(args[0], "--") {
+			args = args[1:]
+		}
+		if len(args) == 0 {
+			continue
+		}
+		ref := args[0]
+		if ref != "scratch" && !stages[strings.ToLower(ref)] && !strings.Contains(ref, "$") {
+			imgs = append(imgs, newImage(file, n+1, ref))
+		}
+		if len(args) >= 3 && strings.EqualFold(args[1], "AS") {
+			stages[strings.ToLower(args[2])] = true
+		}
+	}
+	return imgs
+}
+
+// ScanYAML returns the values of the "image:" keys of a YAML document,
+// which is where both docker-compose files and Kubernetes manifests name
+// their images. Values using variables, such as "${TAG}", are skipped.
+func ScanYAML(file string, data []byte) []Image {
+	var imgs []Image
+	for n, line := range strings.Split(string(data), "\n") {
+		s := strings.TrimSpace(line)
+		s = strings.TrimSpace(strings.TrimPrefix(s, "- "))
+		rest, ok := strings.CutPrefix(s, "image:")
+		if !ok {
+			continue
+		}
+		if i := strings.Index(rest, " #"); i >= 0 {
+			rest = rest[:i]
+		}
+		ref := strings.Trim(strings.TrimSpace(rest), `"'`)
+		if ref == "" || strings.Contains(ref, "$") || strings.ContainsAny(ref, "{}") {
+			continue
+		}
+		imgs = append(imgs, newImage(file, n+1, ref))
+	}
+	return imgs
+}
+
+// IsDockerfile reports whether name is a Dockerfile by its file name:
+// Dockerfile, Containerfile, Dockerfile.* or *.Dockerfile.
+func IsDockerfile(name string) bool {
+	base := filepath.Base(name)
+	return base == "Dockerfile" || base == "Containerfile" ||
+		strings.HasPrefix(base, "Dockerfile.") || strings.HasSuffix(base, ".Dockerfile")
+}
+
+// IsYAML reports whether name is a YAML file.
+func IsYAML(name string) bool {
+	ext := filepath.Ext(name)
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// skipDirs are directories Walk does not descend into.
+var skipDirs = map[string]bool{".git": true, "node_modules": true, "vendor": true}
+
+// Walk scans the Dockerfiles and YAML files under root, which may also be
+// a single file, and returns their images in file order.
+func Walk(root string) ([]Image, error) {
+	var imgs []Image
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && skipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		dockerfile := IsDockerfile(path)
+		if !dockerfile && !IsYAML(path) {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if dockerfile {
+			imgs = append(imgs, ScanDockerfile(path, data)...)
+		} else {
+			imgs = append(imgs, ScanYAML(path, data)...)
+		}
+		return nil
+	})
+	return imgs, err
+}
@@ -0,0 +1,126 @@
+package imagescan
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		ref, repository, tag, digest string
+	}{
+		{"nginx", "nginx", "", ""},
+		{"nginx:1.25-alpine", "nginx", "1.25-alpine", ""},
+		{"registry:5000/app", "registry:5000/app", "", ""},
+		{"registry:5000/app:1.2@sha256:ab", "registry:5000/app", "1.2", "sha256:ab"},
+		{"ghcr.io/org/app@sha256:ab", "ghcr.io/org/app", "", "sha256:ab"},
+	}
+	for _, tt := range tests {
+		repo, tag, digest := ParseReference(tt.ref)
+		if repo != tt.repository || tag != tt.tag || digest != tt.digest {
+			t.Errorf("ParseReference(%q) = %q, %q, %q; want %q, %q, %q", tt.ref, repo, tag, digest, tt.repository, tt.tag, tt.digest)
+		}
+	}
+}
+
+// refs returns the file, line and reference of each image.
+func refs(imgs []Image) []string {
+	var s []string
+	for _, img := range imgs {
+		s = append(s, filepath.Base(img.File)+":"+strconv.Itoa(img.Line)+" "+img.Ref)
+	}
+	return s
+}
+
+func TestScanDockerfile(t *testing.T) {
+	data := `ARG BASE=alpine:3.19
+FROM --platform=$BUILDPLATFORM golang:1.22 AS Build
+from build AS test
+FROM $BASE
+FROM scratch
+FROM
+FROM --platform=linux/amd64
+FROM nginx:1.25-alpine
+COPY --from=build /app /app
+`
+	got := refs(ScanDockerfile("Dockerfile", []byte(data)))
+	if want := []string{"Dockerfile:2 golang:1.22", "Dockerfile:8 nginx:1.25-alpine"}; !slices.Equal(got, want) {
+		t.Errorf("ScanDockerfile = %q; want %q", got, want)
+	}
+}
+
+func TestScanYAML(t *testing.T) {
+	data := `services:
+  web:
+    image: "nginx:1.25" # pinned
+  db:
+    image: postgres
+  app:
+    image: ghcr.io/org/app:${TAG}
+containers:
+  - image: 'busybox:1.36'
+  - name: empty
+    image:
+`
+	got := refs(ScanYAML("compose.yaml", []byte(data)))
+	if want := []string{"compose.yaml:3 nginx:1.25", "compose.yaml:5 postgres", "compose.yaml:9 busybox:1.36"}; !slices.Equal(got, want) {
+		t.Errorf("ScanYAML = %q; want %q", got, want)
+	}
+}
+
+func TestFileNames(t *testing.T) {
+	for name, want := range map[string]bool{
+		"Dockerfile":           true,
+		"a/Containerfile":      true,
+		"Dockerfile.dev":       true,
+		"build/app.Dockerfile": true,
+		"dockerfile.md":        false,
+	} {
+		if got := IsDockerfile(name); got != want {
+			t.Errorf("IsDockerfile(%q) = %v; want %v", name, got, want)
+		}
+	}
+	for name, want := range map[string]bool{"k8s/deploy.yaml": true, "compose.yml": true, "values.json": false} {
+		if got := IsYAML(name); got != want {
+			t.Errorf("IsYAML(%q) = %v; want %v", name, got, want)
+		}
+	}
+}
+
+func TestWalk(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"Dockerfile":                    "FROM golang:1.22\n",
+		"deploy/app.yaml":               "image: app:1.0.0\n",
+		"README.md":                     "image: ignored:1.0\n",
+		"node_modules/x/Dockerfile":     "FROM skipped:1.0\n",
+		".git/hooks/compose.yml":        "image: skipped:1.0\n",
+		"deploy/nested/Dockerfile.test": "FROM alpine:3.19\n",
+	}
+	for name, data := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	imgs, err := Walk(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := refs(imgs), []string{"Dockerfile:1 golang:1.22", "app.yaml:1 app:1.0.0", "Dockerfile.test:1 alpine:3.19"}; !slices.Equal(got, want) {
+		t.Errorf("Walk = %q; want %q", got, want)
+	}
+	imgs, err = Walk(filepath.Join(root, "Dockerfile"))
+	if err != nil || len(imgs) != 1 {
+		t.Errorf("Walk of a single file = %v, %v; want one image", imgs, err)
+	}
+	if _, err := Walk(filepath.Join(root, "missing")); err == nil {
+		t.Error("Walk of a missing path succeeded")
+	}
+}
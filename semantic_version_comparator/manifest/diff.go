@@ -0,0 +1,143 @@
+package manifest
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/pep440"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+// Kind classifies the change of one dependency between two manifests.
+type Kind int
+
+const (
+	Added     Kind = iota // only in the new manifest
+	Removed               // only in the old manifest
+	Downgrade             // the new version is lower
+	Patch                 // a patch, pre-release or build upgrade
+	Minor                 // a minor upgrade
+	Major                 // a major upgrade, or an epoch change
+	// Changed is a change between versions that cannot both be read as
+	// versions, such as a git URL replacing "^1.2.0".
+	Changed
+)
+
+var kindNames = [...]string{"added", "removed", "downgrade", "patch", "minor", "major", "changed"}
+
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return "Kind(" + strconv.Itoa(int(k)) + ")"
+	}
+	return kindNames[k]
+}
+
+// ParseKind returns the kind with the given name, as returned by
+// Kind.String.
+func ParseKind(name string) (Kind, error) {
+	for i, n := range kindNames {
+		if n == name {
+			return Kind(i), nil
+		}
+	}
+	return 0, fmt.Errorf("manifest: unknown change kind %q", name)
+}
+
+// Change is a dependency whose version differs between two manifests.
+type Change struct {
+	Name string
+	// From and To are the versions as written; From is empty for an
+	// added dependency and To for a removed one.
+	From, To string
+	Kind     Kind
+	// Breaking reports whether SemVer allows the change to break
+	// callers: the versions are not semver.Compatible, as across
+	// majors or, below 1.0.0, across minors. Added and removed
+	// dependencies are not breaking.
+	Breaking bool
+}
+
+// Diff returns the dependencies that differ between old and new, two
+// manifests of format f, sorted by name. Ranges such as "^1.2.0" or
+// ">=1.2,<2" are compared by their lower bound.
+func Diff(f Format, old, new []Dependency) []Change {
+	before := make(map[string]string, len(old))
+	for _, d := range old {
+		before[d.Name] = d.Version
+	}
+	var cs []Change
+	for _, d := range new {
+		from, ok := before[d.Name]
+		delete(before, d.Name)
+		switch {
+		case !ok:
+			cs = append(cs, Change{Name: d.Name, To: d.Version, Kind: Added})
+		case from != d.Version:
+			if c, ok := classify(f, d.Name, from, d.Version); ok {
+				cs = append(cs, c)
+			}
+		}
+	}
+	for _, d := range old {
+		if _, ok := before[d.Name]; ok {
+			cs = append(cs, Change{Name: d.Name, From: d.Version, Kind: Removed})
+		}
+	}
+	slices.SortFunc(cs, func(a, b Change) int { return strings.Compare(a.Name, b.Name) })
+	return cs
+}
+
+// classify describes the change from one spelling of a version to
+// another. ok is false if both denote the same version, as "1.2" and
+// "1.2.0" do.
+func classify(f Format, name, from, to string) (c Change, ok bool) {
+	c = Change{Name: name, From: from, To: to, Kind: Changed}
+	a, okA := f.lowerBound(from)
+	b, okB := f.lowerBound(to)
+	if !okA || !okB {
+		return c, true
+	}
+	c.Breaking = !a.Compatible(b)
+	switch d := a.Diff(b); {
+	case a.Compare(b) > 0:
+		c.Kind = Downgrade
+	case d == semver.DiffMajor:
+		c.Kind = Major
+	case d == semver.DiffMinor:
+		c.Kind = Minor
+	case d == semver.DiffNone:
+		return c, false
+	default:
+		c.Kind = Patch
+	}
+	return c, true
+}
+
+// lowerBound reads the version or range spec as written in a manifest of
+// format f and returns the lowest version it names.
+func (f Format) lowerBound(spec string) (semver.Version, bool) {
+	switch f {
+	case GoMod:
+		v, err := semver.Parse(spec)
+		return v, err == nil
+	case PackageJSON:
+		first, _, _ := strings.Cut(strings.TrimSpace(spec), " ")
+		v, err := semver.ParseTolerant(strings.TrimLeft(first, "^~=>"))
+		return v, err == nil
+	case Requirements:
+		first, _, _ := strings.Cut(spec, ",")
+		for _, op := range []string{"===", "==", ">=", "~="} {
+			if rest, ok := strings.CutPrefix(first, op); ok {
+				pv, err := pep440.Parse(rest)
+				if err != nil {
+					return semver.Version{}, false
+				}
+				v, _ := pv.ToSemver()
+				return v, true
+			}
+		}
+	}
+	return semver.Version{}, false
+}
@@ -0,0 +1,71 @@
+package manifest
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		f        Format
+		old, new []Dependency
+		want     []Change
+	}{
+		{
+			f: GoMod,
+			old: []Dependency{
+				{"a", "v1.0.0"}, {"b", "v1.2.0"}, {"c", "v0.3.0"}, {"d", "v2.0.0"}, {"e", "v1.0.0"}, {"gone", "v1.0.0"},
+			},
+			new: []Dependency{
+				{"a", "v1.0.1"}, {"b", "v1.3.0"}, {"c", "v0.4.0"}, {"d", "v1.9.0"}, {"e", "v1.0.0"}, {"new", "v0.1.0"},
+			},
+			want: []Change{
+				{Name: "a", From: "v1.0.0", To: "v1.0.1", Kind: Patch},
+				{Name: "b", From: "v1.2.0", To: "v1.3.0", Kind: Minor},
+				{Name: "c", From: "v0.3.0", To: "v0.4.0", Kind: Minor, Breaking: true},
+				{Name: "d", From: "v2.0.0", To: "v1.9.0", Kind: Downgrade, Breaking: true},
+				{Name: "gone", From: "v1.0.0", Kind: Removed},
+				{Name: "new", To: "v0.1.0", Kind: Added},
+			},
+		},
+		{
+			f:   PackageJSON,
+			old: []Dependency{{"react", "^17.0.2"}, {"lodash", "4.17"}, {"left-pad", "^1.2.0"}, {"vite", ">=4.0.0 <5"}},
+			new: []Dependency{{"react", "^18.2.0"}, {"lodash", "4.17.0"}, {"left-pad", "github:x/left-pad"}, {"vite", "~4.0.1-beta.1"}},
+			want: []Change{
+				{Name: "left-pad", From: "^1.2.0", To: "github:x/left-pad", Kind: Changed},
+				{Name: "react", From: "^17.0.2", To: "^18.2.0", Kind: Major, Breaking: true},
+				{Name: "vite", From: ">=4.0.0 <5", To: "~4.0.1-beta.1", Kind: Patch},
+			},
+		},
+		{
+			f:   Requirements,
+			old: []Dependency{{"django", ">=4.2,<5"}, {"requests", "==2.31.0"}, {"numpy", ""}},
+			new: []Dependency{{"django", "==5.0"}, {"requests", "===2.31.1"}, {"numpy", "==1.26"}},
+			want: []Change{
+				{Name: "django", From: ">=4.2,<5", To: "==5.0", Kind: Major, Breaking: true},
+				{Name: "numpy", To: "==1.26", Kind: Changed},
+				{Name: "requests", From: "==2.31.0", To: "===2.31.1", Kind: Patch},
+			},
+		},
+	}
+	for _, tt := range tests {
+		if got := Diff(tt.f, tt.old, tt.new); !slices.Equal(got, tt.want) {
+			t.Errorf("Diff(%v) = %+v; want %+v", tt.f, got, tt.want)
+		}
+	}
+}
+
+func TestKind(t *testing.T) {
+	for k := Added; k <= Changed; k++ {
+		if got, err := ParseKind(k.String()); err != nil || got != k {
+			t.Errorf("ParseKind(%q) = %v, %v; want %v", k, got, err, k)
+		}
+	}
+	if _, err := ParseKind("sideways"); err == nil {
+		t.Error("ParseKind(sideways) succeeded")
+	}
+	if s := Kind(-1).String(); s != "Kind(-1)" {
+		t.Errorf("Kind(-1).String() = %q", s)
+	}
+}
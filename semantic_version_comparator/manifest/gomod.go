@@ -0,0 +1,160 @@
+package manifest
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// GoModFile holds the module directives of a go.mod file.
+type GoModFile struct {
+	Module  string
+	Require []ModuleVersion
+	Exclude []ModuleVersion
+	Replace []Replacement
+}
+
+// ModuleVersion is a module path and version named by a go.mod
+// directive, with the 1-based line it appears on.
+type ModuleVersion struct {
+	Path    string
+	Version string
+	Line    int
+	// Indirect is set for requirements marked "// indirect".
+	Indirect bool
+}
+
+// Replacement is a replace directive. Old.Version is empty if every
+// version of the module is replaced, and New.Version if the replacement
+// is a local directory.
+type Replacement struct {
+	Old, New ModuleVersion
+}
+
+// ParseGoMod parses the module, require, exclude and replace directives
+// of a go.mod file, both single-line and in blocks. Other directives are
+// ignored.
+func ParseGoMod(data []byte) (*GoModFile, error) {
+	f := &GoModFile{}
+	block := ""
+	for i, line := range strings.Split(string(data), "\n") {
+		n := i + 1
+		code, comment, _ := strings.Cut(line, "//")
+		fields := strings.Fields(code)
+		switch {
+		case len(fields) == 0:
+			continue
+		case block != "" && fields[0] == ")":
+			block = ""
+			continue
+		case block == "" && len(fields) == 2 && fields[1] == "(":
+			block = fields[0]
+			continue
+		}
+		verb := block
+		if verb == "" {
+			verb, fields = fields[0], fields[1:]
+		}
+		var err error
+		switch verb {
+		case "module":
+			if len(fields) != 1 {
+				return nil, fmt.Errorf("manifest: go.mod:%d: malformed module", n)
+			}
+			f.Module, err = unquote(fields[0])
+		case "require", "exclude":
+			var mv ModuleVersion
+			if mv, err = moduleVersion(fields, n); err != nil {
+				break
+			}
+			if verb == "exclude" {
+				f.Exclude = append(f.Exclude, mv)
+				break
+			}
+			mv.Indirect = strings.HasPrefix(strings.TrimSpace(comment), "indirect")
+			f.Require = append(f.Require, mv)
+		case "replace":
+			var r Replacement
+			if r, err = replacement(fields, n); err == nil {
+				f.Replace = append(f.Replace, r)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("manifest: go.mod:%d: %s: %v", n, verb, err)
+		}
+	}
+	if block != "" {
+		return nil, fmt.Errorf("manifest: go.mod: unterminated %s block", block)
+	}
+	return f, nil
+}
+
+func moduleVersion(fields []string, line int) (ModuleVersion, error) {
+	if len(fields) != 2 {
+		return ModuleVersion{}, fmt.Errorf("want a module path and a version")
+	}
+	path, err := unquote(fields[0])
+	if err != nil {
+		return ModuleVersion{}, err
+	}
+	return ModuleVersion{Path: path, Version: fields[1], Line: line}, nil
+}
+
+// replacement parses "OLD [VERSION] => NEW [VERSION]".
+func replacement(fields []string, line int) (Replacement, error) {
+	arrow := slices.Index(fields, "=>")
+	if arrow < 0 {
+		return Replacement{}, fmt.Errorf("want OLD [VERSION] => NEW [VERSION]")
+	}
+	old, repl := fields[:arrow], fields[arrow+1:]
+	if len(old) < 1 || len(old) > 2 || len(repl) < 1 || len(repl) > 2 {
+		return Replacement{}, fmt.Errorf("want OLD [VERSION] => NEW [VERSION]")
+	}
+	var r Replacement
+	for i, side := range [][]string{old, repl} {
+		path, err := unquote(side[0])
+		if err != nil {
+			return Replacement{}, err
+		}
+		mv := ModuleVersion{Path: path, Line: line}
+		if len(side) == 2 {
+			mv.Version = side[1]
+		}
+		if i == 0 {
+			r.Old = mv
+		} else {
+			r.New = mv
+		}
+	}
+	return r, nil
+}
+
+// IsLocal reports whether the replacement module of r is a directory
+// rather than a module path.
+func (r Replacement) IsLocal() bool {
+	p := r.New.Path
+	return strings.HasPrefix(p, "./") || strings.HasPrefix(p, "../") || strings.HasPrefix(p, "/") ||
+		p == "." || p == ".." || len(p) > 1 && p[1] == ':'
+}
+
+// unquote removes the quotes of a module path written as a Go string.
+func unquote(s string) (string, error) {
+	if !strings.HasPrefix(s, `"`) && !strings.HasPrefix(s, "`") {
+		return s, nil
+	}
+	return strconv.Unquote(s)
+}
+
+// parseGoMod returns the required modules of a go.mod file.
+func parseGoMod(data []byte) ([]Dependency, error) {
+	f, err := ParseGoMod(data)
+	if err != nil {
+		return nil, err
+	}
+	deps := make([]Dependency, len(f.Require))
+	for i, r := range f.Require {
+		deps[i] = Dependency{Name: r.Path, Version: r.Version}
+	}
+	return dedup(deps), nil
+}
@@ -0,0 +1,92 @@
+package manifest
+
+import (
+	"slices"
+	"testing"
+)
+
+const goMod = `module "example.com/m"
+
+go 1.21
+
+require example.com/a v1.0.0
+
+require (
+	example.com/b v1.2.0 // indirect
+	example.com/c v0.3.0
+)
+
+exclude example.com/b v1.1.0
+
+replace (
+	example.com/c => ../c
+	example.com/a v1.0.0 => example.com/fork v1.0.1
+)
+`
+
+func TestParseGoMod(t *testing.T) {
+	f, err := ParseGoMod([]byte(goMod))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Module != "example.com/m" {
+		t.Errorf("Module = %q; want example.com/m", f.Module)
+	}
+	wantRequire := []ModuleVersion{
+		{Path: "example.com/a", Version: "v1.0.0", Line: 5},
+		{Path: "example.com/b", Version: "v1.2.0", Line: 8, Indirect: true},
+		{Path: "example.com/c", Version: "v0.3.0", Line: 9},
+	}
+	if !slices.Equal(f.Require, wantRequire) {
+		t.Errorf("Require = %+v; want %+v", f.Require, wantRequire)
+	}
+	if want := []ModuleVersion{{Path: "example.com/b", Version: "v1.1.0", Line: 12}}; !slices.Equal(f.Exclude, want) {
+		t.Errorf("Exclude = %+v; want %+v", f.Exclude, want)
+	}
+	wantReplace := []Replacement{
+		{Old: ModuleVersion{Path: "example.com/c", Line: 15}, New: ModuleVersion{Path: "../c", Line: 15}},
+		{
+			Old: ModuleVersion{Path: "example.com/a", Version: "v1.0.0", Line: 16},
+			New: ModuleVersion{Path: "example.com/fork", Version: "v1.0.1", Line: 16},
+		},
+	}
+	if !slices.Equal(f.Replace, wantReplace) {
+		t.Errorf("Replace = %+v; want %+v", f.Replace, wantReplace)
+	}
+}
+
+func TestParseGoModError(t *testing.T) {
+	for _, data := range []string{
+		"module a b\n",
+		"module \"a\n",
+		"require example.com/a\n",
+		"require \"example.com/a v1.0.0\n",
+		"exclude example.com/a v1 v2\n",
+		"replace example.com/a v1.0.0\n",
+		"replace => example.com/b\n",
+		"replace example.com/a => example.com/b v1 v2\n",
+		"require (\n\texample.com/a v1.0.0\n",
+	} {
+		if f, err := ParseGoMod([]byte(data)); err == nil {
+			t.Errorf("ParseGoMod(%q) = %+v; want an error", data, f)
+		}
+	}
+}
+
+func TestIsLocal(t *testing.T) {
+	for path, want := range map[string]bool{
+		"./c":              true,
+		"../c":             true,
+		"/src/c":           true,
+		".":                true,
+		"..":               true,
+		`C:\src\c`:         true,
+		"example.com/c":    false,
+		"example.com/../c": false,
+	} {
+		r := Replacement{New: ModuleVersion{Path: path}}
+		if got := r.IsLocal(); got != want {
+			t.Errorf("IsLocal(%q) = %v; want %v", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package manifest
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/goproxy"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+// Finding is a problem with a version named by a go.mod directive.
+type Finding struct {
+	ModuleVersion
+	Directive string // require, exclude or replace
+	// Warning is set for findings that do not make the file invalid,
+	// such as pseudo-versions, +incompatible versions and versions that
+	// have been retracted.
+	Warning bool
+	Message string
+}
+
+// Lint checks every version named by f's require, exclude and replace
+// directives: each must be a canonical semantic version with a leading
+// "v" and no build metadata other than "+incompatible", valid for its
+// module path's major version suffix. Pseudo-versions and +incompatible
+// versions are reported as warnings. Findings are in file order.
+func Lint(f *GoModFile) []Finding {
+	var fs []Finding
+	check := func(directive string, mv ModuleVersion) {
+		if msg, warn, ok := lintVersion(mv); !ok {
+			fs = append(fs, Finding{ModuleVersion: mv, Directive: directive, Warning: warn, Message: msg})
+		}
+	}
+	for _, mv := range f.Require {
+		check("require", mv)
+	}
+	for _, mv := range f.Exclude {
+		check("exclude", mv)
+	}
+	for _, r := range f.Replace {
+		if r.Old.Version != "" {
+			check("replace", r.Old)
+		}
+		if !r.IsLocal() {
+			check("replace", r.New)
+		}
+	}
+	slices.SortStableFunc(fs, func(a, b Finding) int { return cmp.Compare(a.Line, b.Line) })
+	return fs
+}
+
+// lintVersion checks one version. ok is true if there is nothing to
+// report; otherwise msg describes the problem and warn tells whether it
+// is only a warning.
+func lintVersion(mv ModuleVersion) (msg string, warn, ok bool) {
+	v, err := semver.Parse(mv.Version)
+	switch {
+	case err != nil:
+		return "invalid version", false, false
+	case mv.Version[0] != 'v':
+		return "version must start with v", false, false
+	case v.Build != "" && !v.IsIncompatible():
+		return "build metadata is not allowed", false, false
+	case "v"+v.String() != mv.Version:
+		return fmt.Sprintf("version is not canonical, want v%s", v), false, false
+	}
+	if err := semver.CheckPathMajor(v, mv.Path); err != nil {
+		msg := strings.TrimPrefix(err.Error(), "semver: ")
+		return strings.TrimPrefix(msg, mv.Version+": "), false, false
+	}
+	switch p, err := v.Pseudo(); {
+	case err == nil:
+		return fmt.Sprintf("pseudo-version of commit %s from %s", p.Rev, p.Time.Format("2006-01-02")), true, false
+	case v.IsIncompatible():
+		return "+incompatible: module lacks a go.mod for its major version", true, false
+	}
+	return "", false, true
+}
+
+// CheckRetracted asks the proxy c whether any version required by f has
+// been retracted and returns a warning, including the rationale, for
+// each that has. Modules the proxy does not know, such as private ones,
+// are skipped.
+func CheckRetracted(ctx context.Context, c *goproxy.Client, f *GoModFile) ([]Finding, error) {
+	var fs []Finding
+	cache := map[string][]goproxy.Retraction{}
+	for _, mv := range f.Require {
+		v, err := semver.Parse(mv.Version)
+		if err != nil {
+			continue
+		}
+		rs, seen := cache[mv.Path]
+		if !seen {
+			rs, err = c.Retractions(ctx, mv.Path)
+			if err != nil && !errors.Is(err, goproxy.ErrNotFound) {
+				return nil, err
+			}
+			cache[mv.Path] = rs
+		}
+		for _, r := range rs {
+			if r.Contains(v) {
+				msg := "retracted"
+				if r.Rationale != "" {
+					msg += ": " + r.Rationale
+				}
+				fs = append(fs, Finding{ModuleVersion: mv, Directive: "require", Warning: true, Message: msg})
+				break
+			}
+		}
+	}
+	return fs, nil
+}
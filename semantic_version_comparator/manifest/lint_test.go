@@ -0,0 +1,111 @@
+package manifest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/goproxy"
+)
+
+func TestLint(t *testing.T) {
+	f, err := ParseGoMod([]byte(`module example.com/m
+
+require (
+	example.com/ok v1.2.3
+	example.com/invalid latest
+	example.com/nov 1.2.3
+	example.com/short v1.2
+	example.com/build v1.2.3+meta
+	example.com/major v2.0.0
+	example.com/major/v2 v2.1.0
+	example.com/wrong/v2 v3.0.0
+	example.com/pseudo v0.0.0-20240102030405-abcdef123456
+	example.com/old v2.0.0+incompatible
+)
+
+exclude example.com/ok v1.2
+replace example.com/ok v1.2.3 => ./local
+replace example.com/x => example.com/y v1.0
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		line      int
+		directive string
+		warning   bool
+		message   string
+	}{
+		{5, "require", false, "invalid version"},
+		{6, "require", false, "version must start with v"},
+		{7, "require", false, "invalid version"},
+		{8, "require", false, "build metadata is not allowed"},
+		{9, "require", false, ""},
+		{11, "require", false, ""},
+		{12, "require", true, "pseudo-version of commit abcdef123456 from 2024-01-02"},
+		{13, "require", true, "+incompatible"},
+		{16, "exclude", false, "invalid version"},
+		{18, "replace", false, "invalid version"},
+	}
+	fs := Lint(f)
+	if len(fs) != len(want) {
+		t.Fatalf("Lint = %+v; want %d findings", fs, len(want))
+	}
+	for i, got := range fs {
+		w := want[i]
+		if got.Line != w.line || got.Directive != w.directive || got.Warning != w.warning || !strings.HasPrefix(got.Message, w.message) {
+			t.Errorf("Lint[%d] = %+v; want line %d %s warning=%v %q", i, got, w.line, w.directive, w.warning, w.message)
+		}
+	}
+}
+
+func TestCheckRetracted(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/example.com/a/@v/list":
+			w.Write([]byte("v1.0.0\nv1.1.0\nv1.2.0\n"))
+		case "/example.com/a/@v/v1.2.0.mod":
+			w.Write([]byte("module example.com/a\n\n// Published with a broken API.\nretract [v1.0.0, v1.0.5]\nretract v1.1.0\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	f, err := ParseGoMod([]byte(`module example.com/m
+
+require (
+	example.com/a v1.0.1
+	example.com/a v1.1.0
+	example.com/a v1.2.0
+	example.com/private v1.0.0
+	example.com/bad latest
+)
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs, err := CheckRetracted(context.Background(), &goproxy.Client{Proxy: srv.URL}, f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"retracted: Published with a broken API.", "retracted"}
+	if len(fs) != len(want) {
+		t.Fatalf("CheckRetracted = %+v; want %d findings", fs, len(want))
+	}
+	for i, got := range fs {
+		if got.Message != want[i] || !got.Warning || got.Line != 4+i {
+			t.Errorf("CheckRetracted[%d] = %+v; want a warning %q on line %d", i, got, want[i], 4+i)
+		}
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	if _, err := CheckRetracted(context.Background(), &goproxy.Client{Proxy: failing.URL}, f); err == nil {
+		t.Error("CheckRetracted succeeded against a failing proxy")
+	}
+}
@@ -0,0 +1,107 @@
+// Package manifest reads the dependency versions pinned by go.mod,
+// package.json and requirements.txt files and classifies how they change
+// between two revisions of a manifest.
+package manifest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Format is a kind of dependency manifest.
+type Format int
+
+const (
+	GoMod        Format = iota // go.mod require directives
+	PackageJSON                // npm package.json dependency maps
+	Requirements               // pip requirements.txt
+)
+
+var formatNames = [...]string{"go", "npm", "pip"}
+
+func (f Format) String() string {
+	if f < 0 || int(f) >= len(formatNames) {
+		return "Format(" + strconv.Itoa(int(f)) + ")"
+	}
+	return formatNames[f]
+}
+
+// ParseFormat returns the format with the given name: "go", "npm" or
+// "pip".
+func ParseFormat(name string) (Format, error) {
+	for i, n := range formatNames {
+		if n == name {
+			return Format(i), nil
+		}
+	}
+	return 0, fmt.Errorf("manifest: unknown format %q", name)
+}
+
+// Detect returns the format of the manifest at path from its file name:
+// go.mod, package.json, or any .txt file whose name starts with
+// "requirements", such as requirements-dev.txt.
+func Detect(path string) (Format, error) {
+	base := filepath.Base(path)
+	switch {
+	case base == "go.mod":
+		return GoMod, nil
+	case base == "package.json":
+		return PackageJSON, nil
+	case strings.HasPrefix(base, "requirements") && strings.HasSuffix(base, ".txt"):
+		return Requirements, nil
+	}
+	return 0, fmt.Errorf("manifest: cannot tell the format of %s", path)
+}
+
+// Dependency is a package required by a manifest.
+type Dependency struct {
+	Name string `json:"name"`
+	// Version is the version or range as written in the manifest, such
+	// as "v1.4.2", "^1.4.2" or "==1.4.2".
+	Version string `json:"version"`
+}
+
+// Parse returns the dependencies of a manifest of format f, in the order
+// they appear. A dependency listed more than once keeps its first entry.
+func Parse(f Format, data []byte) ([]Dependency, error) {
+	switch f {
+	case GoMod:
+		return parseGoMod(data)
+	case PackageJSON:
+		return parsePackageJSON(data)
+	case Requirements:
+		return parseRequirements(data)
+	}
+	return nil, fmt.Errorf("manifest: unknown format %v", f)
+}
+
+// ParseFile reads and parses the manifest at path, detecting its format
+// from the file name.
+func ParseFile(path string) ([]Dependency, Format, error) {
+	f, err := Detect(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	deps, err := Parse(f, data)
+	return deps, f, err
+}
+
+// dedup drops repeated names, keeping the first entry of each.
+func dedup(deps []Dependency) []Dependency {
+	seen := make(map[string]bool, len(deps))
+	out := deps[:0]
+	for _, d := range deps {
+		if !seen[d.Name] {
+			seen[d.Name] = true
+			out = append(out, d)
+		}
+	}
+	return out
+}
@@ -0,0 +1,65 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	for _, f := range []Format{GoMod, PackageJSON, Requirements} {
+		if got, err := ParseFormat(f.String()); err != nil || got != f {
+			t.Errorf("ParseFormat(%q) = %v, %v; want %v", f, got, err, f)
+		}
+	}
+	if _, err := ParseFormat("cargo"); err == nil {
+		t.Error("ParseFormat(cargo) succeeded")
+	}
+	if s := Format(9).String(); s != "Format(9)" {
+		t.Errorf("Format(9).String() = %q", s)
+	}
+	if _, err := Parse(Format(9), nil); err == nil {
+		t.Error("Parse with an unknown format succeeded")
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		path string
+		want Format
+		ok   bool
+	}{
+		{"go.mod", GoMod, true},
+		{"a/b/package.json", PackageJSON, true},
+		{"requirements.txt", Requirements, true},
+		{"ci/requirements-dev.txt", Requirements, true},
+		{"go.sum", 0, false},
+		{"requirements.in", 0, false},
+		{"package-lock.json", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := Detect(tt.path)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("Detect(%q) = %v, %v; want %v", tt.path, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "requirements.txt")
+	if err := os.WriteFile(name, []byte("requests==2.31.0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	deps, f, err := ParseFile(name)
+	if err != nil || f != Requirements || !slices.Equal(deps, []Dependency{{"requests", "==2.31.0"}}) {
+		t.Errorf("ParseFile = %v, %v, %v", deps, f, err)
+	}
+	if _, _, err := ParseFile(filepath.Join(dir, "go.mod")); err == nil {
+		t.Error("ParseFile of a missing file succeeded")
+	}
+	if _, _, err := ParseFile(filepath.Join(dir, "Cargo.toml")); err == nil {
+		t.Error("ParseFile of an unknown format succeeded")
+	}
+}
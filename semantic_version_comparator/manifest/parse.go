@@ -0,0 +1,109 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// packageJSONSections are the dependency maps of package.json, in the
+// order their entries are reported.
+var packageJSONSections = []string{
+	"dependencies", "devDependencies", "optionalDependencies", "peerDependencies",
+}
+
+// parsePackageJSON returns the entries of the dependency maps of a
+// package.json file, each map sorted by name as npm writes them.
+func parsePackageJSON(data []byte) ([]Dependency, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("manifest: package.json: %v", err)
+	}
+	var deps []Dependency
+	for _, section := range packageJSONSections {
+		raw, ok := doc[section]
+		if !ok {
+			continue
+		}
+		var m map[string]string
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return nil, fmt.Errorf("manifest: package.json: %s: %v", section, err)
+		}
+		names := make([]string, 0, len(m))
+		for name := range m {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			deps = append(deps, Dependency{Name: name, Version: m[name]})
+		}
+	}
+	return dedup(deps), nil
+}
+
+// parseRequirements returns the requirements of a requirements.txt file.
+// Options such as "-r other.txt" or "-e ." and requirements given as URLs
+// are skipped; environment markers and extras are dropped, and names are
+// normalized as in PEP 503, so "Foo_Bar[extra]" is "foo-bar".
+func parseRequirements(data []byte) ([]Dependency, error) {
+	var deps []Dependency
+	text := strings.ReplaceAll(string(data), "\\\n", "")
+	for n, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(stripHashComment(line))
+		if line == "" || strings.HasPrefix(line, "-") || strings.Contains(line, "://") {
+			continue
+		}
+		line, _, _ = strings.Cut(line, ";")
+		i := strings.IndexAny(line, "[<>=!~ \t")
+		if i < 0 {
+			i = len(line)
+		}
+		name, spec := line[:i], strings.TrimLeft(line[i:], " \t")
+		if name == "" {
+			return nil, fmt.Errorf("manifest: requirements.txt:%d: missing package name", n+1)
+		}
+		if strings.HasPrefix(spec, "[") {
+			end := strings.IndexByte(spec, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("manifest: requirements.txt:%d: unterminated extras", n+1)
+			}
+			spec = spec[end+1:]
+		}
+		deps = append(deps, Dependency{
+			Name:    normalizeName(name),
+			Version: strings.Join(strings.Fields(spec), ""),
+		})
+	}
+	return dedup(deps), nil
+}
+
+// stripHashComment removes a "#" comment, which must start the line or
+// follow whitespace so that URL fragments survive.
+func stripHashComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		if line[i] == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t') {
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// normalizeName lower-cases a Python package name and replaces runs of
+// "-", "_" and "." with a single "-".
+func normalizeName(name string) string {
+	var b strings.Builder
+	sep := false
+	for _, r := range strings.ToLower(name) {
+		if r == '-' || r == '_' || r == '.' {
+			sep = true
+			continue
+		}
+		if sep && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		sep = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,87 @@
+package manifest
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		f    Format
+		data string
+		want []Dependency
+	}{
+		{GoMod, `module example.com/m
+
+go 1.21
+
+require example.com/a v1.0.0
+
+require (
+	example.com/b v1.2.0 // indirect
+	"example.com/c" v0.3.0
+	example.com/a v1.1.0
+)
+`, []Dependency{{"example.com/a", "v1.0.0"}, {"example.com/b", "v1.2.0"}, {"example.com/c", "v0.3.0"}}},
+		{PackageJSON, `{
+	"name": "app",
+	"version": "1.0.0",
+	"devDependencies": {"vitest": "^1.0.0", "eslint": "~8.56.0"},
+	"dependencies": {"react": "^18.2.0", "lodash": "4.17.21"},
+	"peerDependencies": {"react": "^17 || ^18"}
+}`, []Dependency{
+			{"lodash", "4.17.21"}, {"react", "^18.2.0"}, {"eslint", "~8.56.0"}, {"vitest", "^1.0.0"},
+		}},
+		{Requirements, `# Runtime
+-r base.txt
+-e .
+Django>=4.2,<5 ; python_version >= "3.10"
+requests [socks] == 2.31.0  # pinned
+Foo_Bar.baz~=1.4
+numpy
+git+https://example.com/repo.git#egg=repo
+long-name \
+    >=1.0
+django==3.0
+`, []Dependency{
+			{"django", ">=4.2,<5"}, {"requests", "==2.31.0"}, {"foo-bar-baz", "~=1.4"}, {"numpy", ""}, {"long-name", ">=1.0"},
+		}},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.f, []byte(tt.data))
+		if err != nil || !slices.Equal(got, tt.want) {
+			t.Errorf("Parse(%v) = %v, %v; want %v", tt.f, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		f    Format
+		data string
+	}{
+		{PackageJSON, `{`},
+		{PackageJSON, `{"dependencies": ["react"]}`},
+		{Requirements, "==1.0\n"},
+		{Requirements, "requests[socks==2.31.0\n"},
+	}
+	for _, tt := range tests {
+		if deps, err := Parse(tt.f, []byte(tt.data)); err == nil {
+			t.Errorf("Parse(%v, %q) = %v; want an error", tt.f, tt.data, deps)
+		}
+	}
+}
+
+func TestNormalizeName(t *testing.T) {
+	for in, want := range map[string]string{
+		"Django":         "django",
+		"Foo_Bar":        "foo-bar",
+		"foo.-_bar":      "foo-bar",
+		"zope.interface": "zope-interface",
+		"_private":       "private",
+	} {
+		if got := normalizeName(in); got != want {
+			t.Errorf("normalizeName(%q) = %q; want %q", in, got, want)
+		}
+	}
+}
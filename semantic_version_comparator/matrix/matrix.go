@@ -0,0 +1,275 @@
+// Package matrix generates compatibility matrices: given the versions of
+// several components and rules on which versions work together, it lists
+// every combination and whether it is supported, as CSV, JSON or a
+// Markdown table.
+package matrix
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+// Component is a deliverable with the versions to cover, such as
+// "client" at 1.4.0, 1.5.0 and 1.6.0.
+type Component struct {
+	Name     string
+	Versions []semver.Version
+}
+
+// Rule states that whenever component If is at a version satisfying
+// When, component Then must be at a version satisfying Requires. For
+// example "client ^1.5 requires server >=1.4.0" is
+//
+//	Rule{
+//		If: "client", When: semver.MustParseConstraint("^1.5"),
+//		Then: "server", Requires: semver.MustParseConstraint(">=1.4.0"),
+//	}
+type Rule struct {
+	If       string
+	When     semver.Constraint
+	Then     string
+	Requires semver.Constraint
+	// Note, if set, explains the rule in reports.
+	Note string
+}
+
+func (r Rule) String() string {
+	s := r.If + " " + r.When.String() + " requires " + r.Then + " " + r.Requires.String()
+	if r.Note != "" {
+		s += " (" + r.Note + ")"
+	}
+	return s
+}
+
+// MaxCombinations bounds the number of rows Generate produces.
+const MaxCombinations = 100000
+
+// Matrix is a generated compatibility matrix.
+type Matrix struct {
+	Components []Component
+	Rules      []Rule
+	// Rows holds every combination of component versions, ordered with
+	// the last component varying fastest.
+	Rows []Row
+}
+
+// Row is one combination of component versions.
+type Row struct {
+	// Versions holds the version of each component, in the order of
+	// Matrix.Components.
+	Versions []semver.Version
+	// Broken holds the indexes into Matrix.Rules of the rules the
+	// combination violates. The combination is supported if it is
+	// empty.
+	Broken []int
+}
+
+// Supported reports whether the combination violates no rule.
+func (r Row) Supported() bool {
+	return len(r.Broken) == 0
+}
+
+// Generate returns the matrix of every combination of the components'
+// versions, checked against rules. It fails if a rule names an unknown
+// component or the matrix would exceed MaxCombinations rows.
+func Generate(components []Component, rules []Rule) (*Matrix, error) {
+	index := make(map[string]int, len(components))
+	total := 1
+	for i, c := range components {
+		if _, dup := index[c.Name]; dup {
+			return nil, fmt.Errorf("matrix: component %q listed twice", c.Name)
+		}
+		index[c.Name] = i
+		if total *= len(c.Versions); total > MaxCombinations {
+			return nil, fmt.Errorf("matrix: more than %d combinations", MaxCombinations)
+		}
+	}
+	type edge struct{ rule, a, b int }
+	edges := make([]edge, len(rules))
+	for i, r := range rules {
+		a, okA := index[r.If]
+		b, okB := index[r.Then]
+		switch {
+		case !okA:
+			return nil, fmt.Errorf("matrix: rule %q names unknown component %q", r, r.If)
+		case !okB:
+			return nil, fmt.Errorf("matrix: rule %q names unknown component %q", r, r.Then)
+		}
+		edges[i] = edge{i, a, b}
+	}
+	m := &Matrix{Components: components, Rules: rules}
+	if len(components) == 0 {
+		return m, nil
+	}
+	pos := make([]int, len(components))
+	for n := 0; n < total; n++ {
+		row := Row{Versions: make([]semver.Version, len(components))}
+		for i, c := range components {
+			row.Versions[i] = c.Versions[pos[i]]
+		}
+		for _, e := range edges {
+			r := rules[e.rule]
+			if r.When.Check(row.Versions[e.a]) && !r.Requires.Check(row.Versions[e.b]) {
+				row.Broken = append(row.Broken, e.rule)
+			}
+		}
+		m.Rows = append(m.Rows, row)
+		for i := len(pos) - 1; i >= 0; i-- {
+			if pos[i]++; pos[i] < len(components[i].Versions) {
+				break
+			}
+			pos[i] = 0
+		}
+	}
+	return m, nil
+}
+
+// Supported returns the supported rows of m.
+func (m *Matrix) Supported() []Row {
+	var out []Row
+	for _, r := range m.Rows {
+		if r.Supported() {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// reason returns the rules row breaks, joined by "; ".
+func (m *Matrix) reason(row Row) string {
+	parts := make([]string, len(row.Broken))
+	for i, b := range row.Broken {
+		parts[i] = m.Rules[b].String()
+	}
+	return strings.Join(parts, "; ")
+}
+
+// WriteCSV writes m as CSV: a header of the component names followed by
+// "supported" and "broken", then one record per row.
+func (m *Matrix) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header := make([]string, 0, len(m.Components)+2)
+	for _, c := range m.Components {
+		header = append(header, c.Name)
+	}
+	cw.Write(append(header, "supported", "broken"))
+	for _, row := range m.Rows {
+		rec := make([]string, 0, len(row.Versions)+2)
+		for _, v := range row.Versions {
+			rec = append(rec, v.String())
+		}
+		cw.Write(append(rec, strconv.FormatBool(row.Supported()), m.reason(row)))
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes m as a JSON array with one object per row, such as
+// {"versions": {"client": "1.5.0", "server": "1.3.0"}, "supported": false,
+// "broken": ["client ^1.5 requires server >=1.4.0"]}.
+func (m *Matrix) WriteJSON(w io.Writer) error {
+	type jsonRow struct {
+		Versions  map[string]string `json:"versions"`
+		Supported bool              `json:"supported"`
+		Broken    []string          `json:"broken"`
+	}
+	rows := make([]jsonRow, len(m.Rows))
+	for i, row := range m.Rows {
+		jr := jsonRow{Versions: make(map[string]string, len(row.Versions)), Supported: row.Supported(), Broken: []string{}}
+		for j, v := range row.Versions {
+			jr.Versions[m.Components[j].Name] = v.String()
+		}
+		for _, b := range row.Broken {
+			jr.Broken = append(jr.Broken, m.Rules[b].String())
+		}
+		rows[i] = jr
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rows)
+}
+
+// WriteMarkdown writes m as a Markdown table with a column per component
+// and a "Supported" column holding ✅, or ❌ and the broken rules.
+func (m *Matrix) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("|")
+	for _, c := range m.Components {
+		b.WriteString(" " + markdownCell(c.Name) + " |")
+	}
+	b.WriteString(" Supported |\n|")
+	for range m.Components {
+		b.WriteString(" --- |")
+	}
+	b.WriteString(" --- |\n")
+	for _, row := range m.Rows {
+		b.WriteString("|")
+		for _, v := range row.Versions {
+			b.WriteString(" " + markdownCell(v.String()) + " |")
+		}
+		if row.Supported() {
+			b.WriteString(" ✅ |\n")
+		} else {
+			b.WriteString(" ❌ " + markdownCell(m.reason(row)) + " |\n")
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WritePairMarkdown writes the support grid of two components as a
+// Markdown table, with a's versions as rows and b's as columns. A cell
+// is ✅ if some supported row pairs the two versions and ❌ otherwise.
+func (m *Matrix) WritePairMarkdown(w io.Writer, a, b string) error {
+	ia, ib := -1, -1
+	for i, c := range m.Components {
+		switch c.Name {
+		case a:
+			ia = i
+		case b:
+			ib = i
+		}
+	}
+	if ia < 0 || ib < 0 || ia == ib {
+		return fmt.Errorf("matrix: want two distinct components of the matrix, not %q and %q", a, b)
+	}
+	ok := make(map[[2]string]bool)
+	for _, row := range m.Supported() {
+		ok[[2]string{row.Versions[ia].String(), row.Versions[ib].String()}] = true
+	}
+	var sb strings.Builder
+	sb.WriteString("| " + markdownCell(a) + " \\ " + markdownCell(b) + " |")
+	for _, v := range m.Components[ib].Versions {
+		sb.WriteString(" " + markdownCell(v.String()) + " |")
+	}
+	sb.WriteString("\n| --- |")
+	for range m.Components[ib].Versions {
+		sb.WriteString(" :-: |")
+	}
+	sb.WriteString("\n")
+	for _, va := range m.Components[ia].Versions {
+		sb.WriteString("| " + markdownCell(va.String()) + " |")
+		for _, vb := range m.Components[ib].Versions {
+			if ok[[2]string{va.String(), vb.String()}] {
+				sb.WriteString(" ✅ |")
+			} else {
+				sb.WriteString(" ❌ |")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// markdownCell escapes the characters that would end a table cell.
+func markdownCell(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), "|", `\|`)
+}
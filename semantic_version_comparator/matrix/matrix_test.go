@@ -0,0 +1,151 @@
+package matrix
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+func versions(ss ...string) []semver.Version {
+	vs := make([]semver.Version, len(ss))
+	for i, s := range ss {
+		vs[i] = semver.MustParse(s)
+	}
+	return vs
+}
+
+func example(t *testing.T) *Matrix {
+	t.Helper()
+	m, err := Generate([]Component{
+		{Name: "client", Versions: versions("1.4.0", "1.5.0")},
+		{Name: "server", Versions: versions("1.3.0", "1.4.0")},
+	}, []Rule{{
+		If: "client", When: semver.MustParseConstraint("^1.5"),
+		Then: "server", Requires: semver.MustParseConstraint(">=1.4.0"),
+		Note: "a|b",
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestGenerate(t *testing.T) {
+	m := example(t)
+	want := []string{"1.4.0 1.3.0 ok", "1.4.0 1.4.0 ok", "1.5.0 1.3.0 broken", "1.5.0 1.4.0 ok"}
+	if len(m.Rows) != len(want) {
+		t.Fatalf("Rows = %+v; want %d", m.Rows, len(want))
+	}
+	for i, row := range m.Rows {
+		got := row.Versions[0].String() + " " + row.Versions[1].String() + " ok"
+		if !row.Supported() {
+			got = strings.TrimSuffix(got, "ok") + "broken"
+		}
+		if got != want[i] {
+			t.Errorf("Rows[%d] = %q; want %q", i, got, want[i])
+		}
+	}
+	if n := len(m.Supported()); n != 3 {
+		t.Errorf("Supported has %d rows; want 3", n)
+	}
+	if m, err := Generate(nil, nil); err != nil || len(m.Rows) != 0 {
+		t.Errorf("Generate(nil) = %+v, %v; want no rows", m, err)
+	}
+}
+
+func TestGenerateError(t *testing.T) {
+	a := Component{Name: "a", Versions: versions("1.0.0")}
+	all := semver.MustParseConstraint("*")
+	big := make([]semver.Version, 1000)
+	for i := range big {
+		big[i] = semver.Version{Major: uint64(i)}
+	}
+	tests := []struct {
+		name  string
+		cs    []Component
+		rules []Rule
+	}{
+		{"duplicate", []Component{a, a}, nil},
+		{"unknown if", []Component{a}, []Rule{{If: "x", When: all, Then: "a", Requires: all}}},
+		{"unknown then", []Component{a}, []Rule{{If: "a", When: all, Then: "x", Requires: all}}},
+		{"too many", []Component{{Name: "a", Versions: big}, {Name: "b", Versions: big}}, nil},
+	}
+	for _, tt := range tests {
+		if _, err := Generate(tt.cs, tt.rules); err == nil || !strings.HasPrefix(err.Error(), "matrix: ") {
+			t.Errorf("%s: Generate error = %v", tt.name, err)
+		}
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var b strings.Builder
+	if err := example(t).WriteCSV(&b); err != nil {
+		t.Fatal(err)
+	}
+	want := `client,server,supported,broken
+1.4.0,1.3.0,true,
+1.4.0,1.4.0,true,
+1.5.0,1.3.0,false,client ^1.5 requires server >=1.4.0 (a|b)
+1.5.0,1.4.0,true,
+`
+	if b.String() != want {
+		t.Errorf("WriteCSV =\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var b strings.Builder
+	if err := example(t).WriteJSON(&b); err != nil {
+		t.Fatal(err)
+	}
+	var rows []struct {
+		Versions  map[string]string `json:"versions"`
+		Supported bool              `json:"supported"`
+		Broken    []string          `json:"broken"`
+	}
+	if err := json.Unmarshal([]byte(b.String()), &rows); err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 4 || rows[2].Supported || rows[2].Versions["client"] != "1.5.0" ||
+		len(rows[2].Broken) != 1 || rows[0].Broken == nil || !strings.Contains(b.String(), ">=1.4.0") {
+		t.Errorf("WriteJSON =\n%s", b.String())
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var b strings.Builder
+	m := example(t)
+	if err := m.WriteMarkdown(&b); err != nil {
+		t.Fatal(err)
+	}
+	want := `| client | server | Supported |
+| --- | --- | --- |
+| 1.4.0 | 1.3.0 | ✅ |
+| 1.4.0 | 1.4.0 | ✅ |
+| 1.5.0 | 1.3.0 | ❌ client ^1.5 requires server >=1.4.0 (a\|b) |
+| 1.5.0 | 1.4.0 | ✅ |
+`
+	if b.String() != want {
+		t.Errorf("WriteMarkdown =\n%s\nwant\n%s", b.String(), want)
+	}
+
+	b.Reset()
+	if err := m.WritePairMarkdown(&b, "client", "server"); err != nil {
+		t.Fatal(err)
+	}
+	want = `| client \ server | 1.3.0 | 1.4.0 |
+| --- | :-: | :-: |
+| 1.4.0 | ✅ | ✅ |
+| 1.5.0 | ❌ | ✅ |
+`
+	if b.String() != want {
+		t.Errorf("WritePairMarkdown =\n%s\nwant\n%s", b.String(), want)
+	}
+	for _, pair := range [][2]string{{"client", "client"}, {"client", "db"}} {
+		if err := m.WritePairMarkdown(&b, pair[0], pair[1]); err == nil {
+			t.Errorf("WritePairMarkdown(%s, %s) succeeded", pair[0], pair[1])
+		}
+	}
+}
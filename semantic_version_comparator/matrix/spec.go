@@ -0,0 +1,68 @@
+package matrix
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+// ParseSpec reads the components and rules of a matrix from JSON such as
+//
+//	{
+//	  "components": [
+//	    {"name": "client", "versions": ["1.4.0", "1.5.0"]},
+//	    {"name": "server", "versions": ["1.3.0", "1.4.0"]}
+//	  ],
+//	  "rules": [
+//	    {"if": "client", "when": "^1.5", "then": "server", "requires": ">=1.4.0", "note": "new handshake"}
+//	  ]
+//	}
+//
+// Versions are parsed with semver.ParseTolerant and constraints in the
+// npm dialect. An omitted "when" applies the rule to every version.
+func ParseSpec(data []byte) ([]Component, []Rule, error) {
+	var spec struct {
+		Components []struct {
+			Name     string   `json:"name"`
+			Versions []string `json:"versions"`
+		} `json:"components"`
+		Rules []struct {
+			If       string `json:"if"`
+			When     string `json:"when"`
+			Then     string `json:"then"`
+			Requires string `json:"requires"`
+			Note     string `json:"note"`
+		} `json:"rules"`
+	}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, nil, fmt.Errorf("matrix: %v", err)
+	}
+	components := make([]Component, len(spec.Components))
+	for i, c := range spec.Components {
+		components[i].Name = c.Name
+		for _, s := range c.Versions {
+			v, err := semver.ParseTolerant(s)
+			if err != nil {
+				return nil, nil, fmt.Errorf("matrix: component %s: %v", c.Name, err)
+			}
+			components[i].Versions = append(components[i].Versions, v)
+		}
+	}
+	rules := make([]Rule, len(spec.Rules))
+	for i, r := range spec.Rules {
+		if r.When == "" {
+			r.When = "*"
+		}
+		when, err := semver.ParseConstraint(r.When)
+		if err != nil {
+			return nil, nil, fmt.Errorf("matrix: rule %d: %v", i+1, err)
+		}
+		requires, err := semver.ParseConstraint(r.Requires)
+		if err != nil {
+			return nil, nil, fmt.Errorf("matrix: rule %d: %v", i+1, err)
+		}
+		rules[i] = Rule{If: r.If, When: when, Then: r.Then, Requires: requires, Note: r.Note}
+	}
+	return components, rules, nil
+}
@@ -0,0 +1,51 @@
+package matrix
+
+import (
+	"testing"
+)
+
+const spec = `{
+	"components": [
+		{"name": "client", "versions": ["1.4.0", "v1.5", "1.6.0"]},
+		{"name": "server", "versions": ["1.3.0", "1.4.0"]}
+	],
+	"rules": [
+		{"if": "client", "when": "^1.5", "then": "server", "requires": ">=1.4.0", "note": "new handshake"},
+		{"if": "server", "then": "client", "requires": ">=1.4.0"}
+	]
+}`
+
+func TestParseSpec(t *testing.T) {
+	cs, rules, err := ParseSpec([]byte(spec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cs) != 2 || cs[0].Name != "client" || len(cs[0].Versions) != 3 || cs[0].Versions[1].String() != "1.5.0" {
+		t.Errorf("components = %+v", cs)
+	}
+	want := []string{
+		"client ^1.5 requires server >=1.4.0 (new handshake)",
+		"server * requires client >=1.4.0",
+	}
+	if len(rules) != len(want) {
+		t.Fatalf("rules = %v; want %d", rules, len(want))
+	}
+	for i, r := range rules {
+		if r.String() != want[i] {
+			t.Errorf("rules[%d] = %q; want %q", i, r, want[i])
+		}
+	}
+}
+
+func TestParseSpecError(t *testing.T) {
+	for _, data := range []string{
+		`{`,
+		`{"components": [{"name": "a", "versions": ["one"]}]}`,
+		`{"rules": [{"if": "a", "when": ">=", "then": "b", "requires": "*"}]}`,
+		`{"rules": [{"if": "a", "then": "b", "requires": ">=abc"}]}`,
+	} {
+		if _, _, err := ParseSpec([]byte(data)); err == nil {
+			t.Errorf("ParseSpec(%q) succeeded", data)
+		}
+	}
+}
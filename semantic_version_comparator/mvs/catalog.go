@@ -0,0 +1,48 @@
+package mvs
+
+import (
+	"fmt"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+// Catalog is an in-memory Registry, for graphs assembled from lock files
+// or manifests already at hand. The zero value is an empty catalog ready
+// to use.
+type Catalog struct {
+	versions map[string][]semver.Version
+	reqs     map[string][]Requirement
+}
+
+// Add records that m is available and has the given requirements,
+// replacing any requirements added for it before.
+func (c *Catalog) Add(m Module, reqs ...Requirement) {
+	if c.versions == nil {
+		c.versions = make(map[string][]semver.Version)
+		c.reqs = make(map[string][]Requirement)
+	}
+	key := m.String()
+	if _, ok := c.reqs[key]; !ok {
+		c.versions[m.Path] = append(c.versions[m.Path], m.Version)
+	}
+	c.reqs[key] = reqs
+}
+
+// Versions returns the versions of path added to c, in the order they
+// were added.
+func (c *Catalog) Versions(path string) ([]semver.Version, error) {
+	vs, ok := c.versions[path]
+	if !ok {
+		return nil, fmt.Errorf("mvs: unknown module %s", path)
+	}
+	return vs, nil
+}
+
+// Requirements returns the requirements added for m.
+func (c *Catalog) Requirements(m Module) ([]Requirement, error) {
+	rs, ok := c.reqs[m.String()]
+	if !ok {
+		return nil, fmt.Errorf("mvs: unknown module %s", m)
+	}
+	return rs, nil
+}
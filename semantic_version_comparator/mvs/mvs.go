@@ -0,0 +1,414 @@
+// Package mvs selects a consistent set of module versions from a
+// dependency graph whose edges are version constraints, using Go-style
+// minimal version selection or, optionally, the highest satisfying
+// versions.
+package mvs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+// Module is a version of a module.
+type Module struct {
+	Path    string
+	Version semver.Version
+}
+
+// String returns the module as PATH@VERSION, or "the main module" for the
+// zero Module, which stands for the root of the graph.
+func (m Module) String() string {
+	if m.Path == "" {
+		return "the main module"
+	}
+	return m.Path + "@" + m.Version.String()
+}
+
+// Requirement is a dependency on some version of the module Path that
+// satisfies Constraint.
+type Requirement struct {
+	Path       string
+	Constraint semver.Constraint
+}
+
+// Registry is the dependency graph being solved: the versions of each
+// module and the requirements of each module version.
+type Registry interface {
+	// Versions returns the available versions of path, in any order.
+	Versions(path string) ([]semver.Version, error)
+	// Requirements returns the requirements of m.
+	Requirements(m Module) ([]Requirement, error)
+}
+
+// Mode is the strategy Solve uses to pick versions.
+type Mode int
+
+const (
+	// Minimal is Go's minimal version selection: each requirement asks
+	// for the lowest version satisfying its constraint, and the highest
+	// of those asked for is selected. The result is reproducible and
+	// changes only when requirements do.
+	Minimal Mode = iota
+	// Highest selects for each module the highest version satisfying
+	// every requirement on it, as npm and Cargo do. Solve does not
+	// backtrack: if the requirements of the chosen versions exclude each
+	// other it reports a conflict rather than trying older versions.
+	Highest
+)
+
+var modeNames = [...]string{"minimal", "highest"}
+
+func (m Mode) String() string {
+	if m < 0 || int(m) >= len(modeNames) {
+		return "Mode(" + strconv.Itoa(int(m)) + ")"
+	}
+	return modeNames[m]
+}
+
+// ParseMode returns the mode with the given name: "minimal" or
+// "highest".
+func ParseMode(name string) (Mode, error) {
+	for i, n := range modeNames {
+		if n == name {
+			return Mode(i), nil
+		}
+	}
+	return 0, fmt.Errorf("mvs: unknown mode %q", name)
+}
+
+// Conflict is a requirement that the selection does not satisfy.
+type Conflict struct {
+	Requirement Requirement
+	// RequiredBy is the module version that states the requirement; the
+	// zero Module for a requirement of the main module.
+	RequiredBy Module
+	// Selected reports whether a version of the module was selected at
+	// all. If not, no available version satisfies the requirement.
+	Selected bool
+	// Detail explains why the selected version fails the constraint; it
+	// is set only if Selected.
+	Detail semver.Detail
+}
+
+func (c Conflict) String() string {
+	r := c.Requirement
+	if !c.Selected {
+		return fmt.Sprintf("%s requires %s %s, but no version satisfies it", c.RequiredBy, r.Path, r.Constraint)
+	}
+	return fmt.Sprintf("%s requires %s %s, but %s@%s is selected: %s", c.RequiredBy, r.Path, r.Constraint, r.Path, c.Detail.Version, c.Detail)
+}
+
+// ConflictError is returned by Solve when no consistent selection was
+// found. Selection holds the inconsistent build list it arrived at.
+type ConflictError struct {
+	Conflicts []Conflict
+	Selection []Module
+}
+
+func (e *ConflictError) Error() string {
+	parts := make([]string, len(e.Conflicts))
+	for i, c := range e.Conflicts {
+		parts[i] = c.String()
+	}
+	return "mvs: " + strings.Join(parts, "; ")
+}
+
+// maxRounds bounds the refinement passes of the Highest mode, whose
+// choices can otherwise keep changing each other's requirements.
+const maxRounds = 100
+
+// Solve selects one version of every module reachable from roots, the
+// requirements of the main module, and returns the build list sorted by
+// path. Unknown modules and registry failures are returned as errors; if
+// the selection violates a requirement Solve returns a *ConflictError.
+func Solve(reg Registry, roots []Requirement, mode Mode, opts ...Option) ([]Module, error) {
+	return SolveContext(context.Background(), reg, roots, mode, opts...)
+}
+
+// SolveContext is like Solve, with ctx as the parent of the spans started
+// by WithTracer and passed to the logger of WithLogger.
+func SolveContext(ctx context.Context, reg Registry, roots []Requirement, mode Mode, opts ...Option) (list []Module, err error) {
+	s := &solver{reg: reg, ctx: ctx, versions: make(map[string][]semver.Version), reqs: make(map[string][]Requirement)}
+	for _, o := range opts {
+		o(s)
+	}
+	end := s.span("mvs.Solve", slog.String("mode", mode.String()), slog.Int("roots", len(roots)))
+	defer func() { end(err) }()
+	var sel map[string]semver.Version
+	endSelect := s.span("mvs.select")
+	switch mode {
+	case Minimal:
+		sel, err = s.minimal(roots)
+	case Highest:
+		sel, err = s.highest(roots)
+	default:
+		err = fmt.Errorf("mvs: unknown mode %v", mode)
+	}
+	endSelect(err)
+	if err != nil {
+		return nil, err
+	}
+	endCheck := s.span("mvs.check", slog.Int("modules", len(sel)))
+	list, conflicts, err := s.check(roots, sel)
+	endCheck(err)
+	if err != nil {
+		return nil, err
+	}
+	if len(conflicts) > 0 {
+		for _, c := range conflicts {
+			s.logAttrs(slog.LevelWarn, "mvs: conflict", slog.String("conflict", c.String()))
+		}
+		return nil, &ConflictError{Conflicts: conflicts, Selection: list}
+	}
+	s.logAttrs(slog.LevelInfo, "mvs: solved", slog.String("mode", mode.String()), slog.Int("modules", len(list)),
+		slog.Int("queried", len(s.versions)+len(s.reqs)))
+	return list, nil
+}
+
+// WithExclude makes Solve treat the module versions ms as unavailable, as
+// exclude directives do in go.mod: a requirement that would pick one
+// moves on to the next satisfying version.
+func WithExclude(ms ...Module) Option {
+	return func(s *solver) {
+		if s.exclude == nil {
+			s.exclude = make(map[string]bool)
+		}
+		for _, m := range ms {
+			s.exclude[m.String()] = true
+		}
+	}
+}
+
+// WithReplace makes Solve read the requirements of old from new instead,
+// as replace directives do in go.mod; the build list still names old.
+// An old with the zero Version replaces every version of old.Path.
+func WithReplace(old, new Module) Option {
+	return func(s *solver) {
+		if s.replace == nil {
+			s.replace = make(map[string]Module)
+		}
+		s.replace[replaceKey(old)] = new
+	}
+}
+
+// replaceKey is the key of m in solver.replace: PATH@VERSION, or PATH
+// for the zero Version.
+func replaceKey(m Module) string {
+	if m.Version == (semver.Version{}) {
+		return m.Path
+	}
+	return m.String()
+}
+
+// solver caches registry answers for one Solve call.
+type solver struct {
+	reg      Registry
+	versions map[string][]semver.Version
+	reqs     map[string][]Requirement
+	exclude  map[string]bool
+	replace  map[string]Module
+
+	ctx    context.Context
+	log    *slog.Logger
+	tracer Tracer
+}
+
+func (s *solver) available(path string) (vs []semver.Version, err error) {
+	if vs, ok := s.versions[path]; ok {
+		return vs, nil
+	}
+	end := s.span("mvs.Versions", slog.String("path", path))
+	vs, err = s.reg.Versions(path)
+	end(err)
+	s.debug("mvs: listed versions", slog.String("path", path), slog.Int("count", len(vs)))
+	if err != nil {
+		return nil, err
+	}
+	vs = slices.DeleteFunc(slices.Clone(vs), func(v semver.Version) bool {
+		return s.exclude[Module{path, v}.String()]
+	})
+	semver.Sort(vs)
+	s.versions[path] = vs
+	return vs, nil
+}
+
+func (s *solver) requirements(m Module) ([]Requirement, error) {
+	key := m.String()
+	if rs, ok := s.reqs[key]; ok {
+		return rs, nil
+	}
+	from := m
+	if r, ok := s.replace[key]; ok {
+		from = r
+	} else if r, ok := s.replace[m.Path]; ok {
+		from = r
+	}
+	end := s.span("mvs.Requirements", slog.String("module", key))
+	rs, err := s.reg.Requirements(from)
+	end(err)
+	if err != nil {
+		return nil, err
+	}
+	s.debug("mvs: read requirements", slog.String("module", key), slog.Int("count", len(rs)))
+	s.reqs[key] = rs
+	return rs, nil
+}
+
+// minimal visits the lowest satisfying version of every requirement
+// reachable from roots and selects the highest visited version of each
+// module. Requirements no version satisfies are left to check.
+func (s *solver) minimal(roots []Requirement) (map[string]semver.Version, error) {
+	sel := make(map[string]semver.Version)
+	seen := make(map[string]bool)
+	queue := slices.Clone(roots)
+	for len(queue) > 0 {
+		r := queue[0]
+		queue = queue[1:]
+		vs, err := s.available(r.Path)
+		if err != nil {
+			return nil, err
+		}
+		v, ok := semver.MinSatisfying(vs, r.Constraint)
+		if !ok {
+			continue
+		}
+		if cur, ok := sel[r.Path]; !ok || v.Compare(cur) > 0 {
+			s.debug("mvs: selected", slog.String("path", r.Path), slog.String("version", v.String()), slog.String("constraint", r.Constraint.String()))
+			sel[r.Path] = v
+		}
+		m := Module{r.Path, v}
+		if seen[m.String()] {
+			continue
+		}
+		seen[m.String()] = true
+		rs, err := s.requirements(m)
+		if err != nil {
+			return nil, err
+		}
+		queue = append(queue, rs...)
+	}
+	return sel, nil
+}
+
+// highest repeatedly walks the graph from roots through the current
+// selection and reselects each module as the highest version satisfying
+// every requirement met on the walk, until the selection is stable.
+func (s *solver) highest(roots []Requirement) (map[string]semver.Version, error) {
+	sel := make(map[string]semver.Version)
+	for round := 0; round < maxRounds; round++ {
+		cons := make(map[string][]semver.Constraint)
+		var order []string
+		next := make(map[string]semver.Version)
+		queue := slices.Clone(roots)
+		for len(queue) > 0 {
+			r := queue[0]
+			queue = queue[1:]
+			if _, ok := cons[r.Path]; !ok {
+				order = append(order, r.Path)
+			}
+			cons[r.Path] = append(cons[r.Path], r.Constraint)
+			if _, ok := next[r.Path]; ok {
+				continue
+			}
+			v, ok := sel[r.Path]
+			if !ok {
+				vs, err := s.available(r.Path)
+				if err != nil {
+					return nil, err
+				}
+				if v, ok = semver.MaxSatisfying(vs, r.Constraint); !ok {
+					continue
+				}
+			}
+			next[r.Path] = v
+			rs, err := s.requirements(Module{r.Path, v})
+			if err != nil {
+				return nil, err
+			}
+			queue = append(queue, rs...)
+		}
+		changed := len(next) != len(sel)
+		for _, path := range order {
+			vs, err := s.available(path)
+			if err != nil {
+				return nil, err
+			}
+			best, found := semver.Version{}, false
+			for i := len(vs) - 1; i >= 0 && !found; i-- {
+				best, found = vs[i], satisfiesAll(vs[i], cons[path])
+			}
+			if !found {
+				// Keep the walk's choice; check reports the conflict.
+				continue
+			}
+			if cur, ok := sel[path]; !ok || !cur.Equal(best) {
+				s.debug("mvs: selected", slog.String("path", path), slog.String("version", best.String()), slog.Int("round", round+1))
+				changed = true
+			}
+			next[path] = best
+		}
+		sel = next
+		if !changed {
+			return sel, nil
+		}
+	}
+	return nil, fmt.Errorf("mvs: highest selection did not settle after %d rounds", maxRounds)
+}
+
+func satisfiesAll(v semver.Version, cons []semver.Constraint) bool {
+	for _, c := range cons {
+		if !c.Check(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// check walks the graph from roots through sel and returns the build
+// list and the requirements sel violates.
+func (s *solver) check(roots []Requirement, sel map[string]semver.Version) ([]Module, []Conflict, error) {
+	var list []Module
+	var conflicts []Conflict
+	type edge struct {
+		r  Requirement
+		by Module
+	}
+	queue := make([]edge, len(roots))
+	for i, r := range roots {
+		queue[i] = edge{r: r}
+	}
+	listed := make(map[string]bool)
+	for len(queue) > 0 {
+		e := queue[0]
+		queue = queue[1:]
+		v, ok := sel[e.r.Path]
+		if !ok {
+			conflicts = append(conflicts, Conflict{Requirement: e.r, RequiredBy: e.by})
+			continue
+		}
+		if d := e.r.Constraint.CheckDetail(v); !d.Satisfied {
+			conflicts = append(conflicts, Conflict{Requirement: e.r, RequiredBy: e.by, Selected: true, Detail: d})
+		}
+		if listed[e.r.Path] {
+			continue
+		}
+		listed[e.r.Path] = true
+		m := Module{e.r.Path, v}
+		list = append(list, m)
+		rs, err := s.requirements(m)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, r := range rs {
+			queue = append(queue, edge{r, m})
+		}
+	}
+	slices.SortFunc(list, func(a, b Module) int { return strings.Compare(a.Path, b.Path) })
+	return list, conflicts, nil
+}
@@ -0,0 +1,221 @@
+package mvs
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+func mod(path, v string) Module {
+	return Module{path, semver.MustParse(v)}
+}
+
+func req(path, c string) Requirement {
+	return Requirement{path, semver.MustParseConstraint(c)}
+}
+
+// graph returns a catalog of modules given as "PATH@VERSION" keys, each
+// with requirements given as "PATH CONSTRAINT" values.
+func graph(mods map[string][]string) *Catalog {
+	var c Catalog
+	for m, reqs := range mods {
+		path, v, _ := strings.Cut(m, "@")
+		rs := make([]Requirement, len(reqs))
+		for i, r := range reqs {
+			p, cons, _ := strings.Cut(r, " ")
+			rs[i] = req(p, cons)
+		}
+		c.Add(mod(path, v), rs...)
+	}
+	return &c
+}
+
+func list(ms []Module) string {
+	s := make([]string, len(ms))
+	for i, m := range ms {
+		s[i] = m.String()
+	}
+	return strings.Join(s, " ")
+}
+
+// diamond has a and b both requiring c, at different minimums.
+var diamond = map[string][]string{
+	"a@1.0.0": {"c >=1.1.0"},
+	"a@1.1.0": {"c >=1.1.0"},
+	"b@1.0.0": {"c >=1.2.0"},
+	"c@1.0.0": nil,
+	"c@1.1.0": nil,
+	"c@1.2.0": nil,
+	"c@1.3.0": nil,
+	"d@1.0.0": {"c >=1.3.0"},
+	"e@1.0.0": {"c ^1.0.0"},
+}
+
+func TestSolve(t *testing.T) {
+	tests := []struct {
+		name  string
+		graph map[string][]string
+		roots []Requirement
+		mode  Mode
+		opts  []Option
+		want  string // "" if Solve reports a conflict
+	}{
+		{
+			name:  "diamond",
+			graph: diamond,
+			roots: []Requirement{req("a", "^1.0.0"), req("b", "^1.0.0")},
+			want:  "a@1.0.0 b@1.0.0 c@1.2.0",
+		},
+		{
+			name:  "diamond highest",
+			graph: diamond,
+			roots: []Requirement{req("a", "^1.0.0"), req("b", "^1.0.0")},
+			mode:  Highest,
+			want:  "a@1.1.0 b@1.0.0 c@1.3.0",
+		},
+		{
+			name:  "upgrade by a dependency",
+			graph: diamond,
+			roots: []Requirement{req("c", ">=1.0.0"), req("d", "1.0.0")},
+			want:  "c@1.3.0 d@1.0.0",
+		},
+		{
+			name:  "upgrade by the main module",
+			graph: diamond,
+			roots: []Requirement{req("a", ">=1.1.0"), req("c", ">=1.2.0")},
+			want:  "a@1.1.0 c@1.2.0",
+		},
+		{
+			name: "cycle",
+			graph: map[string][]string{
+				"a@1.0.0": {"b >=1.0.0"},
+				"b@1.0.0": {"a >=1.0.0"},
+				"b@1.1.0": {"a >=1.1.0"},
+				"a@1.1.0": {"b >=1.1.0"},
+			},
+			roots: []Requirement{req("a", ">=1.0.0")},
+			want:  "a@1.0.0 b@1.0.0",
+		},
+		{
+			name: "cycle highest",
+			graph: map[string][]string{
+				"a@1.0.0": {"b >=1.0.0"},
+				"b@1.0.0": {"a >=1.0.0"},
+				"b@1.1.0": {"a >=1.1.0"},
+				"a@1.1.0": {"b >=1.1.0"},
+			},
+			roots: []Requirement{req("a", ">=1.0.0")},
+			mode:  Highest,
+			want:  "a@1.1.0 b@1.1.0",
+		},
+		{
+			name:  "exclude",
+			graph: diamond,
+			roots: []Requirement{req("a", "^1.0.0"), req("b", "^1.0.0")},
+			opts:  []Option{WithExclude(mod("c", "1.2.0"), mod("a", "1.0.0"))},
+			want:  "a@1.1.0 b@1.0.0 c@1.3.0",
+		},
+		{
+			name:  "exclude every satisfying version",
+			graph: diamond,
+			roots: []Requirement{req("d", "1.0.0")},
+			opts:  []Option{WithExclude(mod("c", "1.3.0"))},
+		},
+		{
+			name:  "replace",
+			graph: diamond,
+			roots: []Requirement{req("a", "1.0.0"), req("e", "^1.0.0")},
+			opts:  []Option{WithReplace(mod("a", "1.0.0"), mod("d", "1.0.0"))},
+			want:  "a@1.0.0 c@1.3.0 e@1.0.0",
+		},
+		{
+			name:  "replace every version",
+			graph: diamond,
+			roots: []Requirement{req("a", "^1.0.0")},
+			opts:  []Option{WithReplace(Module{Path: "a"}, mod("b", "1.0.0"))},
+			want:  "a@1.0.0 c@1.2.0",
+		},
+		{
+			name:  "unsatisfiable",
+			graph: diamond,
+			roots: []Requirement{req("c", ">=2.0.0")},
+		},
+		{
+			name: "conflict",
+			graph: map[string][]string{
+				"a@1.0.0": {"c <1.2.0"},
+				"b@1.0.0": {"c >=1.2.0"},
+				"c@1.1.0": nil,
+				"c@1.2.0": nil,
+			},
+			roots: []Requirement{req("a", "1.0.0"), req("b", "1.0.0")},
+		},
+	}
+	for _, tt := range tests {
+		got, err := Solve(graph(tt.graph), tt.roots, tt.mode, tt.opts...)
+		if tt.want == "" {
+			var ce *ConflictError
+			if !errors.As(err, &ce) || len(ce.Conflicts) == 0 {
+				t.Errorf("%s: Solve = %q, %v; want a conflict", tt.name, list(got), err)
+			}
+			continue
+		}
+		if err != nil || list(got) != tt.want {
+			t.Errorf("%s: Solve = %q, %v; want %q", tt.name, list(got), err, tt.want)
+		}
+	}
+}
+
+func TestSolveConflict(t *testing.T) {
+	reg := graph(map[string][]string{
+		"a@1.0.0": {"c <1.2.0"},
+		"b@1.0.0": {"c >=1.2.0"},
+		"c@1.1.0": nil,
+		"c@1.2.0": nil,
+	})
+	_, err := Solve(reg, []Requirement{req("a", "1.0.0"), req("b", "1.0.0"), req("c", ">=3.0.0")}, Minimal)
+	var ce *ConflictError
+	if !errors.As(err, &ce) {
+		t.Fatalf("Solve error = %v, want a *ConflictError", err)
+	}
+	want := []string{
+		"the main module requires c >=3.0.0, but c@1.2.0 is selected",
+		"a@1.0.0 requires c <1.2.0, but c@1.2.0 is selected",
+	}
+	if len(ce.Conflicts) != len(want) {
+		t.Fatalf("Conflicts = %v, want %d", ce.Conflicts, len(want))
+	}
+	for i, c := range ce.Conflicts {
+		if !strings.HasPrefix(c.String(), want[i]) || !c.Selected {
+			t.Errorf("Conflicts[%d] = %q, want %q...", i, c, want[i])
+		}
+	}
+	if list(ce.Selection) != "a@1.0.0 b@1.0.0 c@1.2.0" {
+		t.Errorf("Selection = %q", list(ce.Selection))
+	}
+}
+
+func TestSolveUnknownModule(t *testing.T) {
+	reg := graph(map[string][]string{"a@1.0.0": {"missing >=1.0.0"}})
+	_, err := Solve(reg, []Requirement{req("a", "1.0.0")}, Minimal)
+	var ce *ConflictError
+	if err == nil || errors.As(err, &ce) {
+		t.Errorf("Solve error = %v, want a registry error", err)
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	for _, m := range []Mode{Minimal, Highest} {
+		if got, err := ParseMode(m.String()); err != nil || got != m {
+			t.Errorf("ParseMode(%q) = %v, %v", m, got, err)
+		}
+	}
+	if _, err := ParseMode("lowest"); err == nil {
+		t.Error("ParseMode(lowest) succeeded")
+	}
+	if s := Mode(7).String(); s != "Mode(7)" {
+		t.Errorf("Mode(7).String() = %q", s)
+	}
+}
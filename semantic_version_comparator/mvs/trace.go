@@ -0,0 +1,69 @@
+package mvs
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Option configures Solve and SolveContext.
+type Option func(*solver)
+
+// WithLogger makes Solve log its progress to l: the selection of each
+// module and each registry query at debug level, the outcome at info
+// level and conflicts at warn level.
+func WithLogger(l *slog.Logger) Option {
+	return func(s *solver) { s.log = l }
+}
+
+// Tracer starts the spans Solve reports: "mvs.Solve" around the whole
+// run, "mvs.select" and "mvs.check" around its phases, and
+// "mvs.Versions" and "mvs.Requirements" around each registry query.
+// It is an interface so that the package does not depend on a tracing
+// library; an OpenTelemetry adapter is a few lines:
+//
+//	type otelTracer struct{ t trace.Tracer }
+//
+//	func (o otelTracer) Start(ctx context.Context, name string, attrs ...slog.Attr) (context.Context, mvs.Span) {
+//		ctx, span := o.t.Start(ctx, name, trace.WithAttributes(convert(attrs)...))
+//		return ctx, otelSpan{span}
+//	}
+type Tracer interface {
+	Start(ctx context.Context, name string, attrs ...slog.Attr) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer. End is called exactly once, with
+// the error the traced operation failed with, or nil.
+type Span interface {
+	End(err error)
+}
+
+// WithTracer makes Solve report spans to t.
+func WithTracer(t Tracer) Option {
+	return func(s *solver) { s.tracer = t }
+}
+
+// span starts a span named name if s has a tracer, returning the function
+// that ends it. Spans nest under the context of s.
+func (s *solver) span(name string, attrs ...slog.Attr) func(error) {
+	if s.tracer == nil {
+		return func(error) {}
+	}
+	ctx, sp := s.tracer.Start(s.ctx, name, attrs...)
+	parent := s.ctx
+	s.ctx = ctx
+	return func(err error) {
+		s.ctx = parent
+		sp.End(err)
+	}
+}
+
+// debug logs at debug level if s has a logger.
+func (s *solver) debug(msg string, attrs ...slog.Attr) {
+	s.logAttrs(slog.LevelDebug, msg, attrs...)
+}
+
+func (s *solver) logAttrs(level slog.Level, msg string, attrs ...slog.Attr) {
+	if s.log != nil {
+		s.log.LogAttrs(s.ctx, level, msg, attrs...)
+	}
+}
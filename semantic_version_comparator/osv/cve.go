@@ -0,0 +1,86 @@
+package osv
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseExpr converts a CVE-style affected-version expression into an
+// OSV range: "< 1.2.3", "<= 1.2.3", ">= 1.0.0, < 1.2.3" (the comma is
+// optional), "= 1.0.0" or a bare "1.0.0". A missing lower bound means
+// every earlier version is affected. The returned range has type
+// ECOSYSTEM, so it can be evaluated with IsAffected or with any scheme.
+func ParseExpr(s string) (Range, error) {
+	fields := strings.Fields(strings.ReplaceAll(s, ",", " "))
+	// Join operators written apart from their versions, as in "< 1.2".
+	var terms []string
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		if strings.Trim(f, "<>=") == "" && i+1 < len(fields) {
+			i++
+			f += fields[i]
+		}
+		terms = append(terms, f)
+	}
+	if len(terms) == 0 {
+		return Range{}, fmt.Errorf("osv: empty version expression")
+	}
+	var lower string
+	var upper Event
+	for _, t := range terms {
+		op := t[:len(t)-len(strings.TrimLeft(t, "<>="))]
+		v := t[len(op):]
+		if v == "" {
+			return Range{}, fmt.Errorf("osv: invalid version expression %q: operator without version", s)
+		}
+		switch op {
+		case ">=":
+			lower = v
+		case "<":
+			upper = Event{Fixed: v}
+		case "<=":
+			upper = Event{LastAffected: v}
+		case "", "=", "==":
+			lower, upper = v, Event{LastAffected: v}
+		default:
+			return Range{}, fmt.Errorf("osv: invalid version expression %q: unsupported operator %q", s, op)
+		}
+	}
+	if lower == "" {
+		lower = "0"
+	}
+	r := Range{Type: TypeEcosystem, Events: []Event{{Introduced: lower}}}
+	if upper != (Event{}) {
+		r.Events = append(r.Events, upper)
+	}
+	return r, nil
+}
+
+// CVEVersion is an entry of the versions list of a CVE JSON 5 affected
+// product.
+type CVEVersion struct {
+	Version         string `json:"version"`
+	Status          string `json:"status"`
+	VersionType     string `json:"versionType,omitempty"`
+	LessThan        string `json:"lessThan,omitempty"`
+	LessThanOrEqual string `json:"lessThanOrEqual,omitempty"`
+}
+
+// Range converts c into an OSV range. ok is false if c does not describe
+// affected versions. A lessThan of "*" leaves the range open above.
+func (c CVEVersion) Range() (r Range, ok bool) {
+	if c.Status != "affected" {
+		return Range{}, false
+	}
+	r = Range{Type: TypeEcosystem, Events: []Event{{Introduced: c.Version}}}
+	switch {
+	case c.LessThan == "*" || c.LessThanOrEqual == "*":
+	case c.LessThan != "":
+		r.Events = append(r.Events, Event{Fixed: c.LessThan})
+	case c.LessThanOrEqual != "":
+		r.Events = append(r.Events, Event{LastAffected: c.LessThanOrEqual})
+	default:
+		r.Events = append(r.Events, Event{LastAffected: c.Version})
+	}
+	return r, true
+}
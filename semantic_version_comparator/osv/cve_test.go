@@ -0,0 +1,51 @@
+package osv
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseExpr(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []Event
+	}{
+		{"< 1.2.3", []Event{{Introduced: "0"}, {Fixed: "1.2.3"}}},
+		{"<=1.2.3", []Event{{Introduced: "0"}, {LastAffected: "1.2.3"}}},
+		{">= 1.0.0, < 1.2.3", []Event{{Introduced: "1.0.0"}, {Fixed: "1.2.3"}}},
+		{">=1.0.0 <1.2.3", []Event{{Introduced: "1.0.0"}, {Fixed: "1.2.3"}}},
+		{"= 1.0.0", []Event{{Introduced: "1.0.0"}, {LastAffected: "1.0.0"}}},
+		{"1.0.0", []Event{{Introduced: "1.0.0"}, {LastAffected: "1.0.0"}}},
+		{">= 2.0", []Event{{Introduced: "2.0"}}},
+	}
+	for _, tt := range tests {
+		r, err := ParseExpr(tt.in)
+		if err != nil || r.Type != TypeEcosystem || !reflect.DeepEqual(r.Events, tt.want) {
+			t.Errorf("ParseExpr(%q) = %+v, %v; want %+v", tt.in, r, err, tt.want)
+		}
+	}
+	for _, s := range []string{"", "<", "> 1.0.0", ">== 1.0"} {
+		if r, err := ParseExpr(s); err == nil {
+			t.Errorf("ParseExpr(%q) = %+v, want an error", s, r)
+		}
+	}
+}
+
+func TestCVEVersionRange(t *testing.T) {
+	tests := []struct {
+		in   CVEVersion
+		want []Event // nil if not affected
+	}{
+		{CVEVersion{Version: "1.0.0", Status: "affected", LessThan: "1.2.0"}, []Event{{Introduced: "1.0.0"}, {Fixed: "1.2.0"}}},
+		{CVEVersion{Version: "1.0.0", Status: "affected", LessThanOrEqual: "1.1.9"}, []Event{{Introduced: "1.0.0"}, {LastAffected: "1.1.9"}}},
+		{CVEVersion{Version: "1.0.0", Status: "affected", LessThan: "*"}, []Event{{Introduced: "1.0.0"}}},
+		{CVEVersion{Version: "1.0.0", Status: "affected"}, []Event{{Introduced: "1.0.0"}, {LastAffected: "1.0.0"}}},
+		{CVEVersion{Version: "1.0.0", Status: "unaffected"}, nil},
+	}
+	for _, tt := range tests {
+		r, ok := tt.in.Range()
+		if ok != (tt.want != nil) || ok && !reflect.DeepEqual(r.Events, tt.want) {
+			t.Errorf("%+v.Range() = %+v, %v; want %+v", tt.in, r, ok, tt.want)
+		}
+	}
+}
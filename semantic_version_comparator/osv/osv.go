@@ -0,0 +1,223 @@
+// Package osv evaluates the affected-version ranges of vulnerability
+// advisories in the OSV format (https://ossf.github.io/osv-schema/) and
+// CVE-style "affected < X" expressions.
+package osv
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/scheme"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+// Range types defined by the OSV schema.
+const (
+	TypeSemver    = "SEMVER"
+	TypeEcosystem = "ECOSYSTEM"
+	TypeGit       = "GIT"
+)
+
+// Event is one entry of a range's events list. Exactly one field is set.
+type Event struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+	Limit        string `json:"limit,omitempty"`
+}
+
+func (e Event) version() string {
+	switch {
+	case e.Introduced != "":
+		return e.Introduced
+	case e.Fixed != "":
+		return e.Fixed
+	case e.LastAffected != "":
+		return e.LastAffected
+	}
+	return e.Limit
+}
+
+// Range is an OSV affected range.
+type Range struct {
+	Type   string  `json:"type"`
+	Repo   string  `json:"repo,omitempty"`
+	Events []Event `json:"events"`
+}
+
+// Package identifies the affected package of an advisory.
+type Package struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+}
+
+// Affected is one entry of an advisory's affected list.
+type Affected struct {
+	Package  Package  `json:"package"`
+	Ranges   []Range  `json:"ranges,omitempty"`
+	Versions []string `json:"versions,omitempty"`
+}
+
+// IsAffected reports whether v falls within the SEMVER range r, following
+// the OSV evaluation algorithm: events are sorted by version and walked
+// in order, an introduced event at or below v marks it affected, and a
+// fixed event at or below v, or a last_affected event below v, marks it
+// unaffected again. Pre-releases order by SemVer precedence, so
+// 1.2.0-rc.1 is affected by a range fixed in 1.2.0. Events whose versions
+// are not valid semantic versions are ignored.
+func IsAffected(v semver.Version, r Range) bool {
+	affected, _ := evaluate(v, r.Events, semver.Parse, semver.CompareVersions, true)
+	return affected
+}
+
+// Affects reports whether version, written in the versioning scheme sch,
+// falls within r. SEMVER ranges always use semantic versioning; other
+// range types use sch. GIT ranges, which are ordered by commit history,
+// cannot be evaluated and are reported as an error.
+func (r Range) Affects(sch scheme.Scheme, version string) (bool, error) {
+	switch r.Type {
+	case TypeSemver:
+		sch = scheme.Semver
+	case TypeGit:
+		return false, fmt.Errorf("osv: GIT ranges cannot be evaluated against versions")
+	}
+	if err := sch.Validate(version); err != nil {
+		return false, err
+	}
+	parse := func(s string) (string, error) {
+		return s, sch.Validate(s)
+	}
+	cmp := func(a, b string) int {
+		n, _ := sch.Compare(a, b)
+		return n
+	}
+	return evaluate(version, r.Events, parse, cmp, false)
+}
+
+// ecosystemSchemes maps OSV ecosystem names to the scheme that orders
+// their versions.
+var ecosystemSchemes = map[string]string{
+	"Go":          "semver",
+	"npm":         "semver",
+	"crates.io":   "semver",
+	"Hex":         "semver",
+	"Pub":         "semver",
+	"NuGet":       "semver",
+	"PyPI":        "pep440",
+	"Maven":       "maven",
+	"Debian":      "deb",
+	"Ubuntu":      "deb",
+	"Red Hat":     "rpm",
+	"AlmaLinux":   "rpm",
+	"Rocky Linux": "rpm",
+	"openSUSE":    "rpm",
+	"SUSE":        "rpm",
+}
+
+// SchemeFor returns the scheme used to order versions of ecosystem, an
+// OSV ecosystem name such as "PyPI" or "Debian:12". The suffix after ":"
+// is ignored.
+func SchemeFor(ecosystem string) (scheme.Scheme, error) {
+	name, _, _ := strings.Cut(ecosystem, ":")
+	if s, ok := ecosystemSchemes[name]; ok {
+		return scheme.Lookup(s)
+	}
+	return nil, fmt.Errorf("osv: unsupported ecosystem %q", ecosystem)
+}
+
+// Affects reports whether version of the package is affected: it is
+// listed in a.Versions or falls within one of a.Ranges, evaluated with
+// the scheme of a.Package.Ecosystem. GIT ranges are skipped.
+func (a Affected) Affects(version string) (bool, error) {
+	if slices.Contains(a.Versions, version) {
+		return true, nil
+	}
+	var sch scheme.Scheme
+	for _, r := range a.Ranges {
+		switch r.Type {
+		case TypeGit:
+			continue
+		case TypeEcosystem:
+			if sch == nil {
+				var err error
+				if sch, err = SchemeFor(a.Package.Ecosystem); err != nil {
+					return false, err
+				}
+			}
+		}
+		ok, err := r.Affects(sch, version)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// evaluate runs the OSV algorithm for v over events, whose versions are
+// parsed with parse and ordered with cmp. With skipInvalid, events whose
+// versions do not parse are ignored rather than reported.
+func evaluate[V any](v V, events []Event, parse func(string) (V, error), cmp func(a, b V) int, skipInvalid bool) (bool, error) {
+	type entry struct {
+		e    Event
+		v    V
+		zero bool // introduced "0": below every version
+	}
+	var entries []entry
+	for _, e := range events {
+		s := e.version()
+		if s == "0" && e.Introduced != "" {
+			entries = append(entries, entry{e: e, zero: true})
+			continue
+		}
+		ev, err := parse(s)
+		if err != nil {
+			if skipInvalid {
+				continue
+			}
+			return false, fmt.Errorf("osv: invalid event version %q: %v", s, err)
+		}
+		entries = append(entries, entry{e: e, v: ev})
+	}
+	// at compares v with the version of en.
+	at := func(en entry) int {
+		if en.zero {
+			return 1
+		}
+		return cmp(v, en.v)
+	}
+	var limited, belowLimit bool
+	for _, en := range entries {
+		if en.e.Limit != "" {
+			limited = true
+			belowLimit = belowLimit || at(en) < 0
+		}
+	}
+	if limited && !belowLimit {
+		return false, nil
+	}
+	slices.SortStableFunc(entries, func(a, b entry) int {
+		switch {
+		case a.zero && b.zero:
+			return 0
+		case a.zero:
+			return -1
+		case b.zero:
+			return 1
+		}
+		return cmp(a.v, b.v)
+	})
+	affected := false
+	for _, en := range entries {
+		c := at(en)
+		switch {
+		case en.e.Introduced != "" && c >= 0:
+			affected = true
+		case en.e.Fixed != "" && c >= 0:
+			affected = false
+		case en.e.LastAffected != "" && c > 0:
+			affected = false
+		}
+	}
+	return affected, nil
+}
@@ -0,0 +1,137 @@
+package osv
+
+import (
+	"testing"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/scheme"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+func TestIsAffected(t *testing.T) {
+	tests := []struct {
+		name     string
+		events   []Event
+		affected []string
+		safe     []string
+	}{
+		{
+			name:     "introduced and fixed",
+			events:   []Event{{Introduced: "1.0.0"}, {Fixed: "1.2.0"}},
+			affected: []string{"1.0.0", "1.1.9", "1.2.0-rc.1"},
+			safe:     []string{"0.9.0", "1.0.0-rc.1", "1.2.0", "2.0.0"},
+		},
+		{
+			name:     "introduced zero",
+			events:   []Event{{Introduced: "0"}, {Fixed: "1.2.0"}},
+			affected: []string{"0.0.0", "0.0.0-0", "1.1.0"},
+			safe:     []string{"1.2.0"},
+		},
+		{
+			name:     "introduced zero, open above",
+			events:   []Event{{Introduced: "0"}},
+			affected: []string{"0.0.0-0", "99.0.0"},
+		},
+		{
+			name:     "last_affected",
+			events:   []Event{{Introduced: "1.0.0"}, {LastAffected: "1.2.0"}},
+			affected: []string{"1.0.0", "1.2.0"},
+			safe:     []string{"1.2.1", "1.2.1-rc.1"},
+		},
+		{
+			name:     "events out of order",
+			events:   []Event{{Fixed: "1.2.0"}, {Fixed: "2.1.0"}, {Introduced: "2.0.0"}, {Introduced: "1.0.0"}},
+			affected: []string{"1.1.0", "2.0.5"},
+			safe:     []string{"0.9.0", "1.5.0", "2.1.0"},
+		},
+		{
+			name:     "limit",
+			events:   []Event{{Introduced: "1.0.0"}, {Limit: "1.5.0"}},
+			affected: []string{"1.0.0", "1.4.9"},
+			safe:     []string{"1.5.0", "2.0.0", "0.9.0"},
+		},
+		{
+			name:     "limit above a fix",
+			events:   []Event{{Introduced: "0"}, {Fixed: "1.2.0"}, {Introduced: "1.3.0"}, {Limit: "2.0.0"}},
+			affected: []string{"1.0.0", "1.3.0", "1.9.0"},
+			safe:     []string{"1.2.0", "2.0.0"},
+		},
+		{
+			name:     "invalid events are ignored",
+			events:   []Event{{Introduced: "1.0.0"}, {Fixed: "not-a-version"}, {Fixed: "1.2.0"}},
+			affected: []string{"1.1.0"},
+			safe:     []string{"1.2.0"},
+		},
+	}
+	for _, tt := range tests {
+		r := Range{Type: TypeSemver, Events: tt.events}
+		for _, s := range tt.affected {
+			if !IsAffected(semver.MustParse(s), r) {
+				t.Errorf("%s: %s is not affected", tt.name, s)
+			}
+		}
+		for _, s := range tt.safe {
+			if IsAffected(semver.MustParse(s), r) {
+				t.Errorf("%s: %s is affected", tt.name, s)
+			}
+		}
+	}
+}
+
+func TestMultipleRanges(t *testing.T) {
+	a := Affected{
+		Package: Package{Ecosystem: "npm", Name: "pkg"},
+		Ranges: []Range{
+			{Type: TypeSemver, Events: []Event{{Introduced: "1.0.0"}, {Fixed: "1.2.3"}}},
+			{Type: TypeSemver, Events: []Event{{Introduced: "2.0.0"}, {Fixed: "2.0.4"}}},
+			{Type: TypeGit, Repo: "https://example.com/pkg", Events: []Event{{Introduced: "abc123"}}},
+		},
+		Versions: []string{"0.5.0"},
+	}
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"0.5.0", true},
+		{"0.6.0", false},
+		{"1.2.2", true},
+		{"1.2.3", false},
+		{"2.0.0", true},
+		{"2.0.4", false},
+	}
+	for _, tt := range tests {
+		if got, err := a.Affects(tt.version); err != nil || got != tt.want {
+			t.Errorf("Affects(%s) = %v, %v; want %v", tt.version, got, err, tt.want)
+		}
+	}
+}
+
+func TestEcosystemRanges(t *testing.T) {
+	tests := []struct {
+		ecosystem string
+		events    []Event
+		version   string
+		want      bool
+	}{
+		{"PyPI", []Event{{Introduced: "0"}, {Fixed: "2.0"}}, "2.0rc1", true},
+		{"PyPI", []Event{{Introduced: "0"}, {Fixed: "2.0"}}, "2.0.post1", false},
+		{"Debian:12", []Event{{Introduced: "0"}, {Fixed: "1.2-3"}}, "1.2-2", true},
+		{"Debian:12", []Event{{Introduced: "0"}, {Fixed: "1.2-3"}}, "1:1.0-1", false},
+		{"Maven", []Event{{Introduced: "1.0"}, {LastAffected: "1.5"}}, "1.5", true},
+	}
+	for _, tt := range tests {
+		a := Affected{Package: Package{Ecosystem: tt.ecosystem}, Ranges: []Range{{Type: TypeEcosystem, Events: tt.events}}}
+		if got, err := a.Affects(tt.version); err != nil || got != tt.want {
+			t.Errorf("%s: Affects(%s) = %v, %v; want %v", tt.ecosystem, tt.version, got, err, tt.want)
+		}
+	}
+	if _, err := SchemeFor("Unknown"); err == nil {
+		t.Error("SchemeFor(Unknown) succeeded")
+	}
+	a := Affected{Package: Package{Ecosystem: "PyPI"}, Ranges: []Range{{Type: TypeEcosystem, Events: []Event{{Introduced: "0"}, {Fixed: "not valid"}}}}}
+	if _, err := a.Affects("1.0"); err == nil {
+		t.Error("Affects with an invalid ECOSYSTEM event succeeded")
+	}
+	if _, err := (Range{Type: TypeGit}).Affects(scheme.Semver, "1.0.0"); err == nil {
+		t.Error("Affects of a GIT range succeeded")
+	}
+}
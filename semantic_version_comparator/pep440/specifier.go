@@ -0,0 +1,211 @@
+package pep440
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SpecifierSet is a parsed, comma-separated list of version specifiers
+// such as ">=1.0,<2.0,!=1.5.*". A version matches the set if it matches
+// every specifier.
+type SpecifierSet struct {
+	raw         string
+	specs       []specifier
+	prereleases bool
+}
+
+// specifier is a single clause of a SpecifierSet.
+type specifier struct {
+	op       string
+	raw      string // the version as written, for ===
+	v        Version
+	wildcard bool // ==V.* or !=V.*
+}
+
+var specOperators = []string{"===", "~=", "==", "!=", "<=", ">=", "<", ">"}
+
+// ParseSpecifierSet parses a specifier set. The operators are ~=, ==, !=,
+// <=, >=, <, > and ===; == and != accept a trailing ".*" for prefix
+// matching. An empty string matches every final release.
+func ParseSpecifierSet(s string) (SpecifierSet, error) {
+	set := SpecifierSet{raw: s}
+	if strings.TrimSpace(s) == "" {
+		return set, nil
+	}
+	for _, clause := range strings.Split(s, ",") {
+		sp, err := parseSpecifier(strings.TrimSpace(clause))
+		if err != nil {
+			return SpecifierSet{}, fmt.Errorf("pep440: invalid specifier %q: %v", s, err)
+		}
+		if sp.op != "!=" && sp.v.IsPrerelease() {
+			set.prereleases = true
+		}
+		set.specs = append(set.specs, sp)
+	}
+	return set, nil
+}
+
+// MustParseSpecifierSet is like ParseSpecifierSet but panics on error.
+func MustParseSpecifierSet(s string) SpecifierSet {
+	set, err := ParseSpecifierSet(s)
+	if err != nil {
+		panic(err)
+	}
+	return set
+}
+
+func parseSpecifier(s string) (specifier, error) {
+	var sp specifier
+	for _, op := range specOperators {
+		if strings.HasPrefix(s, op) {
+			sp.op = op
+			break
+		}
+	}
+	if sp.op == "" {
+		return sp, fmt.Errorf("missing operator in %q", s)
+	}
+	sp.raw = strings.TrimSpace(s[len(sp.op):])
+	if sp.raw == "" {
+		return sp, fmt.Errorf("operator %q without version", sp.op)
+	}
+	if sp.op == "===" {
+		// Arbitrary equality compares strings; the version need not parse.
+		sp.v, _ = Parse(sp.raw)
+		return sp, nil
+	}
+	vs := sp.raw
+	if strings.HasSuffix(vs, ".*") {
+		if sp.op != "==" && sp.op != "!=" {
+			return sp, fmt.Errorf("wildcard not allowed with %s", sp.op)
+		}
+		sp.wildcard = true
+		vs = strings.TrimSuffix(vs, ".*")
+	}
+	v, err := Parse(vs)
+	if err != nil {
+		return sp, fmt.Errorf("invalid version %q", sp.raw)
+	}
+	switch {
+	case sp.wildcard && (v.Local != "" || v.HasDev):
+		return sp, fmt.Errorf("wildcard after local or dev segment in %q", sp.raw)
+	case v.Local != "" && sp.op != "==" && sp.op != "!=":
+		return sp, fmt.Errorf("local version not allowed with %s", sp.op)
+	case sp.op == "~=" && len(v.Release) < 2:
+		return sp, fmt.Errorf("~= needs at least two release segments")
+	}
+	sp.v = v
+	return sp, nil
+}
+
+// String returns the specifier set as it was written.
+func (s SpecifierSet) String() string {
+	return s.raw
+}
+
+// WithPrereleases returns a copy of s that admits pre-releases and
+// development releases even if none of its specifiers mention one.
+func (s SpecifierSet) WithPrereleases() SpecifierSet {
+	s.prereleases = true
+	return s
+}
+
+// Contains reports whether v matches every specifier in s. As PEP 440
+// requires, pre-releases are excluded unless a specifier names a
+// pre-release version or WithPrereleases was used.
+func (s SpecifierSet) Contains(v Version) bool {
+	if v.IsPrerelease() && !s.prereleases {
+		return false
+	}
+	for _, sp := range s.specs {
+		if !sp.contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (sp specifier) contains(v Version) bool {
+	switch sp.op {
+	case "===":
+		return strings.EqualFold(v.String(), sp.raw)
+	case "==":
+		return sp.equal(v)
+	case "!=":
+		return !sp.equal(v)
+	case "~=":
+		prefix := sp.v.Base()
+		prefix.Release = prefix.Release[:len(prefix.Release)-1]
+		return v.Public().Compare(sp.v) >= 0 && prefixMatch(prefix, v)
+	case "<=":
+		return v.Public().Compare(sp.v) <= 0
+	case ">=":
+		return v.Public().Compare(sp.v) >= 0
+	case "<":
+		if v.Public().Compare(sp.v) >= 0 {
+			return false
+		}
+		// <V excludes pre-releases of V itself unless V is one.
+		return sp.v.IsPrerelease() || !v.IsPrerelease() || v.Base().Compare(sp.v.Base()) != 0
+	case ">":
+		if v.Public().Compare(sp.v) <= 0 {
+			return false
+		}
+		// >V excludes post-releases and local versions of V itself.
+		if !sp.v.IsPostrelease() && v.IsPostrelease() && v.Base().Compare(sp.v.Base()) == 0 {
+			return false
+		}
+		return v.Local == "" || v.Base().Compare(sp.v.Base()) != 0
+	}
+	return false
+}
+
+// equal implements ==: prefix matching for wildcards, and otherwise exact
+// matching that ignores v's local label when the specifier has none.
+func (sp specifier) equal(v Version) bool {
+	if sp.wildcard {
+		return prefixMatch(sp.v, v)
+	}
+	if sp.v.Local == "" {
+		v = v.Public()
+	}
+	return v.Compare(sp.v) == 0
+}
+
+// prefixMatch reports whether the public version of v starts with the
+// segments of prefix, padding v's release with zeros as needed.
+func prefixMatch(prefix, v Version) bool {
+	if v.Epoch != prefix.Epoch {
+		return false
+	}
+	for i, r := range prefix.Release {
+		var x uint64
+		if i < len(v.Release) {
+			x = v.Release[i]
+		}
+		if x != r {
+			return false
+		}
+	}
+	if prefix.PreLabel == "" && !prefix.HasPost {
+		return true
+	}
+	// A prefix with a pre- or post-release segment covers exactly that
+	// release.
+	if len(v.Release) > len(prefix.Release) && !zeros(v.Release[len(prefix.Release):]) {
+		return false
+	}
+	if v.PreLabel != prefix.PreLabel || v.PreNum != prefix.PreNum {
+		return false
+	}
+	return !prefix.HasPost || v.HasPost && v.PostNum == prefix.PostNum
+}
+
+func zeros(ns []uint64) bool {
+	for _, n := range ns {
+		if n != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,52 @@
+package pep440
+
+import "testing"
+
+func TestContains(t *testing.T) {
+	tests := []struct {
+		set     string
+		match   []string
+		noMatch []string
+	}{
+		{"", []string{"1.0", "0.1.post1"}, []string{"1.0rc1", "1.0.dev1"}},
+		{"~=1.4.2", []string{"1.4.2", "1.4.9"}, []string{"1.4.1", "1.5.0"}},
+		{"~=1.4", []string{"1.4", "1.9"}, []string{"2.0", "1.3"}},
+		{"==1.5.*", []string{"1.5", "1.5.3"}, []string{"1.6", "1.50"}},
+		{"!=1.5.*", []string{"1.4", "1.6"}, []string{"1.5.1"}},
+		{">=1.0,<2.0", []string{"1.0", "1.9.9"}, []string{"2.0", "2.0rc1", "1.5rc1", "0.9"}},
+		{">=1.0rc1", []string{"1.0rc2", "1.0", "2.0b1"}, []string{"1.0b9"}},
+		{"==1.0", []string{"1.0", "1.0.0", "1.0+local.1"}, []string{"1.0.1", "1.0.post1"}},
+		{">1.0", []string{"1.1", "1.0.1"}, []string{"1.0", "1.0.post1"}},
+		{"<1.0", []string{"0.9"}, []string{"1.0", "1.0.dev1"}},
+		{"<=1.0", []string{"1.0", "0.9"}, []string{"1.0.post1"}},
+		{"===1.0", []string{"1.0"}, []string{"1.0.0"}},
+	}
+	for _, tt := range tests {
+		set, err := ParseSpecifierSet(tt.set)
+		if err != nil {
+			t.Errorf("ParseSpecifierSet(%q): %v", tt.set, err)
+			continue
+		}
+		for _, s := range tt.match {
+			if !set.Contains(MustParse(s)) {
+				t.Errorf("%q does not contain %s", tt.set, s)
+			}
+		}
+		for _, s := range tt.noMatch {
+			if set.Contains(MustParse(s)) {
+				t.Errorf("%q contains %s", tt.set, s)
+			}
+		}
+	}
+	if set := MustParseSpecifierSet(">=1.0").WithPrereleases(); !set.Contains(MustParse("2.0b1")) {
+		t.Error(`">=1.0" with pre-releases does not contain 2.0b1`)
+	}
+}
+
+func TestParseSpecifierSetErrors(t *testing.T) {
+	for _, s := range []string{">=", "=>1.0", "~=1", "<1.0.*", ">=1.0,", "==abc"} {
+		if set, err := ParseSpecifierSet(s); err == nil {
+			t.Errorf("ParseSpecifierSet(%q) = %v, want an error", s, set)
+		}
+	}
+}
@@ -0,0 +1,330 @@
+// Package pep440 parses, orders and matches Python package versions as
+// defined by PEP 440, such as 1.0rc1, 2!1.0, 1.0.post1 and 1.0.dev3.
+package pep440
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+// versionPattern is the permissive pattern from PEP 440 Appendix B, which
+// accepts every spelling that normalizes to a valid version.
+var versionPattern = regexp.MustCompile(`(?i)^\s*v?` +
+	`(?:([0-9]+)!)?` + // epoch
+	`([0-9]+(?:\.[0-9]+)*)` + // release
+	`(?:[-_.]?(a|b|c|rc|alpha|beta|pre|preview)[-_.]?([0-9]+)?)?` + // pre-release
+	`(?:-([0-9]+)|[-_.]?(post|rev|r)[-_.]?([0-9]+)?)?` + // post-release
+	`(?:[-_.]?(dev)[-_.]?([0-9]+)?)?` + // dev release
+	`(?:\+([a-z0-9]+(?:[-_.][a-z0-9]+)*))?` + // local version
+	`\s*$`)
+
+// Version is a parsed PEP 440 version.
+type Version struct {
+	Epoch   uint64
+	Release []uint64
+	// PreLabel is "a", "b" or "rc", or empty for no pre-release segment.
+	PreLabel string
+	PreNum   uint64
+	HasPost  bool
+	PostNum  uint64
+	HasDev   bool
+	DevNum   uint64
+	// Local is the normalized local version label, such as "ubuntu.1",
+	// or empty.
+	Local string
+}
+
+// Parse parses s, accepting every alternative spelling PEP 440 allows
+// (case-insensitive labels, "alpha" for "a", "-", "_" or "." separators,
+// implicit numbers, a leading "v") and normalizing it.
+func Parse(s string) (Version, error) {
+	m := versionPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Version{}, fmt.Errorf("pep440: invalid version %q", s)
+	}
+	var v Version
+	var err error
+	num := func(field string) uint64 {
+		if field == "" || err != nil {
+			return 0
+		}
+		var n uint64
+		n, err = strconv.ParseUint(field, 10, 64)
+		return n
+	}
+	v.Epoch = num(m[1])
+	for _, part := range strings.Split(m[2], ".") {
+		v.Release = append(v.Release, num(part))
+	}
+	if m[3] != "" {
+		switch strings.ToLower(m[3]) {
+		case "a", "alpha":
+			v.PreLabel = "a"
+		case "b", "beta":
+			v.PreLabel = "b"
+		default:
+			v.PreLabel = "rc"
+		}
+		v.PreNum = num(m[4])
+	}
+	switch {
+	case m[5] != "":
+		v.HasPost, v.PostNum = true, num(m[5])
+	case m[6] != "":
+		v.HasPost, v.PostNum = true, num(m[7])
+	}
+	if m[8] != "" {
+		v.HasDev, v.DevNum = true, num(m[9])
+	}
+	if m[10] != "" {
+		v.Local = strings.NewReplacer("-", ".", "_", ".").Replace(strings.ToLower(m[10]))
+	}
+	if err != nil {
+		return Version{}, fmt.Errorf("pep440: %q: number out of range", s)
+	}
+	return v, nil
+}
+
+// MustParse is like Parse but panics on error.
+func MustParse(s string) Version {
+	v, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// String returns the normalized form of v.
+func (v Version) String() string {
+	s := v.publicString()
+	if v.Local != "" {
+		s += "+" + v.Local
+	}
+	return s
+}
+
+func (v Version) publicString() string {
+	var b strings.Builder
+	if v.Epoch != 0 {
+		fmt.Fprintf(&b, "%d!", v.Epoch)
+	}
+	for i, r := range v.Release {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(strconv.FormatUint(r, 10))
+	}
+	if v.PreLabel != "" {
+		fmt.Fprintf(&b, "%s%d", v.PreLabel, v.PreNum)
+	}
+	if v.HasPost {
+		fmt.Fprintf(&b, ".post%d", v.PostNum)
+	}
+	if v.HasDev {
+		fmt.Fprintf(&b, ".dev%d", v.DevNum)
+	}
+	return b.String()
+}
+
+// Public returns v without its local version label.
+func (v Version) Public() Version {
+	v.Local = ""
+	return v
+}
+
+// Base returns the epoch and release segment of v only.
+func (v Version) Base() Version {
+	return Version{Epoch: v.Epoch, Release: v.Release}
+}
+
+// IsPrerelease reports whether v is a pre-release or development release.
+func (v Version) IsPrerelease() bool {
+	return v.PreLabel != "" || v.HasDev
+}
+
+// IsPostrelease reports whether v is a post-release.
+func (v Version) IsPostrelease() bool {
+	return v.HasPost
+}
+
+// Compare returns -1, 0 or 1 as v orders before, equal to or after o
+// under PEP 440: by epoch, release (ignoring trailing zeros), then
+// development releases before pre-releases before the final release
+// before post-releases, and finally local labels.
+func (v Version) Compare(o Version) int {
+	if c := cmpUint(v.Epoch, o.Epoch); c != 0 {
+		return c
+	}
+	if c := compareRelease(v.Release, o.Release); c != 0 {
+		return c
+	}
+	if c := cmpInt(v.preRank(), o.preRank()); c != 0 {
+		return c
+	}
+	if v.PreLabel != "" {
+		if c := cmpUint(v.PreNum, o.PreNum); c != 0 {
+			return c
+		}
+	}
+	if c := cmpOptional(v.HasPost, v.PostNum, o.HasPost, o.PostNum, -1); c != 0 {
+		return c
+	}
+	if c := cmpOptional(v.HasDev, v.DevNum, o.HasDev, o.DevNum, 1); c != 0 {
+		return c
+	}
+	return compareLocal(v.Local, o.Local)
+}
+
+// preRank orders the pre-release phase: a development release without a
+// pre-release or post-release sorts before everything, then a, b and rc,
+// then no pre-release at all.
+func (v Version) preRank() int {
+	switch v.PreLabel {
+	case "a":
+		return 1
+	case "b":
+		return 2
+	case "rc":
+		return 3
+	}
+	if v.HasDev && !v.HasPost {
+		return 0
+	}
+	return 4
+}
+
+// cmpOptional compares optional numbers; a missing number sorts before
+// any number if missing is -1 and after any number if it is 1.
+func cmpOptional(hasA bool, a uint64, hasB bool, b uint64, missing int) int {
+	switch {
+	case hasA && hasB:
+		return cmpUint(a, b)
+	case hasA:
+		return -missing
+	case hasB:
+		return missing
+	}
+	return 0
+}
+
+func compareRelease(a, b []uint64) int {
+	n := max(len(a), len(b))
+	for i := 0; i < n; i++ {
+		var x, y uint64
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if c := cmpUint(x, y); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// compareLocal orders local labels: no label sorts first, numeric
+// segments sort after alphanumeric ones, and a label that extends another
+// sorts after it.
+func compareLocal(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return -1
+	case b == "":
+		return 1
+	}
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		x, y := as[i], bs[i]
+		nx, errX := strconv.ParseUint(x, 10, 64)
+		ny, errY := strconv.ParseUint(y, 10, 64)
+		switch {
+		case errX == nil && errY == nil:
+			if c := cmpUint(nx, ny); c != 0 {
+				return c
+			}
+		case errX == nil:
+			return 1
+		case errY == nil:
+			return -1
+		default:
+			if c := strings.Compare(x, y); c != 0 {
+				return c
+			}
+		}
+	}
+	return cmpInt(len(as), len(bs))
+}
+
+func cmpUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// ToSemver converts v to the nearest semantic version for cross-ecosystem
+// reporting. The first three release segments become MAJOR.MINOR.PATCH;
+// a, b and rc pre-releases become "alpha.N", "beta.N" and "rc.N"; a
+// development release becomes a "0.dev.N" pre-release (so it sorts
+// first); post-releases and local labels become build metadata. exact is
+// false if information was lost or the conversion does not preserve
+// ordering: a non-zero epoch, more than three release segments, a
+// post-release or a development release of a pre-release.
+func (v Version) ToSemver() (sv semver.Version, exact bool) {
+	exact = v.Epoch == 0 && len(v.Release) <= 3 && !v.HasPost && !(v.HasDev && v.PreLabel != "")
+	for i, r := range v.Release {
+		switch i {
+		case 0:
+			sv.Major = r
+		case 1:
+			sv.Minor = r
+		case 2:
+			sv.Patch = r
+		}
+	}
+	var pre, build []string
+	switch v.PreLabel {
+	case "a":
+		pre = append(pre, "alpha", strconv.FormatUint(v.PreNum, 10))
+	case "b":
+		pre = append(pre, "beta", strconv.FormatUint(v.PreNum, 10))
+	case "rc":
+		pre = append(pre, "rc", strconv.FormatUint(v.PreNum, 10))
+	}
+	if v.HasDev {
+		if len(pre) == 0 {
+			pre = append(pre, "0")
+		}
+		pre = append(pre, "dev", strconv.FormatUint(v.DevNum, 10))
+	}
+	if v.HasPost {
+		build = append(build, "post", strconv.FormatUint(v.PostNum, 10))
+	}
+	if v.Local != "" {
+		build = append(build, strings.Split(v.Local, ".")...)
+	}
+	sv.Prerelease = strings.Join(pre, ".")
+	sv.Build = strings.Join(build, ".")
+	return sv, exact
+}
@@ -0,0 +1,61 @@
+package pep440
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"1.0", "1.0"},
+		{"v1.0", "1.0"},
+		{" 1.0 ", "1.0"},
+		{"1.0RC1", "1.0rc1"},
+		{"1.0-alpha.1", "1.0a1"},
+		{"1.0c2", "1.0rc2"},
+		{"1.0b", "1.0b0"},
+		{"1.0-1", "1.0.post1"},
+		{"1.0.rev3", "1.0.post3"},
+		{"1.0-dev", "1.0.dev0"},
+		{"2!1.0.post1.dev2", "2!1.0.post1.dev2"},
+		{"1.0+Ubuntu-1", "1.0+ubuntu.1"},
+		{"01.002", "1.2"},
+	}
+	for _, tt := range tests {
+		v, err := Parse(tt.in)
+		if err != nil || v.String() != tt.want {
+			t.Errorf("Parse(%q) = %v, %v; want %s", tt.in, v, err, tt.want)
+		}
+	}
+	for _, s := range []string{"", "abc", "1.0.", "1.0+", "1.0++a", "1.0a1a2"} {
+		if v, err := Parse(s); err == nil {
+			t.Errorf("Parse(%q) = %v, want an error", s, v)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// The ordering example of PEP 440, followed by an epoch.
+	ordered := []string{
+		"1.0.dev456", "1.0a1", "1.0a2.dev456", "1.0a12.dev456", "1.0a12",
+		"1.0b1.dev456", "1.0b2", "1.0b2.post345.dev456", "1.0b2.post345",
+		"1.0rc1.dev456", "1.0rc1", "1.0", "1.0+abc.5", "1.0+abc.7", "1.0+5",
+		"1.0.post456.dev34", "1.0.post456", "1.0.15", "1.1.dev1", "1!0.1",
+	}
+	for i, a := range ordered {
+		for j, b := range ordered {
+			want := 0
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = 1
+			}
+			if got := MustParse(a).Compare(MustParse(b)); got != want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", a, b, got, want)
+			}
+		}
+	}
+	if got := MustParse("1.0").Compare(MustParse("1.0.0")); got != 0 {
+		t.Errorf("Compare(1.0, 1.0.0) = %d, want 0", got)
+	}
+}
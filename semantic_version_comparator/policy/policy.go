@@ -0,0 +1,196 @@
+// Package policy decides whether component versions are allowed by a set
+// of rules loaded from a JSON or YAML policy file, such as "lodash must
+// be >=4.17.21" or "no pre-releases in production".
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/internal/yaml"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+// Rule restricts the versions of the components it applies to. A version
+// is denied by a rule if it lies in Deny, lies outside Allow, or is a
+// pre-release when Prereleases is "deny".
+type Rule struct {
+	// Name identifies the rule in decisions. Rules without a name are
+	// called "rules[i]" after their position.
+	Name string `json:"name,omitempty"`
+	// Component is the component name, or a glob as in path.Match such
+	// as "@types/*", the rule applies to; empty means every component.
+	Component string `json:"component,omitempty"`
+	// Environments limits the rule to the listed environments; empty
+	// means every environment.
+	Environments []string `json:"environments,omitempty"`
+	// Allow and Deny are constraints in the policy's dialect.
+	Allow string `json:"allow,omitempty"`
+	Deny  string `json:"deny,omitempty"`
+	// Prereleases is "deny" to reject every pre-release version.
+	Prereleases string `json:"prereleases,omitempty"`
+
+	allow, deny *semver.Constraint
+}
+
+// Policy is an ordered list of rules.
+type Policy struct {
+	// Dialect is the constraint syntax of the rules: "npm" (the default),
+	// "ruby", "nuget" or "terraform".
+	Dialect string `json:"dialect,omitempty"`
+	// Components maps component names to the constraint their versions
+	// must satisfy. It is shorthand for a rule {component, allow} named
+	// after the component, checked after Rules.
+	Components map[string]string `json:"components,omitempty"`
+	Rules      []Rule            `json:"rules,omitempty"`
+
+	rules []Rule
+}
+
+// Decision is the outcome of evaluating a version against a policy.
+type Decision struct {
+	Allowed bool `json:"allowed"`
+	// Rule is the name of the rule that denied the version or, for an
+	// allowed version, of the first rule whose Allow constraint it
+	// satisfied. It is empty if no rule applied.
+	Rule string `json:"rule,omitempty"`
+	// Reason explains a denial.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Parse parses a policy written in JSON or, if data does not start with
+// "{", in YAML. Only the subset of YAML needed for policies is accepted:
+// block mappings and sequences, flow sequences of scalars, quoted and
+// plain scalars, and comments.
+//
+//	dialect: npm
+//	components:
+//	  lodash: ">=4.17.21"
+//	rules:
+//	  - name: no-prereleases-in-production
+//	    environments: [production]
+//	    prereleases: deny
+//	  - component: left-pad
+//	    deny: "1.3.0"
+func Parse(data []byte) (*Policy, error) {
+	if trimmed := strings.TrimSpace(string(data)); !strings.HasPrefix(trimmed, "{") {
+		tree, err := yaml.Parse(trimmed)
+		if err != nil {
+			return nil, fmt.Errorf("policy: %v", err)
+		}
+		if data, err = json.Marshal(tree); err != nil {
+			return nil, fmt.Errorf("policy: %v", err)
+		}
+	}
+	p := new(Policy)
+	dec := json.NewDecoder(strings.NewReader(string(data)))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(p); err != nil {
+		return nil, fmt.Errorf("policy: %v", err)
+	}
+	if err := p.compile(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ParseFile reads and parses the policy file name.
+func ParseFile(name string) (*Policy, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("policy: %v", err)
+	}
+	return Parse(data)
+}
+
+func (p *Policy) compile() error {
+	dialect := semver.DialectNPM
+	if p.Dialect != "" {
+		d, err := semver.ParseDialect(p.Dialect)
+		if err != nil {
+			return fmt.Errorf("policy: %v", err)
+		}
+		dialect = d
+	}
+	p.rules = slices.Clone(p.Rules)
+	names := make([]string, 0, len(p.Components))
+	for name := range p.Components {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		p.rules = append(p.rules, Rule{Name: name, Component: name, Allow: p.Components[name]})
+	}
+	for i := range p.rules {
+		r := &p.rules[i]
+		if r.Name == "" {
+			r.Name = fmt.Sprintf("rules[%d]", i)
+		}
+		if _, err := path.Match(r.Component, ""); err != nil {
+			return fmt.Errorf("policy: rule %s: invalid component pattern %q", r.Name, r.Component)
+		}
+		switch r.Prereleases {
+		case "", "allow", "deny":
+		default:
+			return fmt.Errorf("policy: rule %s: prereleases must be allow or deny, not %q", r.Name, r.Prereleases)
+		}
+		for _, c := range []struct {
+			s   string
+			dst **semver.Constraint
+		}{{r.Allow, &r.allow}, {r.Deny, &r.deny}} {
+			if c.s == "" {
+				continue
+			}
+			con, err := semver.ParseConstraint(c.s, semver.WithDialect(dialect))
+			if err != nil {
+				return fmt.Errorf("policy: rule %s: %v", r.Name, err)
+			}
+			*c.dst = &con
+		}
+	}
+	return nil
+}
+
+// Evaluate decides whether version v of component name is allowed in
+// every environment, ignoring rules limited to particular environments.
+func (p *Policy) Evaluate(name string, v semver.Version) Decision {
+	return p.EvaluateIn("", name, v)
+}
+
+// EvaluateIn decides whether version v of component name is allowed in
+// environment env. The version is denied by the first applicable rule
+// that rejects it and allowed otherwise.
+func (p *Policy) EvaluateIn(env, name string, v semver.Version) Decision {
+	d := Decision{Allowed: true}
+	for _, r := range p.rules {
+		if !r.applies(env, name) {
+			continue
+		}
+		switch {
+		case r.deny != nil && r.deny.Check(v):
+			return Decision{Rule: r.Name, Reason: fmt.Sprintf("%s is denied by %q", v, r.Deny)}
+		case r.allow != nil && !r.allow.Check(v):
+			return Decision{Rule: r.Name, Reason: fmt.Sprintf("%s does not satisfy %q", v, r.Allow)}
+		case r.Prereleases == "deny" && v.Prerelease != "":
+			return Decision{Rule: r.Name, Reason: fmt.Sprintf("%s is a pre-release", v)}
+		case r.allow != nil && d.Rule == "":
+			d.Rule = r.Name
+		}
+	}
+	return d
+}
+
+func (r *Rule) applies(env, name string) bool {
+	if len(r.Environments) > 0 && !slices.Contains(r.Environments, env) {
+		return false
+	}
+	if r.Component == "" {
+		return true
+	}
+	ok, _ := path.Match(r.Component, name)
+	return ok
+}
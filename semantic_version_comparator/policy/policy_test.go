@@ -0,0 +1,116 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+const yamlPolicy = `
+# Versions we ship.
+dialect: npm
+components:
+  lodash: ">=4.17.21"
+  react: ^18.0.0
+rules:
+  - name: no-prereleases-in-production
+    environments: [production]
+    prereleases: deny
+  - component: left-pad
+    deny: "1.3.0"
+  - component: "@types/*"
+    allow: "<2.0.0"
+`
+
+const jsonPolicy = `{
+	"components": {"lodash": ">=4.17.21", "react": "^18.0.0"},
+	"rules": [
+		{"name": "no-prereleases-in-production", "environments": ["production"], "prereleases": "deny"},
+		{"component": "left-pad", "deny": "1.3.0"},
+		{"component": "@types/*", "allow": "<2.0.0"}
+	]
+}`
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		env, name, version string
+		allowed            bool
+		rule               string
+	}{
+		{"", "lodash", "4.17.21", true, "lodash"},
+		{"", "lodash", "4.17.20", false, "lodash"},
+		{"", "react", "18.2.0", true, "react"},
+		{"", "react", "19.0.0", false, "react"},
+		{"", "left-pad", "1.3.0", false, "rules[1]"},
+		{"", "left-pad", "1.3.1", true, ""},
+		{"", "@types/node", "1.9.0", true, "rules[2]"},
+		{"", "@types/node", "2.0.0", false, "rules[2]"},
+		{"", "other", "0.0.1-alpha", true, ""},
+		{"staging", "other", "1.0.0-rc.1", true, ""},
+		{"production", "other", "1.0.0-rc.1", false, "no-prereleases-in-production"},
+		{"production", "other", "1.0.0", true, ""},
+		{"production", "lodash", "4.17.21", true, "lodash"},
+	}
+	for _, doc := range []string{yamlPolicy, jsonPolicy} {
+		p, err := Parse([]byte(doc))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, tt := range tests {
+			d := p.EvaluateIn(tt.env, tt.name, semver.MustParse(tt.version))
+			if d.Allowed != tt.allowed || d.Rule != tt.rule || d.Allowed != (d.Reason == "") {
+				t.Errorf("EvaluateIn(%q, %s, %s) = %+v; want allowed %v by %q", tt.env, tt.name, tt.version, d, tt.allowed, tt.rule)
+			}
+			if tt.env == "" {
+				if d2 := p.Evaluate(tt.name, semver.MustParse(tt.version)); d2 != d {
+					t.Errorf("Evaluate(%s, %s) = %+v; want %+v", tt.name, tt.version, d2, d)
+				}
+			}
+		}
+	}
+}
+
+func TestParseDialect(t *testing.T) {
+	p, err := Parse([]byte(`{"dialect": "terraform", "components": {"aws": "~> 5.0"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for v, want := range map[string]bool{"5.9.0": true, "6.0.0": false} {
+		if d := p.Evaluate("aws", semver.MustParse(v)); d.Allowed != want {
+			t.Errorf("Evaluate(aws, %s) = %+v; want allowed %v", v, d, want)
+		}
+	}
+}
+
+func TestParseError(t *testing.T) {
+	for _, doc := range []string{
+		`{`,
+		`{"unknown": true}`,
+		`{"dialect": "cobol"}`,
+		`{"rules": [{"component": "[", "allow": "*"}]}`,
+		`{"rules": [{"prereleases": "maybe"}]}`,
+		`{"rules": [{"allow": ">="}]}`,
+		`{"components": {"lodash": "~>"}}`,
+		"rules:\n  - name: x\n   bad: indent",
+	} {
+		if _, err := Parse([]byte(doc)); err == nil {
+			t.Errorf("Parse(%q) succeeded", doc)
+		}
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(name, []byte(yamlPolicy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := ParseFile(name)
+	if err != nil || len(p.rules) != 5 {
+		t.Errorf("ParseFile = %+v, %v; want 5 rules", p, err)
+	}
+	if _, err := ParseFile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("ParseFile of a missing file succeeded")
+	}
+}
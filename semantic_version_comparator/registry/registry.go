@@ -0,0 +1,323 @@
+// Package registry lists image tags from OCI distribution registries such
+// as Docker Hub, GHCR and ECR, and orders them as semantic versions.
+package registry
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+// Client lists tags from OCI registries. The zero value is ready to use
+// for public repositories.
+type Client struct {
+	// Username and Password, if set, are sent with Basic auth to the
+	// registry or its token service. For ECR use "AWS" and the output of
+	// "aws ecr get-login-password"; for GHCR a personal access token.
+	Username, Password string
+	// HTTPClient is used for requests; nil means http.DefaultClient.
+	HTTPClient *http.Client
+	// PlainHTTP makes the client talk to registries over http rather
+	// than https, for local test registries.
+	PlainHTTP bool
+}
+
+// Tags returns every tag of the repository named by ref, such as
+// "nginx", "docker.io/library/nginx" or "ghcr.io/owner/app". References
+// without a registry host refer to Docker Hub. Paginated results are
+// followed to the end. Credentials and tokens are only sent to the
+// registry itself: pages that a Link header places under another scheme
+// or host are fetched without them.
+func (c *Client) Tags(ctx context.Context, ref string) ([]string, error) {
+	host, name := SplitReference(ref)
+	scheme := "https://"
+	if c.PlainHTTP {
+		scheme = "http://"
+	}
+	start := scheme + host + "/v2/" + name + "/tags/list"
+	next := start
+	var tags []string
+	auth := ""
+	for next != "" {
+		foreign := !sameOrigin(start, next)
+		pageAuth := auth
+		if foreign {
+			pageAuth = ""
+		}
+		resp, err := c.do(ctx, next, pageAuth)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusUnauthorized && auth == "" && !foreign {
+			challenge := resp.Header.Get("WWW-Authenticate")
+			resp.Body.Close()
+			if auth, err = c.authorize(ctx, challenge); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		var page struct {
+			Tags []string `json:"tags"`
+		}
+		err = decode(resp, &page)
+		if err != nil {
+			return nil, fmt.Errorf("registry: listing tags of %s: %v", ref, err)
+		}
+		tags = append(tags, page.Tags...)
+		next, err = nextLink(next, resp.Header.Get("Link"))
+		if err != nil {
+			return nil, fmt.Errorf("registry: listing tags of %s: %v", ref, err)
+		}
+	}
+	return tags, nil
+}
+
+// SplitReference splits an image reference into its registry host and
+// repository name, applying Docker Hub's defaults: "nginx" is
+// registry-1.docker.io and library/nginx. A tag or digest suffix is
+// dropped.
+func SplitReference(ref string) (host, name string) {
+	if i := strings.IndexByte(ref, '@'); i >= 0 {
+		ref = ref[:i]
+	}
+	if i := strings.LastIndexByte(ref, ':'); i > strings.LastIndexByte(ref, '/') {
+		ref = ref[:i]
+	}
+	host, name = "docker.io", ref
+	if i := strings.IndexByte(ref, '/'); i >= 0 {
+		if first := ref[:i]; strings.ContainsAny(first, ".:") || first == "localhost" {
+			host, name = first, ref[i+1:]
+		}
+	}
+	if host == "docker.io" {
+		host = "registry-1.docker.io"
+		if !strings.Contains(name, "/") {
+			name = "library/" + name
+		}
+	}
+	return host, name
+}
+
+func (c *Client) do(ctx context.Context, u, auth string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("registry: %v", err)
+	}
+	if auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("registry: %v", err)
+	}
+	return resp, nil
+}
+
+// authorize answers a WWW-Authenticate challenge with the value of the
+// Authorization header to retry with: Basic credentials, or a Bearer
+// token fetched from the realm named by the challenge.
+func (c *Client) authorize(ctx context.Context, challenge string) (string, error) {
+	scheme, params := parseChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if c.Username == "" && c.Password == "" {
+			return "", fmt.Errorf("registry: registry requires credentials")
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(c.Username+":"+c.Password)), nil
+	case "bearer":
+	default:
+		return "", fmt.Errorf("registry: unsupported authentication challenge %q", challenge)
+	}
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Scheme == "" {
+		return "", fmt.Errorf("registry: invalid token realm %q", params["realm"])
+	}
+	q := realm.Query()
+	for _, k := range []string{"service", "scope"} {
+		if v := params[k]; v != "" {
+			q.Set(k, v)
+		}
+	}
+	realm.RawQuery = q.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("registry: %v", err)
+	}
+	if c.Username != "" || c.Password != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("registry: fetching token: %v", err)
+	}
+	var tok struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := decode(resp, &tok); err != nil {
+		return "", fmt.Errorf("registry: fetching token: %v", err)
+	}
+	if tok.Token == "" {
+		tok.Token = tok.AccessToken
+	}
+	if tok.Token == "" {
+		return "", fmt.Errorf("registry: token service returned no token")
+	}
+	return "Bearer " + tok.Token, nil
+}
+
+// parseChallenge splits a WWW-Authenticate value such as
+// `Bearer realm="https://auth.docker.io/token",service="registry.docker.io"`
+// into its scheme and parameters.
+func parseChallenge(s string) (scheme string, params map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(s), " ")
+	params = make(map[string]string)
+	for rest = strings.TrimSpace(rest); rest != ""; {
+		key, after, ok := strings.Cut(rest, "=")
+		if !ok {
+			break
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		var val string
+		if strings.HasPrefix(after, `"`) {
+			end := strings.IndexByte(after[1:], '"')
+			if end < 0 {
+				val, rest = after[1:], ""
+			} else {
+				val, rest = after[1:end+1], after[end+2:]
+			}
+		} else {
+			val, rest, _ = strings.Cut(after, ",")
+		}
+		params[key] = val
+		rest = strings.TrimLeft(rest, ", ")
+	}
+	return scheme, params
+}
+
+// nextLink resolves the rel="next" URL of a Link header against the
+// current page, or returns "" on the last page.
+func nextLink(current, link string) (string, error) {
+	for _, part := range strings.Split(link, ",") {
+		target, attrs, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if !strings.Contains(attrs, `rel="next"`) {
+			continue
+		}
+		base, err := url.Parse(current)
+		if err != nil {
+			return "", err
+		}
+		ref, err := url.Parse(strings.Trim(strings.TrimSpace(target), "<>"))
+		if err != nil {
+			return "", err
+		}
+		return base.ResolveReference(ref).String(), nil
+	}
+	return "", nil
+}
+
+// sameOrigin reports whether URLs a and b have the same scheme and host.
+func sameOrigin(a, b string) bool {
+	ua, err := url.Parse(a)
+	if err != nil {
+		return false
+	}
+	ub, err := url.Parse(b)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(ua.Scheme, ub.Scheme) && strings.EqualFold(ua.Host, ub.Host)
+}
+
+func decode(resp *http.Response, v any) error {
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s", resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+// Tag is an image tag that carries a semantic version.
+type Tag struct {
+	Name    string
+	Version semver.Version
+	// Variant is the suffix naming the image flavor, such as
+	// "alpine3.18" or "bookworm-slim", without its leading "-".
+	Variant string
+}
+
+// Versions returns the tags matching pattern, a glob as in path.Match
+// such as "1.*-alpine", that parse as versions, sorted in ascending
+// precedence order; tags of equal precedence are ordered by name. The
+// literal text after the pattern's last wildcard, "-alpine" here, is a
+// variant suffix and is removed before parsing, so it is not mistaken for
+// a pre-release. A pattern without wildcards matches only the tag it
+// names and has no variant. An empty pattern matches every tag. Tags are
+// parsed with semver.ParseTolerant, so "1.25" is 1.25.0.
+func Versions(tags []string, pattern string) ([]Tag, error) {
+	suffix := ""
+	if pattern != "" {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("registry: invalid tag pattern %q: %v", pattern, err)
+		}
+		if strings.ContainsAny(pattern, "*?[") {
+			suffix = pattern[strings.LastIndexAny(pattern, "*?]")+1:]
+		}
+	}
+	var out []Tag
+	for _, name := range tags {
+		if pattern != "" {
+			if ok, _ := path.Match(pattern, name); !ok {
+				continue
+			}
+		}
+		v, err := semver.ParseTolerant(strings.TrimSuffix(name, suffix))
+		if err != nil {
+			continue
+		}
+		out = append(out, Tag{Name: name, Version: v, Variant: strings.TrimPrefix(suffix, "-")})
+	}
+	slices.SortFunc(out, func(a, b Tag) int {
+		if c := a.Version.Compare(b.Version); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return out, nil
+}
+
+// Latest returns the tag with the highest version that matches pattern
+// (see Versions) and satisfies c. ok is false if no tag qualifies.
+func Latest(tags []string, pattern string, c semver.Constraint) (t Tag, ok bool, err error) {
+	vs, err := Versions(tags, pattern)
+	if err != nil {
+		return Tag{}, false, err
+	}
+	for i := len(vs) - 1; i >= 0; i-- {
+		if c.Check(vs[i].Version) {
+			return vs[i], true, nil
+		}
+	}
+	return Tag{}, false, nil
+}
@@ -0,0 +1,84 @@
+package registry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestVersions(t *testing.T) {
+	tags := []string{"1.21.3", "1.21.3-alpine", "1.21.4-alpine", "1.22.0", "latest", "1.21.10-bookworm"}
+	tests := []struct {
+		pattern string
+		want    string // names and variants of the result
+	}{
+		{"", "1.21.3-alpine 1.21.3 1.21.4-alpine 1.21.10-bookworm 1.22.0"},
+		{"1.21.3", "1.21.3"},
+		{"1.21.3-alpine", "1.21.3-alpine"},
+		{"1.21.*-alpine", "1.21.3-alpine/alpine 1.21.4-alpine/alpine"},
+		{"1.2?.?", "1.21.3 1.22.0"},
+		{"1.21.[0-9]-alpine", "1.21.3-alpine/alpine 1.21.4-alpine/alpine"},
+		{"1.*-bookworm", "1.21.10-bookworm/bookworm"},
+		{"2.*", ""},
+	}
+	for _, tt := range tests {
+		got, err := Versions(tags, tt.pattern)
+		if err != nil {
+			t.Errorf("Versions(%q): %v", tt.pattern, err)
+			continue
+		}
+		names := make([]string, len(got))
+		for i, tag := range got {
+			names[i] = tag.Name
+			if tag.Variant != "" {
+				names[i] += "/" + tag.Variant
+			}
+		}
+		if s := strings.Join(names, " "); s != tt.want {
+			t.Errorf("Versions(%q) = %q, want %q", tt.pattern, s, tt.want)
+		}
+	}
+	if _, err := Versions(tags, "1.[2"); err == nil {
+		t.Error("Versions with an invalid pattern succeeded")
+	}
+}
+
+func TestTagsPagination(t *testing.T) {
+	var otherAuth []string
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		otherAuth = append(otherAuth, r.Header.Get("Authorization"))
+		w.Write([]byte(`{"tags":["d"]}`))
+	}))
+	defer other.Close()
+	var reg *httptest.Server
+	reg = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			w.Write([]byte(`{"token":"secret"}`))
+		case r.Header.Get("Authorization") != "Bearer secret":
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+reg.URL+`/token",service="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Query().Get("last") == "":
+			w.Header().Set("Link", `</v2/app/tags/list?last=b>; rel="next"`)
+			w.Write([]byte(`{"tags":["a","b"]}`))
+		default:
+			w.Header().Set("Link", `<`+other.URL+`/v2/app/tags/list?last=c>; rel="next"`)
+			w.Write([]byte(`{"tags":["c"]}`))
+		}
+	}))
+	defer reg.Close()
+	c := &Client{PlainHTTP: true}
+	tags, err := c.Tags(context.Background(), strings.TrimPrefix(reg.URL, "http://")+"/app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a", "b", "c", "d"}; !slices.Equal(tags, want) {
+		t.Errorf("Tags = %q, want %q", tags, want)
+	}
+	if !slices.Equal(otherAuth, []string{""}) {
+		t.Errorf("the other host received Authorization %q, want none", otherAuth)
+	}
+}
@@ -0,0 +1,184 @@
+package registry
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+// prereleaseWords are the identifiers that begin a pre-release rather
+// than a variant in a tag such as 1.22.0-rc.1-alpine.
+var prereleaseWords = []string{"alpha", "a", "beta", "b", "rc", "pre", "preview", "dev", "snapshot", "canary", "nightly"}
+
+// SplitVariant splits a tag following the Docker convention
+// VERSION[-PRERELEASE][-VARIANT] into its version and variant suffix, so
+// "1.21.3-alpine3.18" is "1.21.3" and "alpine3.18", "1.21.3-bookworm-slim"
+// is "1.21.3" and "bookworm-slim", and "1.22.0-rc.1-alpine" is
+// "1.22.0-rc.1" and "alpine". Each "-" separated part after the first
+// stays in the version while it starts with a digit or a pre-release word
+// such as rc or beta; the first part that does not, and everything after
+// it, is the variant. A tag without a variant is returned unchanged with
+// an empty variant.
+func SplitVariant(tag string) (version, variant string) {
+	parts := strings.Split(tag, "-")
+	for i, p := range parts[1:] {
+		if !isPrereleasePart(p) {
+			return strings.Join(parts[:i+1], "-"), strings.Join(parts[i+1:], "-")
+		}
+	}
+	return tag, ""
+}
+
+func isPrereleasePart(p string) bool {
+	if p == "" {
+		return false
+	}
+	if p[0] >= '0' && p[0] <= '9' {
+		return true
+	}
+	word := strings.ToLower(strings.TrimRight(strings.SplitN(p, ".", 2)[0], "0123456789"))
+	return slices.Contains(prereleaseWords, word)
+}
+
+// ParseTag parses a tag such as "1.21.3-alpine3.18" into a Tag whose
+// Version is its semantic version, parsed with semver.ParseTolerant, and
+// whose Variant is its suffix; see SplitVariant.
+func ParseTag(name string) (Tag, error) {
+	s, variant := SplitVariant(name)
+	v, err := semver.ParseTolerant(s)
+	if err != nil {
+		return Tag{}, fmt.Errorf("registry: tag %q: %v", name, err)
+	}
+	return Tag{Name: name, Version: v, Variant: variant}, nil
+}
+
+// Family returns the variant with the version numbers of each of its
+// parts removed, so "alpine3.18" and "alpine3.19" are both "alpine" and
+// "windowsservercore-ltsc2022" is "windowsservercore-ltsc".
+func Family(variant string) string {
+	parts := strings.Split(variant, "-")
+	for i, p := range parts {
+		if f := strings.TrimRight(p, "0123456789."); f != "" {
+			parts[i] = f
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// Compare orders tags by version first, then by variant family, then by
+// the version numbers within the variant, so 1.21.3-alpine3.18 sorts
+// before 1.21.3-alpine3.19, which sorts before 1.22.0-alpine3.18. Tags
+// that still tie are ordered by name. It returns -1, 0 or +1.
+func Compare(a, b Tag) int {
+	if c := a.Version.Compare(b.Version); c != 0 {
+		return c
+	}
+	if c := strings.Compare(Family(a.Variant), Family(b.Variant)); c != 0 {
+		return c
+	}
+	if c := compareVariants(a.Variant, b.Variant); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Name, b.Name)
+}
+
+// compareVariants compares variants of the same family by the numbers
+// they embed, so alpine3.9 precedes alpine3.18.
+func compareVariants(a, b string) int {
+	for a != "" && b != "" {
+		na, ra := leadingNumber(a)
+		nb, rb := leadingNumber(b)
+		switch {
+		case na != "" && nb != "":
+			if c := compareDigits(na, nb); c != 0 {
+				return c
+			}
+		case a[0] != b[0]:
+			if a[0] < b[0] {
+				return -1
+			}
+			return 1
+		default:
+			ra, rb = a[1:], b[1:]
+		}
+		a, b = ra, rb
+	}
+	switch {
+	case a != "":
+		return 1
+	case b != "":
+		return -1
+	}
+	return 0
+}
+
+// leadingNumber splits the decimal digits at the start of s from the rest.
+func leadingNumber(s string) (num, rest string) {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+func compareDigits(a, b string) int {
+	a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// ParseTags parses the tags that follow the Docker version convention,
+// skipping the rest such as "latest" or "alpine", and returns them sorted
+// by Compare.
+func ParseTags(tags []string) []Tag {
+	var out []Tag
+	for _, name := range tags {
+		if t, err := ParseTag(name); err == nil {
+			out = append(out, t)
+		}
+	}
+	slices.SortFunc(out, Compare)
+	return out
+}
+
+// ByVariant groups the tags that parse with ParseTag by variant, each
+// group sorted by Compare. Tags without a variant are grouped under "".
+func ByVariant(tags []string) map[string][]Tag {
+	groups := make(map[string][]Tag)
+	for _, t := range ParseTags(tags) {
+		groups[t.Variant] = append(groups[t.Variant], t)
+	}
+	return groups
+}
+
+// MatchVariant reports whether t has the given variant, either exactly or
+// as a family: "alpine" matches alpine and alpine3.18, but "alpine3.18"
+// matches only alpine3.18. An empty variant matches only tags without one.
+func (t Tag) MatchVariant(variant string) bool {
+	return t.Variant == variant || variant != "" && Family(t.Variant) == variant
+}
+
+// LatestVariant returns the highest tag, by Compare, that has the given
+// variant (see Tag.MatchVariant) and whose version satisfies c, answering
+// questions such as "the latest 1.21 alpine image":
+//
+//	LatestVariant(tags, "alpine", semver.MustParseConstraint("1.21.x"))
+//
+// Of two tags of the same version the one with the newer variant, such as
+// alpine3.19 over alpine3.18, wins. ok is false if no tag qualifies.
+func LatestVariant(tags []string, variant string, c semver.Constraint) (t Tag, ok bool) {
+	vs := ParseTags(tags)
+	for i := len(vs) - 1; i >= 0; i-- {
+		if vs[i].MatchVariant(variant) && c.Check(vs[i].Version) {
+			return vs[i], true
+		}
+	}
+	return Tag{}, false
+}
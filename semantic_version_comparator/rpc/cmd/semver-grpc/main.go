@@ -0,0 +1,64 @@
+// Command semver-grpc serves the VersionService over gRPC until
+// interrupted.
+//
+//	semver-grpc [-addr ADDR] [-metrics-addr ADDR]
+//
+// With -metrics-addr it also serves Prometheus metrics over HTTP at
+// /metrics on that address.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"google.golang.org/grpc"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/rpc"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/service"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:9090", "listen on `ADDR`")
+	metricsAddr := flag.String("metrics-addr", "", "serve /metrics over HTTP on `ADDR`")
+	flag.Parse()
+	if flag.NArg() != 0 {
+		flag.Usage()
+		os.Exit(3)
+	}
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "semver-grpc:", err)
+		os.Exit(3)
+	}
+	if *metricsAddr != "" {
+		mln, err := net.Listen("tcp", *metricsAddr)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "semver-grpc:", err)
+			os.Exit(3)
+		}
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", service.DefaultMetrics)
+		go http.Serve(mln, mux)
+		fmt.Fprintf(os.Stderr, "semver-grpc: serving metrics on http://%s/metrics\n", mln.Addr())
+	}
+	srv := grpc.NewServer(grpc.UnaryInterceptor(rpc.UnaryInterceptor(service.DefaultMetrics)))
+	rpc.Register(srv)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		// Let in-flight calls finish before exiting.
+		srv.GracefulStop()
+	}()
+	fmt.Fprintf(os.Stderr, "semver-grpc: listening on %s\n", ln.Addr())
+	if err := srv.Serve(ln); err != nil {
+		fmt.Fprintln(os.Stderr, "semver-grpc:", err)
+		os.Exit(3)
+	}
+}
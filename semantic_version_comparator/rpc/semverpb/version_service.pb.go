@@ -0,0 +1,577 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: semver/v1/version_service.proto
+
+package semverpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CompareRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	A     string                 `protobuf:"bytes,1,opt,name=a,proto3" json:"a,omitempty"`
+	B     string                 `protobuf:"bytes,2,opt,name=b,proto3" json:"b,omitempty"`
+	// Version scheme such as "semver", "pep440" or "maven"; empty means
+	// "semver".
+	Scheme        string `protobuf:"bytes,3,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompareRequest) Reset() {
+	*x = CompareRequest{}
+	mi := &file_semver_v1_version_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareRequest) ProtoMessage() {}
+
+func (x *CompareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_semver_v1_version_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareRequest.ProtoReflect.Descriptor instead.
+func (*CompareRequest) Descriptor() ([]byte, []int) {
+	return file_semver_v1_version_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CompareRequest) GetA() string {
+	if x != nil {
+		return x.A
+	}
+	return ""
+}
+
+func (x *CompareRequest) GetB() string {
+	if x != nil {
+		return x.B
+	}
+	return ""
+}
+
+func (x *CompareRequest) GetScheme() string {
+	if x != nil {
+		return x.Scheme
+	}
+	return ""
+}
+
+type CompareResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// -1, 0 or 1 as a orders before, equal to or after b.
+	Result        int32 `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompareResponse) Reset() {
+	*x = CompareResponse{}
+	mi := &file_semver_v1_version_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareResponse) ProtoMessage() {}
+
+func (x *CompareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_semver_v1_version_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareResponse.ProtoReflect.Descriptor instead.
+func (*CompareResponse) Descriptor() ([]byte, []int) {
+	return file_semver_v1_version_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CompareResponse) GetResult() int32 {
+	if x != nil {
+		return x.Result
+	}
+	return 0
+}
+
+type SortRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Versions []string               `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"`
+	// Version scheme; empty means "semver".
+	Scheme string `protobuf:"bytes,2,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	// Sort in descending order.
+	Reverse       bool `protobuf:"varint,3,opt,name=reverse,proto3" json:"reverse,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SortRequest) Reset() {
+	*x = SortRequest{}
+	mi := &file_semver_v1_version_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SortRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SortRequest) ProtoMessage() {}
+
+func (x *SortRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_semver_v1_version_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SortRequest.ProtoReflect.Descriptor instead.
+func (*SortRequest) Descriptor() ([]byte, []int) {
+	return file_semver_v1_version_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SortRequest) GetVersions() []string {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+func (x *SortRequest) GetScheme() string {
+	if x != nil {
+		return x.Scheme
+	}
+	return ""
+}
+
+func (x *SortRequest) GetReverse() bool {
+	if x != nil {
+		return x.Reverse
+	}
+	return false
+}
+
+type SortResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Versions      []string               `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SortResponse) Reset() {
+	*x = SortResponse{}
+	mi := &file_semver_v1_version_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SortResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SortResponse) ProtoMessage() {}
+
+func (x *SortResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_semver_v1_version_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SortResponse.ProtoReflect.Descriptor instead.
+func (*SortResponse) Descriptor() ([]byte, []int) {
+	return file_semver_v1_version_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SortResponse) GetVersions() []string {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+type CheckConstraintRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Version    string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Constraint string                 `protobuf:"bytes,2,opt,name=constraint,proto3" json:"constraint,omitempty"`
+	// Constraint dialect: "npm" (the default), "ruby", "nuget",
+	// "terraform" or "helm".
+	Dialect string `protobuf:"bytes,3,opt,name=dialect,proto3" json:"dialect,omitempty"`
+	// Let pre-releases satisfy ranges by precedence alone.
+	IncludePrerelease bool `protobuf:"varint,4,opt,name=include_prerelease,json=includePrerelease,proto3" json:"include_prerelease,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CheckConstraintRequest) Reset() {
+	*x = CheckConstraintRequest{}
+	mi := &file_semver_v1_version_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckConstraintRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckConstraintRequest) ProtoMessage() {}
+
+func (x *CheckConstraintRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_semver_v1_version_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckConstraintRequest.ProtoReflect.Descriptor instead.
+func (*CheckConstraintRequest) Descriptor() ([]byte, []int) {
+	return file_semver_v1_version_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CheckConstraintRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *CheckConstraintRequest) GetConstraint() string {
+	if x != nil {
+		return x.Constraint
+	}
+	return ""
+}
+
+func (x *CheckConstraintRequest) GetDialect() string {
+	if x != nil {
+		return x.Dialect
+	}
+	return ""
+}
+
+func (x *CheckConstraintRequest) GetIncludePrerelease() bool {
+	if x != nil {
+		return x.IncludePrerelease
+	}
+	return false
+}
+
+type CheckConstraintResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Satisfies     bool                   `protobuf:"varint,1,opt,name=satisfies,proto3" json:"satisfies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckConstraintResponse) Reset() {
+	*x = CheckConstraintResponse{}
+	mi := &file_semver_v1_version_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckConstraintResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckConstraintResponse) ProtoMessage() {}
+
+func (x *CheckConstraintResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_semver_v1_version_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckConstraintResponse.ProtoReflect.Descriptor instead.
+func (*CheckConstraintResponse) Descriptor() ([]byte, []int) {
+	return file_semver_v1_version_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CheckConstraintResponse) GetSatisfies() bool {
+	if x != nil {
+		return x.Satisfies
+	}
+	return false
+}
+
+type MaxSatisfyingRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Versions          []string               `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"`
+	Constraint        string                 `protobuf:"bytes,2,opt,name=constraint,proto3" json:"constraint,omitempty"`
+	Dialect           string                 `protobuf:"bytes,3,opt,name=dialect,proto3" json:"dialect,omitempty"`
+	IncludePrerelease bool                   `protobuf:"varint,4,opt,name=include_prerelease,json=includePrerelease,proto3" json:"include_prerelease,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *MaxSatisfyingRequest) Reset() {
+	*x = MaxSatisfyingRequest{}
+	mi := &file_semver_v1_version_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MaxSatisfyingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaxSatisfyingRequest) ProtoMessage() {}
+
+func (x *MaxSatisfyingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_semver_v1_version_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaxSatisfyingRequest.ProtoReflect.Descriptor instead.
+func (*MaxSatisfyingRequest) Descriptor() ([]byte, []int) {
+	return file_semver_v1_version_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MaxSatisfyingRequest) GetVersions() []string {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+func (x *MaxSatisfyingRequest) GetConstraint() string {
+	if x != nil {
+		return x.Constraint
+	}
+	return ""
+}
+
+func (x *MaxSatisfyingRequest) GetDialect() string {
+	if x != nil {
+		return x.Dialect
+	}
+	return ""
+}
+
+func (x *MaxSatisfyingRequest) GetIncludePrerelease() bool {
+	if x != nil {
+		return x.IncludePrerelease
+	}
+	return false
+}
+
+type MaxSatisfyingResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The highest satisfying version as it was written; empty if found is
+	// false.
+	Version       string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Found         bool   `protobuf:"varint,2,opt,name=found,proto3" json:"found,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MaxSatisfyingResponse) Reset() {
+	*x = MaxSatisfyingResponse{}
+	mi := &file_semver_v1_version_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MaxSatisfyingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaxSatisfyingResponse) ProtoMessage() {}
+
+func (x *MaxSatisfyingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_semver_v1_version_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaxSatisfyingResponse.ProtoReflect.Descriptor instead.
+func (*MaxSatisfyingResponse) Descriptor() ([]byte, []int) {
+	return file_semver_v1_version_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MaxSatisfyingResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *MaxSatisfyingResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+var File_semver_v1_version_service_proto protoreflect.FileDescriptor
+
+const file_semver_v1_version_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1fsemver/v1/version_service.proto\x12\tsemver.v1\"D\n" +
+	"\x0eCompareRequest\x12\f\n" +
+	"\x01a\x18\x01 \x01(\tR\x01a\x12\f\n" +
+	"\x01b\x18\x02 \x01(\tR\x01b\x12\x16\n" +
+	"\x06scheme\x18\x03 \x01(\tR\x06scheme\")\n" +
+	"\x0fCompareResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\x05R\x06result\"[\n" +
+	"\vSortRequest\x12\x1a\n" +
+	"\bversions\x18\x01 \x03(\tR\bversions\x12\x16\n" +
+	"\x06scheme\x18\x02 \x01(\tR\x06scheme\x12\x18\n" +
+	"\areverse\x18\x03 \x01(\bR\areverse\"*\n" +
+	"\fSortResponse\x12\x1a\n" +
+	"\bversions\x18\x01 \x03(\tR\bversions\"\x9b\x01\n" +
+	"\x16CheckConstraintRequest\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x1e\n" +
+	"\n" +
+	"constraint\x18\x02 \x01(\tR\n" +
+	"constraint\x12\x18\n" +
+	"\adialect\x18\x03 \x01(\tR\adialect\x12-\n" +
+	"\x12include_prerelease\x18\x04 \x01(\bR\x11includePrerelease\"7\n" +
+	"\x17CheckConstraintResponse\x12\x1c\n" +
+	"\tsatisfies\x18\x01 \x01(\bR\tsatisfies\"\x9b\x01\n" +
+	"\x14MaxSatisfyingRequest\x12\x1a\n" +
+	"\bversions\x18\x01 \x03(\tR\bversions\x12\x1e\n" +
+	"\n" +
+	"constraint\x18\x02 \x01(\tR\n" +
+	"constraint\x12\x18\n" +
+	"\adialect\x18\x03 \x01(\tR\adialect\x12-\n" +
+	"\x12include_prerelease\x18\x04 \x01(\bR\x11includePrerelease\"G\n" +
+	"\x15MaxSatisfyingResponse\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x14\n" +
+	"\x05found\x18\x02 \x01(\bR\x05found2\xb9\x02\n" +
+	"\x0eVersionService\x12@\n" +
+	"\aCompare\x12\x19.semver.v1.CompareRequest\x1a\x1a.semver.v1.CompareResponse\x127\n" +
+	"\x04Sort\x12\x16.semver.v1.SortRequest\x1a\x17.semver.v1.SortResponse\x12X\n" +
+	"\x0fCheckConstraint\x12!.semver.v1.CheckConstraintRequest\x1a\".semver.v1.CheckConstraintResponse\x12R\n" +
+	"\rMaxSatisfying\x12\x1f.semver.v1.MaxSatisfyingRequest\x1a .semver.v1.MaxSatisfyingResponseBQZOgithub.com/ketiyohannes/sample-dataset/semantic_version_comparator/rpc/semverpbb\x06proto3"
+
+var (
+	file_semver_v1_version_service_proto_rawDescOnce sync.Once
+	file_semver_v1_version_service_proto_rawDescData []byte
+)
+
+func file_semver_v1_version_service_proto_rawDescGZIP() []byte {
+	file_semver_v1_version_service_proto_rawDescOnce.Do(func() {
+		file_semver_v1_version_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_semver_v1_version_service_proto_rawDesc), len(file_semver_v1_version_service_proto_rawDesc)))
+	})
+	return file_semver_v1_version_service_proto_rawDescData
+}
+
+var file_semver_v1_version_service_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_semver_v1_version_service_proto_goTypes = []any{
+	(*CompareRequest)(nil),          // 0: semver.v1.CompareRequest
+	(*CompareResponse)(nil),         // 1: semver.v1.CompareResponse
+	(*SortRequest)(nil),             // 2: semver.v1.SortRequest
+	(*SortResponse)(nil),            // 3: semver.v1.SortResponse
+	(*CheckConstraintRequest)(nil),  // 4: semver.v1.CheckConstraintRequest
+	(*CheckConstraintResponse)(nil), // 5: semver.v1.CheckConstraintResponse
+	(*MaxSatisfyingRequest)(nil),    // 6: semver.v1.MaxSatisfyingRequest
+	(*MaxSatisfyingResponse)(nil),   // 7: semver.v1.MaxSatisfyingResponse
+}
+var file_semver_v1_version_service_proto_depIdxs = []int32{
+	0, // 0: semver.v1.VersionService.Compare:input_type -> semver.v1.CompareRequest
+	2, // 1: semver.v1.VersionService.Sort:input_type -> semver.v1.SortRequest
+	4, // 2: semver.v1.VersionService.CheckConstraint:input_type -> semver.v1.CheckConstraintRequest
+	6, // 3: semver.v1.VersionService.MaxSatisfying:input_type -> semver.v1.MaxSatisfyingRequest
+	1, // 4: semver.v1.VersionService.Compare:output_type -> semver.v1.CompareResponse
+	3, // 5: semver.v1.VersionService.Sort:output_type -> semver.v1.SortResponse
+	5, // 6: semver.v1.VersionService.CheckConstraint:output_type -> semver.v1.CheckConstraintResponse
+	7, // 7: semver.v1.VersionService.MaxSatisfying:output_type -> semver.v1.MaxSatisfyingResponse
+	4, // [4:8] is the sub-list for method output_type
+	0, // [0:4] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_semver_v1_version_service_proto_init() }
+func file_semver_v1_version_service_proto_init() {
+	if File_semver_v1_version_service_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_semver_v1_version_service_proto_rawDesc), len(file_semver_v1_version_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_semver_v1_version_service_proto_goTypes,
+		DependencyIndexes: file_semver_v1_version_service_proto_depIdxs,
+		MessageInfos:      file_semver_v1_version_service_proto_msgTypes,
+	}.Build()
+	File_semver_v1_version_service_proto = out.File
+	file_semver_v1_version_service_proto_goTypes = nil
+	file_semver_v1_version_service_proto_depIdxs = nil
+}
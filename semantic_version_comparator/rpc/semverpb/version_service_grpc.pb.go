@@ -0,0 +1,255 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: semver/v1/version_service.proto
+
+package semverpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	VersionService_Compare_FullMethodName         = "/semver.v1.VersionService/Compare"
+	VersionService_Sort_FullMethodName            = "/semver.v1.VersionService/Sort"
+	VersionService_CheckConstraint_FullMethodName = "/semver.v1.VersionService/CheckConstraint"
+	VersionService_MaxSatisfying_FullMethodName   = "/semver.v1.VersionService/MaxSatisfying"
+)
+
+// VersionServiceClient is the client API for VersionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// VersionService exposes the version operations of the semver command.
+// The rules are those of the Go packages: versions are compared by SemVer
+// 2.0.0 precedence (or the named scheme) and constraints follow the npm,
+// ruby, nuget, terraform or helm dialect, including their pre-release
+// rules.
+type VersionServiceClient interface {
+	// Compare orders two versions.
+	Compare(ctx context.Context, in *CompareRequest, opts ...grpc.CallOption) (*CompareResponse, error)
+	// Sort sorts versions; it fails with INVALID_ARGUMENT if any is invalid.
+	Sort(ctx context.Context, in *SortRequest, opts ...grpc.CallOption) (*SortResponse, error)
+	// CheckConstraint reports whether a version satisfies a constraint.
+	CheckConstraint(ctx context.Context, in *CheckConstraintRequest, opts ...grpc.CallOption) (*CheckConstraintResponse, error)
+	// MaxSatisfying selects the highest version satisfying a constraint.
+	MaxSatisfying(ctx context.Context, in *MaxSatisfyingRequest, opts ...grpc.CallOption) (*MaxSatisfyingResponse, error)
+}
+
+type versionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVersionServiceClient(cc grpc.ClientConnInterface) VersionServiceClient {
+	return &versionServiceClient{cc}
+}
+
+func (c *versionServiceClient) Compare(ctx context.Context, in *CompareRequest, opts ...grpc.CallOption) (*CompareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompareResponse)
+	err := c.cc.Invoke(ctx, VersionService_Compare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *versionServiceClient) Sort(ctx context.Context, in *SortRequest, opts ...grpc.CallOption) (*SortResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SortResponse)
+	err := c.cc.Invoke(ctx, VersionService_Sort_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *versionServiceClient) CheckConstraint(ctx context.Context, in *CheckConstraintRequest, opts ...grpc.CallOption) (*CheckConstraintResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckConstraintResponse)
+	err := c.cc.Invoke(ctx, VersionService_CheckConstraint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *versionServiceClient) MaxSatisfying(ctx context.Context, in *MaxSatisfyingRequest, opts ...grpc.CallOption) (*MaxSatisfyingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MaxSatisfyingResponse)
+	err := c.cc.Invoke(ctx, VersionService_MaxSatisfying_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VersionServiceServer is the server API for VersionService service.
+// All implementations must embed UnimplementedVersionServiceServer
+// for forward compatibility.
+//
+// VersionService exposes the version operations of the semver command.
+// The rules are those of the Go packages: versions are compared by SemVer
+// 2.0.0 precedence (or the named scheme) and constraints follow the npm,
+// ruby, nuget, terraform or helm dialect, including their pre-release
+// rules.
+type VersionServiceServer interface {
+	// Compare orders two versions.
+	Compare(context.Context, *CompareRequest) (*CompareResponse, error)
+	// Sort sorts versions; it fails with INVALID_ARGUMENT if any is invalid.
+	Sort(context.Context, *SortRequest) (*SortResponse, error)
+	// CheckConstraint reports whether a version satisfies a constraint.
+	CheckConstraint(context.Context, *CheckConstraintRequest) (*CheckConstraintResponse, error)
+	// MaxSatisfying selects the highest version satisfying a constraint.
+	MaxSatisfying(context.Context, *MaxSatisfyingRequest) (*MaxSatisfyingResponse, error)
+	mustEmbedUnimplementedVersionServiceServer()
+}
+
+// UnimplementedVersionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedVersionServiceServer struct{}
+
+func (UnimplementedVersionServiceServer) Compare(context.Context, *CompareRequest) (*CompareResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Compare not implemented")
+}
+func (UnimplementedVersionServiceServer) Sort(context.Context, *SortRequest) (*SortResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Sort not implemented")
+}
+func (UnimplementedVersionServiceServer) CheckConstraint(context.Context, *CheckConstraintRequest) (*CheckConstraintResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CheckConstraint not implemented")
+}
+func (UnimplementedVersionServiceServer) MaxSatisfying(context.Context, *MaxSatisfyingRequest) (*MaxSatisfyingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MaxSatisfying not implemented")
+}
+func (UnimplementedVersionServiceServer) mustEmbedUnimplementedVersionServiceServer() {}
+func (UnimplementedVersionServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeVersionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VersionServiceServer will
+// result in compilation errors.
+type UnsafeVersionServiceServer interface {
+	mustEmbedUnimplementedVersionServiceServer()
+}
+
+func RegisterVersionServiceServer(s grpc.ServiceRegistrar, srv VersionServiceServer) {
+	// If the following call panics, it indicates UnimplementedVersionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&VersionService_ServiceDesc, srv)
+}
+
+func _VersionService_Compare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VersionServiceServer).Compare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VersionService_Compare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VersionServiceServer).Compare(ctx, req.(*CompareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VersionService_Sort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VersionServiceServer).Sort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VersionService_Sort_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VersionServiceServer).Sort(ctx, req.(*SortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VersionService_CheckConstraint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckConstraintRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VersionServiceServer).CheckConstraint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VersionService_CheckConstraint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VersionServiceServer).CheckConstraint(ctx, req.(*CheckConstraintRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VersionService_MaxSatisfying_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MaxSatisfyingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VersionServiceServer).MaxSatisfying(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VersionService_MaxSatisfying_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VersionServiceServer).MaxSatisfying(ctx, req.(*MaxSatisfyingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// VersionService_ServiceDesc is the grpc.ServiceDesc for VersionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var VersionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "semver.v1.VersionService",
+	HandlerType: (*VersionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Compare",
+			Handler:    _VersionService_Compare_Handler,
+		},
+		{
+			MethodName: "Sort",
+			Handler:    _VersionService_Sort_Handler,
+		},
+		{
+			MethodName: "CheckConstraint",
+			Handler:    _VersionService_CheckConstraint_Handler,
+		},
+		{
+			MethodName: "MaxSatisfying",
+			Handler:    _VersionService_MaxSatisfying_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "semver/v1/version_service.proto",
+}
@@ -0,0 +1,98 @@
+// Package rpc serves the operations of package service over gRPC as the
+// VersionService defined in proto/semver/v1/version_service.proto. The
+// generated client is in package semverpb.
+//
+// It is a separate module so that users of the version packages do not
+// depend on gRPC.
+package rpc
+
+//go:generate buf generate
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/rpc/semverpb"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/service"
+)
+
+// Server implements semverpb.VersionServiceServer. Invalid versions and
+// constraints are reported with code InvalidArgument.
+type Server struct {
+	semverpb.UnimplementedVersionServiceServer
+}
+
+// Register registers a Server with s.
+func Register(s grpc.ServiceRegistrar) {
+	semverpb.RegisterVersionServiceServer(s, &Server{})
+}
+
+func (*Server) Compare(_ context.Context, req *semverpb.CompareRequest) (*semverpb.CompareResponse, error) {
+	resp, err := service.Compare(service.CompareRequest{A: req.GetA(), B: req.GetB(), Scheme: req.GetScheme()})
+	if err != nil {
+		return nil, invalid(err)
+	}
+	return &semverpb.CompareResponse{Result: int32(resp.Result)}, nil
+}
+
+func (*Server) Sort(_ context.Context, req *semverpb.SortRequest) (*semverpb.SortResponse, error) {
+	resp, err := service.Sort(service.SortRequest{
+		Versions: req.GetVersions(),
+		Scheme:   req.GetScheme(),
+		Reverse:  req.GetReverse(),
+	})
+	if err != nil {
+		return nil, invalid(err)
+	}
+	return &semverpb.SortResponse{Versions: resp.Versions}, nil
+}
+
+func (*Server) CheckConstraint(_ context.Context, req *semverpb.CheckConstraintRequest) (*semverpb.CheckConstraintResponse, error) {
+	resp, err := service.Satisfies(service.SatisfiesRequest{
+		Version:           req.GetVersion(),
+		Constraint:        req.GetConstraint(),
+		Dialect:           req.GetDialect(),
+		IncludePrerelease: req.GetIncludePrerelease(),
+	})
+	if err != nil {
+		return nil, invalid(err)
+	}
+	return &semverpb.CheckConstraintResponse{Satisfies: resp.Satisfies}, nil
+}
+
+func (*Server) MaxSatisfying(_ context.Context, req *semverpb.MaxSatisfyingRequest) (*semverpb.MaxSatisfyingResponse, error) {
+	resp, err := service.MaxSatisfying(service.MaxSatisfyingRequest{
+		Versions:          req.GetVersions(),
+		Constraint:        req.GetConstraint(),
+		Dialect:           req.GetDialect(),
+		IncludePrerelease: req.GetIncludePrerelease(),
+	})
+	if err != nil {
+		return nil, invalid(err)
+	}
+	return &semverpb.MaxSatisfyingResponse{Version: resp.Version, Found: resp.Found}, nil
+}
+
+// UnaryInterceptor records every call in m under the "grpc" protocol,
+// with the method name as the operation and the status code as the code,
+// as service.Handler does for HTTP requests:
+//
+//	srv := grpc.NewServer(grpc.UnaryInterceptor(rpc.UnaryInterceptor(service.DefaultMetrics)))
+func UnaryInterceptor(m *service.Metrics) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		code := status.Code(err)
+		m.Observe("grpc", path.Base(info.FullMethod), code.String(), time.Since(start), code == codes.InvalidArgument)
+		return resp, err
+	}
+}
+
+func invalid(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
@@ -0,0 +1,122 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+// Policy maps components to the version ranges they are allowed in.
+type Policy struct {
+	// Dialect is the constraint syntax of Allowed.
+	Dialect semver.Dialect
+	// Allowed maps a component name, or a package URL without version
+	// such as "pkg:npm/lodash", to the constraint its version must
+	// satisfy.
+	Allowed map[string]semver.Constraint
+}
+
+// ParsePolicy parses a JSON policy of the form
+//
+//	{"dialect": "npm", "allowed": {"lodash": ">=4.17.21", "pkg:golang/golang.org/x/net": ">=0.23.0"}}
+//
+// The dialect is optional and defaults to npm.
+func ParsePolicy(data []byte) (*Policy, error) {
+	var raw struct {
+		Dialect string            `json:"dialect"`
+		Allowed map[string]string `json:"allowed"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("sbom: policy: %v", err)
+	}
+	p := &Policy{Allowed: make(map[string]semver.Constraint, len(raw.Allowed))}
+	if raw.Dialect != "" {
+		d, err := semver.ParseDialect(raw.Dialect)
+		if err != nil {
+			return nil, fmt.Errorf("sbom: policy: %v", err)
+		}
+		p.Dialect = d
+	}
+	for name, s := range raw.Allowed {
+		con, err := semver.ParseConstraint(s, semver.WithDialect(p.Dialect))
+		if err != nil {
+			return nil, fmt.Errorf("sbom: policy: %s: %v", name, err)
+		}
+		p.Allowed[name] = con
+	}
+	return p, nil
+}
+
+// constraint returns the constraint that applies to c and the policy key
+// it was found under. Package URLs take precedence over names.
+func (p *Policy) constraint(c Component) (semver.Constraint, string, bool) {
+	if c.PURL != "" {
+		key := purlBase(c.PURL)
+		if con, ok := p.Allowed[key]; ok {
+			return con, key, true
+		}
+	}
+	con, ok := p.Allowed[c.Name]
+	return con, c.Name, ok
+}
+
+// Violation reasons.
+const (
+	ReasonInvalid    = "invalid-version"
+	ReasonNotAllowed = "not-allowed"
+)
+
+// Violation is a component whose version breaks the policy.
+type Violation struct {
+	Component
+	// Rule is the policy key that matched the component.
+	Rule       string `json:"rule"`
+	Constraint string `json:"constraint"`
+	Reason     string `json:"reason"`
+	// Error explains an invalid version.
+	Error string `json:"error,omitempty"`
+}
+
+// Report is the result of an audit.
+type Report struct {
+	// Components is the number of components in the SBOM and Checked the
+	// number the policy applied to.
+	Components int         `json:"components"`
+	Checked    int         `json:"checked"`
+	Violations []Violation `json:"violations"`
+}
+
+// Audit checks every component the policy names against its allowed
+// range. Versions are parsed with semver.ParseTolerant, so "v1.2" and
+// "1.2" are accepted; a component the policy names whose version is
+// missing or cannot be parsed is a violation. Components the policy does
+// not name are counted but not checked. Violations are sorted by
+// component name.
+func Audit(cs []Component, p *Policy) Report {
+	r := Report{Components: len(cs), Violations: []Violation{}}
+	for _, c := range cs {
+		con, rule, ok := p.constraint(c)
+		if !ok {
+			continue
+		}
+		r.Checked++
+		viol := Violation{Component: c, Rule: rule, Constraint: con.String()}
+		v, err := semver.ParseTolerant(c.Version)
+		switch {
+		case err != nil:
+			viol.Reason, viol.Error = ReasonInvalid, err.Error()
+		case !con.Check(v):
+			viol.Reason = ReasonNotAllowed
+		default:
+			continue
+		}
+		r.Violations = append(r.Violations, viol)
+	}
+	slices.SortStableFunc(r.Violations, func(a, b Violation) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return r
+}
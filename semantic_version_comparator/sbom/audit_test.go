@@ -0,0 +1,75 @@
+package sbom
+
+import (
+	"testing"
+)
+
+func TestParsePolicy(t *testing.T) {
+	p, err := ParsePolicy([]byte(`{"dialect": "terraform", "allowed": {"net": "~> 0.23"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := p.Allowed["net"].String(); got != "~> 0.23" {
+		t.Errorf("Allowed[net] = %q; want %q", got, "~> 0.23")
+	}
+	for _, doc := range []string{
+		`{`,
+		`{"dialect": "cobol"}`,
+		`{"allowed": {"net": ">="}}`,
+		`{"allowed": {"net": 1}}`,
+	} {
+		if _, err := ParsePolicy([]byte(doc)); err == nil {
+			t.Errorf("ParsePolicy(%q) succeeded", doc)
+		}
+	}
+}
+
+func TestAudit(t *testing.T) {
+	p, err := ParsePolicy([]byte(`{"allowed": {
+		"lodash": ">=4.17.21",
+		"pkg:npm/lodash": "^4.17.0",
+		"core": ">=2.0.0",
+		"zlib": ">=1.3.0",
+		"nested": "<1.0.0",
+		"unversioned": "*"
+	}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cs := []Component{
+		{Name: "zlib", Version: "1.2.13"},
+		{Name: "lodash", Version: "4.17.20", PURL: "pkg:npm/lodash@4.17.20?arch=any"},
+		{Name: "lodash", Version: "4.17.20"},
+		{Name: "core", Version: "v2"},
+		{Name: "nested", Version: "v1.2.3"},
+		{Name: "unversioned"},
+		{Name: "other", Version: "0.0.1"},
+	}
+	r := Audit(cs, p)
+	if r.Components != 7 || r.Checked != 6 {
+		t.Errorf("Audit counted %d components, %d checked; want 7, 6", r.Components, r.Checked)
+	}
+	want := []struct {
+		name, rule, reason string
+	}{
+		{"lodash", "lodash", ReasonNotAllowed},
+		{"nested", "nested", ReasonNotAllowed},
+		{"unversioned", "unversioned", ReasonInvalid},
+		{"zlib", "zlib", ReasonNotAllowed},
+	}
+	if len(r.Violations) != len(want) {
+		t.Fatalf("Violations = %+v; want %d", r.Violations, len(want))
+	}
+	for i, v := range r.Violations {
+		w := want[i]
+		if v.Name != w.name || v.Rule != w.rule || v.Reason != w.reason || (v.Reason == ReasonInvalid) != (v.Error != "") {
+			t.Errorf("Violations[%d] = %+v; want %s under %s, %s", i, v, w.name, w.rule, w.reason)
+		}
+	}
+	if c := r.Violations[0].Constraint; c != ">=4.17.21" {
+		t.Errorf("Violations[0].Constraint = %q; want %q", c, ">=4.17.21")
+	}
+	if r := Audit(nil, p); r.Violations == nil || r.Components != 0 {
+		t.Errorf("Audit(nil) = %+v; want no components and an empty list of violations", r)
+	}
+}
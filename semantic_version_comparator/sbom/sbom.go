@@ -0,0 +1,117 @@
+// Package sbom extracts component versions from CycloneDX and SPDX JSON
+// software bills of materials and audits them against allowed version
+// ranges.
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Format names of the supported SBOM documents.
+const (
+	CycloneDX = "CycloneDX"
+	SPDX      = "SPDX"
+)
+
+// Component is a package listed in an SBOM.
+type Component struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	// PURL is the component's package URL, if the SBOM records one.
+	PURL string `json:"purl,omitempty"`
+}
+
+// Parse detects the format of a CycloneDX or SPDX JSON document and
+// returns its components, including those nested inside other CycloneDX
+// components. It returns the detected format.
+func Parse(data []byte) ([]Component, string, error) {
+	var probe struct {
+		BOMFormat   string `json:"bomFormat"`
+		SPDXVersion string `json:"spdxVersion"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, "", fmt.Errorf("sbom: %v", err)
+	}
+	switch {
+	case probe.BOMFormat == CycloneDX:
+		cs, err := parseCycloneDX(data)
+		return cs, CycloneDX, err
+	case strings.HasPrefix(probe.SPDXVersion, "SPDX-"):
+		cs, err := parseSPDX(data)
+		return cs, SPDX, err
+	}
+	return nil, "", fmt.Errorf("sbom: not a CycloneDX or SPDX JSON document")
+}
+
+type cdxComponent struct {
+	Name       string         `json:"name"`
+	Group      string         `json:"group"`
+	Version    string         `json:"version"`
+	PURL       string         `json:"purl"`
+	Components []cdxComponent `json:"components"`
+}
+
+func parseCycloneDX(data []byte) ([]Component, error) {
+	var doc struct {
+		Components []cdxComponent `json:"components"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("sbom: CycloneDX: %v", err)
+	}
+	var cs []Component
+	var walk func([]cdxComponent)
+	walk = func(list []cdxComponent) {
+		for _, c := range list {
+			name := c.Name
+			if c.Group != "" {
+				name = c.Group + "/" + c.Name
+			}
+			cs = append(cs, Component{Name: name, Version: c.Version, PURL: c.PURL})
+			walk(c.Components)
+		}
+	}
+	walk(doc.Components)
+	return cs, nil
+}
+
+func parseSPDX(data []byte) ([]Component, error) {
+	var doc struct {
+		Packages []struct {
+			Name         string `json:"name"`
+			VersionInfo  string `json:"versionInfo"`
+			ExternalRefs []struct {
+				Type    string `json:"referenceType"`
+				Locator string `json:"referenceLocator"`
+			} `json:"externalRefs"`
+		} `json:"packages"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("sbom: SPDX: %v", err)
+	}
+	cs := make([]Component, 0, len(doc.Packages))
+	for _, p := range doc.Packages {
+		c := Component{Name: p.Name, Version: p.VersionInfo}
+		for _, ref := range p.ExternalRefs {
+			if ref.Type == "purl" {
+				c.PURL = ref.Locator
+				break
+			}
+		}
+		cs = append(cs, c)
+	}
+	return cs, nil
+}
+
+// purlBase returns a package URL without its version, qualifiers and
+// subpath: "pkg:npm/lodash@4.17.21?x=y" becomes "pkg:npm/lodash".
+func purlBase(purl string) string {
+	if i := strings.IndexAny(purl, "?#"); i >= 0 {
+		purl = purl[:i]
+	}
+	if i := strings.LastIndexByte(purl, '@'); i >= 0 {
+		purl = purl[:i]
+	}
+	return purl
+}
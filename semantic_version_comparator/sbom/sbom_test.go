@@ -0,0 +1,85 @@
+package sbom
+
+import (
+	"slices"
+	"testing"
+)
+
+const cycloneDX = `{
+	"bomFormat": "CycloneDX",
+	"specVersion": "1.5",
+	"components": [
+		{"name": "lodash", "version": "4.17.20", "purl": "pkg:npm/lodash@4.17.20"},
+		{"group": "org.example", "name": "core", "version": "2.0", "components": [
+			{"name": "nested", "version": "v1.2.3"}
+		]}
+	]
+}`
+
+const spdx = `{
+	"spdxVersion": "SPDX-2.3",
+	"packages": [
+		{"name": "net", "versionInfo": "0.22.0", "externalRefs": [
+			{"referenceType": "cpe23Type", "referenceLocator": "cpe:2.3:a:golang:net"},
+			{"referenceType": "purl", "referenceLocator": "pkg:golang/golang.org/x/net@0.22.0"}
+		]},
+		{"name": "unversioned"}
+	]
+}`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		doc    string
+		format string
+		want   []Component
+	}{
+		{cycloneDX, CycloneDX, []Component{
+			{Name: "lodash", Version: "4.17.20", PURL: "pkg:npm/lodash@4.17.20"},
+			{Name: "org.example/core", Version: "2.0"},
+			{Name: "nested", Version: "v1.2.3"},
+		}},
+		{spdx, SPDX, []Component{
+			{Name: "net", Version: "0.22.0", PURL: "pkg:golang/golang.org/x/net@0.22.0"},
+			{Name: "unversioned"},
+		}},
+		{`{"bomFormat": "CycloneDX"}`, CycloneDX, nil},
+	}
+	for _, tt := range tests {
+		got, format, err := Parse([]byte(tt.doc))
+		if err != nil || format != tt.format || !slices.Equal(got, tt.want) {
+			t.Errorf("Parse(%.30q) = %v, %q, %v; want %v, %q", tt.doc, got, format, err, tt.want, tt.format)
+		}
+	}
+}
+
+func TestParseError(t *testing.T) {
+	for _, doc := range []string{
+		``,
+		`[]`,
+		`{"bomFormat": "other"}`,
+		`{"spdxVersion": "2.3"}`,
+		`{"bomFormat": "CycloneDX", "components": {}}`,
+		`{"spdxVersion": "SPDX-2.3", "packages": [{"name": 1}]}`,
+	} {
+		if cs, format, err := Parse([]byte(doc)); err == nil {
+			t.Errorf("Parse(%q) = %v, %q; want an error", doc, cs, format)
+		}
+	}
+}
+
+func TestPURLBase(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"pkg:npm/lodash@4.17.21", "pkg:npm/lodash"},
+		{"pkg:npm/lodash@4.17.21?x=y#sub", "pkg:npm/lodash"},
+		{"pkg:npm/%40scope/pkg@1.0.0", "pkg:npm/%40scope/pkg"},
+		{"pkg:golang/golang.org/x/net", "pkg:golang/golang.org/x/net"},
+		{"pkg:maven/org.example/core?type=jar", "pkg:maven/org.example/core"},
+	}
+	for _, tt := range tests {
+		if got := purlBase(tt.in); got != tt.want {
+			t.Errorf("purlBase(%q) = %q; want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package scheme
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Constraint is a version requirement evaluated under a scheme, for
+// schemes whose ecosystems have no range syntax of their own. It is a
+// list of comparators =, !=, >, >=, < and <= (a bare version means =),
+// separated by commas or spaces, that must all hold; alternatives are
+// separated by "||". For example "deb" accepts ">= 1:2.0~rc1, < 1:3".
+type Constraint struct {
+	sch    Scheme
+	raw    string
+	groups [][]schemeComparator
+}
+
+type schemeComparator struct {
+	op string
+	v  string
+}
+
+var schemeOperators = []string{">=", "<=", "!=", ">", "<", "="}
+
+// ParseConstraint parses s as a constraint on versions of sch. Every
+// version in s must be valid in sch.
+func ParseConstraint(sch Scheme, s string) (Constraint, error) {
+	c := Constraint{sch: sch, raw: s}
+	for _, alt := range strings.Split(s, "||") {
+		fields := strings.Fields(strings.ReplaceAll(alt, ",", " "))
+		if len(fields) == 0 {
+			return Constraint{}, fmt.Errorf("scheme: constraint %q: empty alternative", s)
+		}
+		var group []schemeComparator
+		for i := 0; i < len(fields); i++ {
+			op, v := "=", fields[i]
+			for _, o := range schemeOperators {
+				if strings.HasPrefix(v, o) {
+					op, v = o, v[len(o):]
+					break
+				}
+			}
+			// Allow a space between an operator and its version.
+			if v == "" && i+1 < len(fields) {
+				i++
+				v = fields[i]
+			}
+			if v == "" {
+				return Constraint{}, fmt.Errorf("scheme: constraint %q: operator %s without version", s, op)
+			}
+			if err := sch.Validate(v); err != nil {
+				return Constraint{}, fmt.Errorf("scheme: constraint %q: %v", s, err)
+			}
+			group = append(group, schemeComparator{op: op, v: v})
+		}
+		c.groups = append(c.groups, group)
+	}
+	return c, nil
+}
+
+// Check reports whether v satisfies c. It returns an error if v is not a
+// valid version of c's scheme.
+func (c Constraint) Check(v string) (bool, error) {
+	if err := c.sch.Validate(v); err != nil {
+		return false, err
+	}
+	for _, group := range c.groups {
+		ok := true
+		for _, cmp := range group {
+			if !cmp.check(c.sch, v) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (cmp schemeComparator) check(sch Scheme, v string) bool {
+	// Both versions were validated.
+	n, _ := sch.Compare(v, cmp.v)
+	switch cmp.op {
+	case ">=":
+		return n >= 0
+	case "<=":
+		return n <= 0
+	case "!=":
+		return n != 0
+	case ">":
+		return n > 0
+	case "<":
+		return n < 0
+	}
+	return n == 0
+}
+
+// String returns the constraint as it was written.
+func (c Constraint) String() string {
+	return c.raw
+}
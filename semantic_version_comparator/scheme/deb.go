@@ -0,0 +1,194 @@
+package scheme
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Deb is the Debian package version scheme,
+// [epoch:]upstream_version[-debian_revision], ordered as dpkg does: "~"
+// sorts before everything, even the end of the string, so 1.0~rc1 comes
+// before 1.0.
+var Deb Scheme = debScheme{}
+
+type debScheme struct{}
+
+func (debScheme) Name() string { return "deb" }
+
+func (debScheme) Validate(s string) error {
+	_, err := ParseDeb(s)
+	return err
+}
+
+func (debScheme) Canonical(s string) (string, error) {
+	v, err := ParseDeb(s)
+	if err != nil {
+		return "", err
+	}
+	return v.String(), nil
+}
+
+func (debScheme) Compare(a, b string) (int, error) {
+	va, err := ParseDeb(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := ParseDeb(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.Compare(vb), nil
+}
+
+func (debScheme) sort(ss []string) error {
+	return sortParsed(ss, ParseDeb, DebVersion.Compare)
+}
+
+// DebVersion is a parsed Debian package version.
+type DebVersion struct {
+	Epoch    uint64
+	Upstream string
+	Revision string // empty if the version has no revision
+}
+
+// ParseDeb parses a Debian package version.
+func ParseDeb(s string) (DebVersion, error) {
+	var v DebVersion
+	rest := s
+	if i := strings.IndexByte(rest, ':'); i >= 0 {
+		epoch, err := strconv.ParseUint(rest[:i], 10, 64)
+		if err != nil {
+			return DebVersion{}, fmt.Errorf("scheme: deb version %q: invalid epoch %q", s, rest[:i])
+		}
+		v.Epoch = epoch
+		rest = rest[i+1:]
+	}
+	if i := strings.LastIndexByte(rest, '-'); i >= 0 {
+		v.Revision = rest[i+1:]
+		rest = rest[:i]
+		if v.Revision == "" {
+			return DebVersion{}, fmt.Errorf("scheme: deb version %q: empty revision", s)
+		}
+		for j := 0; j < len(v.Revision); j++ {
+			if c := v.Revision[j]; !isAlnum(c) && !strings.ContainsRune("+.~", rune(c)) {
+				return DebVersion{}, fmt.Errorf("scheme: deb version %q: invalid character %q in revision", s, c)
+			}
+		}
+	}
+	v.Upstream = rest
+	if rest == "" || !isDigit(rest[0]) {
+		return DebVersion{}, fmt.Errorf("scheme: deb version %q: upstream version must start with a digit", s)
+	}
+	for j := 0; j < len(rest); j++ {
+		if c := rest[j]; !isAlnum(c) && !strings.ContainsRune("+.~-", rune(c)) {
+			return DebVersion{}, fmt.Errorf("scheme: deb version %q: invalid character %q in upstream version", s, c)
+		}
+	}
+	return v, nil
+}
+
+// String returns the version in dpkg's canonical form, omitting a zero
+// epoch.
+func (v DebVersion) String() string {
+	s := v.Upstream
+	if v.Epoch != 0 {
+		s = strconv.FormatUint(v.Epoch, 10) + ":" + s
+	}
+	if v.Revision != "" {
+		s += "-" + v.Revision
+	}
+	return s
+}
+
+// Compare orders v and o by epoch, then upstream version, then revision.
+// A missing revision compares equal to "0".
+func (v DebVersion) Compare(o DebVersion) int {
+	switch {
+	case v.Epoch < o.Epoch:
+		return -1
+	case v.Epoch > o.Epoch:
+		return 1
+	}
+	if c := verrevcmp(v.Upstream, o.Upstream); c != 0 {
+		return c
+	}
+	return verrevcmp(v.Revision, o.Revision)
+}
+
+// debOrder returns the sort weight of c in a non-digit run: letters sort
+// before other characters, "~" before everything including the end of the
+// string (c == 0).
+func debOrder(c byte) int {
+	switch {
+	case isDigit(c):
+		return 0
+	case isAlpha(c):
+		return int(c)
+	case c == '~':
+		return -1
+	case c != 0:
+		return int(c) + 256
+	}
+	return 0
+}
+
+// verrevcmp is dpkg's version fragment comparison: alternating non-digit
+// runs compared by debOrder and digit runs compared numerically.
+func verrevcmp(a, b string) int {
+	at := func(s string, i int) byte {
+		if i < len(s) {
+			return s[i]
+		}
+		return 0
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for i < len(a) && !isDigit(a[i]) || j < len(b) && !isDigit(b[j]) {
+			ac, bc := debOrder(at(a, i)), debOrder(at(b, j))
+			if ac != bc {
+				return sign(ac - bc)
+			}
+			i++
+			j++
+		}
+		for i < len(a) && a[i] == '0' {
+			i++
+		}
+		for j < len(b) && b[j] == '0' {
+			j++
+		}
+		firstDiff := 0
+		for i < len(a) && isDigit(a[i]) && j < len(b) && isDigit(b[j]) {
+			if firstDiff == 0 {
+				firstDiff = int(a[i]) - int(b[j])
+			}
+			i++
+			j++
+		}
+		if i < len(a) && isDigit(a[i]) {
+			return 1
+		}
+		if j < len(b) && isDigit(b[j]) {
+			return -1
+		}
+		if firstDiff != 0 {
+			return sign(firstDiff)
+		}
+	}
+	return 0
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+func isAlpha(c byte) bool { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' }
+func isAlnum(c byte) bool { return isDigit(c) || isAlpha(c) }
@@ -0,0 +1,41 @@
+package scheme
+
+import "github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+
+// Kubernetes is the scheme of Kubernetes release versions, including
+// vendor builds such as "v1.27.3-gke.100" and the "1.28+" form reported
+// by kubectl. See semver.ParseKubernetes.
+var Kubernetes Scheme = kubernetesScheme{}
+
+type kubernetesScheme struct{}
+
+func (kubernetesScheme) Name() string { return "kubernetes" }
+
+func (kubernetesScheme) Validate(s string) error {
+	_, err := semver.ParseKubernetes(s)
+	return err
+}
+
+func (kubernetesScheme) Canonical(s string) (string, error) {
+	v, err := semver.ParseKubernetes(s)
+	if err != nil {
+		return "", err
+	}
+	return v.String(), nil
+}
+
+func (kubernetesScheme) Compare(a, b string) (int, error) {
+	va, err := semver.ParseKubernetes(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := semver.ParseKubernetes(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.Compare(vb), nil
+}
+
+func (kubernetesScheme) sort(ss []string) error {
+	return sortParsed(ss, semver.ParseKubernetes, semver.KubeVersion.Compare)
+}
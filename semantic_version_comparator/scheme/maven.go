@@ -0,0 +1,417 @@
+package scheme
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Maven is the Maven artifact version scheme, ordered like Maven's
+// ComparableVersion: versions are split into numeric and qualifier tokens
+// on ".", "-" and digit/letter transitions, and the qualifiers alpha,
+// beta, milestone, rc, snapshot, the release itself and sp sort in that
+// order, before any unknown qualifier.
+var Maven Scheme = mavenScheme{}
+
+type mavenScheme struct{}
+
+func (mavenScheme) Name() string { return "maven" }
+
+func (mavenScheme) Validate(s string) error {
+	_, err := ParseMaven(s)
+	return err
+}
+
+func (mavenScheme) Canonical(s string) (string, error) {
+	v, err := ParseMaven(s)
+	if err != nil {
+		return "", err
+	}
+	return v.Canonical(), nil
+}
+
+func (mavenScheme) Compare(a, b string) (int, error) {
+	va, err := ParseMaven(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := ParseMaven(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.Compare(vb), nil
+}
+
+func (mavenScheme) sort(ss []string) error {
+	return sortParsed(ss, ParseMaven, MavenVersion.Compare)
+}
+
+// MavenVersion is a parsed Maven artifact version.
+type MavenVersion struct {
+	raw   string
+	items []mavenItem
+}
+
+type mavenKind int
+
+const (
+	mavenInt mavenKind = iota
+	mavenString
+	mavenList
+)
+
+// mavenItem is a token of a Maven version. Integers hold their digits
+// without leading zeros (zero is ""), strings hold the lower-case,
+// de-aliased qualifier (the release itself is "") and lists hold the
+// tokens following a "-" or a digit/letter transition.
+type mavenItem struct {
+	kind mavenKind
+	s    string
+	list []mavenItem
+}
+
+// mavenQualifiers lists the well-known qualifiers in ascending order. The
+// empty string is the release itself.
+var mavenQualifiers = []string{"alpha", "beta", "milestone", "rc", "snapshot", "", "sp"}
+
+var mavenAliases = map[string]string{"ga": "", "final": "", "release": "", "cr": "rc"}
+
+// ParseMaven parses a Maven version. Maven accepts almost any string as a
+// version; ParseMaven rejects only the empty string and strings containing
+// whitespace or the range characters "[](),".
+func ParseMaven(s string) (MavenVersion, error) {
+	if s == "" {
+		return MavenVersion{}, fmt.Errorf("scheme: empty maven version")
+	}
+	if i := strings.IndexAny(s, " \t\r\n[](),"); i >= 0 {
+		return MavenVersion{}, fmt.Errorf("scheme: maven version %q: invalid character %q", s, s[i])
+	}
+	return MavenVersion{raw: s, items: parseMavenItems(strings.ToLower(s))}, nil
+}
+
+func parseMavenItems(s string) []mavenItem {
+	// stack holds the open lists; each new list is appended to its parent
+	// once it is complete, after trailing null items are dropped.
+	stack := [][]mavenItem{nil}
+	add := func(it mavenItem) {
+		stack[len(stack)-1] = append(stack[len(stack)-1], it)
+	}
+	push := func() { stack = append(stack, nil) }
+	digit, start := false, 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '.' || c == '-':
+			if i == start {
+				add(mavenItem{kind: mavenInt})
+			} else {
+				add(newMavenItem(digit, s[start:i], false))
+			}
+			start = i + 1
+			if c == '-' {
+				push()
+			}
+		case isDigit(c):
+			if !digit && i > start {
+				add(newMavenItem(false, s[start:i], true))
+				start = i
+				push()
+			}
+			digit = true
+		default:
+			if digit && i > start {
+				add(newMavenItem(true, s[start:i], false))
+				start = i
+				push()
+			}
+			digit = false
+		}
+	}
+	if len(s) > start {
+		add(newMavenItem(digit, s[start:], false))
+	}
+	for len(stack) > 1 {
+		list := normalizeMaven(stack[len(stack)-1])
+		stack = stack[:len(stack)-1]
+		add(mavenItem{kind: mavenList, list: list})
+	}
+	return normalizeMaven(stack[0])
+}
+
+// newMavenItem returns the token for s. A single letter a, b or m directly
+// followed by a digit abbreviates alpha, beta or milestone.
+func newMavenItem(digit bool, s string, followedByDigit bool) mavenItem {
+	if digit {
+		return mavenItem{kind: mavenInt, s: strings.TrimLeft(s, "0")}
+	}
+	if followedByDigit && len(s) == 1 {
+		switch s {
+		case "a":
+			s = "alpha"
+		case "b":
+			s = "beta"
+		case "m":
+			s = "milestone"
+		}
+	}
+	if alias, ok := mavenAliases[s]; ok {
+		s = alias
+	}
+	return mavenItem{kind: mavenString, s: s}
+}
+
+// normalizeMaven drops trailing null items (zero, the release qualifier
+// and empty lists), stopping at the first non-null, non-list item.
+func normalizeMaven(items []mavenItem) []mavenItem {
+	for i := len(items) - 1; i >= 0; i-- {
+		if items[i].isNull() {
+			items = append(items[:i], items[i+1:]...)
+		} else if items[i].kind != mavenList {
+			break
+		}
+	}
+	return items
+}
+
+func (it mavenItem) isNull() bool {
+	if it.kind == mavenList {
+		return len(it.list) == 0
+	}
+	return it.s == ""
+}
+
+// String returns the version as it was written.
+func (v MavenVersion) String() string {
+	return v.raw
+}
+
+// Canonical returns v in the normalized form of Maven's
+// ComparableVersion, in which versions that compare equal are spelled
+// alike: "1.0.0-GA" and "1" are both "1", "1a1" is "1-alpha-1".
+func (v MavenVersion) Canonical() string {
+	return mavenCanonical(v.items)
+}
+
+func mavenCanonical(items []mavenItem) string {
+	var b strings.Builder
+	for i, it := range items {
+		if i > 0 {
+			if it.kind == mavenList {
+				b.WriteByte('-')
+			} else {
+				b.WriteByte('.')
+			}
+		}
+		switch {
+		case it.kind == mavenList:
+			b.WriteString(mavenCanonical(it.list))
+		case it.kind == mavenInt && it.s == "":
+			b.WriteByte('0')
+		default:
+			b.WriteString(it.s)
+		}
+	}
+	return b.String()
+}
+
+// Compare returns -1, 0 or 1 as v orders before, equal to or after o.
+func (v MavenVersion) Compare(o MavenVersion) int {
+	return compareMavenLists(v.items, o.items)
+}
+
+// compareMaven compares a with b; b is nil when the other version has no
+// token at this position.
+func compareMaven(a mavenItem, b *mavenItem) int {
+	switch a.kind {
+	case mavenInt:
+		switch {
+		case b == nil:
+			if a.s == "" {
+				return 0
+			}
+			return 1
+		case b.kind == mavenInt:
+			if c := sign(len(a.s) - len(b.s)); c != 0 {
+				return c
+			}
+			return strings.Compare(a.s, b.s)
+		}
+		return 1
+	case mavenString:
+		switch {
+		case b == nil:
+			return strings.Compare(mavenQualifierKey(a.s), mavenQualifierKey(""))
+		case b.kind == mavenString:
+			return strings.Compare(mavenQualifierKey(a.s), mavenQualifierKey(b.s))
+		}
+		return -1
+	}
+	switch {
+	case b == nil:
+		if len(a.list) == 0 {
+			return 0
+		}
+		return compareMaven(a.list[0], nil)
+	case b.kind == mavenInt:
+		return -1
+	case b.kind == mavenString:
+		return 1
+	}
+	return compareMavenLists(a.list, b.list)
+}
+
+func compareMavenLists(a, b []mavenItem) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var c int
+		switch {
+		case i >= len(a):
+			c = -compareMaven(b[i], nil)
+		case i >= len(b):
+			c = compareMaven(a[i], nil)
+		default:
+			c = compareMaven(a[i], &b[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// mavenQualifierKey returns a string whose lexical order is the order of
+// qualifier q: well-known qualifiers by position, unknown ones after them
+// in lexical order.
+func mavenQualifierKey(q string) string {
+	for i, known := range mavenQualifiers {
+		if q == known {
+			return string(rune('0' + i))
+		}
+	}
+	return fmt.Sprintf("%d-%s", len(mavenQualifiers), q)
+}
+
+// MavenRange is a parsed Maven version range such as "[1.0,2.0)",
+// "(,1.5]", "[1.2]" or a union like "(,1.0],[1.2,)". A bare version such
+// as "1.0" is a soft requirement and matches every version.
+type MavenRange struct {
+	raw          string
+	restrictions []mavenRestriction
+}
+
+// mavenRestriction is one bracketed interval; a nil bound is unbounded.
+type mavenRestriction struct {
+	lo, hi       *MavenVersion
+	loInc, hiInc bool
+}
+
+// ParseMavenRange parses a Maven version range specification.
+func ParseMavenRange(s string) (MavenRange, error) {
+	r := MavenRange{raw: s}
+	rest := strings.TrimSpace(s)
+	if rest == "" {
+		return MavenRange{}, fmt.Errorf("scheme: empty maven range")
+	}
+	if rest[0] != '[' && rest[0] != '(' {
+		if _, err := ParseMaven(rest); err != nil {
+			return MavenRange{}, fmt.Errorf("scheme: invalid maven range %q: %v", s, err)
+		}
+		return r, nil
+	}
+	for rest != "" {
+		end := strings.IndexAny(rest, "])")
+		if end < 0 || rest[0] != '[' && rest[0] != '(' {
+			return MavenRange{}, fmt.Errorf("scheme: invalid maven range %q: unbalanced brackets", s)
+		}
+		res, err := parseMavenRestriction(rest[:end+1])
+		if err != nil {
+			return MavenRange{}, fmt.Errorf("scheme: invalid maven range %q: %v", s, err)
+		}
+		r.restrictions = append(r.restrictions, res)
+		rest = strings.TrimSpace(rest[end+1:])
+		if rest != "" {
+			if rest[0] != ',' {
+				return MavenRange{}, fmt.Errorf("scheme: invalid maven range %q: expected ',' between ranges", s)
+			}
+			rest = strings.TrimSpace(rest[1:])
+			if rest == "" {
+				return MavenRange{}, fmt.Errorf("scheme: invalid maven range %q: trailing ','", s)
+			}
+		}
+	}
+	return r, nil
+}
+
+func parseMavenRestriction(s string) (mavenRestriction, error) {
+	res := mavenRestriction{loInc: s[0] == '[', hiInc: s[len(s)-1] == ']'}
+	body := strings.TrimSpace(s[1 : len(s)-1])
+	bound := func(t string) (*MavenVersion, error) {
+		if t = strings.TrimSpace(t); t == "" {
+			return nil, nil
+		}
+		v, err := ParseMaven(t)
+		return &v, err
+	}
+	lo, hi, isRange := strings.Cut(body, ",")
+	if !isRange {
+		// [1.0] pins a single version.
+		if !res.loInc || !res.hiInc {
+			return res, fmt.Errorf("single version %q must use []", s)
+		}
+		v, err := bound(body)
+		if err != nil {
+			return res, err
+		}
+		if v == nil {
+			return res, fmt.Errorf("empty range %q", s)
+		}
+		res.lo, res.hi = v, v
+		return res, nil
+	}
+	var err error
+	if res.lo, err = bound(lo); err != nil {
+		return res, err
+	}
+	if res.hi, err = bound(hi); err != nil {
+		return res, err
+	}
+	switch {
+	case res.lo == nil && res.loInc, res.hi == nil && res.hiInc:
+		return res, fmt.Errorf("unbounded side of %q must use ( or )", s)
+	case res.lo != nil && res.hi != nil && res.lo.Compare(*res.hi) > 0:
+		return res, fmt.Errorf("lower bound above upper bound in %q", s)
+	}
+	return res, nil
+}
+
+// String returns the range as it was written.
+func (r MavenRange) String() string {
+	return r.raw
+}
+
+// Contains reports whether v lies within any interval of r. A soft
+// requirement contains every version.
+func (r MavenRange) Contains(v MavenVersion) bool {
+	if len(r.restrictions) == 0 {
+		return true
+	}
+	for _, res := range r.restrictions {
+		if res.contains(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (res mavenRestriction) contains(v MavenVersion) bool {
+	if res.lo != nil {
+		c := v.Compare(*res.lo)
+		if c < 0 || c == 0 && !res.loInc {
+			return false
+		}
+	}
+	if res.hi != nil {
+		c := v.Compare(*res.hi)
+		if c > 0 || c == 0 && !res.hiInc {
+			return false
+		}
+	}
+	return true
+}
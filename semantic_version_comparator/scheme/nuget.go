@@ -0,0 +1,174 @@
+package scheme
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NuGet is the NuGet package version scheme, which extends SemVer with an
+// optional fourth numeric component, Revision, as used by .NET assemblies
+// and Windows file versions: 1.2.3.4 orders before 1.2.3.10. Missing
+// components are zero, so 1.0 equals 1.0.0.0, and pre-release labels
+// compare case-insensitively.
+var NuGet Scheme = nugetScheme{}
+
+type nugetScheme struct{}
+
+func (nugetScheme) Name() string { return "nuget" }
+
+func (nugetScheme) Validate(s string) error {
+	_, err := ParseNuGet(s)
+	return err
+}
+
+func (nugetScheme) Canonical(s string) (string, error) {
+	v, err := ParseNuGet(s)
+	if err != nil {
+		return "", err
+	}
+	return v.String(), nil
+}
+
+func (nugetScheme) Compare(a, b string) (int, error) {
+	va, err := ParseNuGet(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := ParseNuGet(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.Compare(vb), nil
+}
+
+func (nugetScheme) sort(ss []string) error {
+	return sortParsed(ss, ParseNuGet, NuGetVersion.Compare)
+}
+
+// NuGetVersion is a parsed NuGet version.
+type NuGetVersion struct {
+	Major, Minor, Patch, Revision uint64
+	Prerelease                    string // dot-separated labels; empty for a release
+	Metadata                      string // ignored when comparing
+}
+
+// ParseNuGet parses a version of one to four numeric components with
+// optional "-prerelease" and "+metadata" suffixes, as NuGet does. A
+// leading "v" is not allowed; leading zeros are.
+func ParseNuGet(s string) (NuGetVersion, error) {
+	var v NuGetVersion
+	rest := s
+	if i := strings.IndexByte(rest, '+'); i >= 0 {
+		v.Metadata = rest[i+1:]
+		rest = rest[:i]
+		if err := checkNuGetLabels(v.Metadata); err != nil {
+			return NuGetVersion{}, fmt.Errorf("scheme: nuget version %q: metadata: %v", s, err)
+		}
+	}
+	if i := strings.IndexByte(rest, '-'); i >= 0 {
+		v.Prerelease = rest[i+1:]
+		rest = rest[:i]
+		if err := checkNuGetLabels(v.Prerelease); err != nil {
+			return NuGetVersion{}, fmt.Errorf("scheme: nuget version %q: pre-release: %v", s, err)
+		}
+	}
+	parts := strings.Split(rest, ".")
+	if len(parts) > 4 {
+		return NuGetVersion{}, fmt.Errorf("scheme: nuget version %q: more than four numeric components", s)
+	}
+	nums := [4]*uint64{&v.Major, &v.Minor, &v.Patch, &v.Revision}
+	for i, p := range parts {
+		if p == "" || strings.TrimLeft(p, "0123456789") != "" {
+			return NuGetVersion{}, fmt.Errorf("scheme: nuget version %q: invalid numeric component %q", s, p)
+		}
+		n, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return NuGetVersion{}, fmt.Errorf("scheme: nuget version %q: component %q overflows uint64", s, p)
+		}
+		*nums[i] = n
+	}
+	return v, nil
+}
+
+func checkNuGetLabels(s string) error {
+	for _, label := range strings.Split(s, ".") {
+		if label == "" {
+			return fmt.Errorf("empty label")
+		}
+		for j := 0; j < len(label); j++ {
+			if c := label[j]; !isAlnum(c) && c != '-' {
+				return fmt.Errorf("invalid character %q", c)
+			}
+		}
+	}
+	return nil
+}
+
+// String returns the version in NuGet's normalized form: three components,
+// plus the revision if it is not zero, without leading zeros.
+func (v NuGetVersion) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Revision != 0 {
+		s += "." + strconv.FormatUint(v.Revision, 10)
+	}
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Metadata != "" {
+		s += "+" + v.Metadata
+	}
+	return s
+}
+
+// Compare orders v and o by their numeric components, then by
+// pre-release: a release follows its pre-releases, numeric labels compare
+// numerically and before alphanumeric ones, and alphanumeric labels
+// compare case-insensitively.
+func (v NuGetVersion) Compare(o NuGetVersion) int {
+	a := [4]uint64{v.Major, v.Minor, v.Patch, v.Revision}
+	b := [4]uint64{o.Major, o.Minor, o.Patch, o.Revision}
+	for i := range a {
+		switch {
+		case a[i] < b[i]:
+			return -1
+		case a[i] > b[i]:
+			return 1
+		}
+	}
+	switch {
+	case v.Prerelease == o.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case o.Prerelease == "":
+		return -1
+	}
+	la, lb := strings.Split(v.Prerelease, "."), strings.Split(o.Prerelease, ".")
+	for i := 0; i < len(la) && i < len(lb); i++ {
+		if c := compareNuGetLabel(la[i], lb[i]); c != 0 {
+			return c
+		}
+	}
+	return sign(len(la) - len(lb))
+}
+
+func compareNuGetLabel(a, b string) int {
+	na, errA := strconv.ParseUint(a, 10, 64)
+	nb, errB := strconv.ParseUint(b, 10, 64)
+	switch {
+	case errA == nil && errB == nil:
+		switch {
+		case na < nb:
+			return -1
+		case na > nb:
+			return 1
+		}
+		return 0
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
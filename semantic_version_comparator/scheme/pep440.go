@@ -0,0 +1,39 @@
+package scheme
+
+import "github.com/ketiyohannes/sample-dataset/semantic_version_comparator/pep440"
+
+// PEP440 is the Python package version scheme defined by PEP 440.
+var PEP440 Scheme = pep440Scheme{}
+
+type pep440Scheme struct{}
+
+func (pep440Scheme) Name() string { return "pep440" }
+
+func (pep440Scheme) Validate(s string) error {
+	_, err := pep440.Parse(s)
+	return err
+}
+
+func (pep440Scheme) Canonical(s string) (string, error) {
+	v, err := pep440.Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return v.String(), nil
+}
+
+func (pep440Scheme) Compare(a, b string) (int, error) {
+	va, err := pep440.Parse(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := pep440.Parse(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.Compare(vb), nil
+}
+
+func (pep440Scheme) sort(ss []string) error {
+	return sortParsed(ss, pep440.Parse, pep440.Version.Compare)
+}
@@ -0,0 +1,217 @@
+package scheme
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RPM is the RPM package version scheme, [epoch:]version[-release],
+// ordered with rpmvercmp semantics including "~" (sorts before the end of
+// the string) and "^" (sorts after the end of the string but before any
+// further segment).
+var RPM Scheme = rpmScheme{}
+
+type rpmScheme struct{}
+
+func (rpmScheme) Name() string { return "rpm" }
+
+func (rpmScheme) Validate(s string) error {
+	_, err := ParseRPM(s)
+	return err
+}
+
+func (rpmScheme) Canonical(s string) (string, error) {
+	v, err := ParseRPM(s)
+	if err != nil {
+		return "", err
+	}
+	return v.String(), nil
+}
+
+func (rpmScheme) Compare(a, b string) (int, error) {
+	va, err := ParseRPM(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := ParseRPM(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.Compare(vb), nil
+}
+
+func (rpmScheme) sort(ss []string) error {
+	return sortParsed(ss, ParseRPM, RPMVersion.Compare)
+}
+
+// RPMVersion is a parsed RPM epoch-version-release.
+type RPMVersion struct {
+	Epoch   uint64
+	Version string
+	Release string // empty if not given
+}
+
+// ParseRPM parses an RPM [epoch:]version[-release] string.
+func ParseRPM(s string) (RPMVersion, error) {
+	var v RPMVersion
+	rest := s
+	if i := strings.IndexByte(rest, ':'); i >= 0 {
+		epoch, err := strconv.ParseUint(rest[:i], 10, 64)
+		if err != nil {
+			return RPMVersion{}, fmt.Errorf("scheme: rpm version %q: invalid epoch %q", s, rest[:i])
+		}
+		v.Epoch = epoch
+		rest = rest[i+1:]
+	}
+	if i := strings.LastIndexByte(rest, '-'); i >= 0 {
+		v.Release = rest[i+1:]
+		rest = rest[:i]
+		if err := checkRPMField(v.Release); err != nil {
+			return RPMVersion{}, fmt.Errorf("scheme: rpm version %q: release: %v", s, err)
+		}
+	}
+	v.Version = rest
+	if err := checkRPMField(v.Version); err != nil {
+		return RPMVersion{}, fmt.Errorf("scheme: rpm version %q: version: %v", s, err)
+	}
+	return v, nil
+}
+
+func checkRPMField(s string) error {
+	if s == "" {
+		return fmt.Errorf("empty")
+	}
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; !isAlnum(c) && !strings.ContainsRune("._+~^", rune(c)) {
+			return fmt.Errorf("invalid character %q", c)
+		}
+	}
+	return nil
+}
+
+// String returns the version as epoch:version-release, omitting a zero
+// epoch and an empty release.
+func (v RPMVersion) String() string {
+	s := v.Version
+	if v.Epoch != 0 {
+		s = strconv.FormatUint(v.Epoch, 10) + ":" + s
+	}
+	if v.Release != "" {
+		s += "-" + v.Release
+	}
+	return s
+}
+
+// Compare orders v and o by epoch, version and release. To keep the order
+// total, a missing release sorts before any release.
+func (v RPMVersion) Compare(o RPMVersion) int {
+	switch {
+	case v.Epoch < o.Epoch:
+		return -1
+	case v.Epoch > o.Epoch:
+		return 1
+	}
+	if c := rpmvercmp(v.Version, o.Version); c != 0 {
+		return c
+	}
+	switch {
+	case v.Release == o.Release:
+		return 0
+	case v.Release == "":
+		return -1
+	case o.Release == "":
+		return 1
+	}
+	return rpmvercmp(v.Release, o.Release)
+}
+
+// rpmvercmp compares two version or release strings segment by segment
+// the way librpm does.
+func rpmvercmp(a, b string) int {
+	if a == b {
+		return 0
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for i < len(a) && !isAlnum(a[i]) && a[i] != '~' && a[i] != '^' {
+			i++
+		}
+		for j < len(b) && !isAlnum(b[j]) && b[j] != '~' && b[j] != '^' {
+			j++
+		}
+		ca, cb := byteAt(a, i), byteAt(b, j)
+		if ca == '~' || cb == '~' {
+			if ca != '~' {
+				return 1
+			}
+			if cb != '~' {
+				return -1
+			}
+			i++
+			j++
+			continue
+		}
+		if ca == '^' || cb == '^' {
+			switch {
+			case i == len(a):
+				return -1
+			case j == len(b):
+				return 1
+			case ca != '^':
+				return 1
+			case cb != '^':
+				return -1
+			}
+			i++
+			j++
+			continue
+		}
+		if i == len(a) || j == len(b) {
+			break
+		}
+		si, sj := i, j
+		numeric := isDigit(a[i])
+		class := isAlpha
+		if numeric {
+			class = isDigit
+		}
+		for i < len(a) && class(a[i]) {
+			i++
+		}
+		for j < len(b) && class(b[j]) {
+			j++
+		}
+		if j == sj {
+			// Segments of different types: numeric is newer.
+			if numeric {
+				return 1
+			}
+			return -1
+		}
+		segA, segB := a[si:i], b[sj:j]
+		if numeric {
+			segA, segB = strings.TrimLeft(segA, "0"), strings.TrimLeft(segB, "0")
+			if len(segA) != len(segB) {
+				return sign(len(segA) - len(segB))
+			}
+		}
+		if c := strings.Compare(segA, segB); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case i >= len(a) && j >= len(b):
+		return 0
+	case i < len(a):
+		return 1
+	}
+	return -1
+}
+
+func byteAt(s string, i int) byte {
+	if i < len(s) {
+		return s[i]
+	}
+	return 0
+}
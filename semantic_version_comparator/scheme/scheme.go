@@ -0,0 +1,166 @@
+// Package scheme orders version strings from different ecosystems behind a
+// common interface, so tools can handle SemVer tags, Debian packages and
+// RPMs with the same code.
+package scheme
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+// Scheme validates and orders version strings of one versioning
+// convention. Custom schemes, such as four-segment or date-prefixed
+// in-house versions, implement it and are made available by name with
+// Register.
+type Scheme interface {
+	// Name returns the short name of the scheme, such as "deb".
+	Name() string
+	// Validate parses s and reports whether it is a well-formed version in
+	// the scheme.
+	Validate(s string) error
+	// Compare returns -1, 0 or 1 as a orders before, equal to or after b.
+	// It returns an error if either string is not a valid version.
+	Compare(a, b string) (int, error)
+	// Canonical returns the normalized spelling of s, such as "1.0.0" for
+	// the semver version "v1.0", or an error if s is not a valid version.
+	Canonical(s string) (string, error)
+}
+
+var (
+	mu      sync.RWMutex
+	schemes = map[string]Scheme{
+		"semver":     Semver,
+		"deb":        Deb,
+		"rpm":        RPM,
+		"pep440":     PEP440,
+		"maven":      Maven,
+		"nuget":      NuGet,
+		"kubernetes": Kubernetes,
+	}
+)
+
+// Register makes s available by name to Lookup, and so to the -scheme
+// flag of the semver command. It is meant to be called from an init
+// function and panics if name is empty or already registered.
+func Register(name string, s Scheme) {
+	mu.Lock()
+	defer mu.Unlock()
+	if name == "" || s == nil {
+		panic("scheme: Register with empty name or nil scheme")
+	}
+	if _, dup := schemes[name]; dup {
+		panic("scheme: Register called twice for scheme " + name)
+	}
+	schemes[name] = s
+}
+
+// Lookup returns the scheme registered with the given name.
+func Lookup(name string) (Scheme, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if s, ok := schemes[name]; ok {
+		return s, nil
+	}
+	return nil, fmt.Errorf("scheme: unknown version scheme %q", name)
+}
+
+// Names returns the names of the registered schemes in sorted order.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(schemes))
+	for name := range schemes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Sort sorts ss in ascending order under sch. Elements of equal
+// precedence keep their relative order. If any element is invalid, ss is
+// left unchanged and the error is returned. The built-in schemes parse
+// each element once; other schemes are asked to compare strings, and so
+// parse them on every comparison.
+func Sort(sch Scheme, ss []string) error {
+	if s, ok := sch.(sorter); ok {
+		return s.sort(ss)
+	}
+	for _, s := range ss {
+		if err := sch.Validate(s); err != nil {
+			return err
+		}
+	}
+	slices.SortStableFunc(ss, func(a, b string) int {
+		n, _ := sch.Compare(a, b)
+		return n
+	})
+	return nil
+}
+
+// sorter is implemented by the built-in schemes, which sort parsed
+// versions rather than strings.
+type sorter interface {
+	sort(ss []string) error
+}
+
+// sortParsed sorts ss as Sort does, parsing each element once with parse
+// and ordering the results with cmp.
+func sortParsed[V any](ss []string, parse func(string) (V, error), cmp func(a, b V) int) error {
+	type parsed struct {
+		s string
+		v V
+	}
+	ps := make([]parsed, len(ss))
+	for i, s := range ss {
+		v, err := parse(s)
+		if err != nil {
+			return err
+		}
+		ps[i] = parsed{s, v}
+	}
+	slices.SortStableFunc(ps, func(a, b parsed) int { return cmp(a.v, b.v) })
+	for i, p := range ps {
+		ss[i] = p.s
+	}
+	return nil
+}
+
+// Semver is the Semantic Versioning 2.0.0 scheme. It parses versions with
+// semver.ParseTolerant.
+var Semver Scheme = semverScheme{}
+
+type semverScheme struct{}
+
+func (semverScheme) Name() string { return "semver" }
+
+func (semverScheme) Validate(s string) error {
+	_, err := semver.ParseTolerant(s)
+	return err
+}
+
+func (semverScheme) Compare(a, b string) (int, error) {
+	va, err := semver.ParseTolerant(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := semver.ParseTolerant(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.Compare(vb), nil
+}
+
+func (semverScheme) sort(ss []string) error {
+	return sortParsed(ss, semver.ParseTolerant, semver.Version.Compare)
+}
+
+func (semverScheme) Canonical(s string) (string, error) {
+	v, err := semver.ParseTolerant(s)
+	if err != nil {
+		return "", err
+	}
+	return v.String(), nil
+}
@@ -0,0 +1,111 @@
+package scheme
+
+import (
+	"slices"
+	"testing"
+)
+
+var schemeTests = []struct {
+	scheme Scheme
+	// ordered is in ascending order, each version after the previous.
+	ordered []string
+	equal   [][2]string
+	invalid []string
+	// canonical maps inputs to their canonical form.
+	canonical map[string]string
+}{
+	{
+		scheme:    Deb,
+		ordered:   []string{"1.0~~", "1.0~rc1", "1.0", "1.0-0.1", "1.0-1", "1.0-2", "1.0-10", "1.0a", "1.0+b1", "1.0.1", "1.1", "2.0", "10.0", "1:0.1"},
+		equal:     [][2]string{{"0:1.0", "1.0"}, {"1.0-0", "1.0"}, {"1.01", "1.1"}},
+		invalid:   []string{"", "a1.0", "1.0 beta", "x:1.0", "1.0-", "1.0-1_2"},
+		canonical: map[string]string{"0:1.0-1": "1.0-1", "2:1.0": "2:1.0"},
+	},
+	{
+		scheme:    RPM,
+		ordered:   []string{"1.0~rc1", "1.0~rc2", "1.0", "1.0-1", "1.0-2", "1.0^git1", "1.0a", "1.0.1", "1.1", "2.0", "10.0", "1:0.1"},
+		equal:     [][2]string{{"0:1.0", "1.0"}, {"1.010", "1.10"}, {"1.0", "1_0"}},
+		invalid:   []string{"", "1.0-", "x:1.0", "1.0 beta", "1.0/2"},
+		canonical: map[string]string{"0:1.0-1": "1.0-1", "3:2.1": "3:2.1"},
+	},
+	{
+		scheme: PEP440,
+		ordered: []string{
+			"1.0.dev456", "1.0a1", "1.0a2.dev456", "1.0a12.dev456", "1.0a12",
+			"1.0b1.dev456", "1.0b2", "1.0b2.post345.dev456", "1.0b2.post345",
+			"1.0rc1.dev456", "1.0rc1", "1.0", "1.0+abc.5", "1.0+abc.7", "1.0+5",
+			"1.0.post456.dev34", "1.0.post456", "1.0.15", "1.1.dev1", "1!0.1",
+		},
+		equal:     [][2]string{{"1.0", "1.0.0"}, {"1.0RC1", "1.0rc1"}, {"v1.0", "1.0"}, {"1.0-1", "1.0.post1"}, {"1.0alpha1", "1.0a1"}},
+		invalid:   []string{"", "abc", "1.0.", "1.0+", "1.0++a"},
+		canonical: map[string]string{"1.0RC1": "1.0rc1", "v1.0-1": "1.0.post1", "1.0.0": "1.0.0"},
+	},
+}
+
+func TestCompare(t *testing.T) {
+	for _, tt := range schemeTests {
+		name := tt.scheme.Name()
+		for i, a := range tt.ordered {
+			for j, b := range tt.ordered {
+				want := 0
+				switch {
+				case i < j:
+					want = -1
+				case i > j:
+					want = 1
+				}
+				if got, err := tt.scheme.Compare(a, b); err != nil || got != want {
+					t.Errorf("%s: Compare(%q, %q) = %d, %v; want %d", name, a, b, got, err, want)
+				}
+			}
+		}
+		for _, p := range tt.equal {
+			if got, err := tt.scheme.Compare(p[0], p[1]); err != nil || got != 0 {
+				t.Errorf("%s: Compare(%q, %q) = %d, %v; want 0", name, p[0], p[1], got, err)
+			}
+		}
+		for _, s := range tt.invalid {
+			if err := tt.scheme.Validate(s); err == nil {
+				t.Errorf("%s: Validate(%q) succeeded", name, s)
+			}
+			if _, err := tt.scheme.Compare(s, tt.ordered[0]); err == nil {
+				t.Errorf("%s: Compare(%q, %q) succeeded", name, s, tt.ordered[0])
+			}
+		}
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	for _, tt := range schemeTests {
+		for in, want := range tt.canonical {
+			if got, err := tt.scheme.Canonical(in); err != nil || got != want {
+				t.Errorf("%s: Canonical(%q) = %q, %v; want %q", tt.scheme.Name(), in, got, err, want)
+			}
+		}
+	}
+}
+
+func TestSort(t *testing.T) {
+	for _, tt := range schemeTests {
+		ss := slices.Clone(tt.ordered)
+		slices.Reverse(ss)
+		if err := Sort(tt.scheme, ss); err != nil || !slices.Equal(ss, tt.ordered) {
+			t.Errorf("%s: Sort = %q, %v; want %q", tt.scheme.Name(), ss, err, tt.ordered)
+		}
+		if err := Sort(tt.scheme, append(slices.Clone(tt.ordered), tt.invalid[len(tt.invalid)-1])); err == nil {
+			t.Errorf("%s: Sort with an invalid version succeeded", tt.scheme.Name())
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	for _, name := range Names() {
+		s, err := Lookup(name)
+		if err != nil || s.Name() != name {
+			t.Errorf("Lookup(%q) = %v, %v", name, s, err)
+		}
+	}
+	if _, err := Lookup("no-such-scheme"); err == nil {
+		t.Error("Lookup of an unknown scheme succeeded")
+	}
+}
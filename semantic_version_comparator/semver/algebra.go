@@ -0,0 +1,655 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// minVersion is the lowest possible version: no version precedes 0.0.0-0.
+var minVersion = Version{Prerelease: "0"}
+
+// bound is one end of an interval. An unset bound is unbounded.
+type bound struct {
+	v         Version
+	inclusive bool
+	set       bool
+}
+
+// interval is the set of versions described by an AND group: the versions
+// between lo and hi, minus those in excl.
+type interval struct {
+	lo, hi bound
+	excl   []Version
+}
+
+func groupInterval(group []comparator) interval {
+	var iv interval
+	for _, c := range group {
+		switch c.op {
+		case OpEQ:
+			iv.raiseLo(bound{c.v, true, true})
+			iv.lowerHi(bound{c.v, true, true})
+		case OpNE:
+			iv.excl = append(iv.excl, c.v)
+		case OpGT:
+			iv.raiseLo(bound{c.v, false, true})
+		case OpGE:
+			iv.raiseLo(bound{c.v, true, true})
+		case OpLT:
+			iv.lowerHi(bound{c.v, false, true})
+		case OpLE:
+			iv.lowerHi(bound{c.v, true, true})
+		}
+	}
+	excl := iv.excl[:0]
+	for _, v := range iv.excl {
+		if iv.withinBounds(v) && !slices.ContainsFunc(excl, v.Equal) {
+			excl = append(excl, v)
+		}
+	}
+	iv.excl = excl
+	return iv
+}
+
+func (iv *interval) raiseLo(b bound) {
+	if !iv.lo.set {
+		iv.lo = b
+		return
+	}
+	switch n := b.v.Compare(iv.lo.v); {
+	case n > 0:
+		iv.lo = b
+	case n == 0:
+		iv.lo.inclusive = iv.lo.inclusive && b.inclusive
+	}
+}
+
+func (iv *interval) lowerHi(b bound) {
+	if !iv.hi.set {
+		iv.hi = b
+		return
+	}
+	switch n := b.v.Compare(iv.hi.v); {
+	case n < 0:
+		iv.hi = b
+	case n == 0:
+		iv.hi.inclusive = iv.hi.inclusive && b.inclusive
+	}
+}
+
+func (iv interval) withinBounds(v Version) bool {
+	if iv.lo.set {
+		n := v.Compare(iv.lo.v)
+		if n < 0 || n == 0 && !iv.lo.inclusive {
+			return false
+		}
+	}
+	if iv.hi.set {
+		n := v.Compare(iv.hi.v)
+		if n > 0 || n == 0 && !iv.hi.inclusive {
+			return false
+		}
+	}
+	return true
+}
+
+func (iv interval) contains(v Version) bool {
+	return iv.withinBounds(v) && !slices.ContainsFunc(iv.excl, v.Equal)
+}
+
+func (iv interval) empty() bool {
+	if iv.hi.set && !iv.hi.inclusive && iv.hi.v.Compare(minVersion) <= 0 {
+		return true
+	}
+	if !iv.lo.set || !iv.hi.set {
+		return false
+	}
+	n := iv.lo.v.Compare(iv.hi.v)
+	if n > 0 || n == 0 && !(iv.lo.inclusive && iv.hi.inclusive) {
+		return true
+	}
+	return n == 0 && len(iv.excl) > 0
+}
+
+// within reports whether iv is a subset of o.
+func (iv interval) within(o interval) bool {
+	if !loAtOrBelow(o.lo, iv.lo) || !hiAtOrAbove(o.hi, iv.hi) {
+		return false
+	}
+	for _, x := range o.excl {
+		if iv.withinBounds(x) && !slices.ContainsFunc(iv.excl, x.Equal) {
+			return false
+		}
+	}
+	return true
+}
+
+// loAtOrBelow reports whether lower bound a admits everything b admits.
+func loAtOrBelow(a, b bound) bool {
+	if !a.set {
+		return true
+	}
+	if !b.set {
+		return false
+	}
+	n := a.v.Compare(b.v)
+	return n < 0 || n == 0 && (a.inclusive || !b.inclusive)
+}
+
+// hiAtOrAbove reports whether upper bound a admits everything b admits.
+func hiAtOrAbove(a, b bound) bool {
+	if !a.set {
+		return true
+	}
+	if !b.set {
+		return false
+	}
+	n := a.v.Compare(b.v)
+	return n > 0 || n == 0 && (a.inclusive || !b.inclusive)
+}
+
+// touches reports whether iv, whose lower bound is not above o's, overlaps
+// or is adjacent to o, such that their union is a single interval.
+func (iv interval) touches(o interval) bool {
+	if !iv.hi.set || !o.lo.set {
+		return true
+	}
+	n := iv.hi.v.Compare(o.lo.v)
+	return n > 0 || n == 0 && (iv.hi.inclusive || o.lo.inclusive)
+}
+
+func (iv interval) comparators() []comparator {
+	if iv.lo.set && iv.hi.set && iv.lo.inclusive && iv.hi.inclusive && iv.lo.v.Compare(iv.hi.v) == 0 {
+		return []comparator{{op: OpEQ, v: iv.lo.v}}
+	}
+	var group []comparator
+	if iv.lo.set {
+		op := OpGT
+		if iv.lo.inclusive {
+			op = OpGE
+		}
+		group = append(group, comparator{op: op, v: iv.lo.v})
+	}
+	if iv.hi.set {
+		op := OpLT
+		if iv.hi.inclusive {
+			op = OpLE
+		}
+		group = append(group, comparator{op: op, v: iv.hi.v})
+	}
+	for _, x := range iv.excl {
+		group = append(group, comparator{op: OpNE, v: x})
+	}
+	if len(group) == 0 {
+		group = append(group, comparator{op: OpGE, v: minVersion})
+	}
+	return group
+}
+
+// newConstraint builds a constraint from comparator groups, rendering its
+// string form. With no groups the constraint matches nothing.
+func newConstraint(groups [][]comparator) Constraint {
+	if len(groups) == 0 {
+		return Constraint{raw: "<" + minVersion.String(), groups: [][]comparator{{{op: OpLT, v: minVersion}}}}
+	}
+	alts := make([]string, len(groups))
+	for i, g := range groups {
+		parts := make([]string, len(g))
+		for j, c := range g {
+			parts[j] = c.String()
+		}
+		alts[i] = strings.Join(parts, " ")
+	}
+	return Constraint{raw: strings.Join(alts, " || "), groups: groups}
+}
+
+// ErrPrereleaseRule reports that Intersect or Union cannot express their
+// result under the pre-release rule of either constraint. They return it
+// wrapped, so test for it with errors.Is.
+var ErrPrereleaseRule = errors.New("pre-release rules cannot be reconciled")
+
+// IsEmpty reports whether no version can satisfy c.
+func (c Constraint) IsEmpty() bool {
+	a := newAlgebra(c.groups)
+	for _, alt := range a.alternatives(c) {
+		if g, ok := a.render(c.prereleases, alt.iv, alt.admit, alt.namers); !ok || g != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Intersect returns a constraint satisfied exactly by the versions that
+// satisfy both c and o, simplified. A pre-release must get past the
+// pre-release rules of both (see Check); the result has c's rule if it
+// can express that and o's otherwise. If neither can, which only happens
+// when c and o handle pre-releases differently, Intersect fails with
+// ErrPrereleaseRule. The result allows the version flags that both
+// allow (see AllowStatus).
+func (c Constraint) Intersect(o Constraint) (Constraint, error) {
+	a := newAlgebra(c.groups, o.groups)
+	var alts []alternative
+	for _, x := range c.groups {
+		admitX := a.admission(c.prereleases, x)
+		for _, y := range o.groups {
+			g := append(slices.Clip(x), y...)
+			iv := groupInterval(g)
+			if iv.empty() {
+				continue
+			}
+			alt := alternative{iv: iv, admit: and(admitX, a.admission(o.prereleases, y)), namers: g}
+			// Under these rules a pre-release gets past the joined group
+			// exactly when it gets past both; npm's would let a name in x
+			// admit pre-releases that y rejects.
+			if c.prereleases == o.prereleases && c.prereleases != prereleasesNamedInGroup {
+				alt.fallback, alt.rule = g, c.prereleases
+			}
+			alts = append(alts, alt)
+		}
+	}
+	s, ok := a.combine(alts, c.prereleases, o.prereleases)
+	if !ok {
+		return Constraint{}, fmt.Errorf("semver: intersecting %q and %q: %w", c.raw, o.raw, ErrPrereleaseRule)
+	}
+	s.allowStatus = c.allowStatus & o.allowStatus
+	return s, nil
+}
+
+// Union returns a constraint satisfied by the versions that satisfy c or
+// o, simplified. Like Intersect it has c's pre-release rule if that can
+// express the result and o's otherwise, and fails with ErrPrereleaseRule
+// if neither can. The result allows the version flags that either
+// allows.
+func (c Constraint) Union(o Constraint) (Constraint, error) {
+	a := newAlgebra(c.groups, o.groups)
+	s, ok := a.combine(append(a.alternatives(c), a.alternatives(o)...), c.prereleases, o.prereleases)
+	if !ok {
+		return Constraint{}, fmt.Errorf("semver: joining %q and %q: %w", c.raw, o.raw, ErrPrereleaseRule)
+	}
+	s.allowStatus = c.allowStatus | o.allowStatus
+	return s, nil
+}
+
+// Simplify returns an equivalent constraint with redundant comparators and
+// alternatives removed: each alternative is reduced to at most one lower
+// and one upper bound, unsatisfiable alternatives are dropped, alternatives
+// contained in others are dropped and overlapping ranges are merged. For
+// example ">=1.2.0, >=1.0.0" simplifies to ">=1.2.0".
+//
+// Simplify keeps the comparators that name the pre-releases an
+// alternative admits, and merges alternatives only if the merged one
+// admits the same pre-releases, so under npm's rule
+// ">=1.0.0-rc.1 <1.0.1 || >=0.5.0 <1.0.0" stays two alternatives.
+func (c Constraint) Simplify() Constraint {
+	a := newAlgebra(c.groups)
+	// Each alternative can fall back to its own group, so this cannot
+	// fail.
+	s, _ := a.combine(a.alternatives(c), c.prereleases)
+	s.allowStatus = c.allowStatus
+	return s
+}
+
+// triple is the release a pre-release belongs to, as the pre-release
+// rules compare it.
+type triple struct {
+	major, minor, patch uint64
+}
+
+func tripleOf(v Version) triple {
+	return triple{v.Major, v.Minor, v.Patch}
+}
+
+// admits reports whether rule lets the pre-releases of t that satisfy
+// every comparator in group match it.
+func (rule prereleaseRule) admits(group []comparator, t triple) bool {
+	return rule.allows(group, Version{Major: t.major, Minor: t.minor, Patch: t.patch, Prerelease: "0"})
+}
+
+// algebra holds what Intersect, Union and Simplify need to reason about
+// the pre-releases of a set of groups. A rule admits or rejects the
+// pre-releases of a triple as a whole, and treats every triple no
+// comparator names the same way, so admission is a []bool over the
+// triples the groups name followed by one they do not.
+type algebra struct {
+	triples []triple
+	// epochs holds the epochs of the groups' versions, at each of which
+	// a triple has pre-releases.
+	epochs []uint64
+}
+
+func newAlgebra(sets ...[][]comparator) *algebra {
+	a := &algebra{triples: []triple{tripleOf(minVersion)}, epochs: []uint64{0}}
+	for _, groups := range sets {
+		for _, g := range groups {
+			for _, c := range g {
+				if t := tripleOf(c.v); c.v.Prerelease != "" && !slices.Contains(a.triples, t) {
+					a.triples = append(a.triples, t)
+				}
+				if !slices.Contains(a.epochs, c.v.Epoch) {
+					a.epochs = append(a.epochs, c.v.Epoch)
+				}
+			}
+		}
+	}
+	other := triple{math.MaxUint64, math.MaxUint64, math.MaxUint64}
+	for slices.Contains(a.triples, other) {
+		other.patch--
+	}
+	a.triples = append(a.triples, other)
+	return a
+}
+
+// index returns the position in a.triples of the triple of v, a
+// pre-release named by one of the groups.
+func (a *algebra) index(v Version) int {
+	return slices.Index(a.triples, tripleOf(v))
+}
+
+// admission returns which of a.triples rule lets match group.
+func (a *algebra) admission(rule prereleaseRule, group []comparator) []bool {
+	admit := make([]bool, len(a.triples))
+	for i, t := range a.triples {
+		admit[i] = rule.admits(group, t)
+	}
+	return admit
+}
+
+func and(x, y []bool) []bool {
+	z := make([]bool, len(x))
+	for i := range z {
+		z[i] = x[i] && y[i]
+	}
+	return z
+}
+
+// relevant reports whether iv holds pre-releases of a.triples[i], so
+// that their admission matters. The last triple stands for many and is
+// always relevant.
+func (a *algebra) relevant(i int, iv interval) bool {
+	if i == len(a.triples)-1 {
+		return true
+	}
+	t := a.triples[i]
+	for _, e := range a.epochs {
+		release := Version{Epoch: e, Major: t.major, Minor: t.minor, Patch: t.patch}
+		first := release
+		first.Prerelease = "0"
+		if iv.overlaps(first, release) {
+			return true
+		}
+	}
+	return false
+}
+
+// overlaps reports whether the bounds of iv, which is not empty, meet
+// the versions from lo up to but excluding hi.
+func (iv interval) overlaps(lo, hi Version) bool {
+	if iv.lo.set && iv.lo.v.Compare(hi) >= 0 {
+		return false
+	}
+	if iv.hi.set {
+		if n := iv.hi.v.Compare(lo); n < 0 || n == 0 && !iv.hi.inclusive {
+			return false
+		}
+	}
+	return true
+}
+
+// alternative is an interval together with the pre-releases admitted in
+// it.
+type alternative struct {
+	iv    interval
+	admit []bool
+	// namers are comparators that iv satisfies and that can be added
+	// to name the triples admit lets through.
+	namers []comparator
+	// fallback, if set, is a group matching what the alternative
+	// matches under rule, for when no simpler group does.
+	fallback []comparator
+	rule     prereleaseRule
+}
+
+// alternatives returns the satisfiable alternatives of c.
+func (a *algebra) alternatives(c Constraint) []alternative {
+	var alts []alternative
+	for _, g := range c.groups {
+		if iv := groupInterval(g); !iv.empty() {
+			alts = append(alts, alternative{iv: iv, admit: a.admission(c.prereleases, g), namers: g, fallback: g, rule: c.prereleases})
+		}
+	}
+	return alts
+}
+
+// matches reports whether alt matches v.
+func (a *algebra) matches(alt alternative, v Version) bool {
+	return alt.iv.contains(v) && (v.Prerelease == "" || alt.admit[a.index(v)])
+}
+
+// covers reports whether o matches everything alt matches.
+func (a *algebra) covers(o, alt alternative) bool {
+	if !alt.iv.within(o.iv) {
+		return false
+	}
+	for i := range a.triples {
+		if alt.admit[i] && !o.admit[i] && a.relevant(i, alt.iv) {
+			return false
+		}
+	}
+	return true
+}
+
+// combine simplifies alts and renders them under the first of rules that
+// can express each exactly.
+func (a *algebra) combine(alts []alternative, rules ...prereleaseRule) (Constraint, bool) {
+	alts = a.prune(alts, rules)
+	for i, rule := range rules {
+		if slices.Contains(rules[:i], rule) {
+			continue
+		}
+		var groups [][]comparator
+		ok := true
+		for _, alt := range a.merge(rule, alts) {
+			g, exact := a.render(rule, alt.iv, alt.admit, alt.namers)
+			if !exact {
+				if alt.fallback == nil || alt.rule != rule {
+					ok = false
+					break
+				}
+				g = alt.fallback
+			}
+			if g != nil {
+				groups = append(groups, g)
+			}
+		}
+		if ok {
+			s := newConstraint(groups)
+			s.prereleases = rule
+			return s, true
+		}
+	}
+	return Constraint{}, false
+}
+
+// expresses reports whether rule can express alt.
+func (a *algebra) expresses(rule prereleaseRule, alt alternative) bool {
+	_, ok := a.render(rule, alt.iv, alt.admit, alt.namers)
+	return ok || alt.fallback != nil && alt.rule == rule
+}
+
+// prune drops exclusions and alternatives covered by other alternatives.
+func (a *algebra) prune(alts []alternative, rules []prereleaseRule) []alternative {
+	// An exclusion is redundant if another alternative matches the
+	// version, unless a != naming a pre-release is needed under one of
+	// rules. Without it the alternative's own group no longer serves as
+	// a fallback, since what covered the version may itself be dropped.
+	for i := range alts {
+		for k := 0; k < len(alts[i].iv.excl); k++ {
+			x := alts[i].iv.excl[k]
+			covered := false
+			for j, o := range alts {
+				if i != j && a.matches(o, x) {
+					covered = true
+					break
+				}
+			}
+			if !covered {
+				continue
+			}
+			pruned := alts[i]
+			pruned.iv.excl = slices.Delete(slices.Clone(pruned.iv.excl), k, k+1)
+			pruned.fallback = nil
+			if !slices.ContainsFunc(rules, func(rule prereleaseRule) bool {
+				return a.expresses(rule, alts[i]) && !a.expresses(rule, pruned)
+			}) {
+				alts[i] = pruned
+				k--
+			}
+		}
+	}
+	var kept []alternative
+	for i, alt := range alts {
+		redundant := false
+		for j, o := range alts {
+			if i == j || !a.covers(o, alt) {
+				continue
+			}
+			// Of two equivalent alternatives keep only the first.
+			if !a.covers(alt, o) || j < i {
+				redundant = true
+				break
+			}
+		}
+		if !redundant {
+			kept = append(kept, alt)
+		}
+	}
+	return kept
+}
+
+// merge joins the overlapping alternatives of alts that have no
+// exclusions, where rule can express the joined one. The result is
+// ordered by lower bound.
+func (a *algebra) merge(rule prereleaseRule, alts []alternative) []alternative {
+	alts = slices.Clone(alts)
+	slices.SortStableFunc(alts, func(x, y alternative) int {
+		switch {
+		case loAtOrBelow(x.iv.lo, y.iv.lo) && loAtOrBelow(y.iv.lo, x.iv.lo):
+			return 0
+		case loAtOrBelow(x.iv.lo, y.iv.lo):
+			return -1
+		}
+		return 1
+	})
+	var out []alternative
+	for _, alt := range alts {
+		if n := len(out); n > 0 {
+			last := &out[n-1]
+			if len(last.iv.excl) == 0 && len(alt.iv.excl) == 0 && last.iv.touches(alt.iv) {
+				if joined, ok := a.join(rule, *last, alt); ok {
+					*last = joined
+					continue
+				}
+			}
+		}
+		out = append(out, alt)
+	}
+	return out
+}
+
+// join returns the alternative matching what x or y matches, where x's
+// lower bound is not above y's and the two touch. It reports false if
+// they admit different pre-releases of a triple both hold, or if rule
+// cannot express the joined alternative without their own comparators.
+func (a *algebra) join(rule prereleaseRule, x, y alternative) (alternative, bool) {
+	iv := x.iv
+	if !hiAtOrAbove(iv.hi, y.iv.hi) {
+		iv.hi = y.iv.hi
+	}
+	admit := slices.Clone(x.admit)
+	for i := range admit {
+		inX, inY := a.relevant(i, x.iv), a.relevant(i, y.iv)
+		if inX && inY && x.admit[i] != y.admit[i] {
+			return alternative{}, false
+		}
+		if !inX {
+			admit[i] = y.admit[i]
+		}
+	}
+	if _, ok := a.render(rule, iv, admit, nil); !ok {
+		return alternative{}, false
+	}
+	return alternative{iv: iv, admit: admit}, true
+}
+
+// render returns a group matching, under rule, exactly the versions in iv
+// whose pre-release admit lets through, or nil if there are none. It may
+// add comparators from namers to name admitted triples. It reports false
+// if rule cannot express the group.
+func (a *algebra) render(rule prereleaseRule, iv interval, admit []bool, namers []comparator) ([]comparator, bool) {
+	// Renaming only the bounds whose pre-releases iv holds keeps the
+	// result closest to what was written; the Terraform and Helm rules
+	// look at every comparator, so they may need the rest renamed too.
+	for _, all := range []bool{false, true} {
+		if g, ok := a.renderGroup(rule, iv, admit, namers, all); ok {
+			return g, true
+		}
+	}
+	return nil, false
+}
+
+func (a *algebra) renderGroup(rule prereleaseRule, iv interval, admit []bool, namers []comparator, all bool) ([]comparator, bool) {
+	var g []comparator
+	for _, c := range iv.comparators() {
+		if c.v.Prerelease == "" {
+			g = append(g, c)
+			continue
+		}
+		if i := a.index(c.v); admit[i] || !all && !a.relevant(i, iv) {
+			g = append(g, c)
+			continue
+		}
+		// No pre-release of c's triple matches, so c can bound the
+		// release instead and stop naming it.
+		release := Version{Epoch: c.v.Epoch, Major: c.v.Major, Minor: c.v.Minor, Patch: c.v.Patch}
+		switch c.op {
+		case OpEQ:
+			return nil, true
+		case OpGT, OpGE:
+			g = append(g, comparator{op: OpGE, v: release})
+		case OpLT, OpLE:
+			g = append(g, comparator{op: OpLT, v: release})
+		}
+	}
+	if len(g) == 0 {
+		low := Version{}
+		if admit[a.index(minVersion)] {
+			low = minVersion
+		}
+		g = append(g, comparator{op: OpGE, v: low})
+	}
+	bounds := groupInterval(g)
+	if bounds.empty() {
+		return nil, true
+	}
+	for i, t := range a.triples {
+		if !admit[i] || rule.admits(g, t) || !a.relevant(i, bounds) {
+			continue
+		}
+		// A namer must not narrow the group, as a != whose exclusion
+		// was dropped would.
+		if j := slices.IndexFunc(namers, func(c comparator) bool {
+			return c.v.Prerelease != "" && tripleOf(c.v) == t && bounds.within(groupInterval(append(slices.Clip(g), c)))
+		}); j >= 0 {
+			g = append(g, namers[j])
+		}
+	}
+	for i, t := range a.triples {
+		if rule.admits(g, t) != admit[i] && a.relevant(i, bounds) {
+			return nil, false
+		}
+	}
+	return g, true
+}
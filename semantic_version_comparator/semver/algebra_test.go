@@ -0,0 +1,191 @@
+package semver
+
+import (
+	"errors"
+	"testing"
+)
+
+// probes returns versions at and around every version the constraints
+// name, including pre-releases of each release.
+func probes(cs ...Constraint) []Version {
+	vs := []Version{minVersion, {}, MustParse("0.0.1-rc.1"), MustParse("99.0.0")}
+	add := func(r Version) {
+		vs = append(vs, r)
+		for _, pre := range []string{"0", "alpha", "beta", "beta.2", "rc.1", "rc.2"} {
+			p := r
+			p.Prerelease = pre
+			vs = append(vs, p)
+		}
+	}
+	for _, c := range cs {
+		for _, g := range c.groups {
+			for _, cmp := range g {
+				r := Version{Epoch: cmp.v.Epoch, Major: cmp.v.Major, Minor: cmp.v.Minor, Patch: cmp.v.Patch}
+				vs = append(vs, cmp.v)
+				add(r)
+				add(Version{Epoch: r.Epoch, Major: r.Major, Minor: r.Minor, Patch: r.Patch + 1})
+				add(Version{Epoch: r.Epoch, Major: r.Major, Minor: r.Minor + 1})
+				add(Version{Epoch: r.Epoch, Major: r.Major + 1})
+			}
+		}
+	}
+	return vs
+}
+
+func constraintOpts(d Dialect, include bool) []ConstraintOption {
+	opts := []ConstraintOption{WithDialect(d)}
+	if include {
+		opts = append(opts, IncludePrerelease())
+	}
+	return opts
+}
+
+var algebraTests = []struct {
+	dialect Dialect
+	include bool
+	a, b    string
+}{
+	{DialectNPM, false, ">=1.5.0-beta", "^1.0.0"},
+	{DialectNPM, false, ">=1.0.0-rc.1 <1.0.1 || >=0.5.0 <1.0.0", "^1.0.0-0"},
+	{DialectNPM, false, "^1.2.3-beta.1", ">=1.2.3-beta.3 <1.3.0 || 2.x"},
+	{DialectNPM, false, ">=1.0.0-rc.1 >=1.2.0", "<1.2.0-beta || >=1.3.0"},
+	{DialectNPM, false, "!=1.2.0-rc.1 >=1.0.0", "1.2.0-rc.1 || <1.0.0"},
+	{DialectNPM, false, "1.0.0-rc.1 - 1.0.0-rc.5", ">1.0.0-rc.3 <1.1.0"},
+	{DialectNPM, false, ">=1.2.0 <1.4.0", ">=1.3.0 <1.6.0 || >=1.6.0 <2.0.0"},
+	{DialectNPM, true, ">=1.5.0-beta", "^1.0.0"},
+	{DialectTerraform, false, ">= 1.0.0-rc.1, >= 0.5.0", "< 1.0.0-rc.5, >= 1.0.0-rc.2"},
+	{DialectTerraform, false, "= 1.2.0-beta1", "~> 1.2.0"},
+	{DialectTerraform, false, ">= 1.0.0-rc.1", ">= 1.0.0-rc.3, < 1.0.0-rc.9"},
+	{DialectHelm, false, ">=1.19.0-0", "<1.21.0 || >=1.22.0-0 <1.23.0-0"},
+	{DialectHelm, false, ">=1.19.0-0 <1.20.0-0", ">=1.18.0 <1.19.5 || >=1.19.5-0"},
+	{DialectRuby, false, ">= 1.0.0.pre.1, < 2", "~> 1.4"},
+}
+
+func TestIntersect(t *testing.T) {
+	for _, tt := range algebraTests {
+		opts := constraintOpts(tt.dialect, tt.include)
+		a, b := MustParseConstraint(tt.a, opts...), MustParseConstraint(tt.b, opts...)
+		got, err := a.Intersect(b)
+		if err != nil {
+			t.Errorf("%v: Intersect(%q, %q): %v", tt.dialect, tt.a, tt.b, err)
+			continue
+		}
+		for _, v := range probes(a, b) {
+			if want := a.Check(v) && b.Check(v); got.Check(v) != want {
+				t.Errorf("%v: Intersect(%q, %q) = %q: Check(%v) = %v, want %v", tt.dialect, tt.a, tt.b, got, v, got.Check(v), want)
+			}
+		}
+	}
+}
+
+func TestUnion(t *testing.T) {
+	for _, tt := range algebraTests {
+		opts := constraintOpts(tt.dialect, tt.include)
+		a, b := MustParseConstraint(tt.a, opts...), MustParseConstraint(tt.b, opts...)
+		got, err := a.Union(b)
+		if err != nil {
+			t.Errorf("%v: Union(%q, %q): %v", tt.dialect, tt.a, tt.b, err)
+			continue
+		}
+		for _, v := range probes(a, b) {
+			if want := a.Check(v) || b.Check(v); got.Check(v) != want {
+				t.Errorf("%v: Union(%q, %q) = %q: Check(%v) = %v, want %v", tt.dialect, tt.a, tt.b, got, v, got.Check(v), want)
+			}
+		}
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	for _, tt := range algebraTests {
+		ss := []string{tt.a, tt.b}
+		if tt.dialect == DialectNPM || tt.dialect == DialectHelm {
+			ss = append(ss, tt.a+" || "+tt.b)
+		}
+		for _, s := range ss {
+			c := MustParseConstraint(s, constraintOpts(tt.dialect, tt.include)...)
+			got := c.Simplify()
+			for _, v := range probes(c) {
+				if got.Check(v) != c.Check(v) {
+					t.Errorf("%v: Simplify(%q) = %q: Check(%v) = %v, want %v", tt.dialect, s, got, v, got.Check(v), c.Check(v))
+				}
+			}
+		}
+	}
+}
+
+func TestAlgebraString(t *testing.T) {
+	npm := func(s string) Constraint { return MustParseConstraint(s) }
+	tests := []struct {
+		name string
+		got  func() (Constraint, error)
+		want string
+	}{
+		{"intersect", func() (Constraint, error) { return npm(">=1.5.0-beta").Intersect(npm("^1.0.0")) }, ">=1.5.0 <2.0.0"},
+		{"intersect", func() (Constraint, error) { return npm("^1.2.0").Intersect(npm(">=1.0.0 <1.5.0")) }, ">=1.2.0 <1.5.0"},
+		{"intersect", func() (Constraint, error) { return npm(">=1.5.0-beta").Intersect(npm(">=1.5.0-alpha <2.0.0")) }, ">=1.5.0-beta <2.0.0"},
+		{"union", func() (Constraint, error) { return npm("^1.0.0").Union(npm("^2.0.0")) }, ">=1.0.0 <3.0.0"},
+		{"simplify", func() (Constraint, error) { return npm(">=1.2.0, >=1.0.0").Simplify(), nil }, ">=1.2.0"},
+		{"simplify", func() (Constraint, error) { return npm(">=1.0.0-rc.1 >=1.2.0").Simplify(), nil }, ">=1.2.0"},
+		{"simplify", func() (Constraint, error) {
+			return npm(">=1.0.0-rc.1 <1.0.1 || >=0.5.0 <1.0.0").Simplify(), nil
+		}, ">=0.5.0 <1.0.0 || >=1.0.0-rc.1 <1.0.1"},
+		{"simplify", func() (Constraint, error) {
+			return npm(">=1.0.0-rc.1 <1.0.1 || >=1.0.1 <2.0.0").Simplify(), nil
+		}, ">=1.0.0-rc.1 <2.0.0"},
+	}
+	for _, tt := range tests {
+		got, err := tt.got()
+		if err != nil || got.String() != tt.want {
+			t.Errorf("%s = %q, %v; want %q", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestIntersectMixedRules(t *testing.T) {
+	tests := []struct {
+		a, b     Constraint
+		want     string // "" if the rules cannot be reconciled
+		wantRule prereleaseRule
+	}{
+		{
+			a:        MustParseConstraint(">=1.0.0-0", IncludePrerelease()),
+			b:        MustParseConstraint("^1.0.0"),
+			want:     ">=1.0.0 <2.0.0",
+			wantRule: prereleasesNamedInGroup,
+		},
+		{
+			a:        MustParseConstraint("^1.2.3-beta.1"),
+			b:        MustParseConstraint(">=1.2.3-beta.0 <1.3.0-0", WithDialect(DialectHelm)),
+			want:     ">=1.2.3-beta.1 <1.3.0-0",
+			wantRule: prereleasesNamedInGroup,
+		},
+		{
+			a:        MustParseConstraint("^1.2.3-beta.1"),
+			b:        MustParseConstraint(">=1.2.3-beta.0 <1.3", WithDialect(DialectHelm)),
+			want:     ">=1.2.3 <1.3.0",
+			wantRule: prereleasesNamedInGroup,
+		},
+		{
+			a: MustParseConstraint("<2.0.0", IncludePrerelease()),
+			b: MustParseConstraint(">= 1.0.0-rc.1", WithDialect(DialectTerraform)),
+		},
+	}
+	for _, tt := range tests {
+		got, err := tt.a.Intersect(tt.b)
+		if tt.want == "" {
+			if !errors.Is(err, ErrPrereleaseRule) {
+				t.Errorf("Intersect(%q, %q) = %q, %v; want ErrPrereleaseRule", tt.a, tt.b, got, err)
+			}
+			continue
+		}
+		if err != nil || got.String() != tt.want || got.prereleases != tt.wantRule {
+			t.Errorf("Intersect(%q, %q) = %q (rule %d), %v; want %q (rule %d)", tt.a, tt.b, got, got.prereleases, err, tt.want, tt.wantRule)
+			continue
+		}
+		for _, v := range probes(tt.a, tt.b) {
+			if want := tt.a.Check(v) && tt.b.Check(v); got.Check(v) != want {
+				t.Errorf("Intersect(%q, %q) = %q: Check(%v) = %v, want %v", tt.a, tt.b, got, v, got.Check(v), want)
+			}
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package semver
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// AliasRule picks the version a symbolic name stands for from a set of
+// versions. The boolean result is false if no version qualifies.
+type AliasRule func(vs []Version) (Version, bool)
+
+// Resolver maps symbolic names such as "latest", "stable" and "lts" onto
+// concrete versions, so that every pipeline resolves them the same way:
+//
+//	r := semver.NewResolver()
+//	r.Define("lts", semver.HighestInSeries(semver.CompareMajor, "2", "4"))
+//	v, err := r.Resolve("lts", published)
+//
+// Names are case-insensitive. Rules skip versions flagged with
+// WithStatus, so a retracted or yanked release is never "latest".
+type Resolver struct {
+	rules map[string]AliasRule
+}
+
+// NewResolver returns a resolver with the names every pipeline expects:
+//
+//	latest  the highest version, pre-releases included
+//	stable  the highest release
+//	next    the highest pre-release above stable, as npm's next tag
+//
+// "lts" has no default, since which series are long-term supported is
+// up to each project; Define it with HighestInSeries.
+func NewResolver() *Resolver {
+	r := &Resolver{rules: make(map[string]AliasRule)}
+	r.Define("latest", Highest(ChannelUnknown))
+	r.Define("stable", Highest(ChannelStable))
+	r.Define("next", nextRule)
+	return r
+}
+
+// Define makes name resolve with rule, replacing any earlier rule.
+func (r *Resolver) Define(name string, rule AliasRule) {
+	if r.rules == nil {
+		r.rules = make(map[string]AliasRule)
+	}
+	r.rules[strings.ToLower(name)] = rule
+}
+
+// Names returns the defined names, sorted.
+func (r *Resolver) Names() []string {
+	names := make([]string, 0, len(r.rules))
+	for n := range r.rules {
+		names = append(names, n)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Resolve returns the version of vs that name stands for. A name that is
+// not defined is read as a constraint and resolves to the highest
+// version satisfying it, so pipelines may pass "stable", "1.4" or
+// "^2.1" alike.
+func (r *Resolver) Resolve(name string, vs []Version) (Version, error) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if rule, ok := r.rules[key]; ok {
+		if v, ok := rule(vs); ok {
+			return v, nil
+		}
+		return Version{}, fmt.Errorf("semver: no version is %s", key)
+	}
+	c, err := ParseConstraint(name)
+	if err != nil {
+		return Version{}, fmt.Errorf("semver: %q is neither a version alias (%s) nor a constraint", name, strings.Join(r.Names(), ", "))
+	}
+	if v, ok := MaxSatisfying(vs, c); ok {
+		return v, nil
+	}
+	return Version{}, fmt.Errorf("semver: no version satisfies %s", c)
+}
+
+// Highest returns the rule picking the highest version at least as
+// mature as ch: ChannelStable picks the highest release, ChannelRC the
+// highest release or release candidate, and ChannelUnknown any version.
+func Highest(ch Channel) AliasRule {
+	return highestWhere(func(v Version) bool { return v.Channel() >= ch })
+}
+
+// HighestInSeries returns the rule picking the highest release in the
+// given series at level, named as by SeriesOf, such as "2" and "4" for
+// CompareMajor or "1.24" for CompareMajorMinor. It is the usual rule for
+// "lts".
+func HighestInSeries(level CompareMode, series ...string) AliasRule {
+	return highestWhere(func(v Version) bool {
+		return v.Prerelease == "" && slices.Contains(series, SeriesOf(v, level))
+	})
+}
+
+// HighestSatisfying returns the rule picking the highest version that
+// satisfies c, as MaxSatisfying does.
+func HighestSatisfying(c Constraint) AliasRule {
+	return func(vs []Version) (Version, bool) {
+		return MaxSatisfying(vs, c)
+	}
+}
+
+// nextRule picks the highest version if it is a pre-release, which is
+// then above every release.
+func nextRule(vs []Version) (Version, bool) {
+	v, ok := Highest(ChannelUnknown)(vs)
+	if !ok || v.Prerelease == "" {
+		return Version{}, false
+	}
+	return v, true
+}
+
+// highestWhere returns the rule picking the highest unflagged version
+// for which keep is true. Of versions with equal precedence the first is
+// picked.
+func highestWhere(keep func(Version) bool) AliasRule {
+	return func(vs []Version) (Version, bool) {
+		var best Version
+		found := false
+		for _, v := range vs {
+			if v.status != 0 || !keep(v) {
+				continue
+			}
+			if !found || v.Compare(best) > 0 {
+				best, found = v, true
+			}
+		}
+		return best, found
+	}
+}
@@ -0,0 +1,150 @@
+package semver
+
+import "strings"
+
+// Node is a node of the syntax tree of a constraint: an *OrNode, an
+// *AndNode or a *ComparatorNode.
+type Node interface {
+	// String returns the node in canonical form.
+	String() string
+	node()
+}
+
+// OrNode is the root of a constraint's syntax tree: alternatives
+// separated by "||", of which any one must be satisfied. With no groups
+// it matches no version.
+type OrNode struct {
+	Groups []*AndNode
+}
+
+// AndNode is one alternative of a constraint: comparators that must all
+// be satisfied. With no comparators it matches every version.
+type AndNode struct {
+	Comparators []*ComparatorNode
+}
+
+// ComparatorNode is a single comparison, such as ">=1.2.0".
+type ComparatorNode struct {
+	Op      Operator
+	Version Version
+}
+
+func (*OrNode) node()         {}
+func (*AndNode) node()        {}
+func (*ComparatorNode) node() {}
+
+// String returns the alternatives joined by " || ", in the same form as
+// the constraints returned by Simplify, Intersect and Union.
+func (n *OrNode) String() string {
+	if len(n.Groups) == 0 {
+		return (&ComparatorNode{Op: OpLT, Version: minVersion}).String()
+	}
+	alts := make([]string, len(n.Groups))
+	for i, g := range n.Groups {
+		alts[i] = g.String()
+	}
+	return strings.Join(alts, " || ")
+}
+
+// String returns the comparators separated by spaces.
+func (n *AndNode) String() string {
+	if len(n.Comparators) == 0 {
+		return (&ComparatorNode{Op: OpGE, Version: minVersion}).String()
+	}
+	parts := make([]string, len(n.Comparators))
+	for i, c := range n.Comparators {
+		parts[i] = c.String()
+	}
+	return strings.Join(parts, " ")
+}
+
+func (n *ComparatorNode) String() string {
+	return comparator{op: n.Op, v: n.Version}.String()
+}
+
+// AST returns the syntax tree of c. Range sugar is expanded as at parse
+// time, so the tree of "^1.2.3" is ">=1.2.3 <2.0.0" and a tool that
+// needs canonical output can print c.AST() instead of c. The tree is a
+// copy: changing it does not change c.
+func (c Constraint) AST() *OrNode {
+	root := &OrNode{Groups: make([]*AndNode, len(c.groups))}
+	for i, g := range c.groups {
+		and := &AndNode{Comparators: make([]*ComparatorNode, len(g))}
+		for j, cmp := range g {
+			and.Comparators[j] = &ComparatorNode{Op: cmp.op, Version: cmp.v}
+		}
+		root.Groups[i] = and
+	}
+	return root
+}
+
+// Constraint builds a constraint from the tree rooted at n, for example
+// after a tool has rewritten it. Its String is n.String(). Options select
+// the pre-release rule, as for ParseConstraint; without them the rule is
+// npm's.
+func (n *OrNode) Constraint(opts ...ConstraintOption) Constraint {
+	var cfg constraintConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	groups := make([][]comparator, len(n.Groups))
+	for i, g := range n.Groups {
+		group := make([]comparator, len(g.Comparators))
+		for j, c := range g.Comparators {
+			group[j] = comparator{op: c.Op, v: c.Version}
+		}
+		if len(group) == 0 {
+			group = append(group, comparator{op: OpGE, v: minVersion})
+		}
+		groups[i] = group
+	}
+	c := newConstraint(groups)
+	c.prereleases, c.allowStatus = cfg.prereleaseRule(), cfg.allowStatus
+	return c
+}
+
+// A Visitor's Visit method is invoked for each node encountered by Walk.
+// If the result visitor w is not nil, Walk visits each of the children
+// of n with w, followed by a call of w.Visit(nil).
+type Visitor interface {
+	Visit(n Node) (w Visitor)
+}
+
+// Walk traverses a constraint's syntax tree in depth-first order: it
+// starts by calling v.Visit(n); n must not be nil. If the visitor w
+// returned by v.Visit(n) is not nil, Walk is invoked recursively with
+// visitor w for each of the children of n, followed by a call of
+// w.Visit(nil).
+func Walk(v Visitor, n Node) {
+	if v = v.Visit(n); v == nil {
+		return
+	}
+	switch n := n.(type) {
+	case *OrNode:
+		for _, g := range n.Groups {
+			Walk(v, g)
+		}
+	case *AndNode:
+		for _, c := range n.Comparators {
+			Walk(v, c)
+		}
+	}
+	v.Visit(nil)
+}
+
+type inspector func(Node) bool
+
+func (f inspector) Visit(n Node) Visitor {
+	if f(n) {
+		return f
+	}
+	return nil
+}
+
+// Inspect traverses a constraint's syntax tree in depth-first order: it
+// starts by calling f(n); n must not be nil. If f returns true, Inspect
+// invokes f recursively for each of the children of n, followed by a
+// call of f(nil).
+func Inspect(n Node, f func(Node) bool) {
+	Walk(inspector(f), n)
+}
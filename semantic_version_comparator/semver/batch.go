@@ -0,0 +1,172 @@
+package semver
+
+import "sort"
+
+// Batch holds many versions in columns: one slice per numeric component
+// and the pre-release and build metadata of every version copied into a
+// single shared string, referenced by offsets. A batch of a million
+// versions is a handful of allocations, none of which the garbage
+// collector has to scan, where a []Version holds three strings per
+// version and, through them, keeps every input string alive.
+//
+// Build a Batch with BatchParse. Versions are addressed by their index in
+// the batch, from 0 to Len()-1.
+type Batch struct {
+	Epoch, Major, Minor, Patch []uint64
+	// pre and build locate the pre-release and build metadata of each
+	// version in text.
+	pre, build []span
+	// source is the index of each version in the input to BatchParse.
+	source []int
+	text   string
+}
+
+// span is the range [off, end) of Batch.text.
+type span struct{ off, end uint32 }
+
+// BatchError is a string that BatchParse could not parse.
+type BatchError struct {
+	Index int // the index of the string in the input
+	Err   error
+}
+
+func (e BatchError) Error() string { return e.Err.Error() }
+
+func (e BatchError) Unwrap() error { return e.Err }
+
+// BatchParse parses ss with ParseWith and opts into a Batch of the
+// versions that parse, in input order, and returns the errors of those
+// that do not. The batch does not retain ss.
+func BatchParse(ss []string, opts ...ParseOption) (*Batch, []BatchError) {
+	b := &Batch{
+		Epoch:  make([]uint64, 0, len(ss)),
+		Major:  make([]uint64, 0, len(ss)),
+		Minor:  make([]uint64, 0, len(ss)),
+		Patch:  make([]uint64, 0, len(ss)),
+		pre:    make([]span, 0, len(ss)),
+		build:  make([]span, 0, len(ss)),
+		source: make([]int, 0, len(ss)),
+	}
+	var cfg parseConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	var errs []BatchError
+	var arena []byte
+	add := func(s string) span {
+		off := len(arena)
+		arena = append(arena, s...)
+		return span{uint32(off), uint32(len(arena))}
+	}
+	for i, s := range ss {
+		v, err := cfg.parse(s)
+		if err != nil {
+			errs = append(errs, BatchError{Index: i, Err: err})
+			continue
+		}
+		b.Epoch = append(b.Epoch, v.Epoch)
+		b.Major = append(b.Major, v.Major)
+		b.Minor = append(b.Minor, v.Minor)
+		b.Patch = append(b.Patch, v.Patch)
+		b.pre = append(b.pre, add(v.Prerelease))
+		b.build = append(b.build, add(v.Build))
+		b.source = append(b.source, i)
+	}
+	b.text = string(arena)
+	return b, errs
+}
+
+// Len returns the number of versions in b.
+func (b *Batch) Len() int { return len(b.Major) }
+
+// Prerelease returns the pre-release of version i, without the "-".
+func (b *Batch) Prerelease(i int) string {
+	return b.text[b.pre[i].off:b.pre[i].end]
+}
+
+// BuildMetadata returns the build metadata of version i, without the "+".
+func (b *Batch) BuildMetadata(i int) string {
+	return b.text[b.build[i].off:b.build[i].end]
+}
+
+// Source returns the index in the input to BatchParse of version i.
+func (b *Batch) Source(i int) int { return b.source[i] }
+
+// Version returns version i. It does not allocate: its pre-release and
+// build metadata share the memory of b. Original returns its String form,
+// since b does not keep the input.
+func (b *Batch) Version(i int) Version {
+	return Version{
+		Epoch:      b.Epoch[i],
+		Major:      b.Major[i],
+		Minor:      b.Minor[i],
+		Patch:      b.Patch[i],
+		Prerelease: b.Prerelease(i),
+		Build:      b.BuildMetadata(i),
+	}
+}
+
+// Compare compares versions i and j by precedence, as Version.Compare
+// does.
+func (b *Batch) Compare(i, j int) int {
+	for _, col := range [...][]uint64{b.Epoch, b.Major, b.Minor, b.Patch} {
+		if n := compareUint(col[i], col[j]); n != 0 {
+			return n
+		}
+	}
+	return comparePrerelease(b.Prerelease(i), b.Prerelease(j))
+}
+
+// Sort sorts b in place by precedence, keeping versions of equal
+// precedence in input order, without allocating.
+func (b *Batch) Sort() {
+	sort.Sort(batchOrder{b})
+}
+
+// batchOrder sorts a Batch, breaking ties by source index so that the
+// unstable sort.Sort orders like a stable sort.
+type batchOrder struct{ *Batch }
+
+func (o batchOrder) Less(i, j int) bool {
+	if n := o.Compare(i, j); n != 0 {
+		return n < 0
+	}
+	return o.source[i] < o.source[j]
+}
+
+func (o batchOrder) Swap(i, j int) {
+	for _, col := range [...][]uint64{o.Epoch, o.Major, o.Minor, o.Patch} {
+		col[i], col[j] = col[j], col[i]
+	}
+	o.pre[i], o.pre[j] = o.pre[j], o.pre[i]
+	o.build[i], o.build[j] = o.build[j], o.build[i]
+	o.source[i], o.source[j] = o.source[j], o.source[i]
+}
+
+// Filter returns a batch of the versions of b that satisfy c, in the
+// order of b. It shares the pre-release and build metadata of b.
+func (b *Batch) Filter(c Constraint) *Batch {
+	out := &Batch{text: b.text}
+	for i := range b.Major {
+		if !c.Check(b.Version(i)) {
+			continue
+		}
+		out.Epoch = append(out.Epoch, b.Epoch[i])
+		out.Major = append(out.Major, b.Major[i])
+		out.Minor = append(out.Minor, b.Minor[i])
+		out.Patch = append(out.Patch, b.Patch[i])
+		out.pre = append(out.pre, b.pre[i])
+		out.build = append(out.build, b.build[i])
+		out.source = append(out.source, b.source[i])
+	}
+	return out
+}
+
+// Versions returns the versions of b as a slice.
+func (b *Batch) Versions() []Version {
+	vs := make([]Version, b.Len())
+	for i := range vs {
+		vs[i] = b.Version(i)
+	}
+	return vs
+}
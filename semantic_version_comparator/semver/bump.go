@@ -0,0 +1,127 @@
+package semver
+
+import "strings"
+
+// IncMajor returns the next major version. A pre-release of a major
+// version (1.0.0-rc.1) is promoted to that release (1.0.0) instead.
+// Build metadata is always dropped. Like the other Inc methods, IncMajor
+// keeps the epoch, and it saturates rather than wrapping: if the major
+// component is already math.MaxUint64, it returns the release of v.
+func (v Version) IncMajor() Version {
+	if v.Prerelease != "" && v.Minor == 0 && v.Patch == 0 {
+		return Version{Epoch: v.Epoch, Major: v.Major}
+	}
+	return v.incAt(0)
+}
+
+// IncMinor returns the next minor version, resetting the patch component.
+// A pre-release of a minor version (1.2.0-rc.1) is promoted to that
+// release (1.2.0) instead. Build metadata is always dropped. A minor
+// component of math.MaxUint64 carries into the major component, as in
+// NextMinorBoundary.
+func (v Version) IncMinor() Version {
+	if v.Prerelease != "" && v.Patch == 0 {
+		return Version{Epoch: v.Epoch, Major: v.Major, Minor: v.Minor}
+	}
+	return v.incAt(1)
+}
+
+// IncPatch returns the next patch version. A pre-release (1.2.3-rc.1) is
+// promoted to its release (1.2.3) instead. Build metadata is always
+// dropped. A patch component of math.MaxUint64 carries into the minor
+// component, as in NextPatchBoundary.
+func (v Version) IncPatch() Version {
+	if v.Prerelease != "" {
+		return Version{Epoch: v.Epoch, Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	}
+	return v.incAt(2)
+}
+
+// incAt returns the version bumpAt returns, keeping the epoch of v, or
+// the release of v if no component can be incremented.
+func (v Version) incAt(level int) Version {
+	up, ok := bumpAt(v, level)
+	if !ok {
+		return Version{Epoch: v.Epoch, Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	}
+	up.Epoch = v.Epoch
+	return up
+}
+
+// NextMajorBoundary returns the exclusive upper bound of the major
+// series of v: the lowest pre-release of the next major version, so that
+// NextMajorBoundary(1.4.7) is 2.0.0-0. A range [v, 2.0.0-0) then excludes
+// 2.0.0-rc.1 as well as 2.0.0, where [v, 2.0.0) would admit the release
+// candidate. Unlike IncMajor, it does not treat a pre-release of v
+// specially: the boundary of 2.0.0-rc.1 is 3.0.0-0. The epoch is kept.
+// ok is false if the major component cannot be incremented.
+func NextMajorBoundary(v Version) (_ Version, ok bool) {
+	return boundaryAt(v, 0)
+}
+
+// NextMinorBoundary returns the exclusive upper bound of the minor series
+// of v, as NextMajorBoundary does for the major series:
+// NextMinorBoundary(1.4.7) is 1.5.0-0. If the minor component cannot be
+// incremented, the boundary is that of the major series.
+func NextMinorBoundary(v Version) (_ Version, ok bool) {
+	return boundaryAt(v, 1)
+}
+
+// NextPatchBoundary returns the exclusive upper bound of the versions
+// sharing the core of v: NextPatchBoundary(1.4.7) is 1.4.8-0, above every
+// pre-release and build of 1.4.7. If the patch component cannot be
+// incremented, the boundary is that of the minor series.
+func NextPatchBoundary(v Version) (_ Version, ok bool) {
+	return boundaryAt(v, 2)
+}
+
+// boundaryAt returns the lowest pre-release of the version bumpAt
+// returns, keeping the epoch of v.
+func boundaryAt(v Version, level int) (Version, bool) {
+	up, ok := bumpAt(v, level)
+	if !ok {
+		return Version{}, false
+	}
+	up.Epoch, up.Prerelease = v.Epoch, "0"
+	return up, true
+}
+
+// IncPrerelease returns the next pre-release version. If the last
+// pre-release identifier is numeric it is incremented (1.2.3-rc.1 becomes
+// 1.2.3-rc.2), otherwise ".0" is appended (1.2.3-rc becomes 1.2.3-rc.0).
+// A release is bumped to the first pre-release of the next patch
+// (1.2.3 becomes 1.2.4-0), carrying as IncPatch does. Build metadata is
+// always dropped.
+func (v Version) IncPrerelease() Version {
+	next := Version{Epoch: v.Epoch, Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	if v.Prerelease == "" {
+		if up, ok := NextPatchBoundary(v); ok {
+			return up
+		}
+		return next
+	}
+	head, last := "", v.Prerelease
+	if i := strings.LastIndexByte(last, '.'); i >= 0 {
+		head, last = last[:i+1], last[i+1:]
+	}
+	if isNumeric(last) {
+		next.Prerelease = head + incDecimal(strings.TrimLeft(last, "0"))
+	} else {
+		next.Prerelease = v.Prerelease + ".0"
+	}
+	return next
+}
+
+// incDecimal adds one to the non-negative decimal integer s without
+// bounding its size.
+func incDecimal(s string) string {
+	b := []byte(s)
+	for i := len(b) - 1; i >= 0; i-- {
+		if b[i] < '9' {
+			b[i]++
+			return string(b)
+		}
+		b[i] = '0'
+	}
+	return "1" + string(b)
+}
@@ -0,0 +1,53 @@
+package semver
+
+import (
+	"math"
+	"testing"
+)
+
+func TestInc(t *testing.T) {
+	max := uint64(math.MaxUint64)
+	tests := []struct {
+		v                               Version
+		major, minor, patch, prerelease string
+	}{
+		{MustParse("1.2.3"), "2.0.0", "1.3.0", "1.2.4", "1.2.4-0"},
+		{MustParse("1.2.3+build"), "2.0.0", "1.3.0", "1.2.4", "1.2.4-0"},
+		{MustParse("1.0.0-rc.1"), "1.0.0", "1.0.0", "1.0.0", "1.0.0-rc.2"},
+		{MustParse("1.2.0-rc"), "2.0.0", "1.2.0", "1.2.0", "1.2.0-rc.0"},
+		{MustParse("1.2.3-rc.9"), "2.0.0", "1.3.0", "1.2.3", "1.2.3-rc.10"},
+		{MustParse("1.2.3-99999999999999999999999"), "2.0.0", "1.3.0", "1.2.3", "1.2.3-100000000000000000000000"},
+		{Version{Major: 1, Minor: 2, Patch: max}, "2.0.0", "1.3.0", "1.3.0", "1.3.0-0"},
+		{Version{Major: 1, Minor: max, Patch: max}, "2.0.0", "2.0.0", "2.0.0", "2.0.0-0"},
+		{Version{Major: max, Minor: max, Patch: max}, "18446744073709551615.18446744073709551615.18446744073709551615",
+			"18446744073709551615.18446744073709551615.18446744073709551615",
+			"18446744073709551615.18446744073709551615.18446744073709551615",
+			"18446744073709551615.18446744073709551615.18446744073709551615"},
+		{Version{Major: max, Minor: 1, Patch: 2, Build: "b"}, "18446744073709551615.1.2", "18446744073709551615.2.0", "18446744073709551615.1.3", "18446744073709551615.1.3-0"},
+	}
+	for _, tt := range tests {
+		for _, got := range []struct {
+			name string
+			v    Version
+			want string
+		}{
+			{"IncMajor", tt.v.IncMajor(), tt.major},
+			{"IncMinor", tt.v.IncMinor(), tt.minor},
+			{"IncPatch", tt.v.IncPatch(), tt.patch},
+			{"IncPrerelease", tt.v.IncPrerelease(), tt.prerelease},
+		} {
+			if got.v.String() != got.want {
+				t.Errorf("%v.%s() = %v; want %s", tt.v, got.name, got.v, got.want)
+			}
+			if tt.v.Major != max && got.v.Compare(tt.v) <= 0 {
+				t.Errorf("%v.%s() = %v, not above the version", tt.v, got.name, got.v)
+			}
+		}
+	}
+	v := Version{Epoch: 2, Major: 1, Minor: max, Patch: max}
+	for _, got := range []Version{v.IncMajor(), v.IncMinor(), v.IncPatch(), v.IncPrerelease()} {
+		if got.Epoch != 2 {
+			t.Errorf("Inc of %v dropped the epoch: %v", v, got)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package semver
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Cache memoizes the results of parsing version strings. It is safe for
+// concurrent use. A Cache with a positive capacity evicts the least
+// recently used entry once full; with a capacity of zero or less it keeps
+// every entry, interning the parsed form of each distinct string.
+type Cache struct {
+	parse    func(string) (Version, error)
+	capacity int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     list.List // of *cacheEntry, most recently used first
+	hits    uint64
+	misses  uint64
+}
+
+type cacheEntry struct {
+	key string
+	v   Version
+	err error
+}
+
+// NewCache returns a cache that parses with parse, holding at most
+// capacity entries.
+func NewCache(capacity int, parse func(string) (Version, error)) *Cache {
+	return &Cache{
+		parse:    parse,
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Parse returns the cached result of parsing s, parsing and caching it on
+// a miss. Parse errors are cached as well.
+func (c *Cache) Parse(s string) (Version, error) {
+	c.mu.Lock()
+	if el, ok := c.entries[s]; ok {
+		c.lru.MoveToFront(el)
+		e := el.Value.(*cacheEntry)
+		c.hits++
+		c.mu.Unlock()
+		return e.v, e.err
+	}
+	c.misses++
+	c.mu.Unlock()
+
+	// Parse outside the lock; a concurrent miss on the same key just does
+	// the work twice.
+	v, err := c.parse(s)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[s]; ok {
+		c.lru.MoveToFront(el)
+		return v, err
+	}
+	c.entries[s] = c.lru.PushFront(&cacheEntry{s, v, err})
+	if c.capacity > 0 && c.lru.Len() > c.capacity {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+	return v, err
+}
+
+// Len returns the number of cached entries.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Stats returns the number of calls to Parse answered from the cache and
+// the number that had to parse.
+func (c *Cache) Stats() (hits, misses uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
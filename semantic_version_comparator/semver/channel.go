@@ -0,0 +1,89 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Channel is the release channel of a version. Channels order by
+// maturity, so they can be compared with < and >. SemVer precedence
+// agrees only for lower-case labels: 1.0.0-alpha < 1.0.0-beta <
+// 1.0.0-rc, but identifiers compare in ASCII order, so 1.0.0-RC1 sorts
+// before 1.0.0-alpha.
+type Channel int
+
+const (
+	// ChannelUnknown is a pre-release whose first identifier names no
+	// known channel, such as 1.0.0-0 or 1.0.0-snapshot.
+	ChannelUnknown Channel = iota
+	ChannelAlpha           // 1.0.0-alpha.1, 1.0.0-a.1
+	ChannelBeta            // 1.0.0-beta.1, 1.0.0-b.1
+	ChannelRC              // 1.0.0-rc.1
+	ChannelStable          // 1.0.0
+)
+
+var channelNames = [...]string{"unknown", "alpha", "beta", "rc", "stable"}
+
+func (c Channel) String() string {
+	if c < 0 || int(c) >= len(channelNames) {
+		return "Channel(" + strconv.Itoa(int(c)) + ")"
+	}
+	return channelNames[c]
+}
+
+// ParseChannel returns the channel with the given name: "alpha", "beta",
+// "rc" or "stable".
+func ParseChannel(name string) (Channel, error) {
+	for i, n := range channelNames {
+		if i > 0 && n == name {
+			return Channel(i), nil
+		}
+	}
+	return 0, fmt.Errorf("semver: unknown release channel %q", name)
+}
+
+// channelWords maps the first pre-release identifier, lower-cased and
+// stripped of trailing digits, to its channel.
+var channelWords = map[string]Channel{
+	"alpha": ChannelAlpha,
+	"a":     ChannelAlpha,
+	"beta":  ChannelBeta,
+	"b":     ChannelBeta,
+	"rc":    ChannelRC,
+}
+
+// Channel classifies v by its first pre-release identifier, ignoring
+// case and a trailing number: 1.0.0-beta.2 and 1.0.0-Beta2 are both
+// ChannelBeta. A release is ChannelStable.
+func (v Version) Channel() Channel {
+	if v.Prerelease == "" {
+		return ChannelStable
+	}
+	first, _, _ := strings.Cut(v.Prerelease, ".")
+	word := strings.TrimRight(strings.ToLower(first), "0123456789")
+	return channelWords[word]
+}
+
+// Promote moves v to the more mature channel target: to its first
+// pre-release "target.1" or, for ChannelStable, to the release itself.
+// So 2.0.0-beta.1 becomes 2.0.0-rc.1, which becomes 2.0.0. Build metadata
+// is dropped. Promote fails if target is not above v's channel or if the
+// result would not have higher precedence than v, as for an unknown
+// pre-release such as 2.0.0-snapshot promoted to alpha.
+func Promote(v Version, target Channel) (Version, error) {
+	if target <= ChannelUnknown || target > ChannelStable {
+		return Version{}, fmt.Errorf("semver: cannot promote to channel %v", target)
+	}
+	if cur := v.Channel(); target <= cur {
+		return Version{}, fmt.Errorf("semver: cannot promote %s from %v to %v", v, cur, target)
+	}
+	next := Version{Epoch: v.Epoch, Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	if target != ChannelStable {
+		next.Prerelease = target.String() + ".1"
+	}
+	if next.Compare(v) <= 0 {
+		return Version{}, fmt.Errorf("semver: promoting %s to %v would not increase its precedence", v, target)
+	}
+	return next, nil
+}
@@ -0,0 +1,293 @@
+package semver
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Comparator parses and compares version strings with a fixed set of
+// options. Unlike the package-level functions it may hold state, such as a
+// parse cache, so it should be created once and reused. A Comparator is
+// safe for concurrent use.
+//
+// Because the options live in the Comparator rather than in global
+// settings, one process can handle several ecosystems at once, say Go
+// module versions with NewComparator(WithVPrefix(VPrefix)) and loosely
+// written tags with NewComparator(WithLenient(), WithIgnorePrerelease()).
+type Comparator struct {
+	parse func(string) (Version, error)
+	cache *Cache
+
+	lenient          bool
+	ignorePrerelease bool
+	prefix           Prefix
+	prefixSet        bool
+	scheme           Orderer
+	mode             CompareMode
+	buildEquality    bool
+	labels           *LabelOrder
+}
+
+// CompareMode selects how much of two versions Comparator.Compare looks
+// at, so that versions can be grouped by release series without
+// truncating them first.
+type CompareMode int
+
+const (
+	CompareFull       CompareMode = iota // precedence, as Version.Compare
+	CompareCore                          // major, minor and patch only
+	CompareMajorMinor                    // major and minor only
+	CompareMajor                         // major only
+)
+
+var compareModeNames = [...]string{"full", "core", "major-minor", "major"}
+
+func (m CompareMode) String() string {
+	if m < 0 || int(m) >= len(compareModeNames) {
+		return "CompareMode(" + strconv.Itoa(int(m)) + ")"
+	}
+	return compareModeNames[m]
+}
+
+// Compare returns -1, 0 or 1 as a orders before, equal to or after b,
+// looking only at the components selected by m; the epoch always counts.
+// Under CompareMajorMinor, 1.4.0 and 1.4.7-rc.1 are equal.
+func (m CompareMode) Compare(a, b Version) int {
+	if m == CompareFull {
+		return a.Compare(b)
+	}
+	if n := compareUint(a.Epoch, b.Epoch); n != 0 {
+		return n
+	}
+	if n := compareUint(a.Major, b.Major); n != 0 || m == CompareMajor {
+		return n
+	}
+	if n := compareUint(a.Minor, b.Minor); n != 0 || m == CompareMajorMinor {
+		return n
+	}
+	return compareUint(a.Patch, b.Patch)
+}
+
+// Orderer validates and orders version strings of some versioning
+// scheme. The schemes of the scheme package implement it.
+type Orderer interface {
+	Validate(s string) error
+	Compare(a, b string) (int, error)
+}
+
+// ComparatorOption configures a Comparator.
+type ComparatorOption func(*Comparator)
+
+// WithCache makes the Comparator memoize parsed versions in an LRU cache
+// holding up to size entries; a size of zero or less caches every distinct
+// string.
+func WithCache(size int) ComparatorOption {
+	return func(c *Comparator) {
+		c.cache = NewCache(size, nil)
+	}
+}
+
+// WithLenient makes the Comparator parse versions with ParseTolerant.
+func WithLenient() ComparatorOption {
+	return func(c *Comparator) {
+		c.lenient = true
+	}
+}
+
+// WithIgnorePrerelease makes the Comparator drop the pre-release and build
+// metadata of every version it parses, so that 1.2.3-rc.1 compares equal
+// to 1.2.3 and satisfies "^1.2.0".
+func WithIgnorePrerelease() ComparatorOption {
+	return func(c *Comparator) {
+		c.ignorePrerelease = true
+	}
+}
+
+// WithVPrefix makes the Comparator require a leading "v", as Go module
+// versions have, if p is VPrefix, or reject one, as SemVer 2.0.0 does, if
+// p is NoPrefix. By default a leading "v" is optional.
+func WithVPrefix(p Prefix) ComparatorOption {
+	return func(c *Comparator) {
+		c.prefix, c.prefixSet = p, true
+	}
+}
+
+// WithCompareMode makes the Comparator's Compare and Sort methods compare
+// versions under m. Check is not affected.
+func WithCompareMode(m CompareMode) ComparatorOption {
+	return func(c *Comparator) {
+		c.mode = m
+	}
+}
+
+// WithBuildEquality makes the Comparator's Equal method tell apart
+// versions that differ only in build metadata, such as 1.2.3+sha.3f9a1c2
+// and 1.2.3+sha.8e0d4b7, for tracking the provenance of artifacts.
+// Ordering is not affected: Compare and Sort still follow precedence.
+func WithBuildEquality() ComparatorOption {
+	return func(c *Comparator) {
+		c.buildEquality = true
+	}
+}
+
+// WithLabelOrder makes the Comparator order pre-releases of the same
+// version core by o instead of by SemVer rules. It has no effect with a
+// CompareMode other than CompareFull.
+func WithLabelOrder(o *LabelOrder) ComparatorOption {
+	return func(c *Comparator) {
+		c.labels = o
+	}
+}
+
+// WithScheme makes the Comparator order strings with s, such as
+// scheme.Deb, instead of as semantic versions. Such a Comparator cannot
+// parse versions, so its Parse and Check methods fail, and the other
+// options do not apply.
+func WithScheme(s Orderer) ComparatorOption {
+	return func(c *Comparator) {
+		c.scheme = s
+	}
+}
+
+// NewComparator returns a Comparator configured by opts. Without options
+// it behaves like Parse and Version.Compare.
+func NewComparator(opts ...ComparatorOption) *Comparator {
+	c := &Comparator{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.parse = c.parseVersion
+	if c.cache != nil {
+		c.cache.parse = c.parse
+	}
+	return c
+}
+
+// parseVersion parses s according to the options of c.
+func (c *Comparator) parseVersion(s string) (Version, error) {
+	if c.scheme != nil {
+		return Version{}, fmt.Errorf("semver: %q: comparator orders by scheme and does not parse semantic versions", s)
+	}
+	allowV := true
+	if c.prefixSet {
+		trimmed := s
+		if c.lenient {
+			trimmed = strings.TrimSpace(Normalize(s))
+		}
+		hasV := trimV(trimmed) != trimmed
+		switch {
+		case c.prefix == VPrefix && !hasV:
+			return Version{}, fmt.Errorf("semver: %q: missing leading \"v\"", s)
+		case c.prefix == NoPrefix:
+			allowV = false
+		}
+	}
+	v, err := parseVersion(s, c.lenient, allowV)
+	if err != nil {
+		return Version{}, err
+	}
+	if c.ignorePrerelease {
+		v.Prerelease, v.Build = "", ""
+	}
+	return v, nil
+}
+
+// Parse parses s according to the Comparator's options.
+func (c *Comparator) Parse(s string) (Version, error) {
+	if c.cache != nil {
+		return c.cache.Parse(s)
+	}
+	return c.parse(s)
+}
+
+// Compare parses a and b and returns -1 if a < b, 0 if a == b and 1 if
+// a > b.
+func (c *Comparator) Compare(a, b string) (int, error) {
+	if c.scheme != nil {
+		return c.scheme.Compare(a, b)
+	}
+	va, err := c.Parse(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := c.Parse(b)
+	if err != nil {
+		return 0, err
+	}
+	return c.compare(va, vb), nil
+}
+
+// compare compares parsed versions under the Comparator's options.
+func (c *Comparator) compare(a, b Version) int {
+	if c.labels != nil && c.mode == CompareFull {
+		return c.labels.Compare(a, b)
+	}
+	return c.mode.Compare(a, b)
+}
+
+// Equal parses a and b and reports whether they are the same version:
+// of equal precedence under the Comparator's CompareMode and, with
+// WithBuildEquality, with the same build metadata.
+func (c *Comparator) Equal(a, b string) (bool, error) {
+	if c.scheme != nil {
+		n, err := c.scheme.Compare(a, b)
+		return n == 0, err
+	}
+	va, err := c.Parse(a)
+	if err != nil {
+		return false, err
+	}
+	vb, err := c.Parse(b)
+	if err != nil {
+		return false, err
+	}
+	return c.compare(va, vb) == 0 && (!c.buildEquality || va.Build == vb.Build), nil
+}
+
+// Check parses v and reports whether it satisfies con.
+func (c *Comparator) Check(v string, con Constraint) (bool, error) {
+	parsed, err := c.Parse(v)
+	if err != nil {
+		return false, err
+	}
+	return con.Check(parsed), nil
+}
+
+// Sort sorts ss in ascending order under the Comparator's options.
+// Strings of equal precedence keep their relative order. If any element
+// is invalid, ss is left unchanged and the first error is returned.
+func (c *Comparator) Sort(ss []string) error {
+	if c.scheme != nil {
+		for _, s := range ss {
+			if err := c.scheme.Validate(s); err != nil {
+				return err
+			}
+		}
+		slices.SortStableFunc(ss, func(a, b string) int {
+			n, _ := c.scheme.Compare(a, b)
+			return n
+		})
+		return nil
+	}
+	vs := make([]Version, len(ss))
+	for i, s := range ss {
+		v, err := c.Parse(s)
+		if err != nil {
+			return err
+		}
+		vs[i] = v
+	}
+	idx := make([]int, len(ss))
+	for i := range idx {
+		idx[i] = i
+	}
+	slices.SortStableFunc(idx, func(i, j int) int { return c.compare(vs[i], vs[j]) })
+	sorted := make([]string, len(ss))
+	for k, i := range idx {
+		sorted[k] = ss[i]
+	}
+	copy(ss, sorted)
+	return nil
+}
@@ -0,0 +1,495 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Constraint is a parsed version range such as ">=1.2.0 <2.0.0 || ^3.1".
+// Comparators within a group are ANDed together and groups separated by
+// "||" are ORed.
+type Constraint struct {
+	raw    string
+	groups [][]comparator
+	// prereleases restricts which groups pre-releases may satisfy (see
+	// Check).
+	prereleases prereleaseRule
+	// allowStatus holds the version flags that MaxSatisfying and
+	// MinSatisfying accept (see AllowStatus).
+	allowStatus Status
+}
+
+// prereleaseRule is a dialect's policy for matching pre-release versions.
+type prereleaseRule int
+
+const (
+	// prereleasesByPrecedence matches pre-releases like any other version.
+	prereleasesByPrecedence prereleaseRule = iota
+	// prereleasesNamedInGroup is npm's rule: some comparator in the group
+	// must name a pre-release of the same release triple.
+	prereleasesNamedInGroup
+	// prereleasesNamedByAll is go-version's rule, used by Terraform: every
+	// comparator in the group must name a pre-release of the same triple.
+	prereleasesNamedByAll
+	// prereleasesNamedByEach is Masterminds/semver's rule, used by Helm:
+	// every comparator in the group other than != must name a
+	// pre-release, of any triple.
+	prereleasesNamedByEach
+)
+
+// Operator is the comparison made by a comparator of a constraint.
+type Operator int
+
+const (
+	OpEQ Operator = iota // =
+	OpNE                 // !=
+	OpGT                 // >
+	OpGE                 // >=
+	OpLT                 // <
+	OpLE                 // <=
+)
+
+var operatorStrings = [...]string{"=", "!=", ">", ">=", "<", "<="}
+
+func (op Operator) String() string {
+	if op < 0 || int(op) >= len(operatorStrings) {
+		return "Operator(" + strconv.Itoa(int(op)) + ")"
+	}
+	return operatorStrings[op]
+}
+
+// comparator is a single primitive check against a concrete version. All
+// range sugar (caret, tilde, wildcards, hyphen ranges) is expanded into
+// comparators at parse time.
+type comparator struct {
+	op Operator
+	v  Version
+}
+
+func (c comparator) check(v Version) bool {
+	n := v.Compare(c.v)
+	switch c.op {
+	case OpEQ:
+		return n == 0
+	case OpNE:
+		return n != 0
+	case OpGT:
+		return n > 0
+	case OpGE:
+		return n >= 0
+	case OpLT:
+		return n < 0
+	case OpLE:
+		return n <= 0
+	}
+	return false
+}
+
+func (c comparator) String() string {
+	return c.op.String() + c.v.String()
+}
+
+// ParseConstraint parses a constraint expression. In the default npm
+// dialect it supports the operators =, !=, >, >=, <, <=, caret (^1.2.3),
+// tilde (~1.2.3), wildcards (1.2.x, 1.*, *), hyphen ranges
+// (1.2.3 - 2.0.0) and "||" alternatives. Comparators within an
+// alternative are separated by spaces or commas. Use WithDialect to parse
+// the syntax of another ecosystem.
+func ParseConstraint(s string, opts ...ConstraintOption) (Constraint, error) {
+	var cfg constraintConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if err := cfg.limits.checkLength(s); err != nil {
+		return Constraint{}, err
+	}
+	var groups [][]comparator
+	var err error
+	switch cfg.dialect {
+	case DialectRuby:
+		groups, err = parseRuby(s)
+	case DialectNuGet:
+		groups, err = parseNuGet(s)
+	case DialectTerraform:
+		groups, err = parseTerraform(s)
+	case DialectHelm:
+		groups, err = parseHelm(s)
+	default:
+		groups, err = parseNPM(s)
+	}
+	if err != nil {
+		return Constraint{}, fmt.Errorf("semver: invalid constraint %q: %v", s, err)
+	}
+	if err := cfg.limits.checkConstraint(groups); err != nil {
+		return Constraint{}, err
+	}
+	if cfg.includePrerelease && (cfg.dialect == DialectNPM || cfg.dialect == DialectHelm) {
+		// As in npm, ">=0.0.0" (the expansion of "*") admits every
+		// pre-release once pre-releases are included.
+		for _, g := range groups {
+			for i, cmp := range g {
+				if cmp.op == OpGE && cmp.v.Equal(Version{}) {
+					g[i].v = minVersion
+				}
+			}
+		}
+	}
+	return Constraint{raw: s, groups: groups, prereleases: cfg.prereleaseRule(), allowStatus: cfg.allowStatus}, nil
+}
+
+// prereleaseRule returns the pre-release rule of the configured dialect.
+func (cfg constraintConfig) prereleaseRule() prereleaseRule {
+	switch {
+	case cfg.includePrerelease:
+	case cfg.dialect == DialectNPM:
+		return prereleasesNamedInGroup
+	case cfg.dialect == DialectTerraform:
+		return prereleasesNamedByAll
+	case cfg.dialect == DialectHelm:
+		return prereleasesNamedByEach
+	}
+	return prereleasesByPrecedence
+}
+
+// MustParseConstraint is like ParseConstraint but panics if s cannot be
+// parsed.
+func MustParseConstraint(s string, opts ...ConstraintOption) Constraint {
+	c, err := ParseConstraint(s, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func parseNPM(s string) ([][]comparator, error) {
+	var groups [][]comparator
+	for _, alt := range strings.Split(s, "||") {
+		group, err := parseGroup(alt, parseTerm)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// Check reports whether v satisfies the constraint.
+//
+// Constraints in the npm dialect follow npm's pre-release rule: a
+// pre-release version satisfies an alternative only if one of its
+// comparators names a pre-release of the same MAJOR.MINOR.PATCH, so
+// 1.2.3-beta.2 satisfies ">=1.2.3-beta.1" but neither 1.2.4-beta nor
+// 2.0.0-rc.1 satisfies "^1.2.3". The Terraform dialect is stricter and
+// requires every comparator of the alternative to name one. The Helm
+// dialect requires every comparator except != to name a pre-release, but
+// of any release, so ">=1.19.0-0" admits 1.20.5-gke.1. Parse with
+// IncludePrerelease to order pre-releases by precedence alone.
+func (c Constraint) Check(v Version) bool {
+	for _, group := range c.groups {
+		if checkGroup(group, v) && c.prereleases.allows(group, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the constraint as it was written.
+func (c Constraint) String() string {
+	return c.raw
+}
+
+func checkGroup(group []comparator, v Version) bool {
+	for _, cmp := range group {
+		if !cmp.check(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// allows reports whether rule lets v, which satisfies every comparator in
+// group, match the group.
+func (rule prereleaseRule) allows(group []comparator, v Version) bool {
+	if v.Prerelease == "" || rule == prereleasesByPrecedence {
+		return true
+	}
+	if rule == prereleasesNamedByEach {
+		for _, cmp := range group {
+			if cmp.op != OpNE && cmp.v.Prerelease == "" {
+				return false
+			}
+		}
+		return true
+	}
+	all := rule == prereleasesNamedByAll
+	for _, cmp := range group {
+		named := cmp.v.Prerelease != "" && cmp.v.Major == v.Major && cmp.v.Minor == v.Minor && cmp.v.Patch == v.Patch
+		if named != all {
+			return named
+		}
+	}
+	return all
+}
+
+// parseGroup parses a single "||" alternative into ANDed comparators,
+// expanding each operator-prefixed version with term.
+func parseGroup(s string, term func(string) ([]comparator, error)) ([]comparator, error) {
+	fields := strings.Fields(strings.ReplaceAll(s, ",", " "))
+	if len(fields) == 0 {
+		return []comparator{{op: OpGE}}, nil
+	}
+	if len(fields) == 3 && fields[1] == "-" {
+		return parseHyphen(fields[0], fields[2])
+	}
+	var terms []string
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		if f == "-" {
+			return nil, fmt.Errorf("unexpected hyphen")
+		}
+		if isOperator(f) {
+			if i+1 == len(fields) {
+				return nil, fmt.Errorf("operator %q without version", f)
+			}
+			i++
+			f += fields[i]
+		}
+		terms = append(terms, f)
+	}
+	var group []comparator
+	for _, t := range terms {
+		cmps, err := term(t)
+		if err != nil {
+			return nil, err
+		}
+		group = append(group, cmps...)
+	}
+	return group, nil
+}
+
+var operatorPrefixes = []string{">=", "<=", "!=", ">", "<", "=", "^", "~"}
+
+func isOperator(s string) bool {
+	for _, p := range operatorPrefixes {
+		if s == p {
+			return true
+		}
+	}
+	return false
+}
+
+func splitOperator(s string) (op, rest string) {
+	for _, p := range operatorPrefixes {
+		if strings.HasPrefix(s, p) {
+			return p, s[len(p):]
+		}
+	}
+	return "", s
+}
+
+// parseTerm expands a single operator-prefixed version into comparators.
+func parseTerm(t string) ([]comparator, error) {
+	op, rest := splitOperator(t)
+	p, err := parsePartial(rest)
+	if err != nil {
+		return nil, err
+	}
+	switch op {
+	case "^":
+		return caretRange(p), nil
+	case "~":
+		return tildeRange(p), nil
+	case ">":
+		if p.n == 0 {
+			return []comparator{{op: OpLT}}, nil
+		}
+		if p.n < 3 {
+			up, ok := p.upper()
+			if !ok {
+				return []comparator{{op: OpLT, v: minVersion}}, nil
+			}
+			return []comparator{{op: OpGE, v: up}}, nil
+		}
+		return []comparator{{op: OpGT, v: p.v}}, nil
+	case ">=":
+		return []comparator{{op: OpGE, v: p.v}}, nil
+	case "<":
+		return []comparator{{op: OpLT, v: p.v}}, nil
+	case "<=":
+		if p.n == 0 {
+			return []comparator{{op: OpGE}}, nil
+		}
+		if p.n < 3 {
+			return upTo(p), nil
+		}
+		return []comparator{{op: OpLE, v: p.v}}, nil
+	case "!=":
+		if p.n < 3 {
+			return nil, fmt.Errorf("wildcard %q not allowed with !=", rest)
+		}
+		return []comparator{{op: OpNE, v: p.v}}, nil
+	}
+	return p.exact(), nil
+}
+
+func parseHyphen(lo, hi string) ([]comparator, error) {
+	pl, err := parsePartial(lo)
+	if err != nil {
+		return nil, err
+	}
+	ph, err := parsePartial(hi)
+	if err != nil {
+		return nil, err
+	}
+	group := []comparator{{op: OpGE, v: pl.v}}
+	switch {
+	case ph.n == 0:
+	case ph.n < 3:
+		if up, ok := ph.upper(); ok {
+			group = append(group, comparator{op: OpLT, v: up})
+		}
+	default:
+		group = append(group, comparator{op: OpLE, v: ph.v})
+	}
+	return group, nil
+}
+
+func caretRange(p partial) []comparator {
+	lo := comparator{op: OpGE, v: p.v}
+	var hi Version
+	var ok bool
+	switch {
+	case p.n == 0:
+		return []comparator{lo}
+	case p.v.Major > 0 || p.n == 1:
+		hi, ok = bumpAt(p.v, 0)
+	case p.v.Minor > 0 || p.n == 2:
+		hi, ok = bumpAt(p.v, 1)
+	default:
+		hi, ok = bumpAt(p.v, 2)
+	}
+	if !ok {
+		return []comparator{lo}
+	}
+	return []comparator{lo, {op: OpLT, v: hi}}
+}
+
+func tildeRange(p partial) []comparator {
+	lo := comparator{op: OpGE, v: p.v}
+	if p.n == 0 {
+		return []comparator{lo}
+	}
+	level := 1
+	if p.n == 1 {
+		level = 0
+	}
+	hi, ok := bumpAt(p.v, level)
+	if !ok {
+		return []comparator{lo}
+	}
+	return []comparator{lo, {op: OpLT, v: hi}}
+}
+
+// bumpAt returns the release that increments component level of v (0 for
+// major, 1 for minor, 2 for patch) and zeroes those after it. A component
+// at math.MaxUint64 carries into the one before it; ok is false if even
+// the major component cannot be incremented.
+func bumpAt(v Version, level int) (Version, bool) {
+	const max = 1<<64 - 1
+	for ; level >= 0; level-- {
+		switch {
+		case level == 0 && v.Major != max:
+			return Version{Major: v.Major + 1}, true
+		case level == 1 && v.Minor != max:
+			return Version{Major: v.Major, Minor: v.Minor + 1}, true
+		case level == 2 && v.Patch != max:
+			return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}, true
+		}
+	}
+	return Version{}, false
+}
+
+// partial is a version whose trailing components may be missing or
+// wildcards; n counts the components that were given.
+type partial struct {
+	v Version
+	n int
+}
+
+// exact returns the comparators matching every version covered by p.
+func (p partial) exact() []comparator {
+	switch p.n {
+	case 0:
+		return []comparator{{op: OpGE}}
+	case 3:
+		return []comparator{{op: OpEQ, v: p.v}}
+	}
+	return append([]comparator{{op: OpGE, v: p.v}}, upTo(p)...)
+}
+
+// upTo returns the comparators admitting every version up to and
+// including those covered by the incomplete version p.
+func upTo(p partial) []comparator {
+	if up, ok := p.upper(); ok {
+		return []comparator{{op: OpLT, v: up}}
+	}
+	return []comparator{{op: OpGE, v: minVersion}}
+}
+
+// upper returns the smallest version above every version covered by the
+// incomplete version p. ok is false if no such version exists.
+func (p partial) upper() (Version, bool) {
+	return bumpAt(p.v, p.n-1)
+}
+
+func isWildcard(s string) bool {
+	return s == "x" || s == "X" || s == "*"
+}
+
+// parsePartial parses a possibly incomplete version such as "1", "1.2.x"
+// or "*". Pre-release and build suffixes are only allowed on complete
+// versions.
+func parsePartial(s string) (partial, error) {
+	if s == "" {
+		return partial{}, fmt.Errorf("missing version")
+	}
+	s = trimV(s)
+	core, suffix := s, ""
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		core, suffix = s[:i], s[i:]
+	}
+	parts := strings.Split(core, ".")
+	if len(parts) > 3 {
+		return partial{}, fmt.Errorf("invalid version %q", s)
+	}
+	var p partial
+	nums := [3]*uint64{&p.v.Major, &p.v.Minor, &p.v.Patch}
+	wild := false
+	for i, part := range parts {
+		if isWildcard(part) {
+			wild = true
+			continue
+		}
+		if wild || !isNumeric(part) {
+			return partial{}, fmt.Errorf("invalid version %q", s)
+		}
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return partial{}, fmt.Errorf("invalid version %q: %s component overflows uint64", s, componentNames[i])
+		}
+		*nums[i] = n
+		p.n++
+	}
+	if suffix != "" {
+		if p.n < 3 {
+			return partial{}, fmt.Errorf("invalid version %q: pre-release or build on partial version", s)
+		}
+		v, err := ParseTolerant(s)
+		if err != nil {
+			return partial{}, err
+		}
+		p.v = v
+	}
+	return p, nil
+}
@@ -0,0 +1,89 @@
+package semver
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		dialect    Dialect
+		include    bool
+		constraint string
+		match      []string
+		noMatch    []string
+	}{
+		{DialectNPM, false, "^1.2.3", []string{"1.2.3", "1.9.9"}, []string{"1.2.2", "2.0.0", "1.3.0-beta", "2.0.0-rc.1"}},
+		{DialectNPM, false, "^0.2.3", []string{"0.2.3", "0.2.9"}, []string{"0.3.0"}},
+		{DialectNPM, false, "^0.0.3", []string{"0.0.3"}, []string{"0.0.4"}},
+		{DialectNPM, false, "~1.2.3", []string{"1.2.3", "1.2.9"}, []string{"1.3.0"}},
+		{DialectNPM, false, "1.2.x", []string{"1.2.0", "1.2.99"}, []string{"1.3.0", "1.1.9"}},
+		{DialectNPM, false, "*", []string{"0.0.0", "1.0.0"}, []string{"1.0.0-rc.1"}},
+		{DialectNPM, false, "1.2.3 - 2.3.4", []string{"1.2.3", "2.3.4"}, []string{"1.2.2", "2.3.5"}},
+		{DialectNPM, false, "1.2 - 2.3", []string{"1.2.0", "2.3.9"}, []string{"2.4.0"}},
+		{DialectNPM, false, ">=1.2.3-beta.1", []string{"1.2.3-beta.2", "1.2.3", "3.0.0"}, []string{"1.2.3-alpha", "1.2.4-beta"}},
+		{DialectNPM, false, "<1.0.0 || >=2.0.0", []string{"0.9.0", "2.0.0"}, []string{"1.0.0", "1.5.0"}},
+		{DialectNPM, false, ">=1.0.0 <1.5.0, !=1.2.0", []string{"1.1.0", "1.2.1"}, []string{"1.2.0", "1.5.0"}},
+		{DialectNPM, true, "^1.2.3", []string{"1.3.0-beta", "1.2.3"}, []string{"2.0.0", "1.2.3-rc.1"}},
+		{DialectTerraform, false, "~> 1.4", []string{"1.4.0", "1.9.0"}, []string{"1.3.9", "2.0.0"}},
+		{DialectTerraform, false, "~> 1.4.2", []string{"1.4.2", "1.4.9"}, []string{"1.5.0"}},
+		{DialectTerraform, false, ">= 1.0, < 2.0, != 1.5.0", []string{"1.0.0", "1.4.9"}, []string{"1.5.0", "2.0.0"}},
+		{DialectTerraform, false, "= 1.2.0-beta1", []string{"1.2.0-beta1"}, []string{"1.2.0", "1.2.0-beta2"}},
+		{DialectTerraform, false, ">= 1.0.0", []string{"1.2.0"}, []string{"1.2.0-beta1"}},
+		{DialectHelm, false, ">=1.19.0-0", []string{"1.19.0", "1.20.5-gke.1"}, []string{"1.18.9"}},
+		{DialectHelm, false, ">=1.19.0", []string{"1.20.5"}, []string{"1.20.5-gke.1"}},
+		{DialectHelm, false, "=>1.2, =<1.5", []string{"1.2.0", "1.5.9"}, []string{"1.6.0"}},
+		{DialectHelm, false, "~>1.2", []string{"1.2.9"}, []string{"1.3.0"}},
+		{DialectRuby, false, "~> 1.4", []string{"1.4.0", "1.9.0"}, []string{"2.0.0"}},
+		{DialectRuby, false, "= 1.0.0.pre.1", []string{"1.0.0-pre.1"}, []string{"1.0.0"}},
+		{DialectNuGet, false, "[1.0,2.0)", []string{"1.0.0", "1.5.0"}, []string{"2.0.0", "0.9.0"}},
+		{DialectNuGet, false, "1.0", []string{"1.0.0", "3.0.0"}, []string{"0.9.0"}},
+		{DialectNuGet, false, "(,1.5]", []string{"0.1.0", "1.5.0"}, []string{"1.5.1"}},
+		{DialectNuGet, false, "[1.0]", []string{"1.0.0"}, []string{"1.0.1"}},
+	}
+	for _, tt := range tests {
+		c, err := ParseConstraint(tt.constraint, constraintOpts(tt.dialect, tt.include)...)
+		if err != nil {
+			t.Errorf("%v: ParseConstraint(%q): %v", tt.dialect, tt.constraint, err)
+			continue
+		}
+		if c.String() != tt.constraint {
+			t.Errorf("%v: ParseConstraint(%q).String() = %q", tt.dialect, tt.constraint, c)
+		}
+		for _, s := range tt.match {
+			if !c.Check(MustParse(s)) {
+				t.Errorf("%v: %q does not match %s", tt.dialect, tt.constraint, s)
+			}
+		}
+		for _, s := range tt.noMatch {
+			if c.Check(MustParse(s)) {
+				t.Errorf("%v: %q matches %s", tt.dialect, tt.constraint, s)
+			}
+		}
+	}
+}
+
+func TestParseConstraintErrors(t *testing.T) {
+	tests := []struct {
+		dialect    Dialect
+		constraint string
+	}{
+		{DialectNPM, ">="},
+		{DialectNPM, ">=abc"},
+		{DialectNPM, "1.2.3 - "},
+		{DialectNPM, "- 1.2.3"},
+		{DialectNuGet, "[1.0,2.0"},
+		{DialectNuGet, "[1.0,2.0,3.0]"},
+		{DialectTerraform, "~>"},
+	}
+	for _, tt := range tests {
+		if c, err := ParseConstraint(tt.constraint, WithDialect(tt.dialect)); err == nil {
+			t.Errorf("%v: ParseConstraint(%q) = %q, want an error", tt.dialect, tt.constraint, c)
+		}
+	}
+	many := strings.Repeat(">=1.0.0 ", DefaultLimits.MaxComparators+1)
+	if _, err := ParseConstraint(many, WithConstraintLimits(DefaultLimits)); !errors.Is(err, ErrLimit) {
+		t.Errorf("ParseConstraint(%d comparators) error = %v, want ErrLimit", DefaultLimits.MaxComparators+1, err)
+	}
+}
@@ -0,0 +1,99 @@
+package semver
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Dated is a tag together with the time it was published, for ordering
+// tags that precedence alone cannot separate: a registry may republish
+// 1.2.3 as 1.2.3+build.2, and repositories often carry tags such as
+// "nightly" that are not versions at all.
+type Dated struct {
+	// Tag is the tag as published.
+	Tag string
+	// Version is Tag parsed with ParseTolerant, if Valid.
+	Version Version
+	Valid   bool
+	// Time is when the tag was published. The zero Time means unknown.
+	Time time.Time
+}
+
+// NewDated parses tag with ParseTolerant and returns it with time t.
+func NewDated(tag string, t time.Time) Dated {
+	v, err := ParseTolerant(tag)
+	return Dated{Tag: tag, Version: v, Valid: err == nil, Time: t}
+}
+
+// OrderRule names the rule of Dated.Compare that decided an order.
+type OrderRule int
+
+const (
+	ByNone       OrderRule = iota // identical
+	ByValidity                    // a version before a tag that is not one
+	ByPrecedence                  // version precedence
+	ByTime                        // publication time
+	ByBuild                       // build metadata
+	ByTag                         // the tag itself
+)
+
+var ruleNames = [...]string{"none", "validity", "precedence", "time", "build", "tag"}
+
+func (r OrderRule) String() string {
+	if r < 0 || int(r) >= len(ruleNames) {
+		return "OrderRule(" + strconv.Itoa(int(r)) + ")"
+	}
+	return ruleNames[r]
+}
+
+// Compare orders d and o primarily by precedence, so that it agrees with
+// Version.Compare whenever that does not return 0. It returns -1, 0 or 1;
+// see Explain for the tie-breaking rules.
+func (d Dated) Compare(o Dated) int {
+	n, _ := d.Explain(o)
+	return n
+}
+
+// Explain is like Compare but also reports which rule decided the order,
+// or ByNone if d and o are identical. The rules apply in turn:
+//
+//   - a valid version orders before a tag that is not one (ByValidity);
+//   - valid versions order by precedence (ByPrecedence);
+//   - then by time, earlier first, an unknown time before any known one
+//     (ByTime);
+//   - then versions by build metadata in ASCII order (ByBuild);
+//   - and finally by tag, non-version tags in natural order (ByTag).
+func (d Dated) Explain(o Dated) (int, OrderRule) {
+	switch {
+	case d.Valid != o.Valid:
+		if d.Valid {
+			return -1, ByValidity
+		}
+		return 1, ByValidity
+	case d.Valid:
+		if n := d.Version.Compare(o.Version); n != 0 {
+			return n, ByPrecedence
+		}
+	}
+	if n := d.Time.Compare(o.Time); n != 0 {
+		return n, ByTime
+	}
+	if d.Valid {
+		if n := strings.Compare(d.Version.Build, o.Version.Build); n != 0 {
+			return n, ByBuild
+		}
+	}
+	if n := compareNatural(d.Tag, o.Tag); n != 0 {
+		return n, ByTag
+	}
+	return 0, ByNone
+}
+
+// SortDated sorts ds in ascending order by Dated.Compare: versions by
+// precedence, republished builds of one version by date, then tags that
+// are not versions by date.
+func SortDated(ds []Dated) {
+	slices.SortStableFunc(ds, Dated.Compare)
+}
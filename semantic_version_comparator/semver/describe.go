@@ -0,0 +1,111 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Describe is the output of git describe --tags, such as
+// "v1.4.2-14-gdeadbee-dirty": the nearest tag, the number of commits
+// made since it, the abbreviated hash of the described commit and
+// whether the working tree had local modifications.
+type Describe struct {
+	Base    Version // the version of the nearest tag
+	Commits uint64  // commits since Base; zero when describing the tag itself
+	Hash    string  // abbreviated commit hash, without the "g"; empty if Commits is zero
+	Dirty   bool
+}
+
+// ParseDescribe parses the output of git describe --tags for a tag that
+// Parse accepts, in any of the forms "v1.4.2", "v1.4.2-dirty",
+// "v1.4.2-14-gdeadbee" and "v1.4.2-14-gdeadbee-dirty". The tag may be a
+// pre-release, as in "v2.0.0-rc.1-3-gabc1234". Parsed directly,
+// "v1.4.2-14-gdeadbee" would be a pre-release of 1.4.2 and sort below
+// it; Describe.Compare and Describe.Version order it above.
+func ParseDescribe(s string) (Describe, error) {
+	var d Describe
+	rest := strings.TrimSpace(s)
+	rest, d.Dirty = strings.CutSuffix(rest, "-dirty")
+	if i := strings.LastIndex(rest, "-g"); i > 0 {
+		hash := rest[i+2:]
+		if j := strings.LastIndexByte(rest[:i], '-'); j > 0 && len(hash) >= 4 && isHex(hash) {
+			if n, err := strconv.ParseUint(rest[j+1:i], 10, 64); err == nil && isNumeric(rest[j+1:i]) {
+				d.Commits, d.Hash, rest = n, hash, rest[:j]
+			}
+		}
+	}
+	v, err := Parse(rest)
+	if err != nil {
+		return Describe{}, fmt.Errorf("semver: %q is not git describe output of a version tag", s)
+	}
+	d.Base = v
+	return d, nil
+}
+
+// Compare orders d and o by base version, then by commits since it, then
+// a dirty tree after a clean one. Descriptions that differ only in their
+// hash, commits on different branches, order by hash so that sorting is
+// deterministic.
+func (d Describe) Compare(o Describe) int {
+	if n := d.Base.Compare(o.Base); n != 0 {
+		return n
+	}
+	if n := compareUint(d.Commits, o.Commits); n != 0 {
+		return n
+	}
+	if d.Dirty != o.Dirty {
+		if d.Dirty {
+			return 1
+		}
+		return -1
+	}
+	return strings.Compare(d.Hash, o.Hash)
+}
+
+// Version returns a semantic version that orders like d among tagged
+// versions: the base itself when d describes a clean tag, and otherwise,
+// in the manner of Go pseudo-versions, a pre-release after the base and
+// before the next release. "v1.4.2-14-gdeadbee" becomes
+// 1.4.3-0.14+gdeadbee and "v2.0.0-rc.1-3-gabc1234-dirty" becomes
+// 2.0.0-rc.1.0.3+gabc1234.dirty.
+func (d Describe) Version() Version {
+	if d.Commits == 0 && !d.Dirty {
+		return d.Base
+	}
+	b := d.Base
+	b.original = ""
+	var build []string
+	if d.Hash != "" {
+		build = append(build, "g"+d.Hash)
+	}
+	if d.Dirty {
+		build = append(build, "dirty")
+	}
+	b.Build = strings.Join(build, ".")
+	n := strconv.FormatUint(d.Commits, 10)
+	if b.Prerelease != "" {
+		b.Prerelease += ".0." + n
+		return b
+	}
+	next, ok := bumpAt(b, 2)
+	if !ok {
+		b.Prerelease = "0." + n
+		return b
+	}
+	next.Epoch, next.Prerelease, next.Build = b.Epoch, "0."+n, b.Build
+	return next
+}
+
+// String returns d in git describe form, with the base tag as it was
+// written.
+func (d Describe) String() string {
+	s := d.Base.Original()
+	if d.Commits > 0 || d.Hash != "" {
+		s += "-" + strconv.FormatUint(d.Commits, 10) + "-g" + d.Hash
+	}
+	if d.Dirty {
+		s += "-dirty"
+	}
+	return s
+}
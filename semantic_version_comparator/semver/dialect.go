@@ -0,0 +1,306 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Dialect selects the constraint syntax accepted by ParseConstraint.
+// Every dialect produces the same kind of Constraint, so constraints from
+// different ecosystems can be checked, intersected and combined together.
+type Dialect int
+
+const (
+	// DialectNPM is the default syntax: npm-style comparators, caret,
+	// tilde, wildcards, hyphen ranges and "||" alternatives.
+	DialectNPM Dialect = iota
+	// DialectRuby is the RubyGems requirement syntax: comma-separated
+	// requirements using =, !=, >, >=, <, <= and the pessimistic operator
+	// "~>", where "~> 1.4" means >=1.4.0 <2.0.0 and "~> 1.4.2" means
+	// >=1.4.2 <1.5.0. A requirement without an operator means "=".
+	// Pre-release segments may be written RubyGems-style, as in
+	// "1.0.0.pre.1".
+	DialectRuby
+	// DialectNuGet is the NuGet version range syntax: a bare version is a
+	// minimum ("1.0" means >=1.0.0), "[1.0]" is an exact match and
+	// intervals are written with brackets for inclusive and parentheses
+	// for exclusive bounds, as in "[1.0,2.0)" or "(,1.5]".
+	DialectNuGet
+	// DialectTerraform is the HashiCorp constraint syntax used in
+	// Terraform required_version and required_providers blocks: the
+	// RubyGems operators, including "~>", separated by commas. As in
+	// Terraform, a pre-release only satisfies a constraint whose
+	// comparators all name a pre-release of the same release, which in
+	// practice means an exact "= 1.2.0-beta1".
+	DialectTerraform
+	// DialectHelm is the Masterminds/semver syntax that Helm uses for the
+	// kubeVersion and dependency version fields of Chart.yaml: the npm
+	// operators, plus "=>", "=<" and "~>" as aliases of ">=", "<=" and
+	// "~". Its pre-release rule differs from npm's: a pre-release only
+	// satisfies comparators that name a pre-release themselves, of any
+	// release, which is what the "-0" idiom relies on: ">=1.19.0-0"
+	// admits the 1.20.5-gke.1 of a managed cluster, while ">=1.19.0" does
+	// not. A caret or tilde range naming a pre-release also admits
+	// pre-releases up to, but excluding, those of its upper bound.
+	DialectHelm
+)
+
+var dialectNames = [...]string{"npm", "ruby", "nuget", "terraform", "helm"}
+
+func (d Dialect) String() string {
+	if d >= 0 && int(d) < len(dialectNames) {
+		return dialectNames[d]
+	}
+	return "Dialect(" + strconv.Itoa(int(d)) + ")"
+}
+
+// ParseDialect returns the dialect with the given name: "npm", "ruby",
+// "nuget", "terraform" or "helm".
+func ParseDialect(name string) (Dialect, error) {
+	for i, n := range dialectNames {
+		if n == name {
+			return Dialect(i), nil
+		}
+	}
+	return 0, fmt.Errorf("semver: unknown constraint dialect %q", name)
+}
+
+// ConstraintOption configures ParseConstraint.
+type ConstraintOption func(*constraintConfig)
+
+type constraintConfig struct {
+	dialect           Dialect
+	includePrerelease bool
+	allowStatus       Status
+	limits            Limits
+}
+
+// WithDialect makes ParseConstraint accept the syntax of dialect d.
+func WithDialect(d Dialect) ConstraintOption {
+	return func(c *constraintConfig) {
+		c.dialect = d
+	}
+}
+
+// IncludePrerelease lifts the npm and Terraform pre-release rules (see
+// Constraint.Check), so that pre-release versions satisfy a constraint
+// whenever their precedence falls within its ranges. As in npm, ">=0.0.0"
+// and therefore "*" then match pre-releases of 0.0.0 too.
+func IncludePrerelease() ConstraintOption {
+	return func(c *constraintConfig) {
+		c.includePrerelease = true
+	}
+}
+
+var rubyOperators = []string{"~>", ">=", "<=", "!=", ">", "<", "="}
+
+func parseRuby(s string) ([][]comparator, error) {
+	return parseRequirements(s, func(v string) (partial, error) {
+		return parseDialectVersion(rubyPrerelease(v))
+	})
+}
+
+func parseTerraform(s string) ([][]comparator, error) {
+	return parseRequirements(s, parseDialectVersion)
+}
+
+// parseRequirements parses the comma-separated requirement lists shared by
+// RubyGems and Terraform, parsing each version with version.
+func parseRequirements(s string, version func(string) (partial, error)) ([][]comparator, error) {
+	var group []comparator
+	for _, req := range strings.Split(s, ",") {
+		req = strings.TrimSpace(req)
+		if req == "" {
+			return nil, fmt.Errorf("empty requirement")
+		}
+		op, rest := "=", req
+		for _, o := range rubyOperators {
+			if strings.HasPrefix(req, o) {
+				op, rest = o, strings.TrimSpace(req[len(o):])
+				break
+			}
+		}
+		p, err := version(rest)
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case "~>":
+			group = append(group, pessimisticRange(p)...)
+		case "!=":
+			group = append(group, comparator{op: OpNE, v: p.v})
+		case ">":
+			group = append(group, comparator{op: OpGT, v: p.v})
+		case ">=":
+			group = append(group, comparator{op: OpGE, v: p.v})
+		case "<":
+			group = append(group, comparator{op: OpLT, v: p.v})
+		case "<=":
+			group = append(group, comparator{op: OpLE, v: p.v})
+		default:
+			group = append(group, comparator{op: OpEQ, v: p.v})
+		}
+	}
+	return [][]comparator{group}, nil
+}
+
+// rubyPrerelease rewrites a RubyGems pre-release version such as
+// "1.0.0.pre.1", whose pre-release starts at the first segment containing
+// a letter, to SemVer form ("1.0.0-pre.1").
+func rubyPrerelease(s string) string {
+	if strings.ContainsAny(s, "-+") {
+		return s
+	}
+	parts := strings.Split(s, ".")
+	for i, part := range parts {
+		if i > 0 && !isNumeric(part) {
+			return strings.Join(parts[:i], ".") + "-" + strings.Join(parts[i:], ".")
+		}
+	}
+	return s
+}
+
+// pessimisticRange expands "~> V": V up to, but excluding, the next
+// release of the second-to-last given component.
+func pessimisticRange(p partial) []comparator {
+	lo := comparator{op: OpGE, v: p.v}
+	level := max(p.n-2, 0)
+	hi, ok := bumpAt(p.v, level)
+	if !ok {
+		return []comparator{lo}
+	}
+	return []comparator{lo, {op: OpLT, v: hi}}
+}
+
+// helmAliases rewrites the operator spellings Masterminds/semver accepts
+// beyond npm's.
+var helmAliases = strings.NewReplacer("=>", ">=", "=<", "<=", "~>", "~")
+
+func parseHelm(s string) ([][]comparator, error) {
+	var groups [][]comparator
+	for _, alt := range strings.Split(helmAliases.Replace(s), "||") {
+		group, err := parseGroup(alt, parseHelmTerm)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// parseHelmTerm is parseTerm for Masterminds/semver, which checks caret
+// and tilde ranges as single comparators: when the lower bound names a
+// pre-release the upper bound admits pre-releases too, so it becomes the
+// lowest pre-release of the next release ("^1.2.3-0" is
+// ">=1.2.3-0 <2.0.0-0").
+func parseHelmTerm(t string) ([]comparator, error) {
+	cmps, err := parseTerm(t)
+	if err != nil {
+		return nil, err
+	}
+	if op, _ := splitOperator(t); (op == "^" || op == "~") && len(cmps) == 2 && cmps[0].v.Prerelease != "" {
+		cmps[1].v.Prerelease = "0"
+	}
+	return cmps, nil
+}
+
+func parseNuGet(s string) ([][]comparator, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, fmt.Errorf("missing version")
+	}
+	open, close := s[0], s[len(s)-1]
+	if open != '[' && open != '(' {
+		p, err := parseDialectVersion(s)
+		if err != nil {
+			return nil, err
+		}
+		return [][]comparator{{{op: OpGE, v: p.v}}}, nil
+	}
+	if len(s) < 2 || close != ']' && close != ')' {
+		return nil, fmt.Errorf("unbalanced brackets")
+	}
+	body := s[1 : len(s)-1]
+	lo, hi, isRange := strings.Cut(body, ",")
+	if !isRange {
+		if open != '[' || close != ']' {
+			return nil, fmt.Errorf("exact version must be written as [version]")
+		}
+		p, err := parseDialectVersion(strings.TrimSpace(body))
+		if err != nil {
+			return nil, err
+		}
+		return [][]comparator{{{op: OpEQ, v: p.v}}}, nil
+	}
+	lo, hi = strings.TrimSpace(lo), strings.TrimSpace(hi)
+	if lo == "" && hi == "" {
+		return nil, fmt.Errorf("range needs at least one bound")
+	}
+	var group []comparator
+	if lo != "" {
+		p, err := parseDialectVersion(lo)
+		if err != nil {
+			return nil, err
+		}
+		op := OpGT
+		if open == '[' {
+			op = OpGE
+		}
+		group = append(group, comparator{op: op, v: p.v})
+	} else if open == '[' {
+		return nil, fmt.Errorf("unbounded minimum must use (")
+	}
+	if hi != "" {
+		p, err := parseDialectVersion(hi)
+		if err != nil {
+			return nil, err
+		}
+		op := OpLT
+		if close == ']' {
+			op = OpLE
+		}
+		group = append(group, comparator{op: op, v: p.v})
+	} else if close == ']' {
+		return nil, fmt.Errorf("unbounded maximum must use )")
+	}
+	return [][]comparator{group}, nil
+}
+
+// parseDialectVersion parses a version of one to three numeric components
+// with an optional pre-release and build suffix. Missing components are
+// zero, not wildcards: "1.4" is the single version 1.4.0.
+func parseDialectVersion(s string) (partial, error) {
+	if s == "" {
+		return partial{}, fmt.Errorf("missing version")
+	}
+	core, suffix := trimV(s), ""
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core, suffix = core[:i], core[i:]
+	}
+	parts := strings.Split(core, ".")
+	if len(parts) > 3 {
+		return partial{}, fmt.Errorf("invalid version %q", s)
+	}
+	var p partial
+	nums := [3]*uint64{&p.v.Major, &p.v.Minor, &p.v.Patch}
+	for i, part := range parts {
+		if !isNumeric(part) {
+			return partial{}, fmt.Errorf("invalid version %q", s)
+		}
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return partial{}, fmt.Errorf("invalid version %q: %s component overflows uint64", s, componentNames[i])
+		}
+		*nums[i] = n
+		p.n++
+	}
+	if suffix != "" {
+		v, err := ParseTolerant(p.v.String() + suffix)
+		if err != nil {
+			return partial{}, err
+		}
+		p.v = v
+	}
+	return p, nil
+}
@@ -0,0 +1,154 @@
+package semver
+
+// Change identifies the most significant component that differs between
+// two versions. Changes order by significance, so they can be compared
+// with < and >.
+type Change int
+
+const (
+	DiffNone Change = iota
+	DiffBuild
+	DiffPrerelease
+	DiffPatch
+	DiffMinor
+	DiffMajor
+)
+
+var changeNames = [...]string{"none", "build", "prerelease", "patch", "minor", "major"}
+
+func (c Change) String() string {
+	if c < 0 || int(c) >= len(changeNames) {
+		return "unknown"
+	}
+	return changeNames[c]
+}
+
+// Diff returns the most significant component that differs between v and
+// o. A change of epoch counts as a major change.
+func (v Version) Diff(o Version) Change {
+	switch {
+	case v.Epoch != o.Epoch, v.Major != o.Major:
+		return DiffMajor
+	case v.Minor != o.Minor:
+		return DiffMinor
+	case v.Patch != o.Patch:
+		return DiffPatch
+	case v.Prerelease != o.Prerelease:
+		return DiffPrerelease
+	case v.Build != o.Build:
+		return DiffBuild
+	}
+	return DiffNone
+}
+
+// Delta describes the difference between two versions in detail.
+type Delta struct {
+	From, To Version
+	Change   Change
+	// Direction is 1 for an upgrade, -1 for a downgrade and 0 when the
+	// versions have equal precedence.
+	Direction int
+	// MajorDelta, MinorDelta and PatchDelta are To minus From for each
+	// numeric component, saturated to the int64 range.
+	MajorDelta, MinorDelta, PatchDelta int64
+	PrereleaseChanged                  bool
+	BuildChanged                       bool
+}
+
+// DiffDetail describes the change from v to o.
+func (v Version) DiffDetail(o Version) Delta {
+	return Delta{
+		From:              v,
+		To:                o,
+		Change:            v.Diff(o),
+		Direction:         o.Compare(v),
+		MajorDelta:        subUint(o.Major, v.Major),
+		MinorDelta:        subUint(o.Minor, v.Minor),
+		PatchDelta:        subUint(o.Patch, v.Patch),
+		PrereleaseChanged: v.Prerelease != o.Prerelease,
+		BuildChanged:      v.Build != o.Build,
+	}
+}
+
+// Breaking reports whether the change may be incompatible under SemVer:
+// the major version changed, or the versions are in the 0.y.z range
+// (where minor changes, and patch changes within 0.0.z, may break) and
+// that component changed.
+func (d Delta) Breaking() bool {
+	switch d.Change {
+	case DiffMajor:
+		return true
+	case DiffMinor:
+		return d.From.Major == 0
+	case DiffPatch:
+		return d.From.Major == 0 && d.From.Minor == 0
+	}
+	return false
+}
+
+// subUint returns a-b saturated to the int64 range.
+func subUint(a, b uint64) int64 {
+	const maxInt64 = 1<<63 - 1
+	if a >= b {
+		if a-b > maxInt64 {
+			return maxInt64
+		}
+		return int64(a - b)
+	}
+	if b-a > maxInt64 {
+		return -maxInt64 - 1
+	}
+	return -int64(b - a)
+}
+
+// Distance measures how far apart two versions are, in releases skipped,
+// so that dependencies can be ranked by how far behind they are.
+type Distance struct {
+	// Majors, Minors and Patches count the releases between the lower
+	// and higher version: below the most significant component that
+	// differs, the higher version's components count from zero, so
+	// 1.2.3 to 2.1.0 is 1 major and 1 minor, and 1.2.3 to 1.4.1 is
+	// 2 minors and 1 patch. A change of epoch counts as at least one
+	// major.
+	Majors, Minors, Patches uint64
+	// Prerelease reports whether the versions have the same release but
+	// different pre-releases, as 1.2.0-rc.1 and 1.2.0 do.
+	Prerelease bool
+	// Score weighs the counts into a single number for sorting: 100 per
+	// major, 10 per minor and 1 per patch, or 0.1 for a pre-release
+	// difference alone.
+	Score float64
+}
+
+// Distance returns the distance between v and o, which is the same in
+// either order. Build metadata is ignored.
+func (v Version) Distance(o Version) Distance {
+	a, b := v, o
+	if a.Compare(b) > 0 {
+		a, b = b, a
+	}
+	var d Distance
+	switch a.Diff(b) {
+	case DiffMajor:
+		switch {
+		case a.Epoch == b.Epoch:
+			d.Majors = b.Major - a.Major
+		case b.Major > a.Major:
+			d.Majors = b.Major - a.Major
+		default:
+			d.Majors = 1
+		}
+		d.Minors, d.Patches = b.Minor, b.Patch
+	case DiffMinor:
+		d.Minors, d.Patches = b.Minor-a.Minor, b.Patch
+	case DiffPatch:
+		d.Patches = b.Patch - a.Patch
+	case DiffPrerelease:
+		d.Prerelease = true
+	}
+	d.Score = 100*float64(d.Majors) + 10*float64(d.Minors) + float64(d.Patches)
+	if d.Prerelease {
+		d.Score = 0.1
+	}
+	return d
+}
@@ -0,0 +1,38 @@
+package semver
+
+import "encoding/json"
+
+// MarshalText implements encoding.TextMarshaler, encoding v in its
+// canonical form.
+func (v Version) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using Parse. An epoch
+// prefix is accepted, so that every Version round-trips.
+func (v *Version) UnmarshalText(text []byte) error {
+	parsed, err := ParseWith(string(text), AllowEpoch())
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, encoding v as a JSON string.
+func (v Version) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts a JSON string in
+// any form Parse understands; a JSON null leaves v unchanged.
+func (v *Version) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return v.UnmarshalText([]byte(s))
+}
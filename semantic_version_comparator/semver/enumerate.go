@@ -0,0 +1,116 @@
+package semver
+
+// EnumerateOption configures Enumerate.
+type EnumerateOption func(*enumerateConfig)
+
+type enumerateConfig struct {
+	step    CompareMode
+	limit   int
+	catalog []Version
+	known   bool
+}
+
+// DefaultEnumerateLimit is the number of versions Enumerate produces at
+// most unless EnumerateLimit says otherwise.
+const DefaultEnumerateLimit = 1000
+
+// EnumerateStep sets the granularity of Enumerate: CompareMajor yields
+// X.0.0 versions, CompareMajorMinor X.Y.0 versions, and CompareCore, the
+// default, every patch. With EnumerateFrom, the step instead keeps the
+// highest known version of each series at that level.
+func EnumerateStep(level CompareMode) EnumerateOption {
+	return func(c *enumerateConfig) { c.step = level }
+}
+
+// EnumerateLimit sets the maximum number of versions Enumerate yields;
+// past it Enumerate fails with a *LimitError. n <= 0 means
+// DefaultEnumerateLimit.
+func EnumerateLimit(n int) EnumerateOption {
+	return func(c *enumerateConfig) { c.limit = n }
+}
+
+// EnumerateFrom makes Enumerate pick from the known versions vs, such as
+// a registry's published releases, instead of constructing versions.
+func EnumerateFrom(vs []Version) EnumerateOption {
+	return func(c *enumerateConfig) { c.catalog, c.known = vs, true }
+}
+
+// Enumerate returns, in ascending order, concrete release versions that
+// satisfy c, for expanding a supported range such as ">=1.19 <1.23" into
+// build targets:
+//
+//	Enumerate(MustParseConstraint(">=1.19 <1.23"), EnumerateStep(CompareMajorMinor))
+//	// 1.19.0 1.20.0 1.21.0 1.22.0
+//
+// Constructed versions start at the lower bound of each range of c and
+// advance by the step, so ">=1.2.3 <1.2.6" yields 1.2.3, 1.2.4 and 1.2.5.
+// A range without an upper bound yields only its boundary, the lowest
+// version it admits: ">=2.1" yields 2.1.0. Pre-releases are never
+// constructed. A range with more versions than the limit, such as every
+// patch of ">=1.0 <2.0", fails with a *LimitError; step by minor or
+// major, or enumerate known versions with EnumerateFrom.
+func Enumerate(c Constraint, opts ...EnumerateOption) ([]Version, error) {
+	cfg := enumerateConfig{step: CompareCore}
+	for _, o := range opts {
+		o(&cfg)
+	}
+	if cfg.limit <= 0 {
+		cfg.limit = DefaultEnumerateLimit
+	}
+	if cfg.known {
+		return enumerateKnown(c, cfg)
+	}
+	level := 2
+	switch cfg.step {
+	case CompareMajor:
+		level = 0
+	case CompareMajorMinor:
+		level = 1
+	}
+	var out []Version
+	for _, r := range c.Ranges() {
+		v := r.min
+		v.Prerelease = ""
+		for {
+			if r.bounded && v.Compare(r.max) >= 0 {
+				break
+			}
+			if c.Check(v) {
+				if len(out) == cfg.limit {
+					return nil, &LimitError{Limit: "EnumerateLimit", Max: cfg.limit, Got: len(out) + 1}
+				}
+				out = append(out, v)
+			}
+			if !r.bounded {
+				break
+			}
+			next, ok := bumpAt(v, level)
+			if !ok {
+				break
+			}
+			next.Epoch = v.Epoch
+			v = next
+		}
+	}
+	return out, nil
+}
+
+// enumerateKnown returns the versions of cfg.catalog that satisfy c,
+// keeping the highest of each series at the step level.
+func enumerateKnown(c Constraint, cfg enumerateConfig) ([]Version, error) {
+	out := Unique(Filter(cfg.catalog, c))
+	Sort(out)
+	if cfg.step != CompareFull && cfg.step != CompareCore {
+		kept := out[:0]
+		for i, v := range out {
+			if i+1 == len(out) || SeriesOf(out[i+1], cfg.step) != SeriesOf(v, cfg.step) {
+				kept = append(kept, v)
+			}
+		}
+		out = kept
+	}
+	if len(out) > cfg.limit {
+		return nil, &LimitError{Limit: "EnumerateLimit", Max: cfg.limit, Got: len(out)}
+	}
+	return out, nil
+}
@@ -0,0 +1,94 @@
+package semver
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ParseOption configures ParseWith.
+type ParseOption func(*parseConfig)
+
+type parseConfig struct {
+	tolerant bool
+	epoch    bool
+	limits   Limits
+}
+
+// Tolerant makes ParseWith coerce versions as ParseTolerant does.
+func Tolerant() ParseOption {
+	return func(c *parseConfig) {
+		c.tolerant = true
+	}
+}
+
+// AllowEpoch makes ParseWith accept a Debian-style "EPOCH:" prefix, a
+// decimal number that orders before every other component: "1:2.0.0"
+// follows "3.5.0". Packagers use it to correct historical versioning
+// mistakes while keeping SemVer ordering for the rest of the version.
+// A version without a prefix has epoch zero.
+func AllowEpoch() ParseOption {
+	return func(c *parseConfig) {
+		c.epoch = true
+	}
+}
+
+// ParseWith parses s as Parse does, modified by opts.
+func ParseWith(s string, opts ...ParseOption) (Version, error) {
+	var cfg parseConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg.parse(s)
+}
+
+// parse parses s as ParseWith does with the options of cfg.
+func (cfg *parseConfig) parse(s string) (Version, error) {
+	if err := cfg.limits.checkLength(s); err != nil {
+		return Version{}, err
+	}
+	if err := cfg.limits.checkIdentifiers(s); err != nil {
+		return Version{}, err
+	}
+	raw := s
+	if cfg.tolerant {
+		s = Normalize(s)
+	} else if err := checkASCII(s); err != nil {
+		return Version{}, err
+	}
+	i := strings.IndexByte(s, ':')
+	if !cfg.epoch || i < 0 {
+		return parseVersion(raw, cfg.tolerant, true)
+	}
+	digits, off := s[:i], 0
+	if cfg.tolerant {
+		trimmed := strings.TrimLeftFunc(digits, unicode.IsSpace)
+		off, digits = len(digits)-len(trimmed), trimmed
+	}
+	if digits == "" {
+		return Version{}, &ParseError{Input: s, Pos: off, Expected: "digit", Component: "epoch", Err: ErrEmpty}
+	}
+	if j := strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' }); j >= 0 {
+		return Version{}, &ParseError{Input: s, Pos: off + j, Expected: "digit", Component: "epoch", Value: digits, Err: ErrInvalidCharacter}
+	}
+	if !cfg.tolerant && len(digits) > 1 && digits[0] == '0' {
+		return Version{}, &ParseError{Input: s, Pos: off, Component: "epoch", Value: digits, Err: ErrLeadingZero}
+	}
+	epoch, err := strconv.ParseUint(digits, 10, 64)
+	if err != nil && !cfg.tolerant {
+		return Version{}, &ParseError{Input: s, Pos: off, Component: "epoch", Value: digits, Err: ErrOverflow}
+	}
+	v, err := parseVersion(s[i+1:], cfg.tolerant, true)
+	if err != nil {
+		// Report positions relative to the whole input.
+		var pe *ParseError
+		if errors.As(err, &pe) {
+			pe.Input, pe.Pos = s, pe.Pos+i+1
+		}
+		return Version{}, err
+	}
+	v.Epoch = epoch
+	v.original = trimOriginal(raw)
+	return v, nil
+}
@@ -0,0 +1,99 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Sentinel errors classifying version parse failures. Parse functions
+// return them wrapped in a *ParseError, so test for them with errors.Is.
+var (
+	// ErrEmpty reports an empty version string or numeric component.
+	ErrEmpty = errors.New("empty")
+
+	// ErrInvalidCharacter reports a non-digit in a numeric component.
+	ErrInvalidCharacter = errors.New("invalid character")
+
+	// ErrComponentCount reports a version without exactly three numeric
+	// components (or more than three in tolerant mode).
+	ErrComponentCount = errors.New("wrong number of components")
+
+	// ErrOverflow reports a numeric component too large for a uint64.
+	ErrOverflow = errors.New("overflows uint64")
+
+	// ErrLeadingZero reports a numeric component or pre-release identifier
+	// with a leading zero, such as "01.2.3" or "1.2.3-rc.01".
+	ErrLeadingZero = errors.New("leading zero")
+
+	// ErrInvalidIdentifier reports a pre-release or build identifier that
+	// is empty or contains characters outside [0-9A-Za-z-].
+	ErrInvalidIdentifier = errors.New("invalid identifier")
+
+	// ErrNonASCII reports a non-ASCII character, such as a full-width
+	// digit or an en dash, in a version parsed strictly.
+	ErrNonASCII = errors.New("non-ASCII character")
+)
+
+// ParseError describes where and why a version string failed to parse.
+type ParseError struct {
+	Input     string // the string being parsed
+	Pos       int    // byte offset in Input where the problem was found
+	Expected  string // what the parser expected at Pos, if known
+	Component string // "epoch", "major", "minor", "patch", "pre-release" or "build metadata"
+	Value     string // the offending component or identifier
+	Err       error  // one of the sentinel errors above
+}
+
+func (e *ParseError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "semver: %q: ", e.Input)
+	switch e.Err {
+	case ErrInvalidCharacter:
+		fmt.Fprintf(&b, "invalid character %s in %s", e.char(), e.Component)
+	case ErrInvalidIdentifier:
+		if e.Value == "" {
+			fmt.Fprintf(&b, "empty %s identifier", e.Component)
+		} else {
+			fmt.Fprintf(&b, "invalid character %s in %s identifier %q", e.char(), e.Component, e.Value)
+		}
+	case ErrEmpty:
+		if e.Component == "" {
+			b.WriteString("empty version string")
+		} else {
+			fmt.Fprintf(&b, "empty %s component", e.Component)
+		}
+	case ErrComponentCount:
+		b.WriteString("want MAJOR.MINOR.PATCH")
+	case ErrNonASCII:
+		r, _ := utf8.DecodeRuneInString(e.Value)
+		b.WriteString(describeRune(r))
+	case ErrLeadingZero:
+		fmt.Fprintf(&b, "leading zero in %s %q", e.Component, e.Value)
+	case ErrOverflow:
+		fmt.Fprintf(&b, "%s %q overflows uint64", e.Component, e.Value)
+	default:
+		fmt.Fprintf(&b, "%s %q: %v", e.Component, e.Value, e.Err)
+	}
+	if e.Input != "" {
+		fmt.Fprintf(&b, " at position %d", e.Pos)
+	}
+	if e.Expected != "" {
+		fmt.Fprintf(&b, " (expected %s)", e.Expected)
+	}
+	return b.String()
+}
+
+func (e *ParseError) Unwrap() error { return e.Err }
+
+// char quotes the character of Input at Pos.
+func (e *ParseError) char() string {
+	if e.Pos < 0 || e.Pos >= len(e.Input) {
+		return "end of input"
+	}
+	if r, _ := utf8.DecodeRuneInString(e.Input[e.Pos:]); r >= utf8.RuneSelf {
+		return fmt.Sprintf("%q (U+%04X)", r, r)
+	}
+	return fmt.Sprintf("%q", e.Input[e.Pos])
+}
@@ -0,0 +1,107 @@
+package semver
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ComparatorResult is the outcome of checking a version against one
+// comparator of a constraint, after range sugar is expanded: "^1.2.3"
+// contributes >=1.2.3 and <2.0.0.
+type ComparatorResult struct {
+	Op      Operator
+	Version Version
+	Passed  bool
+}
+
+func (r ComparatorResult) String() string {
+	return comparator{op: r.Op, v: r.Version}.String()
+}
+
+// ClauseResult is the outcome of checking a version against one "||"
+// alternative of a constraint.
+type ClauseResult struct {
+	// Clause is the 1-based position of the alternative.
+	Clause      int
+	Comparators []ComparatorResult
+	// PrereleaseExcluded reports that the version, a pre-release, passed
+	// every comparator but is excluded by the constraint's pre-release
+	// rule (see Constraint.Check).
+	PrereleaseExcluded bool
+	Passed             bool
+}
+
+// String returns the alternative's comparators separated by spaces.
+func (r ClauseResult) String() string {
+	if len(r.Comparators) == 0 {
+		return comparator{op: OpGE, v: minVersion}.String()
+	}
+	parts := make([]string, len(r.Comparators))
+	for i, c := range r.Comparators {
+		parts[i] = c.String()
+	}
+	return strings.Join(parts, " ")
+}
+
+// Detail explains the outcome of Constraint.CheckDetail.
+type Detail struct {
+	Version    Version
+	Constraint Constraint
+	Satisfied  bool
+	// Clauses holds the result of every alternative, in order.
+	Clauses []ClauseResult
+}
+
+// CheckDetail is like Check but reports, for every alternative of c,
+// which comparators v passed and failed, for use in dependency
+// resolution errors. Its Satisfied field equals c.Check(v).
+func (c Constraint) CheckDetail(v Version) Detail {
+	d := Detail{Version: v, Constraint: c}
+	for i, group := range c.groups {
+		r := ClauseResult{Clause: i + 1, Comparators: make([]ComparatorResult, len(group))}
+		all := true
+		for j, cmp := range group {
+			ok := cmp.check(v)
+			r.Comparators[j] = ComparatorResult{Op: cmp.op, Version: cmp.v, Passed: ok}
+			all = all && ok
+		}
+		r.PrereleaseExcluded = all && !c.prereleases.allows(group, v)
+		r.Passed = all && !r.PrereleaseExcluded
+		d.Satisfied = d.Satisfied || r.Passed
+		d.Clauses = append(d.Clauses, r)
+	}
+	return d
+}
+
+// String returns a one-line explanation such as
+// "1.9.0 rejected: requires >=2.0.0 from clause 2" or
+// "1.2.0 satisfies >=1.0.0 <2.0.0 (clause 1)". A rejection lists the
+// failed comparators of every alternative.
+func (d Detail) String() string {
+	if d.Satisfied {
+		for _, r := range d.Clauses {
+			if r.Passed {
+				return d.Version.String() + " satisfies " + r.String() + " (clause " + strconv.Itoa(r.Clause) + ")"
+			}
+		}
+	}
+	if len(d.Clauses) == 0 {
+		return d.Version.String() + " rejected: " + strconv.Quote(d.Constraint.String()) + " matches no version"
+	}
+	reasons := make([]string, 0, len(d.Clauses))
+	for _, r := range d.Clauses {
+		clause := " from clause " + strconv.Itoa(r.Clause)
+		if r.PrereleaseExcluded {
+			reasons = append(reasons, "pre-releases are excluded"+clause)
+			continue
+		}
+		var failed []string
+		for _, c := range r.Comparators {
+			if !c.Passed {
+				failed = append(failed, c.String())
+			}
+		}
+		reasons = append(reasons, "requires "+strings.Join(failed, " ")+clause)
+	}
+	return d.Version.String() + " rejected: " + strings.Join(reasons, "; ")
+}
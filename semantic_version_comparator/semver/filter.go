@@ -0,0 +1,123 @@
+package semver
+
+import "errors"
+
+// ErrNoVersions is returned by the string helpers when given no versions.
+var ErrNoVersions = errors.New("semver: no versions")
+
+// Filter returns the versions in vs that satisfy c, in their original
+// order.
+func Filter(vs []Version, c Constraint) []Version {
+	var out []Version
+	for _, v := range vs {
+		if c.Check(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// FilterStrings returns the elements of ss that satisfy c, parsing each
+// with ParseTolerant. It returns an error if any element fails to parse.
+func FilterStrings(ss []string, c Constraint) ([]string, error) {
+	var out []string
+	for _, s := range ss {
+		v, err := ParseTolerant(s)
+		if err != nil {
+			return nil, err
+		}
+		if c.Check(v) {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+// Unique returns vs with versions of equal precedence removed, keeping the
+// first occurrence of each. Build metadata is ignored, so 1.0.0 and
+// 1.0.0+meta are duplicates.
+func Unique(vs []Version) []Version {
+	seen := make(map[Key]bool, len(vs))
+	var out []Version
+	for _, v := range vs {
+		if k := v.Key(); !seen[k] {
+			seen[k] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// UniqueStrings is like Unique for version strings parsed with
+// ParseTolerant, so "1.0", "v1.0.0" and "1.0.0+meta" are duplicates. It
+// returns an error if any element fails to parse.
+func UniqueStrings(ss []string) ([]string, error) {
+	seen := make(map[Key]bool, len(ss))
+	var out []string
+	for _, s := range ss {
+		v, err := ParseTolerant(s)
+		if err != nil {
+			return nil, err
+		}
+		if k := v.Key(); !seen[k] {
+			seen[k] = true
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+// Latest returns the highest version in vs. The boolean result is false if
+// vs is empty. Of versions with equal precedence the first is returned.
+func Latest(vs []Version) (Version, bool) {
+	return extreme(vs, 1)
+}
+
+// Oldest returns the lowest version in vs. The boolean result is false if
+// vs is empty. Of versions with equal precedence the first is returned.
+func Oldest(vs []Version) (Version, bool) {
+	return extreme(vs, -1)
+}
+
+// LatestString returns the highest version string in ss, parsing each
+// with ParseTolerant.
+func LatestString(ss []string) (string, error) {
+	return extremeString(ss, 1)
+}
+
+// OldestString returns the lowest version string in ss, parsing each with
+// ParseTolerant.
+func OldestString(ss []string) (string, error) {
+	return extremeString(ss, -1)
+}
+
+func extreme(vs []Version, dir int) (Version, bool) {
+	if len(vs) == 0 {
+		return Version{}, false
+	}
+	best := vs[0]
+	for _, v := range vs[1:] {
+		if v.Compare(best) == dir {
+			best = v
+		}
+	}
+	return best, true
+}
+
+func extremeString(ss []string, dir int) (string, error) {
+	if len(ss) == 0 {
+		return "", ErrNoVersions
+	}
+	var best Version
+	bestIdx := -1
+	for i, s := range ss {
+		v, err := ParseTolerant(s)
+		if err != nil {
+			return "", err
+		}
+		if bestIdx < 0 || v.Compare(best) == dir {
+			best, bestIdx = v, i
+		}
+	}
+	return ss[bestIdx], nil
+}
@@ -0,0 +1,165 @@
+package semver
+
+import "strings"
+
+// Match is a version found in text by Find or FindAll. Text[Start:End]
+// is the matched substring, including any leading "v".
+type Match struct {
+	Version    Version
+	Start, End int
+}
+
+// Find returns the first version embedded in s, such as the 1.4.2 in
+// "app-1.4.2-linux-amd64.tar.gz". ok is false if s contains none.
+func Find(s string) (m Match, ok bool) {
+	ms := FindAll(s, 1)
+	if len(ms) == 0 {
+		return Match{}, false
+	}
+	return ms[0], true
+}
+
+// FindAll returns up to n versions embedded in s, in order; if n < 0 it
+// returns all of them.
+//
+// A match is a complete MAJOR.MINOR.PATCH, optionally prefixed by "v",
+// that is not part of a longer word or dotted number such as an IP
+// address. Because text rarely marks where a version ends, suffixes are
+// taken conservatively: a pre-release only extends over tokens that look
+// like one (numbers, words such as alpha, beta, rc, dev or snapshot with
+// an optional number, and hexadecimal commit hashes), and build metadata
+// stops before file extensions such as ".tar.gz". So "1.2.3-rc.1.tar.gz"
+// yields 1.2.3-rc.1 and "tool-2.0.0-linux" yields 2.0.0.
+func FindAll(s string, n int) []Match {
+	var ms []Match
+	for i := 0; i < len(s) && (n < 0 || len(ms) < n); {
+		m, next, ok := matchAt(s, i)
+		if ok {
+			ms = append(ms, m)
+		}
+		i = next
+	}
+	return ms
+}
+
+// matchAt tries to match a version starting at s[i]. It returns the index
+// at which to resume scanning.
+func matchAt(s string, i int) (Match, int, bool) {
+	start := i
+	if s[i] == 'v' || s[i] == 'V' {
+		i++
+	}
+	if i == len(s) || !isDigitByte(s[i]) || start > 0 && (isAlnumByte(s[start-1]) || s[start-1] == '.') {
+		return Match{}, start + 1, false
+	}
+	// Consume the whole dotted run of numbers so that "1.2.3.4" or an
+	// address is skipped entirely rather than matched in part.
+	j, parts := i, 0
+	for {
+		k := j
+		for k < len(s) && isDigitByte(s[k]) {
+			k++
+		}
+		if k == j {
+			break
+		}
+		parts++
+		j = k
+		if j+1 < len(s) && s[j] == '.' && isDigitByte(s[j+1]) {
+			j++
+			continue
+		}
+		break
+	}
+	if parts != 3 || j < len(s) && isAlnumByte(s[j]) {
+		return Match{}, j, false
+	}
+	end := j
+	if end < len(s) && s[end] == '-' {
+		if n := suffixLen(s[end+1:], isPrereleaseToken); n > 0 {
+			end += 1 + n
+		}
+	}
+	if end < len(s) && s[end] == '+' {
+		if n := suffixLen(s[end+1:], isBuildToken); n > 0 {
+			end += 1 + n
+		}
+	}
+	v, err := Parse(s[start:end])
+	if err != nil {
+		// Fall back to the bare release if the suffix is malformed, for
+		// example a pre-release number with a leading zero.
+		end = j
+		if v, err = Parse(s[start:end]); err != nil {
+			return Match{}, j, false
+		}
+	}
+	return Match{Version: v, Start: start, End: end}, end, true
+}
+
+// suffixLen returns the length of the longest prefix of s made of tokens
+// accepted by ok, separated by "." or "-".
+func suffixLen(s string, ok func(string) bool) int {
+	n := 0
+	for i := 0; ; {
+		j := i
+		for j < len(s) && isAlnumByte(s[j]) {
+			j++
+		}
+		if j == i || !ok(s[i:j]) {
+			return n
+		}
+		n = j
+		if j+1 < len(s) && (s[j] == '.' || s[j] == '-') {
+			i = j + 1
+			continue
+		}
+		return n
+	}
+}
+
+var prereleaseWords = []string{
+	"alpha", "beta", "rc", "pre", "preview", "dev", "snapshot",
+	"canary", "nightly", "next", "exp", "experimental",
+}
+
+func isPrereleaseToken(t string) bool {
+	if isNumeric(t) || len(t) >= 7 && isHex(t) {
+		return true
+	}
+	word := strings.TrimRight(strings.ToLower(t), "0123456789")
+	for _, w := range prereleaseWords {
+		if word == w {
+			return true
+		}
+	}
+	return false
+}
+
+var fileExtensions = []string{
+	"tar", "gz", "tgz", "bz2", "xz", "zst", "zip", "7z", "jar", "war",
+	"whl", "deb", "rpm", "apk", "exe", "msi", "dmg", "pkg", "txt", "log",
+	"json", "yaml", "yml", "sig", "asc", "sha256", "md5",
+}
+
+func isBuildToken(t string) bool {
+	for _, ext := range fileExtensions {
+		if strings.EqualFold(t, ext) {
+			return false
+		}
+	}
+	return true
+}
+
+func isHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; !isDigitByte(c) && (c < 'a' || c > 'f') && (c < 'A' || c > 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlnumByte(c byte) bool {
+	return isDigitByte(c) || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
@@ -0,0 +1,31 @@
+package semver
+
+// Set implements flag.Value, so a Version can be bound to a command-line
+// flag with flag.Var and is validated when flags are parsed.
+func (v *Version) Set(s string) error {
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+// Type returns "version". Together with String and Set it implements the
+// pflag.Value interface.
+func (v *Version) Type() string { return "version" }
+
+// Set implements flag.Value, so a Constraint can be bound to a
+// command-line flag with flag.Var and is validated when flags are parsed.
+func (c *Constraint) Set(s string) error {
+	parsed, err := ParseConstraint(s)
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
+
+// Type returns "constraint". Together with String and Set it implements the
+// pflag.Value interface.
+func (c *Constraint) Type() string { return "constraint" }
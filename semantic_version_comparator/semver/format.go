@@ -0,0 +1,147 @@
+package semver
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FormatOption configures StringWith.
+type FormatOption func(*formatConfig)
+
+type formatConfig struct {
+	prefix  Prefix
+	noBuild bool
+	minimal bool
+}
+
+// WithPrefix makes StringWith emit a leading "v" if p is VPrefix.
+func WithPrefix(p Prefix) FormatOption {
+	return func(c *formatConfig) {
+		c.prefix = p
+	}
+}
+
+// WithoutBuild makes StringWith omit build metadata.
+func WithoutBuild() FormatOption {
+	return func(c *formatConfig) {
+		c.noBuild = true
+	}
+}
+
+// Minimal makes StringWith drop trailing zero minor and patch components
+// of a release, writing 1.2.0 as "1.2" and 2.0.0 as "2". Versions with a
+// pre-release keep all three. ParseTolerant reads the result back as the
+// same version.
+func Minimal() FormatOption {
+	return func(c *formatConfig) {
+		c.minimal = true
+	}
+}
+
+// StringWith returns v formatted as String does, modified by opts.
+func (v Version) StringWith(opts ...FormatOption) string {
+	var cfg formatConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	var b strings.Builder
+	if v.Epoch != 0 {
+		b.WriteString(strconv.FormatUint(v.Epoch, 10))
+		b.WriteByte(':')
+	}
+	if cfg.prefix == VPrefix {
+		b.WriteByte('v')
+	}
+	b.WriteString(strconv.FormatUint(v.Major, 10))
+	keep := 3
+	if cfg.minimal && v.Prerelease == "" {
+		switch {
+		case v.Minor == 0 && v.Patch == 0:
+			keep = 1
+		case v.Patch == 0:
+			keep = 2
+		}
+	}
+	if keep > 1 {
+		b.WriteByte('.')
+		b.WriteString(strconv.FormatUint(v.Minor, 10))
+	}
+	if keep > 2 {
+		b.WriteByte('.')
+		b.WriteString(strconv.FormatUint(v.Patch, 10))
+	}
+	if v.Prerelease != "" {
+		b.WriteByte('-')
+		b.WriteString(v.Prerelease)
+	}
+	if v.Build != "" && !cfg.noBuild {
+		b.WriteByte('+')
+		b.WriteString(v.Build)
+	}
+	return b.String()
+}
+
+// Layouts for Format. As with time.Format, a layout shows how the
+// reference version 0:1.2.3-pre+build would be written.
+const (
+	LayoutCanonical  = "0:1.2.3-pre+build" // String
+	LayoutTag        = "v1.2.3-pre+build"  // git tags and Go modules
+	LayoutRelease    = "1.2.3"             // no pre-release or build
+	LayoutMajorMinor = "1.2"
+)
+
+// layoutTokens are the elements of a layout, longest first.
+var layoutTokens = []string{"+build", "-pre", "build", "pre", "0:", "1", "2", "3"}
+
+// Format returns v written according to layout, which shows how the
+// reference version 0:1.2.3-pre+build would be written:
+//
+//	1, 2, 3        the major, minor and patch components
+//	pre, -pre      the pre-release; -pre adds a "-" and is omitted entirely if there is none
+//	build, +build  the build metadata; +build adds a "+" and is omitted entirely if there is none
+//	0:             the epoch and ":", omitted if the epoch is zero
+//
+// Everything else is copied verbatim, so v.Format("v1.2") of 1.4.2 is
+// "v1.4". As in time.Format, the digits 0 to 3 cannot appear literally.
+func (v Version) Format(layout string) string {
+	var b strings.Builder
+	for i := 0; i < len(layout); {
+		tok := ""
+		for _, t := range layoutTokens {
+			if strings.HasPrefix(layout[i:], t) {
+				tok = t
+				break
+			}
+		}
+		switch tok {
+		case "":
+			b.WriteByte(layout[i])
+			i++
+			continue
+		case "1":
+			b.WriteString(strconv.FormatUint(v.Major, 10))
+		case "2":
+			b.WriteString(strconv.FormatUint(v.Minor, 10))
+		case "3":
+			b.WriteString(strconv.FormatUint(v.Patch, 10))
+		case "pre":
+			b.WriteString(v.Prerelease)
+		case "build":
+			b.WriteString(v.Build)
+		case "-pre":
+			if v.Prerelease != "" {
+				b.WriteString("-" + v.Prerelease)
+			}
+		case "+build":
+			if v.Build != "" {
+				b.WriteString("+" + v.Build)
+			}
+		case "0:":
+			if v.Epoch != 0 {
+				b.WriteString(strconv.FormatUint(v.Epoch, 10) + ":")
+			}
+		}
+		i += len(tok)
+	}
+	return b.String()
+}
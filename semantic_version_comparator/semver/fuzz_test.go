@@ -0,0 +1,138 @@
+package semver
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// FuzzParseTolerant checks that ParseTolerant never panics, that the
+// String form of what it accepts parses back to the same version, and
+// that numeric components too large for a uint64 saturate. digits, with
+// its non-digits dropped, is also parsed as the three components of a
+// version.
+func FuzzParseTolerant(f *testing.F) {
+	for _, s := range []string{
+		"1.2.3", "v1.2", " 1 ", "01.02.03", "1.0.0-rc.01+build.007", "1.2.3-", "1..2",
+		"99999999999999999999.0.0", "18446744073709551615.18446744073709551616.0",
+		"\uFF11.\uFF12.\uFF13", "1.0.0\u20131", "\uFEFF1.2.3", "1.2.3.4", "",
+	} {
+		f.Add(s, "18446744073709551616")
+	}
+	f.Add("1.2.3", "0")
+	f.Add("1.2.3", "000000000000000000000000000042")
+	f.Fuzz(func(t *testing.T, s, digits string) {
+		if v, err := ParseTolerant(s); err == nil {
+			w, err := Parse(v.String())
+			if err != nil {
+				t.Fatalf("ParseTolerant(%q) = %q, which Parse rejects: %v", s, v, err)
+			}
+			if w.String() != v.String() || !w.Equal(v) {
+				t.Fatalf("ParseTolerant(%q) = %q, which parses back as %q", s, v, w)
+			}
+		}
+
+		digits = strings.Map(func(r rune) rune {
+			if r < '0' || r > '9' {
+				return -1
+			}
+			return r
+		}, digits)
+		if digits == "" {
+			return
+		}
+		want, err := strconv.ParseUint(digits, 10, 64)
+		if err != nil {
+			want = math.MaxUint64
+		}
+		v, err := ParseTolerant(digits + "." + digits + "." + digits)
+		if err != nil {
+			t.Fatalf("ParseTolerant(%s.%[1]s.%[1]s): %v", digits, err)
+		}
+		if v.Major != want || v.Minor != want || v.Patch != want {
+			t.Fatalf("ParseTolerant(%s.%[1]s.%[1]s) = %v, want %d in each component", digits, v, want)
+		}
+	})
+}
+
+// FuzzParse checks that Parse never panics, that what it accepts prints
+// as a version it accepts again, and that ParseStrict accepts nothing
+// Parse rejects.
+func FuzzParse(f *testing.F) {
+	for _, s := range []string{
+		"1.2.3", "v1.2.3", "1.2.3-rc.1+build.7", "1.0.0-alpha.beta.1", "1.0.0+0.build.1-rc.10000aaa-kk-0.1",
+		"01.2.3", "1.2", "1.2.3-01", "1.2.3-", "1.2.3+", "1.2.3-+", "18446744073709551616.0.0", "",
+	} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		v, err := Parse(s)
+		if _, serr := ParseStrict(s); serr == nil && err != nil {
+			t.Fatalf("ParseStrict(%q) succeeds but Parse fails: %v", s, err)
+		}
+		if err != nil {
+			return
+		}
+		w, err := Parse(v.String())
+		if err != nil {
+			t.Fatalf("Parse(%q) = %q, which Parse rejects: %v", s, v, err)
+		}
+		if w.String() != v.String() || w.Compare(v) != 0 {
+			t.Fatalf("Parse(%q) = %q, which parses back as %q", s, v, w)
+		}
+	})
+}
+
+// FuzzCompare checks that Compare never panics, orders consistently in
+// both directions, and agrees with parsing whenever both inputs parse.
+func FuzzCompare(f *testing.F) {
+	for _, p := range [][2]string{
+		{"1.2.3", "1.2.4"}, {"v1.0.0", "1.0.0"}, {"1.0.0-alpha", "1.0.0-alpha.1"}, {"1.0.0-rc.1", "1.0.0"},
+		{"1.0.0-2", "1.0.0-10"}, {"1.0.0+a", "1.0.0+b"}, {"1.2", "1.2.0"}, {"01.2.3", "1.2.3"},
+		{"18446744073709551616.0.0", "18446744073709551615.0.0"}, {"", "abc"},
+	} {
+		f.Add(p[0], p[1])
+	}
+	f.Fuzz(func(t *testing.T, a, b string) {
+		n := Compare(a, b)
+		if m := Compare(b, a); m != -n {
+			t.Fatalf("Compare(%q, %q) = %d but Compare(%[2]q, %[1]q) = %[4]d", a, b, n, m)
+		}
+		if m := Compare(a, a); m != 0 {
+			t.Fatalf("Compare(%q, %[1]q) = %d", a, m)
+		}
+		if m, err := CompareStrict(a, b); err == nil && m != n {
+			t.Fatalf("Compare(%q, %q) = %d but CompareStrict = %d", a, b, n, m)
+		}
+		if m := compareLenient(a, b); m != n {
+			t.Fatalf("Compare(%q, %q) = %d but the lenient parser gives %d", a, b, n, m)
+		}
+	})
+}
+
+// FuzzParseConstraint checks that ParseConstraint never panics and that
+// the String form of what it accepts parses again to a constraint that
+// checks v, if it is a version, the same way.
+func FuzzParseConstraint(f *testing.F) {
+	for _, p := range [][2]string{
+		{"^1.2.3", "1.4.0"}, {"~1.2", "1.2.9"}, {">=1.0.0 <2.0.0 || >=3", "3.1.0"}, {"1.x", "1.9.9"},
+		{"1.2.3 - 2.3", "2.3.5"}, {"*", "0.0.1"}, {">=1.0.0-rc.1", "1.0.0-rc.2"}, {"~> 2.1", "2.9.0"},
+		{"!=1.2.3", "1.2.3"}, {"^0.0.1", "0.0.1"}, {"=1.2.3 ||", "1.2.3"}, {">", ""},
+	} {
+		f.Add(p[0], p[1])
+	}
+	f.Fuzz(func(t *testing.T, s, version string) {
+		c, err := ParseConstraint(s)
+		if err != nil {
+			return
+		}
+		d, err := ParseConstraint(c.String())
+		if err != nil {
+			t.Fatalf("ParseConstraint(%q) = %q, which ParseConstraint rejects: %v", s, c, err)
+		}
+		if v, err := Parse(version); err == nil && c.Check(v) != d.Check(v) {
+			t.Fatalf("ParseConstraint(%q) = %q; Check(%q) is %v before printing and %v after", s, c, v, c.Check(v), d.Check(v))
+		}
+	})
+}
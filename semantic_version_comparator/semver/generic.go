@@ -0,0 +1,57 @@
+package semver
+
+import (
+	"slices"
+	"strings"
+)
+
+// CompareBy returns a comparison function that orders values of type T
+// by the version key returns, for slices.SortFunc, slices.BinarySearchFunc
+// and friends:
+//
+//	slices.SortFunc(releases, semver.CompareBy(func(r Release) semver.Version { return r.Version }))
+func CompareBy[T any](key func(T) Version) func(a, b T) int {
+	return func(a, b T) int {
+		return key(a).Compare(key(b))
+	}
+}
+
+// SortBy sorts s in ascending precedence order of the version key returns.
+// Elements of equal precedence keep their relative order.
+func SortBy[T any](s []T, key func(T) Version) {
+	slices.SortStableFunc(s, CompareBy(key))
+}
+
+// SortedKeys returns the keys of m in ascending precedence order. Keys of
+// equal precedence, such as 1.0.0 and 1.0.0+build, are ordered by their
+// canonical string.
+func SortedKeys[M ~map[Version]V, V any](m M) []Version {
+	keys := make([]Version, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, func(a, b Version) int {
+		if c := a.Compare(b); c != 0 {
+			return c
+		}
+		return strings.Compare(a.String(), b.String())
+	})
+	return keys
+}
+
+// SortedStringKeys returns the keys of m, such as the series names of
+// GroupBySeries, in ascending order under Compare. Keys that Compare
+// considers equal are ordered lexically.
+func SortedStringKeys[M ~map[string]V, V any](m M) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, func(a, b string) int {
+		if c := Compare(a, b); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+	return keys
+}
@@ -0,0 +1,116 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Problem is a kind of violation found by CheckHistory.
+type Problem int
+
+const (
+	// Downgrade is a version published after a higher one of its own
+	// release series (major and minor), or a series started below a
+	// version already published. Backports to older series are not
+	// downgrades: 1.4.3 may follow 2.0.0 once 1.4.2 exists.
+	Downgrade Problem = iota
+	// SkippedMajor is a version whose major exceeds the highest major
+	// published so far by more than one, as 3.0.0 following 1.9.0.
+	SkippedMajor
+	// Rereleased is a version published twice, possibly with different
+	// build metadata.
+	Rereleased
+	// LatePrerelease is a pre-release published after the release it
+	// precedes, as 1.4.0-rc.3 following 1.4.0.
+	LatePrerelease
+)
+
+var problemNames = [...]string{"downgrade", "skipped-major", "rereleased", "late-prerelease"}
+
+func (p Problem) String() string {
+	if p < 0 || int(p) >= len(problemNames) {
+		return "Problem(" + strconv.Itoa(int(p)) + ")"
+	}
+	return problemNames[p]
+}
+
+// ParseProblem returns the problem with the given name, as returned by
+// Problem.String.
+func ParseProblem(name string) (Problem, error) {
+	for i, n := range problemNames {
+		if n == name {
+			return Problem(i), nil
+		}
+	}
+	return 0, fmt.Errorf("semver: unknown history problem %q", name)
+}
+
+// Violation is a version of a release history that breaks monotonicity.
+type Violation struct {
+	// Index is the position of Version in the history.
+	Index   int
+	Version Version
+	Problem Problem
+	// Previous is the earlier version the problem relates to, such as
+	// the higher version a downgrade follows.
+	Previous Version
+	Message  string
+}
+
+// CheckHistory checks a release history, the versions of a project in
+// the order they were published, and returns its violations in order.
+// Each version gets at most one violation, the first of Rereleased,
+// LatePrerelease, Downgrade and SkippedMajor that applies.
+func CheckHistory(history []Version) []Violation {
+	var out []Violation
+	seen := make(map[Key]int)
+	released := make(map[Key]int)      // index of each release triple's release
+	highest := make(map[[3]uint64]int) // index of the highest version of each series
+	highestMajor := make(map[[2]uint64]int)
+	top, topMajor := -1, -1
+	for i, v := range history {
+		flag := func(p Problem, prev int, format string, args ...any) {
+			out = append(out, Violation{Index: i, Version: v, Problem: p, Previous: history[prev], Message: fmt.Sprintf(format, args...)})
+		}
+		series, major := [3]uint64{v.Epoch, v.Major, v.Minor}, [2]uint64{v.Epoch, v.Major}
+		core := Version{Epoch: v.Epoch, Major: v.Major, Minor: v.Minor, Patch: v.Patch}.Key()
+		j, dup := seen[v.Key()]
+		r, late := released[core]
+		h, inSeries := highest[series]
+		ref, inMajor := highestMajor[major]
+		if !inMajor {
+			ref = top
+		}
+		switch {
+		case dup:
+			flag(Rereleased, j, "%s was already published as %s", v, history[j].Original())
+		case v.Prerelease != "" && late:
+			flag(LatePrerelease, r, "pre-release %s follows its release %s", v, history[r])
+		case inSeries && history[h].Compare(v) > 0:
+			flag(Downgrade, h, "%s follows %s in the same series", v, history[h])
+		case !inSeries && ref >= 0 && history[ref].Compare(v) > 0:
+			flag(Downgrade, ref, "%s starts a series below %s", v, history[ref])
+		case topMajor >= 0 && v.Epoch == history[topMajor].Epoch && v.Major > history[topMajor].Major+1:
+			flag(SkippedMajor, topMajor, "%s skips from major %d to %d", v, history[topMajor].Major, v.Major)
+		}
+		if !dup {
+			seen[v.Key()] = i
+		}
+		if v.Prerelease == "" && !late {
+			released[core] = i
+		}
+		if !inSeries || v.Compare(history[h]) > 0 {
+			highest[series] = i
+		}
+		if !inMajor || v.Compare(history[ref]) > 0 {
+			highestMajor[major] = i
+		}
+		if top < 0 || v.Compare(history[top]) > 0 {
+			top = i
+		}
+		if topMajor < 0 || v.Epoch > history[topMajor].Epoch || v.Epoch == history[topMajor].Epoch && v.Major > history[topMajor].Major {
+			topMajor = i
+		}
+	}
+	return out
+}
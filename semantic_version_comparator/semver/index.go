@@ -0,0 +1,231 @@
+package semver
+
+import "strings"
+
+// Index is a sorted collection of versions that stays sorted as
+// versions are inserted and deleted, for long-running watchers that
+// learn of new tags one at a time: Insert, Delete, Has, Floor and
+// Ceiling take logarithmic expected time, where keeping a sorted slice
+// costs linear time per change and re-sorting O(n log n).
+//
+// Unlike a VersionSet, an Index keeps versions of equal precedence but
+// different build metadata apart, as a registry keeps 1.2.0+a and
+// 1.2.0+b as two tags. It orders them by precedence and then by build
+// metadata, compared as strings, so 1.2.0 comes before 1.2.0+a.
+//
+// The zero value is an empty index ready to use. An Index is not safe for
+// concurrent modification.
+type Index struct {
+	head  indexNode // head.next[i] is the first node of level i
+	level int       // the number of levels in use
+	n     int
+	rand  uint64 // xorshift state choosing node levels
+}
+
+// indexLevels bounds the levels of the skip list. With a quarter of
+// nodes promoted to each next level, it suits trillions of versions.
+const indexLevels = 20
+
+type indexNode struct {
+	v    Version
+	next []*indexNode
+}
+
+// NewIndex returns an index holding vs.
+func NewIndex(vs ...Version) *Index {
+	x := &Index{}
+	for _, v := range vs {
+		x.Insert(v)
+	}
+	return x
+}
+
+// compareIndex orders versions by precedence and then build metadata.
+func compareIndex(a, b Version) int {
+	if n := a.Compare(b); n != 0 {
+		return n
+	}
+	return strings.Compare(a.Build, b.Build)
+}
+
+// search returns, for each level, the last node before v, with the head
+// standing for none.
+func (x *Index) search(v Version) (prev [indexLevels]*indexNode) {
+	n := &x.head
+	for i := x.level - 1; i >= 0; i-- {
+		for n.next[i] != nil && compareIndex(n.next[i].v, v) < 0 {
+			n = n.next[i]
+		}
+		prev[i] = n
+	}
+	return prev
+}
+
+// seek returns the first node at or after v, or nil.
+func (x *Index) seek(v Version) *indexNode {
+	if x.level == 0 {
+		return nil
+	}
+	return x.search(v)[0].next[0]
+}
+
+// randomLevel returns the number of levels of a new node.
+func (x *Index) randomLevel() int {
+	if x.rand == 0 {
+		x.rand = 0x9E3779B97F4A7C15
+	}
+	level := 1
+	for level < indexLevels {
+		x.rand ^= x.rand << 13
+		x.rand ^= x.rand >> 7
+		x.rand ^= x.rand << 17
+		if x.rand&3 != 0 {
+			break
+		}
+		level++
+	}
+	return level
+}
+
+// Len returns the number of versions in x.
+func (x *Index) Len() int {
+	return x.n
+}
+
+// Insert adds v to x and reports whether it was not already present. A
+// version already present, build metadata included, is left as it was.
+func (x *Index) Insert(v Version) bool {
+	if x.head.next == nil {
+		x.head.next = make([]*indexNode, indexLevels)
+	}
+	prev := x.search(v)
+	if n := prev[0]; x.level > 0 && n.next[0] != nil && compareIndex(n.next[0].v, v) == 0 {
+		return false
+	}
+	level := x.randomLevel()
+	for ; x.level < level; x.level++ {
+		prev[x.level] = &x.head
+	}
+	node := &indexNode{v: v, next: make([]*indexNode, level)}
+	for i := 0; i < level; i++ {
+		node.next[i], prev[i].next[i] = prev[i].next[i], node
+	}
+	x.n++
+	return true
+}
+
+// Delete removes v, matched by precedence and build metadata, from x and
+// reports whether it was present.
+func (x *Index) Delete(v Version) bool {
+	if x.level == 0 {
+		return false
+	}
+	prev := x.search(v)
+	node := prev[0].next[0]
+	if node == nil || compareIndex(node.v, v) != 0 {
+		return false
+	}
+	for i := range node.next {
+		prev[i].next[i] = node.next[i]
+	}
+	for x.level > 0 && x.head.next[x.level-1] == nil {
+		x.level--
+	}
+	x.n--
+	return true
+}
+
+// Has reports whether x holds v, matched by precedence and build
+// metadata.
+func (x *Index) Has(v Version) bool {
+	n := x.seek(v)
+	return n != nil && compareIndex(n.v, v) == 0
+}
+
+// Ceiling returns the least version of x at or after v. ok is false if
+// there is none.
+func (x *Index) Ceiling(v Version) (_ Version, ok bool) {
+	if n := x.seek(v); n != nil {
+		return n.v, true
+	}
+	return Version{}, false
+}
+
+// Floor returns the greatest version of x at or before v. ok is false if
+// there is none.
+func (x *Index) Floor(v Version) (_ Version, ok bool) {
+	if x.level == 0 {
+		return Version{}, false
+	}
+	prev := x.search(v)
+	if n := prev[0].next[0]; n != nil && compareIndex(n.v, v) == 0 {
+		return n.v, true
+	}
+	if prev[0] == &x.head {
+		return Version{}, false
+	}
+	return prev[0].v, true
+}
+
+// Min returns the least version of x. ok is false if x is empty.
+func (x *Index) Min() (_ Version, ok bool) {
+	if x.n == 0 {
+		return Version{}, false
+	}
+	return x.head.next[0].v, true
+}
+
+// Max returns the greatest version of x. ok is false if x is empty.
+func (x *Index) Max() (_ Version, ok bool) {
+	if x.n == 0 {
+		return Version{}, false
+	}
+	n := &x.head
+	for i := x.level - 1; i >= 0; i-- {
+		for n.next[i] != nil {
+			n = n.next[i]
+		}
+	}
+	return n.v, true
+}
+
+// Each calls f for each version of x in ascending order, stopping early
+// if f returns false. f must not modify x.
+func (x *Index) Each(f func(Version) bool) {
+	if x.level == 0 {
+		return
+	}
+	for n := x.head.next[0]; n != nil; n = n.next[0] {
+		if !f(n.v) {
+			return
+		}
+	}
+}
+
+// Scan calls f, in ascending order, for each version of x that
+// satisfies c, stopping early if f returns false. It visits only the
+// versions within c.Ranges, so a scan for "~1.4" over every release of
+// a project takes time in proportion to the 1.4 releases. f must not
+// modify x.
+func (x *Index) Scan(c Constraint, f func(Version) bool) {
+	for _, r := range c.Ranges() {
+		for n := x.seek(r.min); n != nil; n = n.next[0] {
+			if r.bounded && n.v.Compare(r.max) >= 0 {
+				break
+			}
+			if c.Check(n.v) && !f(n.v) {
+				return
+			}
+		}
+	}
+}
+
+// Versions returns the versions of x in ascending order.
+func (x *Index) Versions() []Version {
+	vs := make([]Version, 0, x.n)
+	x.Each(func(v Version) bool {
+		vs = append(vs, v)
+		return true
+	})
+	return vs
+}
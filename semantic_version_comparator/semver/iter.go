@@ -0,0 +1,69 @@
+//go:build go1.23
+
+package semver
+
+import (
+	"iter"
+	"slices"
+)
+
+// All returns an iterator over the versions of s in ascending order. s
+// must not be modified during iteration.
+func (s *VersionSet) All() iter.Seq[Version] {
+	return slices.Values(s.list())
+}
+
+// Backward returns an iterator over the versions of s in descending
+// order. s must not be modified during iteration.
+func (s *VersionSet) Backward() iter.Seq[Version] {
+	return func(yield func(Version) bool) {
+		vs := s.list()
+		for i := len(vs) - 1; i >= 0; i-- {
+			if !yield(vs[i]) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the versions of x in ascending order. x
+// must not be modified during iteration.
+func (x *Index) All() iter.Seq[Version] {
+	return x.Each
+}
+
+// Matching returns an iterator over the versions of x that satisfy c, in
+// ascending order, as Scan visits them. x must not be modified during
+// iteration.
+func (x *Index) Matching(c Constraint) iter.Seq[Version] {
+	return func(yield func(Version) bool) {
+		x.Scan(c, yield)
+	}
+}
+
+// CollectSet returns a set of the versions produced by seq, keeping the
+// first of each precedence.
+func CollectSet(seq iter.Seq[Version]) *VersionSet {
+	return NewVersionSet(slices.Collect(seq)...)
+}
+
+// Sorted returns an iterator over the versions of seq in ascending
+// precedence order. Versions of equal precedence keep their relative
+// order.
+func Sorted(seq iter.Seq[Version]) iter.Seq[Version] {
+	vs := slices.Collect(seq)
+	Sort(vs)
+	return slices.Values(vs)
+}
+
+// SortedMap returns an iterator over the entries of m in the key order of
+// SortedKeys.
+func SortedMap[M ~map[Version]V, V any](m M) iter.Seq2[Version, V] {
+	return func(yield func(Version, V) bool) {
+		for _, k := range SortedKeys(m) {
+			if !yield(k, m[k]) {
+				return
+			}
+		}
+	}
+}
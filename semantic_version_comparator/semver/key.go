@@ -0,0 +1,50 @@
+package semver
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"strings"
+)
+
+// Key is a comparable form of a version's precedence, for use as a map
+// key or set element: two versions have equal keys exactly when Compare
+// reports them equal, whatever their spelling or build metadata, so
+// "v1.2", "1.2.0" and "1.2.0+build.5" share a key.
+type Key struct {
+	epoch, major, minor, patch uint64
+	prerelease                 string
+}
+
+// Key returns the key of v. Numeric pre-release identifiers are
+// normalized, so 1.0.0-rc.01 built field by field shares the key of
+// 1.0.0-rc.1, as it shares its precedence.
+func (v Version) Key() Key {
+	pre := v.Prerelease
+	if strings.Contains(pre, ".0") || strings.HasPrefix(pre, "0") {
+		pre = trimNumericIdents(pre)
+	}
+	return Key{epoch: v.Epoch, major: v.Major, minor: v.Minor, patch: v.Patch, prerelease: pre}
+}
+
+// Version returns the version that k was made from, without build
+// metadata.
+func (k Key) Version() Version {
+	return Version{Epoch: k.epoch, Major: k.major, Minor: k.minor, Patch: k.patch, Prerelease: k.prerelease}
+}
+
+// Hash returns a hash of v's precedence: versions that Compare reports
+// equal have equal hashes. The hash is FNV-1a over the components of
+// v.Key() and is stable across processes and releases of this package,
+// so it may be stored or used to shard versions between machines.
+func (v Version) Hash() uint64 {
+	k := v.Key()
+	h := fnv.New64a()
+	var buf [8 * 4]byte
+	binary.BigEndian.PutUint64(buf[0:], k.epoch)
+	binary.BigEndian.PutUint64(buf[8:], k.major)
+	binary.BigEndian.PutUint64(buf[16:], k.minor)
+	binary.BigEndian.PutUint64(buf[24:], k.patch)
+	h.Write(buf[:])
+	h.Write([]byte(k.prerelease))
+	return h.Sum64()
+}
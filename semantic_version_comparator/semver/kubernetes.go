@@ -0,0 +1,116 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// KubeVersion is a Kubernetes version as reported by clusters and
+// kubectl, such as "v1.27.3", "v1.28.0-rc.1", "v1.27.3-gke.100" or
+// "1.28+".
+type KubeVersion struct {
+	// Version is the upstream Kubernetes release. Its pre-release is
+	// only set for upstream alpha, beta and rc releases.
+	Version Version
+	// Vendor is a distribution suffix that SemVer would read as a
+	// pre-release, such as "gke.100" or "eks-4f4795d". It marks a build
+	// of the upstream release, not a version before it.
+	Vendor string
+	// Plus is set for the "MAJOR.MINOR+" form that kubectl reports for
+	// some managed clusters; the patch version is then unknown and zero.
+	Plus bool
+}
+
+// ParseKubernetes parses a Kubernetes version. A leading "v" is optional.
+// A suffix after "-" is an upstream pre-release if it starts with a
+// release channel (alpha, beta or rc) and a vendor suffix otherwise.
+// Build metadata, as in "v1.26.5+k3s1", is kept in Version.Build.
+func ParseKubernetes(s string) (KubeVersion, error) {
+	s = strings.TrimSpace(s)
+	if core, ok := strings.CutSuffix(s, "+"); ok && strings.Count(core, ".") == 1 {
+		v, err := ParseTolerant(core + ".0")
+		if err != nil || v.Prerelease != "" || v.Build != "" {
+			return KubeVersion{}, fmt.Errorf("semver: invalid Kubernetes version %q", s)
+		}
+		return KubeVersion{Version: v, Plus: true}, nil
+	}
+	v, err := Parse(s)
+	if err != nil {
+		return KubeVersion{}, err
+	}
+	k := KubeVersion{Version: v}
+	if v.Prerelease != "" && v.Channel() == ChannelUnknown {
+		k.Vendor, k.Version.Prerelease, k.Version.original = v.Prerelease, "", ""
+	}
+	return k, nil
+}
+
+// String returns k as Kubernetes writes it, with a leading "v":
+// "v1.27.3-gke.100", or "v1.28+" for the Plus form.
+func (k KubeVersion) String() string {
+	if k.Plus {
+		return fmt.Sprintf("v%d.%d+", k.Version.Major, k.Version.Minor)
+	}
+	v := k.Version
+	if k.Vendor != "" {
+		v.Prerelease = k.Vendor
+	}
+	return "v" + v.String()
+}
+
+// Compare orders k and o by their upstream versions and, between builds
+// of the same release, by vendor suffix, which sorts after the plain
+// upstream release: v1.27.3 < v1.27.3-gke.100 < v1.27.3-gke.200 <
+// v1.27.4.
+func (k KubeVersion) Compare(o KubeVersion) int {
+	if c := k.Version.Compare(o.Version); c != 0 {
+		return c
+	}
+	switch {
+	case k.Vendor == o.Vendor:
+		return 0
+	case k.Vendor == "":
+		return -1
+	case o.Vendor == "":
+		return 1
+	}
+	return comparePrerelease(k.Vendor, o.Vendor)
+}
+
+// CheckSkew reports whether a component at version c may talk to a
+// control plane at version server: c must have the same major version
+// and lie at most behind minor versions below and ahead minor versions
+// above server. Patch versions, pre-releases and vendor suffixes are
+// ignored.
+func CheckSkew(server, c KubeVersion, behind, ahead int) error {
+	s, v := server.Version, c.Version
+	if s.Major != v.Major {
+		return fmt.Errorf("semver: %v and %v differ in major version", c, server)
+	}
+	switch d := subUint(v.Minor, s.Minor); {
+	case d < -int64(behind):
+		return fmt.Errorf("semver: %v is %d minor versions older than %v, more than the %d allowed", c, -d, server, behind)
+	case d > int64(ahead):
+		return fmt.Errorf("semver: %v is %d minor versions newer than %v, more than the %d allowed", c, d, server, ahead)
+	}
+	return nil
+}
+
+// CheckKubeletSkew applies the Kubernetes version skew policy between a
+// kube-apiserver and a kubelet: the kubelet may not be newer than the
+// apiserver and may be up to three minor versions older, or two for an
+// apiserver older than 1.28.
+func CheckKubeletSkew(apiserver, kubelet KubeVersion) error {
+	behind := 3
+	if apiserver.Version.Major == 1 && apiserver.Version.Minor < 28 {
+		behind = 2
+	}
+	return CheckSkew(apiserver, kubelet, behind, 0)
+}
+
+// CheckKubectlSkew applies the Kubernetes version skew policy between a
+// kube-apiserver and kubectl, which is supported within one minor
+// version of the apiserver in either direction.
+func CheckKubectlSkew(apiserver, kubectl KubeVersion) error {
+	return CheckSkew(apiserver, kubectl, 1, 1)
+}
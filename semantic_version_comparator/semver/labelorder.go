@@ -0,0 +1,132 @@
+package semver
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ReleaseLabel stands for the release itself in a LabelOrder. Labels
+// listed after it sort above the release they belong to.
+const ReleaseLabel = "release"
+
+// LabelOrder is an organization's own precedence of pre-release labels,
+// for labels whose ASCII order is not their order of maturity: under
+// SemVer, 1.0.0-dev sorts after 1.0.0-beta, and under the order
+// "dev < alpha < beta < rc" before it.
+//
+// The label of a pre-release is its first identifier, lower-cased and
+// without a trailing number, as for Channel: both 1.0.0-rc.2 and
+// 1.0.0-RC2 are labeled rc. Versions with different labels order by the
+// position of their labels; those with the same label, by SemVer rules.
+// Labels not listed, numeric ones included, sort before every listed
+// label, among themselves by SemVer rules, so an unexpected label counts
+// as the least mature.
+//
+// ReleaseLabel marks the place of the release, last unless listed, and
+// lets an order rank labels such as hotfix above it: under
+// "alpha < rc < release < hotfix", 1.0.0-hotfix.1 follows 1.0.0 and
+// precedes 1.0.1-alpha. Such post-release labels contradict SemVer,
+// which sorts every pre-release before its release, so constraints,
+// which follow SemVer, do not see that order.
+type LabelOrder struct {
+	rank    map[string]int
+	labels  []string
+	release int // the rank of the release
+}
+
+// NewLabelOrder returns the order of labels, from least to most mature.
+// It fails if a label is listed twice, is not a single identifier or is
+// numeric or ends in a digit, since trailing digits are not part of a
+// label.
+func NewLabelOrder(labels ...string) (*LabelOrder, error) {
+	o := &LabelOrder{rank: make(map[string]int, len(labels)), release: len(labels) + 1}
+	for i, l := range labels {
+		l = strings.ToLower(strings.TrimSpace(l))
+		switch {
+		case !validLabel(l):
+			return nil, fmt.Errorf("semver: invalid pre-release label %q", strings.TrimSpace(labels[i]))
+		case o.rank[l] != 0 || l == ReleaseLabel && o.release <= len(labels):
+			return nil, fmt.Errorf("semver: pre-release label %q listed twice", l)
+		case l == ReleaseLabel:
+			o.release = i + 1
+		default:
+			o.rank[l] = i + 1
+		}
+		o.labels = append(o.labels, l)
+	}
+	return o, nil
+}
+
+func validLabel(l string) bool {
+	if l == "" || l[len(l)-1] >= '0' && l[len(l)-1] <= '9' {
+		return false
+	}
+	for i := 0; i < len(l); i++ {
+		if !isIdentChar(l[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseLabelOrder parses an order written as its labels separated by
+// "<", such as "dev < alpha < beta < rc < release < hotfix".
+func ParseLabelOrder(s string) (*LabelOrder, error) {
+	return NewLabelOrder(strings.Split(s, "<")...)
+}
+
+// MustParseLabelOrder is like ParseLabelOrder but panics on error.
+func MustParseLabelOrder(s string) *LabelOrder {
+	o, err := ParseLabelOrder(s)
+	if err != nil {
+		panic(err)
+	}
+	return o
+}
+
+// String returns o in the form ParseLabelOrder reads.
+func (o *LabelOrder) String() string {
+	return strings.Join(o.labels, " < ")
+}
+
+// Labels returns the labels of o, from least to most mature.
+func (o *LabelOrder) Labels() []string {
+	return slices.Clone(o.labels)
+}
+
+// label returns the rank of the label of the pre-release pre: zero for
+// an unlisted label and o.release for a release.
+func (o *LabelOrder) label(pre string) int {
+	if pre == "" {
+		return o.release
+	}
+	first, _, _ := strings.Cut(pre, ".")
+	return o.rank[strings.TrimRight(strings.ToLower(first), "0123456789")]
+}
+
+// ComparePrerelease compares the pre-releases a and b, written without
+// the "-", under o. An empty string is a release.
+func (o *LabelOrder) ComparePrerelease(a, b string) int {
+	if n := compareInt(o.label(a), o.label(b)); n != 0 {
+		return n
+	}
+	return comparePrerelease(a, b)
+}
+
+// Compare compares a and b as Version.Compare does, with pre-releases of
+// the same version core ordered by o.
+func (o *LabelOrder) Compare(a, b Version) int {
+	for _, p := range [...][2]uint64{{a.Epoch, b.Epoch}, {a.Major, b.Major}, {a.Minor, b.Minor}, {a.Patch, b.Patch}} {
+		if n := compareUint(p[0], p[1]); n != 0 {
+			return n
+		}
+	}
+	return o.ComparePrerelease(a.Prerelease, b.Prerelease)
+}
+
+// Sort sorts vs in ascending order under o. Versions of equal precedence
+// keep their relative order.
+func (o *LabelOrder) Sort(vs []Version) {
+	slices.SortStableFunc(vs, o.Compare)
+}
@@ -0,0 +1,127 @@
+package semver
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Support is the support status of a version under a SupportPolicy.
+// Statuses order from best to worst, so they can be compared with < and >.
+type Support int
+
+const (
+	Supported   Support = iota // fully supported
+	Maintenance                // receiving fixes for critical issues only
+	EOL                        // no longer supported
+)
+
+var supportNames = [...]string{"supported", "maintenance", "eol"}
+
+func (s Support) String() string {
+	if s < 0 || int(s) >= len(supportNames) {
+		return "Support(" + strconv.Itoa(int(s)) + ")"
+	}
+	return supportNames[s]
+}
+
+// ParseSupport returns the support status with the given name:
+// "supported", "maintenance" or "eol".
+func ParseSupport(name string) (Support, error) {
+	for i, n := range supportNames {
+		if n == name {
+			return Support(i), nil
+		}
+	}
+	return 0, fmt.Errorf("semver: unknown support status %q", name)
+}
+
+// SupportDates are the dates at which a release series enters
+// maintenance and reaches end of life. A zero date is not set.
+type SupportDates struct {
+	Maintenance, EOL time.Time
+}
+
+// SupportPolicy declares the support windows of a project's release
+// series, by position among the released series, by date, or both.
+type SupportPolicy struct {
+	// Level is the granularity of a series: CompareMajor for "2.x" lines
+	// or CompareMajorMinor for "2.4" lines. The zero value, CompareFull,
+	// means CompareMajorMinor.
+	Level CompareMode
+	// Supported is the number of newest released series that are fully
+	// supported, and Maintenance the number of series after those that
+	// are in maintenance; older series are end of life. If both are zero,
+	// only Dates limit support.
+	Supported, Maintenance int
+	// Dates holds explicit dates per series, keyed by SeriesOf at Level,
+	// such as "1.4". A series past its EOL date is end of life even if
+	// its position says otherwise.
+	Dates map[string]SupportDates
+}
+
+// Lifecycle evaluates a SupportPolicy against the released versions of a
+// project.
+type Lifecycle struct {
+	policy SupportPolicy
+	series []Version // the newest release of each series, newest first
+}
+
+// NewLifecycle returns the lifecycle of a project with the given released
+// versions under p. Only releases count towards the positions of series:
+// a 3.0.0-rc.1 does not push the 1.x line out of support.
+func NewLifecycle(p SupportPolicy, released []Version) *Lifecycle {
+	if p.Level == CompareFull {
+		p.Level = CompareMajorMinor
+	}
+	var stable []Version
+	for _, v := range released {
+		if v.Prerelease == "" {
+			stable = append(stable, v)
+		}
+	}
+	latest := LatestPerSeries(stable, p.Level)
+	l := &Lifecycle{policy: p}
+	for _, v := range latest {
+		l.series = append(l.series, v)
+	}
+	slices.SortFunc(l.series, func(a, b Version) int { return b.Compare(a) })
+	return l
+}
+
+// Series returns the name of the series of v, as used by Dates.
+func (l *Lifecycle) Series(v Version) string {
+	return SeriesOf(v, l.policy.Level)
+}
+
+// Status returns the support status of v at time at: the worse of the
+// status given by the position of its series among the released series
+// and the status given by the series' dates. A series newer than every
+// released one, such as that of an upcoming pre-release, is supported.
+func (l *Lifecycle) Status(v Version, at time.Time) Support {
+	status := Supported
+	if p := l.policy; p.Supported > 0 || p.Maintenance > 0 {
+		newer := 0
+		for _, s := range l.series {
+			if p.Level.Compare(s, v) > 0 {
+				newer++
+			}
+		}
+		switch {
+		case newer >= p.Supported+p.Maintenance:
+			status = EOL
+		case newer >= p.Supported:
+			status = Maintenance
+		}
+	}
+	if d, ok := l.policy.Dates[l.Series(v)]; ok {
+		switch {
+		case !d.EOL.IsZero() && !at.Before(d.EOL):
+			status = EOL
+		case !d.Maintenance.IsZero() && !at.Before(d.Maintenance):
+			status = max(status, Maintenance)
+		}
+	}
+	return status
+}
@@ -0,0 +1,107 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Limits bounds the input that ParseWith and ParseConstraint accept, so
+// that untrusted strings, such as those received by an HTTP API or read
+// from registry tags, cannot make later comparisons and constraint
+// algebra arbitrarily expensive. A zero field means no limit.
+type Limits struct {
+	// MaxLength is the maximum length in bytes of a version or
+	// constraint string.
+	MaxLength int
+	// MaxIdentifiers is the maximum number of pre-release and build
+	// identifiers of a version, together.
+	MaxIdentifiers int
+	// MaxComparators is the maximum number of comparators of a
+	// constraint, after expanding ranges such as "^1.2.3" into ">=1.2.3
+	// <2.0.0".
+	MaxComparators int
+}
+
+// DefaultLimits are limits suitable for untrusted input. Like npm, they
+// allow versions and constraints of up to 256 bytes.
+var DefaultLimits = Limits{MaxLength: 256, MaxIdentifiers: 32, MaxComparators: 64}
+
+// ErrLimit reports input that exceeds Limits. Parse functions return it
+// wrapped in a *LimitError.
+var ErrLimit = errors.New("input exceeds limit")
+
+// LimitError describes input rejected by Limits. It does not repeat the
+// input, which may be large.
+type LimitError struct {
+	Limit string // "MaxLength", "MaxIdentifiers", "MaxComparators" or "EnumerateLimit"
+	Max   int    // the value of the limit
+	Got   int    // the size of the input
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("semver: input exceeds %s of %d (got %d)", e.Limit, e.Max, e.Got)
+}
+
+func (e *LimitError) Unwrap() error { return ErrLimit }
+
+// WithLimits makes ParseWith reject versions that exceed l with a
+// *LimitError. The length is checked before any parsing.
+func WithLimits(l Limits) ParseOption {
+	return func(c *parseConfig) {
+		c.limits = l
+	}
+}
+
+// WithConstraintLimits makes ParseConstraint reject constraints that
+// exceed l with a *LimitError. MaxIdentifiers applies to the version of
+// each comparator.
+func WithConstraintLimits(l Limits) ConstraintOption {
+	return func(c *constraintConfig) {
+		c.limits = l
+	}
+}
+
+// checkLength checks the length of s.
+func (l Limits) checkLength(s string) error {
+	if l.MaxLength > 0 && len(s) > l.MaxLength {
+		return &LimitError{Limit: "MaxLength", Max: l.MaxLength, Got: len(s)}
+	}
+	return nil
+}
+
+// checkIdentifiers counts the pre-release and build identifiers of the
+// version string s. It only looks for separators, so it may run before s
+// is parsed.
+func (l Limits) checkIdentifiers(s string) error {
+	if l.MaxIdentifiers <= 0 {
+		return nil
+	}
+	i := strings.IndexAny(s, "-+")
+	if i < 0 {
+		return nil
+	}
+	suffix := s[i+1:]
+	n := strings.Count(suffix, ".") + strings.Count(suffix, "+") + 1
+	if n > l.MaxIdentifiers {
+		return &LimitError{Limit: "MaxIdentifiers", Max: l.MaxIdentifiers, Got: n}
+	}
+	return nil
+}
+
+// checkConstraint checks the comparators of a parsed constraint.
+func (l Limits) checkConstraint(groups [][]comparator) error {
+	n := 0
+	for _, g := range groups {
+		n += len(g)
+		for _, cmp := range g {
+			if err := l.checkIdentifiers(cmp.v.String()); err != nil {
+				return err
+			}
+		}
+	}
+	if l.MaxComparators > 0 && n > l.MaxComparators {
+		return &LimitError{Limit: "MaxComparators", Max: l.MaxComparators, Got: n}
+	}
+	return nil
+}
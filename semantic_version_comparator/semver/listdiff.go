@@ -0,0 +1,150 @@
+package semver
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// ListChange classifies an entry of the difference between two
+// snapshots of a version list, or the change of a component between two
+// version maps.
+type ListChange int
+
+const (
+	ListAdded      ListChange = iota // a version only in the new list
+	ListRemoved                      // a version only in the old list
+	ListUpgraded                     // the latest version of a series went up
+	ListDowngraded                   // the latest version of a series went down
+)
+
+var listChangeNames = [...]string{"added", "removed", "upgraded", "downgraded"}
+
+func (c ListChange) String() string {
+	if c < 0 || int(c) >= len(listChangeNames) {
+		return "ListChange(" + strconv.Itoa(int(c)) + ")"
+	}
+	return listChangeNames[c]
+}
+
+// ParseListChange returns the list change with the given name: "added",
+// "removed", "upgraded" or "downgraded".
+func ParseListChange(name string) (ListChange, error) {
+	for i, n := range listChangeNames {
+		if n == name {
+			return ListChange(i), nil
+		}
+	}
+	return 0, fmt.Errorf("semver: unknown list change %q", name)
+}
+
+// ListEntry is one difference between two version lists.
+type ListEntry struct {
+	Change ListChange
+	// Series is the release series of Version, named by SeriesOf.
+	Series string
+	// Version is the added or removed version or, for an upgrade or
+	// downgrade, the new latest version of the series.
+	Version Version
+	// From is what Version is measured against: for an added version the
+	// highest old version below it, for a removed one the highest new
+	// version below it, which is what users of the removed version fall
+	// back to, and for an upgrade or downgrade the old latest version of
+	// the series. HasFrom is false if there is no such version.
+	From    Version
+	HasFrom bool
+	// Bump is the most significant component that differs between From
+	// and Version, or DiffNone without From.
+	Bump Change
+}
+
+// DiffLists compares two snapshots of a version list, such as the tags
+// of a mirrored repository before and after a sync. It reports every
+// version added or removed and, for each release series at level present
+// in both lists, a change of its latest version, as LatestPerSeries picks
+// it:
+//
+//	DiffLists(old, new, CompareMajorMinor)
+//	// added 1.3.0 (minor after 1.2.5), upgraded 1.2: 1.2.4 -> 1.2.5 (patch)
+//
+// Versions are matched by precedence and build metadata, so "v1.2.0" and
+// "1.2.0" are the same entry. Entries are sorted by version, with an
+// upgrade or downgrade after the addition or removal of its version.
+func DiffLists(old, new []Version, level CompareMode) []ListEntry {
+	inOld := versionKeys(old)
+	inNew := versionKeys(new)
+	sortedOld := sortedUnique(old)
+	sortedNew := sortedUnique(new)
+	var out []ListEntry
+	entry := func(c ListChange, v Version, base []Version) ListEntry {
+		e := ListEntry{Change: c, Series: SeriesOf(v, level), Version: v}
+		// The highest version of base below v.
+		i, _ := slices.BinarySearchFunc(base, v, Version.Compare)
+		if i > 0 {
+			e.From, e.HasFrom, e.Bump = base[i-1], true, base[i-1].Diff(v)
+		}
+		return e
+	}
+	for _, v := range sortedNew {
+		if !inOld[fullKey(v)] {
+			out = append(out, entry(ListAdded, v, sortedOld))
+		}
+	}
+	for _, v := range sortedOld {
+		if !inNew[fullKey(v)] {
+			out = append(out, entry(ListRemoved, v, sortedNew))
+		}
+	}
+	before := LatestPerSeries(old, level)
+	for series, to := range LatestPerSeries(new, level) {
+		from, ok := before[series]
+		if !ok || fullKey(from) == fullKey(to) {
+			continue
+		}
+		c := ListUpgraded
+		if n := to.Compare(from); n < 0 || n == 0 && to.Build < from.Build {
+			c = ListDowngraded
+		}
+		out = append(out, ListEntry{Change: c, Series: series, Version: to, From: from, HasFrom: true, Bump: from.Diff(to)})
+	}
+	slices.SortStableFunc(out, func(a, b ListEntry) int {
+		if n := a.Version.Compare(b.Version); n != 0 {
+			return n
+		}
+		return int(a.Change) - int(b.Change)
+	})
+	return out
+}
+
+// buildKey identifies a version by precedence and build metadata.
+type buildKey struct {
+	Key
+	build string
+}
+
+func fullKey(v Version) buildKey {
+	return buildKey{v.Key(), v.Build}
+}
+
+func versionKeys(vs []Version) map[buildKey]bool {
+	keys := make(map[buildKey]bool, len(vs))
+	for _, v := range vs {
+		keys[fullKey(v)] = true
+	}
+	return keys
+}
+
+// sortedUnique returns the distinct versions of vs, by fullKey, sorted
+// by precedence.
+func sortedUnique(vs []Version) []Version {
+	seen := make(map[buildKey]bool, len(vs))
+	out := make([]Version, 0, len(vs))
+	for _, v := range vs {
+		if k := fullKey(v); !seen[k] {
+			seen[k] = true
+			out = append(out, v)
+		}
+	}
+	Sort(out)
+	return out
+}
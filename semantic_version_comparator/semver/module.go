@@ -0,0 +1,79 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// IsIncompatible reports whether v carries the "+incompatible" build
+// suffix, which the go command uses for v2+ versions of modules that do
+// not have a major version suffix in their module path.
+func (v Version) IsIncompatible() bool {
+	return v.Build == "incompatible"
+}
+
+// SplitPathMajor splits a Go module path into its prefix and major version
+// suffix, such as "/v2" or, for gopkg.in paths, ".v2". The suffix is empty
+// if the path has none. ok is false if the path carries an invalid suffix
+// such as "/v1" or "/v02".
+func SplitPathMajor(path string) (prefix, pathMajor string, ok bool) {
+	if strings.HasPrefix(path, "gopkg.in/") {
+		i := strings.LastIndex(path, ".v")
+		if i < 0 {
+			return path, "", false
+		}
+		n := strings.TrimSuffix(path[i+2:], "-unstable")
+		if !isNumeric(n) || len(n) > 1 && n[0] == '0' {
+			return path, "", false
+		}
+		return path[:i], path[i:], true
+	}
+	i := strings.LastIndex(path, "/v")
+	if i < 0 || !isNumeric(path[i+2:]) {
+		return path, "", true
+	}
+	n := path[i+2:]
+	if n[0] == '0' || n == "1" {
+		return path, "", false
+	}
+	return path[:i], path[i:], true
+}
+
+// CheckPathMajor reports whether v is a valid version for the Go module
+// at modulePath: modules without a major version suffix accept v0 and v1
+// (and v2+ only with +incompatible), while a "/vN" or gopkg.in ".vN"
+// suffix requires major version N.
+func CheckPathMajor(v Version, modulePath string) error {
+	_, pathMajor, ok := SplitPathMajor(modulePath)
+	if !ok {
+		return fmt.Errorf("semver: invalid major version suffix in module path %q", modulePath)
+	}
+	if v.IsIncompatible() {
+		if pathMajor != "" {
+			return fmt.Errorf("semver: v%s: +incompatible not allowed for module path %q with major version suffix", v, modulePath)
+		}
+		if v.Major < 2 {
+			return fmt.Errorf("semver: v%s: +incompatible requires major version 2 or higher", v)
+		}
+		return nil
+	}
+	if pathMajor == "" {
+		if v.Major > 1 {
+			return fmt.Errorf("semver: v%s: module path %q requires major version 0 or 1, or a /v%d suffix", v, modulePath, v.Major)
+		}
+		return nil
+	}
+	want, err := strconv.ParseUint(strings.TrimSuffix(pathMajor[2:], "-unstable"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("semver: invalid major version suffix in module path %q", modulePath)
+	}
+	if pathMajor[0] == '.' && want == 1 && v.Major == 0 && v.Minor == 0 && v.Patch == 0 && v.IsPseudo() {
+		// gopkg.in/x.v1 predates tagging and accepts v0.0.0 pseudo-versions.
+		return nil
+	}
+	if v.Major != want {
+		return fmt.Errorf("semver: v%s: module path %q requires major version %d", v, modulePath, want)
+	}
+	return nil
+}
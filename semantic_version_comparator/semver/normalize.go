@@ -0,0 +1,103 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// lookalike reports whether r is a character that copy-pasted versions
+// commonly carry in place of ASCII, such as a full-width digit from an
+// IME or an en dash from a word processor, and returns a description of
+// it and the ASCII text it stands for, which is empty for characters
+// that stand for nothing, such as a byte order mark.
+func lookalike(r rune) (name, ascii string, ok bool) {
+	switch {
+	case r == '\uFEFF':
+		return "byte order mark", "", true
+	case r >= '\u200B' && r <= '\u200D' || r == '\u2060':
+		return "zero-width character", "", true
+	case r >= '\uFF10' && r <= '\uFF19':
+		return "full-width digit", string(r - 0xFEE0), true
+	case r >= '\uFF01' && r <= '\uFF5E':
+		return "full-width character", string(r - 0xFEE0), true
+	case r >= '\u2010' && r <= '\u2015' || r == '\u2212' || r == '\uFE63':
+		return "typographic dash", "-", true
+	case r == '\u00A0' || r == '\u2007' || r == '\u202F':
+		return "non-breaking space", " ", true
+	case r >= utf8.RuneSelf && unicode.IsSpace(r):
+		return "non-ASCII space", " ", true
+	}
+	return "", "", false
+}
+
+// trimOriginal removes from s the surrounding whitespace, Unicode spaces
+// and invisible characters such as a byte order mark, which parsing
+// ignores, leaving the version as the caller wrote it.
+func trimOriginal(s string) string {
+	return strings.TrimFunc(s, func(r rune) bool {
+		if unicode.IsSpace(r) {
+			return true
+		}
+		_, ascii, ok := lookalike(r)
+		return ok && (ascii == "" || ascii == " ")
+	})
+}
+
+// Normalize replaces the non-ASCII lookalikes that text copied from
+// documents, chat and terminals often carries with the ASCII characters
+// they stand for: full-width digits, letters and punctuation become
+// ASCII, en and em dashes, minus signs and other typographic hyphens
+// become "-", non-breaking and other Unicode spaces become " ", and
+// byte order marks and zero-width characters are removed. Other
+// characters are left as they are.
+//
+// ParseTolerant normalizes its input; Parse and ParseStrict reject these
+// characters with an error naming them.
+func Normalize(s string) string {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return strings.Map(func(r rune) rune {
+				_, ascii, ok := lookalike(r)
+				switch {
+				case !ok:
+					return r
+				case ascii == "":
+					return -1
+				}
+				return rune(ascii[0])
+			}, s)
+		}
+	}
+	return s
+}
+
+// checkASCII returns a *ParseError for the first non-ASCII character of
+// s, naming it and, if it is a lookalike, the ASCII character meant.
+func checkASCII(s string) error {
+	for i, r := range s {
+		if r < utf8.RuneSelf {
+			continue
+		}
+		pe := &ParseError{Input: s, Pos: i, Value: string(r), Err: ErrNonASCII}
+		if _, ascii, ok := lookalike(r); ok && ascii != "" {
+			pe.Expected = fmt.Sprintf("%q", ascii[0])
+		}
+		return pe
+	}
+	return nil
+}
+
+// describeRune names r for an error message, as in
+// "full-width digit '１' (U+FF11)".
+func describeRune(r rune) string {
+	name, _, ok := lookalike(r)
+	if !ok {
+		name = "non-ASCII character"
+	}
+	if !unicode.IsPrint(r) || unicode.IsSpace(r) {
+		return fmt.Sprintf("%s U+%04X", name, r)
+	}
+	return fmt.Sprintf("%s %q (U+%04X)", name, r, r)
+}
@@ -0,0 +1,62 @@
+package semver
+
+// Equal reports whether v and o are identical, including build metadata.
+// The strings they were parsed from, as returned by Original, may differ.
+func (v Version) Equal(o Version) bool {
+	v.original, o.original = "", ""
+	v.status, o.status = 0, 0
+	return v == o
+}
+
+// EqualIgnoringBuild reports whether v and o have equal precedence, that
+// is whether they differ at most in build metadata.
+func (v Version) EqualIgnoringBuild(o Version) bool {
+	return v.Compare(o) == 0
+}
+
+// LessThan reports whether v has lower precedence than o.
+func (v Version) LessThan(o Version) bool {
+	return v.Compare(o) < 0
+}
+
+// GreaterThan reports whether v has higher precedence than o.
+func (v Version) GreaterThan(o Version) bool {
+	return v.Compare(o) > 0
+}
+
+// AtLeast reports whether v has precedence equal to or higher than o.
+func (v Version) AtLeast(o Version) bool {
+	return v.Compare(o) >= 0
+}
+
+// AtMost reports whether v has precedence equal to or lower than o.
+func (v Version) AtMost(o Version) bool {
+	return v.Compare(o) <= 0
+}
+
+// Between reports whether lo <= v <= hi.
+func (v Version) Between(lo, hi Version) bool {
+	return v.AtLeast(lo) && v.AtMost(hi)
+}
+
+// Max returns the version with the highest precedence among its
+// arguments. Of versions with equal precedence the first is returned.
+func Max(v Version, vs ...Version) Version {
+	for _, o := range vs {
+		if o.GreaterThan(v) {
+			v = o
+		}
+	}
+	return v
+}
+
+// Min returns the version with the lowest precedence among its arguments.
+// Of versions with equal precedence the first is returned.
+func Min(v Version, vs ...Version) Version {
+	for _, o := range vs {
+		if o.LessThan(v) {
+			v = o
+		}
+	}
+	return v
+}
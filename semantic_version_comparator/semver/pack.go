@@ -0,0 +1,128 @@
+package semver
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// binaryVersion is the first byte of the MarshalBinary encoding,
+// reserved for future changes to the format.
+const binaryVersion = 1
+
+// MarshalBinary implements encoding.BinaryMarshaler with a compact
+// encoding of every field of v: a format byte, the epoch, major, minor and
+// patch as uvarints, then the pre-release and build metadata, each
+// prefixed by its length as a uvarint. 1.2.3 takes 7 bytes. The encoding
+// is not ordered; use Pack for keys that sort by precedence.
+func (v Version) MarshalBinary() ([]byte, error) {
+	return v.AppendBinary(make([]byte, 0, 8+len(v.Prerelease)+len(v.Build)))
+}
+
+// AppendBinary appends the MarshalBinary encoding of v to b.
+func (v Version) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, binaryVersion)
+	for _, n := range [...]uint64{v.Epoch, v.Major, v.Minor, v.Patch} {
+		b = binary.AppendUvarint(b, n)
+	}
+	for _, s := range [...]string{v.Prerelease, v.Build} {
+		b = binary.AppendUvarint(b, uint64(len(s)))
+		b = append(b, s...)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, decoding the
+// output of MarshalBinary. It rejects truncated or trailing data and
+// invalid pre-release or build identifiers.
+func (v *Version) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != binaryVersion {
+		return fmt.Errorf("semver: invalid binary version encoding")
+	}
+	rest := data[1:]
+	var out Version
+	for _, p := range [...]*uint64{&out.Epoch, &out.Major, &out.Minor, &out.Patch} {
+		n, size := binary.Uvarint(rest)
+		if size <= 0 {
+			return fmt.Errorf("semver: invalid binary version encoding: bad number")
+		}
+		*p, rest = n, rest[size:]
+	}
+	for _, p := range [...]*string{&out.Prerelease, &out.Build} {
+		n, size := binary.Uvarint(rest)
+		if size <= 0 || n > uint64(len(rest)-size) {
+			return fmt.Errorf("semver: invalid binary version encoding: bad string length")
+		}
+		rest = rest[size:]
+		*p, rest = string(rest[:n]), rest[n:]
+	}
+	if len(rest) != 0 {
+		return fmt.Errorf("semver: invalid binary version encoding: %d trailing bytes", len(rest))
+	}
+	s := out.String()
+	buildOff := len(s) - len(out.Build)
+	if out.Prerelease != "" {
+		off := buildOff - len(out.Prerelease)
+		if out.Build != "" {
+			off--
+		}
+		if err := checkIdents(s, "pre-release", out.Prerelease, off, true); err != nil {
+			return err
+		}
+	}
+	if out.Build != "" {
+		if err := checkIdents(s, "build metadata", out.Build, buildOff, false); err != nil {
+			return err
+		}
+	}
+	*v = out
+	return nil
+}
+
+// PackBits is the number of bits Pack gives each of the major, minor and
+// patch components, which limits them to MaxPacked.
+const (
+	PackBits  = 21
+	MaxPacked = 1<<PackBits - 1
+)
+
+// Pack packs the release of v into an integer that orders like v, so that
+// versions can be stored in an integer column and compared with plain
+// integer indexes: the major, minor and patch each take PackBits bits,
+// and the lowest bit is 1 for a release and 0 for a pre-release. For
+// versions a and b, Pack(a) < Pack(b) implies a < b, and a < b implies
+// Pack(a) <= Pack(b).
+//
+// The packing is lossy: the pre-release and build metadata themselves are
+// dropped, so that every pre-release of 1.2.3 packs to the same value,
+// just below that of 1.2.3. Pack fails if the epoch is not zero or a
+// component exceeds MaxPacked.
+func (v Version) Pack() (uint64, error) {
+	if v.Epoch != 0 {
+		return 0, fmt.Errorf("semver: cannot pack %s: epoch is not zero", v)
+	}
+	for i, n := range [...]uint64{v.Major, v.Minor, v.Patch} {
+		if n > MaxPacked {
+			return 0, fmt.Errorf("semver: cannot pack %s: %s component exceeds %d", v, componentNames[i], MaxPacked)
+		}
+	}
+	n := v.Major<<(2*PackBits+1) | v.Minor<<(PackBits+1) | v.Patch<<1
+	if v.Prerelease == "" {
+		n |= 1
+	}
+	return n, nil
+}
+
+// Unpack returns the version packed into n by Pack. A packed pre-release
+// unpacks to the lowest pre-release of its release, as 1.2.3-0, so
+// Unpack(Pack(v)) never has higher precedence than v.
+func Unpack(n uint64) Version {
+	v := Version{
+		Major: n >> (2*PackBits + 1) & MaxPacked,
+		Minor: n >> (PackBits + 1) & MaxPacked,
+		Patch: n >> 1 & MaxPacked,
+	}
+	if n&1 == 0 {
+		v.Prerelease = "0"
+	}
+	return v
+}
@@ -0,0 +1,153 @@
+package semver
+
+import (
+	"runtime"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// minShard is the smallest slice worth handing to a goroutine of the
+// parallel functions; shorter inputs are processed serially.
+const minShard = 1 << 12
+
+// SortParallel sorts vs in ascending precedence order like Sort, whose
+// result it matches exactly, but splits the work across up to workers
+// goroutines: each sorts a shard of vs, and the sorted shards are merged
+// in rounds, each merge itself split across the workers. If workers <= 0
+// it uses runtime.GOMAXPROCS(0). SortParallel allocates a buffer the size
+// of vs, and pays off on slices of hundreds of thousands of versions and
+// more; shorter slices are sorted serially.
+func SortParallel(vs []Version, workers int) {
+	sortParallel(vs, CompareVersions, workers)
+}
+
+// CompareAll returns, for each i, a[i].Compare(b[i]), computed by up to
+// workers goroutines; if workers <= 0 it uses runtime.GOMAXPROCS(0). The
+// result has the length of the shorter of a and b.
+func CompareAll(a, b []Version, workers int) []int {
+	n := min(len(a), len(b))
+	out := make([]int, n)
+	parallelFor(n, workers, func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			out[i] = a[i].Compare(b[i])
+		}
+	})
+	return out
+}
+
+// shards returns how many goroutines parallel work over n elements should
+// use, given the requested number of workers.
+func shards(n, workers int) int {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return max(min(workers, n/minShard), 1)
+}
+
+// parallelFor calls fn on consecutive, disjoint subranges [lo, hi) that
+// cover [0, n), concurrently, and waits for them to return.
+func parallelFor(n, workers int, fn func(lo, hi int)) {
+	k := shards(n, workers)
+	if k == 1 {
+		fn(0, n)
+		return
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < k; i++ {
+		wg.Add(1)
+		go func(lo, hi int) {
+			defer wg.Done()
+			fn(lo, hi)
+		}(i*n/k, (i+1)*n/k)
+	}
+	wg.Wait()
+}
+
+// sortParallel is a stable parallel merge sort of s by cmp.
+func sortParallel[T any](s []T, cmp func(a, b T) int, workers int) {
+	k := shards(len(s), workers)
+	if k == 1 {
+		slices.SortStableFunc(s, cmp)
+		return
+	}
+	runs := make([]int, k+1)
+	for i := range runs {
+		runs[i] = i * len(s) / k
+	}
+	parallelFor(len(s), k, func(lo, hi int) {
+		slices.SortStableFunc(s[lo:hi], cmp)
+	})
+	src, dst := s, make([]T, len(s))
+	for len(runs) > 2 {
+		merges := (len(runs) - 1) / 2
+		pieces := max(k/merges, 1)
+		next := []int{0}
+		var wg sync.WaitGroup
+		for i := 0; i+1 < len(runs); i += 2 {
+			lo, mid := runs[i], runs[i+1]
+			if i+2 == len(runs) {
+				// An odd run out is carried over to the next round.
+				copy(dst[lo:mid], src[lo:mid])
+				next = append(next, mid)
+				continue
+			}
+			hi := runs[i+2]
+			parallelMerge(&wg, dst[lo:hi], src[lo:mid], src[mid:hi], cmp, pieces)
+			next = append(next, hi)
+		}
+		wg.Wait()
+		src, dst, runs = dst, src, next
+	}
+	if &src[0] != &s[0] {
+		copy(s, src)
+	}
+}
+
+// parallelMerge merges the sorted slices a and b into dst, splitting the
+// output into pieces merged by separate goroutines added to wg. Elements
+// of a precede equal elements of b, which keeps the sort stable.
+func parallelMerge[T any](wg *sync.WaitGroup, dst, a, b []T, cmp func(a, b T) int, pieces int) {
+	prevI, prevJ := 0, 0
+	for p := 1; p <= pieces; p++ {
+		t := p * len(dst) / pieces
+		i := coRank(t, a, b, cmp)
+		j := t - i
+		wg.Add(1)
+		go func(dst, a, b []T) {
+			defer wg.Done()
+			merge(dst, a, b, cmp)
+		}(dst[prevI+prevJ:t], a[prevI:i], b[prevJ:j])
+		prevI, prevJ = i, j
+	}
+}
+
+// coRank returns how many elements of a are among the first t elements
+// of the stable merge of a and b.
+func coRank[T any](t int, a, b []T, cmp func(a, b T) int) int {
+	lo, hi := max(0, t-len(b)), min(t, len(a))
+	return lo + sort.Search(hi-lo, func(k int) bool {
+		i := lo + k
+		j := t - i
+		// i is too small while a[i] would still be taken before b[j-1].
+		return !(j > 0 && cmp(a[i], b[j-1]) <= 0)
+	})
+}
+
+// merge merges the sorted slices a and b into dst, which has room for
+// both, taking elements of a first on ties.
+func merge[T any](dst, a, b []T, cmp func(a, b T) int) {
+	i, j, k := 0, 0, 0
+	for i < len(a) && j < len(b) {
+		if cmp(b[j], a[i]) < 0 {
+			dst[k] = b[j]
+			j++
+		} else {
+			dst[k] = a[i]
+			i++
+		}
+		k++
+	}
+	k += copy(dst[k:], a[i:])
+	copy(dst[k:], b[j:])
+}
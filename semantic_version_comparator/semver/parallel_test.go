@@ -0,0 +1,93 @@
+package semver
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// parallelInput returns n versions, drawn from SampleVersions with many
+// of equal precedence, so that stability is exercised.
+func parallelInput(n int) []Version {
+	vs := make([]Version, n)
+	for i, s := range SampleVersions(n, 1) {
+		vs[i], _ = ParseTolerant(s)
+	}
+	return vs
+}
+
+func TestSortParallel(t *testing.T) {
+	for _, n := range []int{0, 1, minShard - 1, 5*minShard + 17} {
+		want := parallelInput(n)
+		got := slices.Clone(want)
+		Sort(want)
+		for _, workers := range []int{1, 2, 3, 8} {
+			SortParallel(got, workers)
+			if !slices.Equal(got, want) {
+				t.Errorf("SortParallel of %d versions with %d workers differs from Sort", n, workers)
+			}
+		}
+	}
+}
+
+func TestCompareAll(t *testing.T) {
+	a, b := parallelInput(5*minShard), parallelInput(5*minShard+3)
+	slices.Reverse(b)
+	got := CompareAll(a, b, 4)
+	if len(got) != len(a) {
+		t.Fatalf("CompareAll returned %d results, want %d", len(got), len(a))
+	}
+	for i := range got {
+		if got[i] != a[i].Compare(b[i]) {
+			t.Fatalf("CompareAll[%d] = %d, want %d", i, got[i], a[i].Compare(b[i]))
+		}
+	}
+}
+
+// The benchmarks compare the parallel functions against their serial
+// equivalents around minShard, below which they run serially, and on
+// the large inputs they are meant for.
+var parallelSizes = []int{minShard, 1 << 16, 1 << 20}
+
+func BenchmarkSortParallel(b *testing.B) {
+	for _, n := range parallelSizes {
+		input := parallelInput(n)
+		vs := make([]Version, n)
+		b.Run(fmt.Sprintf("serial/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				copy(vs, input)
+				Sort(vs)
+			}
+		})
+		b.Run(fmt.Sprintf("parallel/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				copy(vs, input)
+				SortParallel(vs, 0)
+			}
+		})
+	}
+}
+
+func BenchmarkCompareAll(b *testing.B) {
+	for _, n := range parallelSizes {
+		x, y := parallelInput(n), parallelInput(n)
+		slices.Reverse(y)
+		b.Run(fmt.Sprintf("serial/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				out := make([]int, n)
+				for j := range out {
+					out[j] = x[j].Compare(y[j])
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("parallel/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				CompareAll(x, y, 0)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package semver
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Parse parses s as MAJOR.MINOR.PATCH with an optional -PRERELEASE and
+// +BUILD suffix. It follows the same rules as ParseStrict except that a
+// single leading "v" or "V", as used by git tags and Go modules, is
+// accepted and discarded.
+func Parse(s string) (Version, error) {
+	return parseVersion(s, false, true)
+}
+
+// Prefix selects whether Canonical emits a leading "v".
+type Prefix int
+
+const (
+	NoPrefix Prefix = iota // 1.2.3
+	VPrefix                // v1.2.3
+)
+
+// Canonical parses s with ParseTolerant and returns its canonical string
+// form, with or without a leading "v" according to p.
+func Canonical(s string, p Prefix) (string, error) {
+	v, err := ParseTolerant(s)
+	if err != nil {
+		return "", err
+	}
+	if p == VPrefix {
+		return "v" + v.String(), nil
+	}
+	return v.String(), nil
+}
+
+// trimV removes a single leading "v" or "V" from s.
+func trimV(s string) string {
+	if s != "" && (s[0] == 'v' || s[0] == 'V') {
+		return s[1:]
+	}
+	return s
+}
+
+// ParseStrict parses s according to the SemVer 2.0.0 grammar: exactly three
+// numeric components without leading zeros, and pre-release and build
+// identifiers drawn from [0-9A-Za-z-]. It returns an error describing the
+// first problem found; a non-ASCII character, such as a full-width digit
+// or a non-breaking space, is reported as ErrNonASCII.
+func ParseStrict(s string) (Version, error) {
+	return parseVersion(s, false, false)
+}
+
+// ParseTolerant parses s, coercing common deviations from the grammar into
+// a canonical version: copy-paste lookalikes such as full-width digits and
+// en dashes are replaced as Normalize does, surrounding whitespace and a
+// leading "v" or "V" are removed, missing minor and patch components
+// default to zero, leading zeros are dropped from numeric components and
+// components too large for a uint64 saturate to math.MaxUint64.
+func ParseTolerant(s string) (Version, error) {
+	return parseVersion(s, true, true)
+}
+
+// MustParse is like Parse but panics if s cannot be parsed.
+func MustParse(s string) Version {
+	v, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+var componentNames = [3]string{"major", "minor", "patch"}
+
+func parseVersion(s string, tolerant, allowV bool) (Version, error) {
+	var v Version
+	raw := s
+	if tolerant {
+		// Errors then report positions in the normalized input.
+		s = Normalize(s)
+	} else if err := checkASCII(s); err != nil {
+		return Version{}, err
+	}
+	rest, off := s, 0 // off is the byte offset of rest within s
+	if tolerant {
+		trimmed := strings.TrimLeftFunc(rest, unicode.IsSpace)
+		off = len(rest) - len(trimmed)
+		rest = strings.TrimRightFunc(trimmed, unicode.IsSpace)
+	}
+	if allowV && trimV(rest) != rest {
+		rest = rest[1:]
+		off++
+	}
+	if rest == "" {
+		return Version{}, &ParseError{Input: s, Pos: off, Expected: "version", Err: ErrEmpty}
+	}
+	end := off + len(rest) // offset just past the version proper
+	if i := strings.IndexByte(rest, '+'); i >= 0 {
+		v.Build = rest[i+1:]
+		if err := checkIdents(s, "build metadata", v.Build, off+i+1, false); err != nil {
+			return Version{}, err
+		}
+		rest = rest[:i]
+	}
+	if i := strings.IndexByte(rest, '-'); i >= 0 {
+		v.Prerelease = rest[i+1:]
+		if err := checkIdents(s, "pre-release", v.Prerelease, off+i+1, !tolerant); err != nil {
+			return Version{}, err
+		}
+		if tolerant {
+			v.Prerelease = trimNumericIdents(v.Prerelease)
+		}
+		rest = rest[:i]
+	}
+	suffixStart := off + len(rest)
+	nums := [3]*uint64{&v.Major, &v.Minor, &v.Patch}
+	// Walk the components without strings.Split, which would allocate.
+	count := 0 // the number of numeric components read
+	for core, more := rest, true; more; count++ {
+		var p string
+		p, core, more = strings.Cut(core, ".")
+		if count == 3 {
+			return Version{}, &ParseError{Input: s, Pos: off - 1, Expected: "'-', '+' or end of input", Err: ErrComponentCount}
+		}
+		name := componentNames[count]
+		if p == "" {
+			return Version{}, &ParseError{Input: s, Pos: off, Expected: "digit", Component: name, Err: ErrEmpty}
+		}
+		for j := 0; j < len(p); j++ {
+			if p[j] < '0' || p[j] > '9' {
+				return Version{}, &ParseError{Input: s, Pos: off + j, Expected: "digit", Component: name, Value: p, Err: ErrInvalidCharacter}
+			}
+		}
+		if !tolerant && len(p) > 1 && p[0] == '0' {
+			return Version{}, &ParseError{Input: s, Pos: off, Component: name, Value: p, Err: ErrLeadingZero}
+		}
+		n, err := strconv.ParseUint(p, 10, 64)
+		if err != nil && !tolerant {
+			return Version{}, &ParseError{Input: s, Pos: off, Component: name, Value: p, Err: ErrOverflow}
+		}
+		// ParseUint saturates to the maximum value on overflow, which is
+		// what tolerant parsing wants.
+		*nums[count] = n
+		off += len(p) + 1
+	}
+	if !tolerant && count != 3 {
+		pos := suffixStart
+		if pos == end {
+			pos = len(s)
+		}
+		return Version{}, &ParseError{Input: s, Pos: pos, Expected: "'.'", Err: ErrComponentCount}
+	}
+	v.original = trimOriginal(raw)
+	return v, nil
+}
+
+// checkIdents validates the dot-separated identifier list ids, which
+// starts at byte offset off of input. It returns a *ParseError if the list
+// contains an empty identifier or characters outside [0-9A-Za-z-], or, if
+// noLeadingZero is set, a numeric identifier with a leading zero.
+func checkIdents(input, component, ids string, off int, noLeadingZero bool) error {
+	for rest := ids; ; {
+		var id string
+		id, rest = nextIdent(rest)
+		if id == "" {
+			return &ParseError{Input: input, Pos: off, Expected: "identifier", Component: component, Err: ErrInvalidIdentifier}
+		}
+		for i := 0; i < len(id); i++ {
+			if !isIdentChar(id[i]) {
+				return &ParseError{Input: input, Pos: off + i, Expected: "[0-9A-Za-z-]", Component: component, Value: id, Err: ErrInvalidIdentifier}
+			}
+		}
+		if noLeadingZero && len(id) > 1 && id[0] == '0' && isNumeric(id) {
+			return &ParseError{Input: input, Pos: off, Component: component, Value: id, Err: ErrLeadingZero}
+		}
+		off += len(id) + 1
+		if rest == "" {
+			if strings.HasSuffix(ids, ".") {
+				return &ParseError{Input: input, Pos: off, Expected: "identifier", Component: component, Err: ErrInvalidIdentifier}
+			}
+			return nil
+		}
+	}
+}
+
+func isIdentChar(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '-'
+}
+
+// trimNumericIdents drops leading zeros from every numeric identifier in
+// the dot-separated list s.
+func trimNumericIdents(s string) string {
+	if !hasLeadingZeroIdent(s) {
+		return s
+	}
+	ids := strings.Split(s, ".")
+	for i, id := range ids {
+		if len(id) > 1 && isNumeric(id) {
+			if id = strings.TrimLeft(id, "0"); id == "" {
+				id = "0"
+			}
+			ids[i] = id
+		}
+	}
+	return strings.Join(ids, ".")
+}
+
+// hasLeadingZeroIdent reports whether the dot-separated list s has a
+// numeric identifier with a leading zero.
+func hasLeadingZeroIdent(s string) bool {
+	for rest, more := s, true; more; {
+		var id string
+		id, rest, more = strings.Cut(rest, ".")
+		if len(id) > 1 && id[0] == '0' && isNumeric(id) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package semver
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Version
+	}{
+		{"1.2.3", Version{Major: 1, Minor: 2, Patch: 3}},
+		{"v1.2.3", Version{Major: 1, Minor: 2, Patch: 3}},
+		{"0.0.0", Version{}},
+		{"1.2.3-alpha.1+build.5", Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "alpha.1", Build: "build.5"}},
+		{"1.0.0-0A.is.legal", Version{Major: 1, Prerelease: "0A.is.legal"}},
+		{"1.2.3----RC-SNAPSHOT.12.9.1--.12+788", Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "---RC-SNAPSHOT.12.9.1--.12", Build: "788"}},
+		{"1.0.0+0.build.1-rc.10000aaa-kk-0.1", Version{Major: 1, Build: "0.build.1-rc.10000aaa-kk-0.1"}},
+	}
+	for _, tt := range tests {
+		tt.want.original = tt.in
+		if v, err := Parse(tt.in); err != nil || v != tt.want {
+			t.Errorf("Parse(%q) = %#v, %v; want %v", tt.in, v, err, tt.want)
+		}
+	}
+	for _, s := range []string{"", "1.2", "1.2.3.4", "1..3", "1.a.3", "1.2.3-", "1.2.3+", "abc"} {
+		if v, err := Parse(s); err == nil {
+			t.Errorf("Parse(%q) = %v, want an error", s, v)
+		}
+	}
+}
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		in  string
+		err error
+	}{
+		{"01.2.3", ErrLeadingZero},
+		{"1.2.3-rc.01", ErrLeadingZero},
+		{"1.2.3-", ErrInvalidIdentifier},
+		{"1.2.3-a..b", ErrInvalidIdentifier},
+		{"1.2.3-a_b", ErrInvalidIdentifier},
+		{"1.2.3+", ErrInvalidIdentifier},
+		{"", ErrEmpty},
+		{"1..3", ErrEmpty},
+		{"1.2", ErrComponentCount},
+		{"1.2.3.4", ErrComponentCount},
+		{"1.a.3", ErrInvalidCharacter},
+		{"99999999999999999999.0.0", ErrOverflow},
+		{"\uFF11.2.3", ErrNonASCII},
+	}
+	for _, tt := range tests {
+		v, err := Parse(tt.in)
+		var pe *ParseError
+		if !errors.Is(err, tt.err) || !errors.As(err, &pe) || pe.Input != tt.in {
+			t.Errorf("Parse(%q) = %v, %v; want %v", tt.in, v, err, tt.err)
+		}
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	for _, s := range []string{"1.2.3", "1.0.0-rc.1+b.2"} {
+		if _, err := ParseStrict(s); err != nil {
+			t.Errorf("ParseStrict(%q): %v", s, err)
+		}
+	}
+	for _, s := range []string{"v1.2.3", "V1.2.3", " 1.2.3", "1.2"} {
+		if v, err := ParseStrict(s); err == nil {
+			t.Errorf("ParseStrict(%q) = %v, want an error", s, v)
+		}
+	}
+}
+
+func TestParseTolerant(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"1.2.3", "1.2.3"},
+		{"v1.2", "1.2.0"},
+		{" V1 ", "1.0.0"},
+		{"01.02.03", "1.2.3"},
+		{"1.2-rc.1", "1.2.0-rc.1"},
+		{"1.2.3-rc.01", "1.2.3-rc.1"},
+		{"\uFF11.\uFF12.\uFF13", "1.2.3"},
+		{"1.0.0\u2013rc.1", "1.0.0-rc.1"},
+	}
+	for _, tt := range tests {
+		v, err := ParseTolerant(tt.in)
+		if err != nil || v.String() != tt.want {
+			t.Errorf("ParseTolerant(%q) = %v, %v; want %s", tt.in, v, err, tt.want)
+		}
+	}
+	if v, err := ParseTolerant("99999999999999999999.1"); err != nil || v.Major != math.MaxUint64 || v.Minor != 1 {
+		t.Errorf("ParseTolerant(overflowing major) = %v, %v; want a saturated major", v, err)
+	}
+	for _, s := range []string{"", "abc", "1.2.3.4.5", "1.2.3-a..b"} {
+		if v, err := ParseTolerant(s); err == nil {
+			t.Errorf("ParseTolerant(%q) = %v, want an error", s, v)
+		}
+	}
+}
+
+func TestOriginal(t *testing.T) {
+	tests := []struct {
+		in, want, canonical string
+	}{
+		{"1.2.3", "1.2.3", "1.2.3"},
+		{"  v1.2 ", "v1.2", "1.2.0"},
+		{"\uFEFF1.2.3", "1.2.3", "1.2.3"},
+		{"\uFF11.2.3", "\uFF11.2.3", "1.2.3"},
+		{"1.0.0\u20131", "1.0.0\u20131", "1.0.0-1"},
+		{"\u00A01.2.3\u200B", "1.2.3", "1.2.3"},
+	}
+	for _, tt := range tests {
+		v, err := ParseTolerant(tt.in)
+		if err != nil {
+			t.Errorf("ParseTolerant(%q): %v", tt.in, err)
+			continue
+		}
+		if v.Original() != tt.want || v.String() != tt.canonical {
+			t.Errorf("ParseTolerant(%q) = %q with original %q, want %q with original %q", tt.in, v, v.Original(), tt.canonical, tt.want)
+		}
+		w, err := ParseWith(tt.in, Tolerant(), AllowEpoch())
+		if err != nil || w.Original() != tt.want {
+			t.Errorf("ParseWith(%q) has original %q, %v; want %q", tt.in, w.Original(), err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Partial is a possibly incomplete version such as "1", "1.2", "1.x",
+// "1.2.*" or "*". Unlike ParseTolerant, which coerces missing components
+// to zero, a Partial stands for the range of versions it covers: "1.2"
+// covers every 1.2.z release.
+type Partial struct {
+	v Version
+	n int
+}
+
+// ParsePartial parses a partial version. Missing trailing components and
+// the wildcards x, X and * are equivalent. Pre-release and build suffixes
+// are only allowed on complete versions.
+func ParsePartial(s string) (Partial, error) {
+	p, err := parsePartial(s)
+	if err != nil {
+		return Partial{}, fmt.Errorf("semver: %v", err)
+	}
+	return Partial(p), nil
+}
+
+// IsComplete reports whether all three components were given, in which
+// case p covers exactly one version.
+func (p Partial) IsComplete() bool {
+	return p.n == 3
+}
+
+// Bounds returns the half-open interval [min, max) of versions covered by
+// p. ok is false, and max unset, if p has no upper bound: "*" (whose min
+// is 0.0.0-0), a complete version (whose min is the version itself) or a
+// range whose upper bound would overflow.
+//
+// The upper bound is the lowest pre-release of the next release, so
+// "1.2" covers [1.2.0, 1.3.0-0) and excludes 1.3.0-rc.1.
+func (p Partial) Bounds() (min, max Version, ok bool) {
+	switch p.n {
+	case 0:
+		return minVersion, Version{}, false
+	case 3:
+		return p.v, Version{}, false
+	}
+	up, ok := boundaryAt(p.v, p.n-1)
+	if !ok {
+		return p.v, Version{}, false
+	}
+	return p.v, up, true
+}
+
+// Contains reports whether v lies within the range covered by p. A
+// complete partial contains only versions of equal precedence.
+func (p Partial) Contains(v Version) bool {
+	return p.Compare(v) == 0
+}
+
+// Compare orders the range covered by p against v: it returns 0 if p
+// contains v, -1 if the whole range lies below v and 1 if it lies above.
+func (p Partial) Compare(v Version) int {
+	if p.n == 3 {
+		return p.v.Compare(v)
+	}
+	min, max, bounded := p.Bounds()
+	switch {
+	case v.Compare(min) < 0:
+		return 1
+	case bounded && v.Compare(max) >= 0:
+		return -1
+	}
+	return 0
+}
+
+// String returns p in normalized form, writing missing components as a
+// single trailing "x": "1.x", "1.2.x". It returns "*" for the empty
+// partial and the full version when p is complete.
+func (p Partial) String() string {
+	if p.n == 3 {
+		return p.v.String()
+	}
+	if p.n == 0 {
+		return "*"
+	}
+	parts := []string{strconv.FormatUint(p.v.Major, 10)}
+	if p.n == 2 {
+		parts = append(parts, strconv.FormatUint(p.v.Minor, 10))
+	}
+	return strings.Join(append(parts, "x"), ".")
+}
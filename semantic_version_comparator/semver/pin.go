@@ -0,0 +1,182 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Strictness is how far a recommended constraint lets a dependency move
+// from the version it was written for. Levels order from strictest to
+// loosest.
+type Strictness int
+
+const (
+	PinExact      Strictness = iota // only the version itself: =1.4.2
+	PinPatch                        // patch updates: ~1.4.2, >=1.4.2 <1.5.0
+	PinCompatible                   // updates Compatible reports as safe: ^1.4.2, >=1.4.2 <2.0.0
+	PinMajor                        // anything below the next major: >=0.4.2 <1.0.0
+)
+
+var strictnessNames = [...]string{"exact", "patch", "compatible", "major"}
+
+func (s Strictness) String() string {
+	if s < 0 || int(s) >= len(strictnessNames) {
+		return "Strictness(" + strconv.Itoa(int(s)) + ")"
+	}
+	return strictnessNames[s]
+}
+
+// ParseStrictness returns the strictness with the given name: "exact",
+// "patch", "compatible" or "major".
+func ParseStrictness(name string) (Strictness, error) {
+	for i, n := range strictnessNames {
+		if n == name {
+			return Strictness(i), nil
+		}
+	}
+	return 0, fmt.Errorf("semver: unknown pin strictness %q", name)
+}
+
+// Recommendation is a constraint recommended for pinning a dependency.
+type Recommendation struct {
+	Strictness Strictness
+	// Constraint is written in the requested dialect, in the form its
+	// ecosystem would write it by hand, such as "~> 1.4.2" for Ruby.
+	Constraint string
+}
+
+// RecommendPin returns the constraint that pins v at strictness s in
+// dialect d. Build metadata is dropped. A pre-release v is the lower
+// bound, so "^1.4.2-rc.1" admits 1.4.2-rc.2 and later releases. Versions
+// with an epoch cannot be written in any dialect.
+//
+// For 1.x and later versions PinCompatible and PinMajor agree; for 0.y.z
+// versions PinCompatible stays below 0.(y+1).0, as Compatible does.
+func RecommendPin(v Version, s Strictness, d Dialect) (string, error) {
+	if v.Epoch != 0 {
+		return "", fmt.Errorf("semver: cannot pin %s: constraints have no epoch syntax", v)
+	}
+	v.Build = ""
+	if s == PinExact {
+		return exactPin(v, d)
+	}
+	hi, ok := pinCeiling(v, s)
+	if !ok {
+		return "", fmt.Errorf("semver: cannot pin %s at %v strictness", v, s)
+	}
+	return rangePin(v, hi, s, d)
+}
+
+// RecommendPins returns a recommendation for every strictness, from
+// strictest to loosest.
+func RecommendPins(v Version, d Dialect) ([]Recommendation, error) {
+	out := make([]Recommendation, 0, len(strictnessNames))
+	for s := PinExact; int(s) < len(strictnessNames); s++ {
+		c, err := RecommendPin(v, s, d)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, Recommendation{Strictness: s, Constraint: c})
+	}
+	return out, nil
+}
+
+// SafePin is the inverse of RecommendPin: given the versions of a
+// dependency in use, such as across the services of a monorepo, it
+// returns the widest constraint that all of them satisfy without leaving
+// the compatibility series of the oldest: PinCompatible anchored at the
+// lowest version. It fails if vs is empty or the versions are not all
+// Compatible with each other, since no safe pin admits them all.
+func SafePin(vs []Version, d Dialect) (Recommendation, error) {
+	lo, ok := Oldest(vs)
+	if !ok {
+		return Recommendation{}, ErrNoVersions
+	}
+	for _, v := range vs {
+		if !lo.Compatible(v) {
+			return Recommendation{}, fmt.Errorf("semver: no safe pin admits both %s and %s", lo, v)
+		}
+	}
+	c, err := RecommendPin(lo, PinCompatible, d)
+	if err != nil {
+		return Recommendation{}, err
+	}
+	return Recommendation{Strictness: PinCompatible, Constraint: c}, nil
+}
+
+// pinCeiling returns the exclusive upper bound of a pin of v at s.
+func pinCeiling(v Version, s Strictness) (Version, bool) {
+	switch s {
+	case PinPatch:
+		return bumpAt(v, 1)
+	case PinCompatible:
+		if v.Major == 0 {
+			return bumpAt(v, 1)
+		}
+		return bumpAt(v, 0)
+	case PinMajor:
+		return bumpAt(v, 0)
+	}
+	return Version{}, false
+}
+
+func exactPin(v Version, d Dialect) (string, error) {
+	switch d {
+	case DialectNPM, DialectHelm:
+		return v.String(), nil
+	case DialectRuby:
+		return "= " + rubyVersion(v), nil
+	case DialectTerraform:
+		return "= " + v.String(), nil
+	case DialectNuGet:
+		return "[" + v.String() + "]", nil
+	}
+	return "", fmt.Errorf("semver: unknown constraint dialect %v", d)
+}
+
+// rangePin writes >=lo <hi, a pin at strictness s, in dialect d, using
+// the dialect's shorthand when one means exactly that.
+func rangePin(lo, hi Version, s Strictness, d Dialect) (string, error) {
+	nextMinor, _ := bumpAt(lo, 1)
+	nextMajor, _ := bumpAt(lo, 0)
+	switch d {
+	case DialectNPM, DialectHelm:
+		// ^ stops below the first non-zero component, so ^0.4.2 is
+		// <0.5.0 but ^0.0.3 is <0.0.4.
+		caret := lo.Major != 0 && hi.Equal(nextMajor) || lo.Major == 0 && lo.Minor != 0 && hi.Equal(nextMinor)
+		switch {
+		case caret && s == PinCompatible:
+			return "^" + lo.String(), nil
+		case hi.Equal(nextMinor):
+			return "~" + lo.String(), nil
+		case caret:
+			return "^" + lo.String(), nil
+		}
+		return ">=" + lo.String() + " <" + hi.String(), nil
+	case DialectRuby, DialectTerraform:
+		v := lo.String()
+		if d == DialectRuby {
+			v = rubyVersion(lo)
+		}
+		switch {
+		case hi.Equal(nextMinor):
+			return "~> " + v, nil
+		case hi.Equal(nextMajor) && lo.Patch == 0 && lo.Prerelease == "":
+			return "~> " + strconv.FormatUint(lo.Major, 10) + "." + strconv.FormatUint(lo.Minor, 10), nil
+		}
+		return ">= " + v + ", < " + hi.String(), nil
+	case DialectNuGet:
+		return "[" + lo.String() + ", " + hi.String() + ")", nil
+	}
+	return "", fmt.Errorf("semver: unknown constraint dialect %v", d)
+}
+
+// rubyVersion writes v the RubyGems way, with its pre-release as a dotted
+// segment: 1.0.0.pre.1 rather than 1.0.0-pre.1.
+func rubyVersion(v Version) string {
+	s := strconv.FormatUint(v.Major, 10) + "." + strconv.FormatUint(v.Minor, 10) + "." + strconv.FormatUint(v.Patch, 10)
+	if v.Prerelease != "" {
+		s += "." + v.Prerelease
+	}
+	return s
+}
@@ -0,0 +1,30 @@
+package semver
+
+// IsStable reports whether v is a release with a public API: it has no
+// pre-release and its major version is at least 1.
+func (v Version) IsStable() bool {
+	return v.Prerelease == "" && !v.IsZeroMajor()
+}
+
+// IsPrerelease reports whether v has a pre-release suffix.
+func (v Version) IsPrerelease() bool {
+	return v.Prerelease != ""
+}
+
+// IsZeroMajor reports whether v is in initial development (0.y.z), where
+// SemVer allows anything to change at any time.
+func (v Version) IsZeroMajor() bool {
+	return v.Major == 0
+}
+
+// Compatible reports whether code written against v can be expected to
+// work with o under SemVer: both share an epoch and a major version and,
+// when that major version is 0, the same minor version too, following the
+// caret convention that 0.Y releases break compatibility. Compatible does
+// not order the versions; v may be newer or older than o.
+func (v Version) Compatible(o Version) bool {
+	if v.Epoch != o.Epoch || v.Major != o.Major {
+		return false
+	}
+	return v.Major != 0 || v.Minor == o.Minor
+}
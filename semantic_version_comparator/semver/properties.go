@@ -0,0 +1,189 @@
+package semver
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// Comparer orders version strings. Comparator and every Orderer, such as
+// the schemes of the scheme package, implement it.
+type Comparer interface {
+	Compare(a, b string) (int, error)
+}
+
+// OrderViolation is a failure of a Comparer to behave as a total order,
+// found by CheckComparatorProperties.
+type OrderViolation struct {
+	// Property is "reflexivity", "antisymmetry", "transitivity" or
+	// "consistency".
+	Property string
+	// Versions are the samples that show the failure, in the order the
+	// message names them.
+	Versions []string
+	Message  string
+}
+
+func (v OrderViolation) Error() string {
+	return fmt.Sprintf("semver: %s: %s", v.Property, v.Message)
+}
+
+// maxViolations bounds the violations CheckComparatorProperties reports,
+// since one broken rule usually fails for many samples.
+const maxViolations = 20
+
+// CheckComparatorProperties checks that c orders samples as a total
+// order should, and returns up to 20 violations, or nil if it found none:
+//
+//   - reflexivity: every sample compares equal to itself
+//   - antisymmetry: Compare(a, b) is the negation of Compare(b, a)
+//   - transitivity: a <= b and b <= c imply a <= c, and a == b and
+//     b == c imply a == c
+//   - consistency: Compare returns only -1, 0 or 1, gives the same answer
+//     when asked again, and does not fail for a sample that the
+//     Validate method of c, if it has one, accepts
+//
+// Samples that c rejects are skipped: those Validate fails or, without
+// Validate, those Compare fails to compare with themselves. If samples
+// is empty, SampleVersions(200, 1) is used. The check compares every
+// pair and examines every triple of samples, so keep samples to a few
+// hundred. It is meant for the tests of custom schemes:
+//
+//	if vs := semver.CheckComparatorProperties(myScheme, nil); vs != nil {
+//		t.Fatal(vs[0])
+//	}
+func CheckComparatorProperties(c Comparer, samples []string) []OrderViolation {
+	if len(samples) == 0 {
+		samples = SampleVersions(200, 1)
+	}
+	var out []OrderViolation
+	report := func(property string, versions []string, format string, args ...any) {
+		if len(out) < maxViolations {
+			out = append(out, OrderViolation{Property: property, Versions: versions, Message: fmt.Sprintf(format, args...)})
+		}
+	}
+
+	validator, _ := c.(interface{ Validate(s string) error })
+	seen := make(map[string]bool, len(samples))
+	var vs []string
+	for _, s := range samples {
+		if seen[s] {
+			continue
+		}
+		seen[s] = true
+		if validator != nil {
+			if validator.Validate(s) != nil {
+				continue
+			}
+		} else if _, err := c.Compare(s, s); err != nil {
+			continue
+		}
+		vs = append(vs, s)
+	}
+
+	// cmp[i][j] is Compare(vs[i], vs[j]); ok[i][j] is false if it failed
+	// or returned something other than -1, 0 or 1.
+	n := len(vs)
+	cmp := make([][]int, n)
+	ok := make([][]bool, n)
+	for i, a := range vs {
+		cmp[i], ok[i] = make([]int, n), make([]bool, n)
+		for j, b := range vs {
+			r, err := c.Compare(a, b)
+			switch {
+			case err != nil:
+				report("consistency", []string{a, b}, "Compare(%q, %q) failed for valid versions: %v", a, b, err)
+			case r < -1 || r > 1:
+				report("consistency", []string{a, b}, "Compare(%q, %q) = %d, want -1, 0 or 1", a, b, r)
+			default:
+				cmp[i][j], ok[i][j] = r, true
+				if again, err := c.Compare(a, b); err != nil || again != r {
+					report("consistency", []string{a, b}, "Compare(%q, %q) returned %d, then %d", a, b, r, again)
+				}
+			}
+		}
+	}
+	for i, a := range vs {
+		if ok[i][i] && cmp[i][i] != 0 {
+			report("reflexivity", []string{a}, "Compare(%q, %q) = %d, want 0", a, a, cmp[i][i])
+		}
+		for j := i + 1; j < n; j++ {
+			if ok[i][j] && ok[j][i] && cmp[i][j] != -cmp[j][i] {
+				b := vs[j]
+				report("antisymmetry", []string{a, b}, "Compare(%q, %q) = %d but Compare(%q, %q) = %d", a, b, cmp[i][j], b, a, cmp[j][i])
+			}
+		}
+	}
+	for i := 0; i < n && len(out) < maxViolations; i++ {
+		for j := 0; j < n; j++ {
+			if i == j || !ok[i][j] || cmp[i][j] > 0 {
+				continue
+			}
+			for k := 0; k < n; k++ {
+				if k == i || k == j || !ok[j][k] || !ok[i][k] || cmp[j][k] > 0 {
+					continue
+				}
+				// a <= b <= c: then a <= c, and a == c if both are ==.
+				want := min(cmp[i][j], cmp[j][k])
+				if got := cmp[i][k]; got > 0 || want == 0 && got != 0 {
+					x, y, z := vs[i], vs[j], vs[k]
+					report("transitivity", []string{x, y, z}, "%s %s %s and %s %s %s, but %s %s %s",
+						x, relation(cmp[i][j]), y, y, relation(cmp[j][k]), z, x, relation(got), z)
+				}
+			}
+		}
+	}
+	return out
+}
+
+func relation(n int) string {
+	switch {
+	case n < 0:
+		return "<"
+	case n > 0:
+		return ">"
+	}
+	return "=="
+}
+
+// SampleVersions returns n version strings for checking orderings,
+// generated deterministically from seed. They start with cases that
+// orderings often get wrong, such as 1.0.0-rc.2 and 1.0.0-rc.10, 1.0.0-1
+// and 1.0.0-a, build metadata, a "v" prefix and leading zeros, followed
+// by random versions with small components, so that many share a core.
+func SampleVersions(n int, seed int64) []string {
+	out := []string{
+		"0.0.0", "0.0.1", "0.1.0", "1.0.0", "v1.0.0", "1.0.0+build.1", "1.0.0+build.2",
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2",
+		"1.0.0-beta.11", "1.0.0-rc.1", "1.0.0-rc.2", "1.0.0-rc.10", "1.0.0-1", "1.0.0-a",
+		"1.0.0-A", "1.0.0-0", "1.0.0-00a", "1.0.0-alpha-1", "1.0.1", "1.1.0", "1.10.0",
+		"1.9.9", "2.0.0", "10.0.0", "01.2.3", "1.2.3", "18446744073709551615.0.0",
+	}
+	if n <= len(out) {
+		return out[:max(n, 0)]
+	}
+	r := rand.New(rand.NewSource(seed))
+	ids := []string{"alpha", "beta", "rc", "dev", "0", "1", "2", "10", "x-1"}
+	for len(out) < n {
+		var b strings.Builder
+		if r.Intn(8) == 0 {
+			b.WriteByte('v')
+		}
+		fmt.Fprintf(&b, "%d.%d.%d", r.Intn(3), r.Intn(4), r.Intn(4))
+		if r.Intn(2) == 0 {
+			b.WriteByte('-')
+			for i := r.Intn(3); i >= 0; i-- {
+				b.WriteString(ids[r.Intn(len(ids))])
+				if i > 0 {
+					b.WriteByte('.')
+				}
+			}
+		}
+		if r.Intn(6) == 0 {
+			b.WriteString("+b" + strconv.Itoa(r.Intn(3)))
+		}
+		out = append(out, b.String())
+	}
+	return out
+}
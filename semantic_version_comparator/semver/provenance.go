@@ -0,0 +1,106 @@
+package semver
+
+import "strings"
+
+// Provenance is what the build metadata of a version says about the
+// artifact it names, read from the common conventions:
+//
+//	1.2.3+sha.3f9a1c2          commit (also git., commit., or g3f9a1c2)
+//	1.2.3+build.42             build number (also b42, or a bare number)
+//	1.2.3+ci.118, run.118      CI run (also pipeline., job., workflow.)
+//	1.2.3+sha.3f9a1c2.dirty    built from a modified working tree
+//
+// Identifiers may come in any order and be combined, as in
+// 1.2.3+build.42.sha.3f9a1c2. A bare identifier of 7 to 40 hexadecimal
+// digits, not all decimal, is taken as a commit.
+type Provenance struct {
+	Commit string // lower-cased, possibly abbreviated
+	Build  string // build number
+	Run    string // CI run or pipeline identifier
+	Dirty  bool
+	// Extra holds the identifiers that follow no convention, in order.
+	Extra []string
+}
+
+// provenanceKeys maps the identifiers that introduce a value to the
+// field the value goes to.
+var provenanceKeys = map[string]string{
+	"sha": "commit", "git": "commit", "commit": "commit",
+	"build": "build",
+	"ci":    "run", "run": "run", "pipeline": "run", "job": "run", "workflow": "run",
+}
+
+// Provenance reads the build metadata of v.
+func (v Version) Provenance() Provenance {
+	return ParseProvenance(v.Build)
+}
+
+// ParseProvenance reads build metadata, written without the leading "+".
+func ParseProvenance(build string) Provenance {
+	var p Provenance
+	if build == "" {
+		return p
+	}
+	ids := strings.Split(build, ".")
+	for i := 0; i < len(ids); i++ {
+		id := ids[i]
+		lower := strings.ToLower(id)
+		if field, ok := provenanceKeys[lower]; ok && i+1 < len(ids) {
+			i++
+			p.set(field, ids[i])
+			continue
+		}
+		switch {
+		case lower == "dirty":
+			p.Dirty = true
+		case p.Build == "" && isNumeric(id):
+			p.Build = id
+		case p.Build == "" && len(id) > 1 && (id[0] == 'b' || id[0] == 'B') && isNumeric(id[1:]):
+			p.Build = id[1:]
+		case p.Commit == "" && len(id) > 7 && id[0] == 'g' && isHex(id[1:]):
+			// The suffix of git describe: v1.2.3-4-g3f9a1c2.
+			p.Commit = lower[1:]
+		case p.Commit == "" && len(id) >= 7 && len(id) <= 40 && isHex(id) && !isNumeric(id):
+			p.Commit = lower
+		default:
+			p.Extra = append(p.Extra, id)
+		}
+	}
+	return p
+}
+
+func (p *Provenance) set(field, value string) {
+	switch field {
+	case "commit":
+		p.Commit = strings.ToLower(value)
+	case "build":
+		p.Build = value
+	case "run":
+		p.Run = value
+	}
+}
+
+// SameCommit reports whether p and o name the same commit, allowing
+// either to be abbreviated: 3f9a1c2 and 3f9a1c2e4b are the same commit.
+// It is false if either has no commit.
+func (p Provenance) SameCommit(o Provenance) bool {
+	if p.Commit == "" || o.Commit == "" {
+		return false
+	}
+	return strings.HasPrefix(p.Commit, o.Commit) || strings.HasPrefix(o.Commit, p.Commit)
+}
+
+// UniqueBuilds returns vs without duplicates, keeping the first of each
+// set of versions that are Equal, build metadata included. Unlike Unique
+// it keeps 1.2.3+sha.abc1234 and 1.2.3+sha.def5678 apart.
+func UniqueBuilds(vs []Version) []Version {
+	seen := make(map[buildKey]bool, len(vs))
+	var out []Version
+	for _, v := range vs {
+		if k := fullKey(v); !seen[k] {
+			seen[k] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
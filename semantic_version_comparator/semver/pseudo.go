@@ -0,0 +1,132 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// pseudoTimeLayout is the UTC timestamp layout embedded in Go module
+// pseudo-versions.
+const pseudoTimeLayout = "20060102150405"
+
+// Pseudo holds the information encoded in a Go module pseudo-version such
+// as v1.2.4-0.20230101120000-abcdef123456.
+type Pseudo struct {
+	// Base is the tagged version the pseudo-version descends from, if
+	// HasBase is true. Pseudo-versions of the form
+	// vX.0.0-yyyymmddhhmmss-abcdefabcdef have no base, and for them Base
+	// holds just the major version X. Build metadata, such as
+	// +incompatible, is kept.
+	Base    Version
+	HasBase bool
+	Time    time.Time // UTC commit time
+	Rev     string    // abbreviated commit hash
+}
+
+// IsPseudo reports whether v has the shape of a Go module pseudo-version.
+func (v Version) IsPseudo() bool {
+	_, err := v.Pseudo()
+	return err == nil
+}
+
+// Pseudo decodes v as a Go module pseudo-version. It recognizes the three
+// forms produced by the go command:
+//
+//	vX.0.0-yyyymmddhhmmss-abcdefabcdef      (no earlier tag)
+//	vX.Y.(Z+1)-0.yyyymmddhhmmss-abcdefabcdef (base vX.Y.Z)
+//	vX.Y.Z-pre.0.yyyymmddhhmmss-abcdefabcdef (base vX.Y.Z-pre)
+//
+// Pseudo-versions already order correctly under Compare: they sort after
+// their base and before the next release.
+func (v Version) Pseudo() (Pseudo, error) {
+	var p Pseudo
+	pre := v.Prerelease
+	head, last := "", pre
+	if i := strings.LastIndexByte(pre, '.'); i >= 0 {
+		head, last = pre[:i], pre[i+1:]
+	}
+	ts, rev, ok := strings.Cut(last, "-")
+	if !ok || len(ts) != len(pseudoTimeLayout) || !isNumeric(ts) || rev == "" || !isAlnum(rev) {
+		return Pseudo{}, fmt.Errorf("semver: %s is not a pseudo-version", v)
+	}
+	t, err := time.Parse(pseudoTimeLayout, ts)
+	if err != nil {
+		return Pseudo{}, fmt.Errorf("semver: %s: invalid pseudo-version timestamp %q", v, ts)
+	}
+	p.Time, p.Rev = t, rev
+	switch {
+	case head == "":
+		if v.Minor != 0 || v.Patch != 0 {
+			return Pseudo{}, fmt.Errorf("semver: %s is not a pseudo-version", v)
+		}
+		p.Base = Version{Major: v.Major, Build: v.Build}
+	case head == "0":
+		if v.Patch == 0 {
+			return Pseudo{}, fmt.Errorf("semver: %s is not a pseudo-version", v)
+		}
+		p.Base = Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch - 1, Build: v.Build}
+		p.HasBase = true
+	case strings.HasSuffix(head, ".0"):
+		p.Base = Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch, Prerelease: strings.TrimSuffix(head, ".0"), Build: v.Build}
+		p.HasBase = true
+	default:
+		return Pseudo{}, fmt.Errorf("semver: %s is not a pseudo-version", v)
+	}
+	return p, nil
+}
+
+// Version encodes p as a pseudo-version, in the form the go command
+// would choose for its base, the inverse of Version.Pseudo:
+//
+//	vX.0.0-yyyymmddhhmmss-abcdefabcdef      (no base; X is Base.Major)
+//	vX.Y.(Z+1)-0.yyyymmddhhmmss-abcdefabcdef (base vX.Y.Z)
+//	vX.Y.Z-pre.0.yyyymmddhhmmss-abcdefabcdef (base vX.Y.Z-pre)
+//
+// The base's build metadata, as in +incompatible, is kept. Rev is
+// shortened to 12 characters, as the go command abbreviates commit
+// hashes. Version fails if Rev is empty or not alphanumeric, or if Base
+// has an epoch or its patch cannot be incremented.
+func (p Pseudo) Version() (Version, error) {
+	rev := p.Rev
+	if len(rev) > 12 {
+		rev = rev[:12]
+	}
+	if rev == "" || !isAlnum(rev) {
+		return Version{}, fmt.Errorf("semver: invalid pseudo-version revision %q", p.Rev)
+	}
+	if p.Base.Epoch != 0 {
+		return Version{}, fmt.Errorf("semver: pseudo-version base %s has an epoch", p.Base)
+	}
+	segment := p.Time.UTC().Format(pseudoTimeLayout) + "-" + rev
+	b := p.Base
+	switch {
+	case !p.HasBase:
+		return Version{Major: b.Major, Prerelease: segment, Build: b.Build}, nil
+	case b.Prerelease != "":
+		return Version{Major: b.Major, Minor: b.Minor, Patch: b.Patch, Prerelease: b.Prerelease + ".0." + segment, Build: b.Build}, nil
+	}
+	next, ok := bumpAt(b, 2)
+	if !ok {
+		return Version{}, fmt.Errorf("semver: cannot increment the patch of pseudo-version base %s", b)
+	}
+	return Version{Major: next.Major, Minor: next.Minor, Patch: next.Patch, Prerelease: "0." + segment, Build: b.Build}, nil
+}
+
+// GeneratePseudoVersion returns the pseudo-version of the commit rev,
+// made at time t, that descends from the tagged version base; see
+// Pseudo.Version. For a commit with no earlier tag, encode
+// Pseudo{Base: Version{Major: X}, Time: t, Rev: rev} instead.
+func GeneratePseudoVersion(base Version, t time.Time, rev string) (Version, error) {
+	return Pseudo{Base: base, HasBase: true, Time: t, Rev: rev}.Version()
+}
+
+func isAlnum(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
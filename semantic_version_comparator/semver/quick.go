@@ -0,0 +1,77 @@
+package semver
+
+import "strings"
+
+// The functions of this file parse their arguments on every call, for
+// callers checking a version or two in the style of node-semver and
+// Masterminds/semver. They accept what Parse accepts: a leading "v" is
+// allowed. Code comparing many versions should parse them once.
+
+// Valid returns the canonical form of s, without any leading "v" and
+// surrounding whitespace, or "" if s is not a valid version: Valid("v1.2.3")
+// is "1.2.3". Unlike node-semver's valid, it keeps build metadata.
+func Valid(s string) string {
+	v, err := Parse(strings.TrimSpace(s))
+	if err != nil {
+		return ""
+	}
+	return v.String()
+}
+
+// Clean is like Valid but also removes the leading "=" that node-semver
+// users write: Clean(" =v1.2.3 ") is "1.2.3".
+func Clean(s string) string {
+	return Valid(strings.TrimLeft(strings.TrimSpace(s), "="))
+}
+
+// Satisfies reports whether version satisfies the constraint rng, as
+// node-semver's satisfies does. It returns an error if either fails to
+// parse.
+func Satisfies(version, rng string) (bool, error) {
+	v, err := Parse(version)
+	if err != nil {
+		return false, err
+	}
+	c, err := ParseConstraint(rng)
+	if err != nil {
+		return false, err
+	}
+	return c.Check(v), nil
+}
+
+// Gt reports whether a has higher precedence than b.
+func Gt(a, b string) (bool, error) {
+	n, err := CompareStrict(a, b)
+	return err == nil && n > 0, err
+}
+
+// Gte reports whether a has precedence higher than or equal to b.
+func Gte(a, b string) (bool, error) {
+	n, err := CompareStrict(a, b)
+	return err == nil && n >= 0, err
+}
+
+// Lt reports whether a has lower precedence than b.
+func Lt(a, b string) (bool, error) {
+	n, err := CompareStrict(a, b)
+	return err == nil && n < 0, err
+}
+
+// Lte reports whether a has precedence lower than or equal to b.
+func Lte(a, b string) (bool, error) {
+	n, err := CompareStrict(a, b)
+	return err == nil && n <= 0, err
+}
+
+// Eq reports whether a and b have equal precedence; build metadata is
+// ignored, so Eq("1.2.3+a", "1.2.3+b") is true.
+func Eq(a, b string) (bool, error) {
+	n, err := CompareStrict(a, b)
+	return err == nil && n == 0, err
+}
+
+// Neq reports whether a and b differ in precedence.
+func Neq(a, b string) (bool, error) {
+	n, err := CompareStrict(a, b)
+	return err == nil && n != 0, err
+}
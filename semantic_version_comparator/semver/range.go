@@ -0,0 +1,194 @@
+package semver
+
+import "slices"
+
+// Range is a half-open interval [min, max) of versions by precedence, or
+// [min, ∞) if it has no upper bound. Every constraint is a union of
+// ranges (see Constraint.Ranges), so two requirements conflict exactly
+// when none of their ranges overlap. Ranges ignore the npm pre-release
+// rules: a range contains every version, pre-release or not, within its
+// bounds.
+type Range struct {
+	min, max Version
+	bounded  bool
+}
+
+// NewRange returns the range [min, max). It is empty if max does not
+// exceed min.
+func NewRange(min, max Version) Range {
+	return Range{min: min, max: max, bounded: true}
+}
+
+// NewRangeFrom returns the unbounded range [min, ∞).
+func NewRangeFrom(min Version) Range {
+	return Range{min: min}
+}
+
+// Bounds returns the bounds of r. ok is false, and max unset, if r has no
+// upper bound.
+func (r Range) Bounds() (min, max Version, ok bool) {
+	return r.min, r.max, r.bounded
+}
+
+// IsEmpty reports whether r contains no version.
+func (r Range) IsEmpty() bool {
+	return r.bounded && r.min.Compare(r.max) >= 0
+}
+
+// Contains reports whether v lies within r.
+func (r Range) Contains(v Version) bool {
+	return v.Compare(r.min) >= 0 && (!r.bounded || v.Compare(r.max) < 0)
+}
+
+// ContainsRange reports whether every version in o lies within r. The
+// empty range is contained in every range.
+func (r Range) ContainsRange(o Range) bool {
+	if o.IsEmpty() {
+		return true
+	}
+	if o.min.Compare(r.min) < 0 {
+		return false
+	}
+	return !r.bounded || o.bounded && o.max.Compare(r.max) <= 0
+}
+
+// Overlaps reports whether some version lies within both r and o.
+func (r Range) Overlaps(o Range) bool {
+	if r.IsEmpty() || o.IsEmpty() {
+		return false
+	}
+	return (!o.bounded || r.min.Compare(o.max) < 0) && (!r.bounded || o.min.Compare(r.max) < 0)
+}
+
+// Intersect returns the versions within both r and o, which may be
+// empty.
+func (r Range) Intersect(o Range) Range {
+	out := Range{min: Max(r.min, o.min)}
+	switch {
+	case r.bounded && o.bounded:
+		out.max, out.bounded = Min(r.max, o.max), true
+	case r.bounded:
+		out.max, out.bounded = r.max, true
+	case o.bounded:
+		out.max, out.bounded = o.max, true
+	}
+	return out
+}
+
+// String returns r in interval notation, such as "[1.2.0, 2.0.0)" or
+// "[1.2.0, ∞)".
+func (r Range) String() string {
+	if !r.bounded {
+		return "[" + r.min.String() + ", ∞)"
+	}
+	return "[" + r.min.String() + ", " + r.max.String() + ")"
+}
+
+// Constraint returns a constraint satisfied by the versions in r, with
+// no pre-release restrictions.
+func (r Range) Constraint() Constraint {
+	if r.IsEmpty() {
+		return newConstraint(nil)
+	}
+	group := []comparator{{op: OpGE, v: r.min}}
+	if r.bounded {
+		group = append(group, comparator{op: OpLT, v: r.max})
+	}
+	return newConstraint([][]comparator{group})
+}
+
+// Ranges returns the versions that satisfy c, by precedence alone, as
+// sorted, disjoint and non-adjacent ranges: "^1.2.0 || ^1.5.0, !=1.6.0"
+// is [1.2.0, 2.0.0-0), and "!=1.0.0" is [0.0.0-0, 1.0.0) and
+// [1.0.1-0, ∞). An inclusive upper bound such as "<=1.2.3" becomes the
+// exclusive bound of the next version in precedence, 1.2.4-0.
+func (c Constraint) Ranges() []Range {
+	var rs []Range
+	for _, g := range c.groups {
+		rs = append(rs, groupInterval(g).ranges()...)
+	}
+	slices.SortFunc(rs, func(a, b Range) int { return a.min.Compare(b.min) })
+	var out []Range
+	for _, r := range rs {
+		if n := len(out); n > 0 {
+			last := &out[n-1]
+			if !last.bounded || r.min.Compare(last.max) <= 0 {
+				if last.bounded && (!r.bounded || r.max.Compare(last.max) > 0) {
+					last.max, last.bounded = r.max, r.bounded
+				}
+				continue
+			}
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// Overlaps reports whether some version satisfies both c and o, by
+// precedence alone, which is how conflicting requirements are found.
+func (c Constraint) Overlaps(o Constraint) bool {
+	for _, a := range c.Ranges() {
+		for _, b := range o.Ranges() {
+			if a.Overlaps(b) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ranges converts iv to half-open ranges, splitting it at its exclusions.
+func (iv interval) ranges() []Range {
+	r := Range{min: minVersion}
+	if iv.lo.set {
+		r.min = iv.lo.v
+		if !iv.lo.inclusive {
+			next, ok := successor(iv.lo.v)
+			if !ok {
+				return nil
+			}
+			r.min = next
+		}
+	}
+	if iv.hi.set {
+		r.max, r.bounded = iv.hi.v, true
+		if iv.hi.inclusive {
+			r.max, r.bounded = successor(iv.hi.v)
+		}
+	}
+	excl := slices.Clone(iv.excl)
+	Sort(excl)
+	var out []Range
+	for _, x := range excl {
+		if !r.Contains(x) {
+			continue
+		}
+		next, ok := successor(x)
+		if below := (Range{min: r.min, max: x, bounded: true}); !below.IsEmpty() {
+			out = append(out, below)
+		}
+		if !ok {
+			return out
+		}
+		r.min = next
+	}
+	if !r.IsEmpty() {
+		out = append(out, r)
+	}
+	return out
+}
+
+// successor returns the lowest version of higher precedence than v,
+// ignoring build metadata: 1.2.4-0 follows 1.2.3, and 1.2.3-rc.1.0
+// follows 1.2.3-rc.1. ok is false if no version follows v.
+func successor(v Version) (Version, bool) {
+	if v.Prerelease != "" {
+		return Version{Epoch: v.Epoch, Major: v.Major, Minor: v.Minor, Patch: v.Patch, Prerelease: v.Prerelease + ".0"}, true
+	}
+	next, ok := bumpAt(v, 2)
+	if !ok {
+		return Version{}, false
+	}
+	next.Epoch, next.Prerelease = v.Epoch, "0"
+	return next, true
+}
@@ -0,0 +1,205 @@
+package semver
+
+import "strings"
+
+// SourceConstraint is a constraint on a dependency together with where it
+// came from, such as "go.mod", "charts/app/Chart.yaml" or "policy".
+type SourceConstraint struct {
+	Source     string
+	Constraint Constraint
+}
+
+func (s SourceConstraint) String() string {
+	return s.Source + " (" + s.Constraint.String() + ")"
+}
+
+// ConstraintConflict is a pair of sources no single version satisfies
+// together. A and B are indexes into the constraints given to Reconcile,
+// with A < B.
+type ConstraintConflict struct {
+	A, B int
+}
+
+// Reconciliation is the result of Reconcile.
+type Reconciliation struct {
+	// Satisfiable reports whether some version satisfies every source.
+	Satisfiable bool
+	// Combined is the intersection of all the constraints; it matches
+	// no version unless Satisfiable.
+	Combined Constraint
+	// Conflicts lists the pairs of sources that exclude each other. A
+	// set of sources can be unsatisfiable without any pair conflicting,
+	// as with ">=1 <3", ">=2 <4" and "<2 || >=3".
+	Conflicts []ConstraintConflict
+	// Drop is the nearest satisfiable relaxation: the fewest sources,
+	// by index, whose removal leaves the rest satisfiable. Of equally
+	// small choices, the one dropping the latest sources is chosen, so
+	// list sources from most to least authoritative. Relaxed is the
+	// intersection of the remaining sources. Both are zero if
+	// Satisfiable.
+	Drop    []int
+	Relaxed Constraint
+}
+
+// maxExhaustive is the number of sources up to which Reconcile finds the
+// smallest relaxation by trying every subset; above it, sources are
+// dropped greedily.
+const maxExhaustive = 16
+
+// Reconcile reports whether one version can satisfy constraints gathered
+// for the same dependency from several sources, and if not, which sources
+// conflict and which to drop to restore agreement. It fails, like
+// Intersect, if the sources come from dialects whose pre-release rules
+// cannot be reconciled.
+func Reconcile(cs []SourceConstraint) (Reconciliation, error) {
+	combined, err := intersectAll(cs, nil)
+	if err != nil {
+		return Reconciliation{}, err
+	}
+	r := Reconciliation{Combined: combined}
+	if r.Satisfiable = !r.Combined.IsEmpty(); r.Satisfiable {
+		return r, nil
+	}
+	for i := range cs {
+		for j := i + 1; j < len(cs); j++ {
+			both, err := cs[i].Constraint.Intersect(cs[j].Constraint)
+			if err != nil {
+				return Reconciliation{}, err
+			}
+			if both.IsEmpty() {
+				r.Conflicts = append(r.Conflicts, ConstraintConflict{A: i, B: j})
+			}
+		}
+	}
+	if r.Drop, err = relaxation(cs); err != nil {
+		return Reconciliation{}, err
+	}
+	if r.Relaxed, err = intersectAll(cs, r.Drop); err != nil {
+		return Reconciliation{}, err
+	}
+	return r, nil
+}
+
+// Explain returns a one-line description of r for the sources cs it was
+// computed from, such as `go.mod (>=1.4.0) conflicts with policy (<1.3.0);
+// dropping policy (<1.3.0) allows >=1.4.0`.
+func (r Reconciliation) Explain(cs []SourceConstraint) string {
+	if r.Satisfiable {
+		return "all sources allow " + r.Combined.String()
+	}
+	var parts []string
+	for _, c := range r.Conflicts {
+		parts = append(parts, cs[c.A].String()+" conflicts with "+cs[c.B].String())
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "the sources have no version in common")
+	}
+	s := strings.Join(parts, "; ")
+	if len(r.Drop) > 0 {
+		dropped := make([]string, len(r.Drop))
+		for i, d := range r.Drop {
+			dropped[i] = cs[d].String()
+		}
+		s += "; dropping " + strings.Join(dropped, ", ") + " allows " + r.Relaxed.String()
+	}
+	return s
+}
+
+// intersectAll returns the intersection of the constraints of cs except
+// those at the indexes in skip, which are ascending. With every source
+// skipped it matches any version.
+func intersectAll(cs []SourceConstraint, skip []int) (Constraint, error) {
+	var all Constraint
+	first := true
+	for i, c := range cs {
+		if len(skip) > 0 && skip[0] == i {
+			skip = skip[1:]
+			continue
+		}
+		if first {
+			all, first = c.Constraint.Simplify(), false
+			continue
+		}
+		var err error
+		if all, err = all.Intersect(c.Constraint); err != nil {
+			return Constraint{}, err
+		}
+	}
+	if first {
+		all = Constraint{raw: ">=" + minVersion.String(), groups: [][]comparator{{{op: OpGE, v: minVersion}}}}
+	}
+	return all, nil
+}
+
+// relaxation returns the indexes of the sources to drop from the
+// unsatisfiable cs.
+func relaxation(cs []SourceConstraint) ([]int, error) {
+	n := len(cs)
+	if n > maxExhaustive {
+		return greedyRelaxation(cs)
+	}
+	for k := 1; k < n; k++ {
+		// Enumerate the k-subsets of the sources counted from the last,
+		// so the first that works drops the least authoritative ones.
+		back := make([]int, k)
+		for i := range back {
+			back[i] = i
+		}
+		drop := make([]int, k)
+		for {
+			for i, b := range back {
+				drop[k-1-i] = n - 1 - b
+			}
+			rest, err := intersectAll(cs, drop)
+			if err != nil {
+				return nil, err
+			}
+			if !rest.IsEmpty() {
+				return drop, nil
+			}
+			if !nextCombination(back, n) {
+				break
+			}
+		}
+	}
+	return greedyRelaxation(cs)
+}
+
+// nextCombination advances c, an ascending subset of [0, n), to the next
+// subset of the same size in lexicographic order. It reports false after
+// the last one.
+func nextCombination(c []int, n int) bool {
+	k := len(c)
+	for i := k - 1; i >= 0; i-- {
+		if c[i] < n-k+i {
+			c[i]++
+			for j := i + 1; j < k; j++ {
+				c[j] = c[j-1] + 1
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// greedyRelaxation keeps sources in order, dropping each one that would
+// make the kept sources unsatisfiable.
+func greedyRelaxation(cs []SourceConstraint) ([]int, error) {
+	var drop []int
+	var kept Constraint
+	for i, c := range cs {
+		next := c.Constraint
+		if i > len(drop) {
+			var err error
+			if next, err = kept.Intersect(c.Constraint); err != nil {
+				return nil, err
+			}
+		}
+		if next.IsEmpty() {
+			drop = append(drop, i)
+		} else {
+			kept = next
+		}
+	}
+	return drop, nil
+}
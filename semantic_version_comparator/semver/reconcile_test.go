@@ -0,0 +1,57 @@
+package semver
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestReconcile(t *testing.T) {
+	src := func(source, s string, opts ...ConstraintOption) SourceConstraint {
+		return SourceConstraint{Source: source, Constraint: MustParseConstraint(s, opts...)}
+	}
+	tests := []struct {
+		cs          []SourceConstraint
+		satisfiable bool
+		combined    string
+		conflicts   []ConstraintConflict
+		drop        []int
+	}{
+		{
+			cs:          []SourceConstraint{src("go.mod", ">=1.4.0"), src("policy", "<2.0.0")},
+			satisfiable: true,
+			combined:    ">=1.4.0 <2.0.0",
+		},
+		{
+			cs:        []SourceConstraint{src("go.mod", ">=1.4.0"), src("policy", "<1.3.0"), src("lock", "^1.4.2")},
+			combined:  "<0.0.0-0",
+			conflicts: []ConstraintConflict{{0, 1}, {1, 2}},
+			drop:      []int{1},
+		},
+		{
+			cs: []SourceConstraint{
+				src("Chart.yaml", ">=1.19.0-0", WithDialect(DialectHelm)),
+				src("package.json", "^1.20.0"),
+			},
+			satisfiable: true,
+			combined:    ">=1.20.0 <2.0.0",
+		},
+	}
+	for _, tt := range tests {
+		r, err := Reconcile(tt.cs)
+		if err != nil {
+			t.Errorf("Reconcile(%v): %v", tt.cs, err)
+			continue
+		}
+		if r.Satisfiable != tt.satisfiable || r.Combined.String() != tt.combined || !slices.Equal(r.Conflicts, tt.conflicts) || !slices.Equal(r.Drop, tt.drop) {
+			t.Errorf("Reconcile(%v) = %v, %q, %v, %v; want %v, %q, %v, %v", tt.cs, r.Satisfiable, r.Combined, r.Conflicts, r.Drop, tt.satisfiable, tt.combined, tt.conflicts, tt.drop)
+		}
+	}
+	_, err := Reconcile([]SourceConstraint{
+		src("policy", "<2.0.0", IncludePrerelease()),
+		src("versions.tf", ">= 1.0.0-rc.1", WithDialect(DialectTerraform)),
+	})
+	if !errors.Is(err, ErrPrereleaseRule) {
+		t.Errorf("Reconcile with irreconcilable rules: error = %v, want ErrPrereleaseRule", err)
+	}
+}
@@ -0,0 +1,32 @@
+package semver
+
+// MaxSatisfying returns the highest version in vs that satisfies c. The
+// boolean result is false if no version does. Versions flagged with
+// WithStatus are skipped unless c allows their flags (see AllowStatus).
+func MaxSatisfying(vs []Version, c Constraint) (Version, bool) {
+	return selectSatisfying(vs, c, 1)
+}
+
+// MinSatisfying returns the lowest version in vs that satisfies c. The
+// boolean result is false if no version does. Like MaxSatisfying it
+// skips flagged versions that c does not allow.
+func MinSatisfying(vs []Version, c Constraint) (Version, bool) {
+	return selectSatisfying(vs, c, -1)
+}
+
+// selectSatisfying returns the satisfying version that compares in the
+// direction dir against every other satisfying version. Ties keep the
+// earliest element.
+func selectSatisfying(vs []Version, c Constraint, dir int) (Version, bool) {
+	var best Version
+	found := false
+	for _, v := range vs {
+		if !c.allowStatus.Has(v.status) || !c.Check(v) {
+			continue
+		}
+		if !found || v.Compare(best) == dir {
+			best, found = v, true
+		}
+	}
+	return best, found
+}
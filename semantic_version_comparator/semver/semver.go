@@ -0,0 +1,323 @@
+// Package semver parses and compares semantic version strings.
+//
+// No function in this package panics on malformed input except the Must
+// helpers, which exist for values known to be valid at compile time.
+// Functions that can fail return an error instead.
+package semver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Version is a parsed semantic version.
+type Version struct {
+	// Epoch is a Debian-style epoch, as in "1:2.0.0", that orders before
+	// every other component. It is zero unless the version was parsed
+	// with AllowEpoch.
+	Epoch      uint64
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease string
+	Build      string
+
+	// original is the string v was parsed from; see Original.
+	original string
+	// status holds the flags set by WithStatus.
+	status Status
+}
+
+// Original returns the string v was parsed from, such as "v1.2" for the
+// version 1.2.0 read by ParseTolerant, so that tools can show or write
+// back what the user wrote while comparing canonically. It is the input
+// as given, so after ParseTolerant it keeps any lookalike characters,
+// such as full-width digits, that parsing normalized; surrounding
+// whitespace and invisible characters such as a byte order mark are not
+// kept. Versions built from their fields or derived from others, as by
+// IncMinor, have no original, and for them Original returns String.
+// Assigning to the fields of a parsed version does not update its
+// original.
+//
+// Because the original is part of the value, == distinguishes versions
+// parsed from "v1.2.0" and "1.2.0". Use Equal or Compare to compare
+// versions.
+func (v Version) Original() string {
+	if v.original == "" {
+		return v.String()
+	}
+	return v.original
+}
+
+// Compare returns -1 if v < o, 0 if v == o and 1 if v > o. Build metadata
+// does not affect precedence.
+func (v Version) Compare(o Version) int {
+	if c := compareUint(v.Epoch, o.Epoch); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Major, o.Major); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Minor, o.Minor); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Patch, o.Patch); c != 0 {
+		return c
+	}
+	return comparePrerelease(v.Prerelease, o.Prerelease)
+}
+
+// String returns the canonical form of v, with an "EPOCH:" prefix if the
+// epoch is not zero. StringWith and Format write other spellings.
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Epoch != 0 {
+		s = fmt.Sprintf("%d:%s", v.Epoch, s)
+	}
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// comparePrerelease orders two dot-separated pre-release strings following
+// SemVer 2.0.0 section 11. An empty string denotes a release, which has
+// higher precedence than any pre-release.
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+	for a != "" && b != "" {
+		var ida, idb string
+		ida, a = nextIdent(a)
+		idb, b = nextIdent(b)
+		if c := compareIdent(ida, idb); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case a == "" && b == "":
+		return 0
+	case a == "":
+		return -1
+	}
+	return 1
+}
+
+// nextIdent splits the first dot-separated identifier off s.
+func nextIdent(s string) (ident, rest string) {
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return s[:i], s[i+1:]
+	}
+	return s, ""
+}
+
+// compareIdent compares a single pair of pre-release identifiers: numeric
+// identifiers compare numerically and sort before alphanumeric ones, which
+// compare lexically in ASCII order.
+func compareIdent(a, b string) int {
+	na, nb := isNumeric(a), isNumeric(b)
+	switch {
+	case na && nb:
+		return compareDigits(a, b)
+	case na:
+		return -1
+	case nb:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// CompareStrict parses a and b with Parse and returns -1 if a < b, 0
+// if a == b and 1 if a > b. Unlike Compare it reports invalid input as an
+// error rather than ordering it.
+func CompareStrict(a, b string) (int, error) {
+	va, err := Parse(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := Parse(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.Compare(vb), nil
+}
+
+// Compare returns -1 if a < b, 0 if a == b and 1 if a > b. Versions whose
+// numeric components are equal are ordered by their pre-release suffix;
+// build metadata is ignored.
+//
+// Compare never fails: a leading "v" and other non-digit characters in
+// numeric components are skipped, missing or empty components count as
+// zero and components beyond math.MaxUint64 compare equal to it, so
+// malformed input such as "" or "abc" may compare equal to "0.0.0". Use
+// CompareStrict when invalid input must be detected.
+//
+// Inputs of the common [v]MAJOR.MINOR.PATCH[-PRERELEASE][+BUILD] shape are
+// compared in place without allocating; anything else goes through the
+// lenient parser.
+func Compare(a, b string) int {
+	if ma, na, pa, preA, ok := splitCore(a); ok {
+		if mb, nb, pb, preB, ok := splitCore(b); ok {
+			if c := compareUint(component(ma), component(mb)); c != 0 {
+				return c
+			}
+			if c := compareUint(component(na), component(nb)); c != 0 {
+				return c
+			}
+			if c := compareUint(component(pa), component(pb)); c != 0 {
+				return c
+			}
+			return comparePrerelease(preA, preB)
+		}
+	}
+	return compareLenient(a, b)
+}
+
+// compareLenient is Compare through the lenient parser. It agrees with the
+// in-place path on every input that path accepts.
+func compareLenient(a, b string) int {
+	partsA, preA := parse(a)
+	partsB, preB := parse(b)
+	var coreA, coreB [3]uint64
+	copy(coreA[:], partsA)
+	copy(coreB[:], partsB)
+	for i := range coreA {
+		if c := compareUint(coreA[i], coreB[i]); c != 0 {
+			return c
+		}
+	}
+	return comparePrerelease(preA, preB)
+}
+
+// splitCore splits s of the form [v]MAJOR.MINOR.PATCH[-PRERELEASE][+BUILD],
+// with surrounding white space trimmed as parse does, into its numeric
+// components and pre-release without allocating. ok is false if s has any
+// other shape.
+func splitCore(s string) (major, minor, patch, pre string, ok bool) {
+	s = trimV(strings.TrimSpace(s))
+	var nums [3]string
+	i := 0
+	for n := 0; n < 3; n++ {
+		start := i
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		if i == start {
+			return "", "", "", "", false
+		}
+		nums[n] = s[start:i]
+		if n < 2 {
+			if i == len(s) || s[i] != '.' {
+				return "", "", "", "", false
+			}
+			i++
+		}
+	}
+	rest := s[i:]
+	if j := strings.IndexByte(rest, '+'); j >= 0 {
+		rest = rest[:j]
+	}
+	switch {
+	case rest == "":
+	case rest[0] == '-':
+		pre = rest[1:]
+	default:
+		return "", "", "", "", false
+	}
+	return nums[0], nums[1], nums[2], pre, true
+}
+
+// compareDigits compares two non-empty decimal digit strings numerically
+// without converting them, so it cannot overflow.
+func compareDigits(a, b string) int {
+	a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	if c := compareInt(len(a), len(b)); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// parse is the lenient parser behind Compare. Each component is read with
+// component, so oversized components compare as math.MaxUint64 instead of
+// wrapping around.
+func parse(s string) ([]uint64, string) {
+	s = strings.TrimSpace(s)
+	if idx := strings.Index(s, "+"); idx >= 0 {
+		s = s[:idx]
+	}
+	var pre string
+	if idx := strings.Index(s, "-"); idx >= 0 {
+		s, pre = s[:idx], s[idx+1:]
+	}
+	parts := strings.Split(s, ".")
+	var nums []uint64
+	for _, p := range parts {
+		nums = append(nums, component(p))
+	}
+	return nums, pre
+}
+
+// component is the value of one numeric component for Compare, on both
+// the in-place path and the lenient parser: its decimal digits, with any
+// other bytes skipped, accumulated with saturation.
+func component(s string) uint64 {
+	var n uint64
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c >= '0' && c <= '9' {
+			n = appendDigit(n, c)
+		}
+	}
+	return n
+}
+
+// appendDigit returns n*10 + the decimal digit d, saturating at
+// math.MaxUint64.
+func appendDigit(n uint64, d byte) uint64 {
+	const max = 1<<64 - 1
+	x := uint64(d - '0')
+	if n > (max-x)/10 {
+		return max
+	}
+	return n*10 + x
+}
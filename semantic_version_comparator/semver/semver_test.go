@@ -0,0 +1,144 @@
+package semver
+
+import (
+	"slices"
+	"testing"
+)
+
+// precedence follows the example ordering of the SemVer 2.0.0
+// specification, plus build metadata, which does not count.
+var precedence = []string{
+	"0.0.0-0",
+	"0.0.0",
+	"0.9.10",
+	"1.0.0-alpha",
+	"1.0.0-alpha.1",
+	"1.0.0-alpha.beta",
+	"1.0.0-beta",
+	"1.0.0-beta.2",
+	"1.0.0-beta.11",
+	"1.0.0-rc.1",
+	"1.0.0",
+	"1.2.3",
+	"1.10.0",
+	"2.0.0",
+	"18446744073709551615.0.0",
+}
+
+func TestCompare(t *testing.T) {
+	for i, a := range precedence {
+		for j, b := range precedence {
+			want := compareInt(i, j)
+			if got := MustParse(a).Compare(MustParse(b)); got != want {
+				t.Errorf("%s.Compare(%s) = %d, want %d", a, b, got, want)
+			}
+			if got := Compare(a, b); got != want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", a, b, got, want)
+			}
+			if got, err := CompareStrict(a, b); err != nil || got != want {
+				t.Errorf("CompareStrict(%q, %q) = %d, %v; want %d", a, b, got, err, want)
+			}
+		}
+	}
+	if got := MustParse("1.0.0-rc.1").Compare(MustParse("1.0.0-rc.1.1")); got != -1 {
+		t.Errorf("1.0.0-rc.1.Compare(1.0.0-rc.1.1) = %d, want -1", got)
+	}
+	if got := MustParse("1.2.3+build.1").Compare(MustParse("1.2.3+build.2")); got != 0 {
+		t.Errorf("1.2.3+build.1.Compare(1.2.3+build.2) = %d, want 0", got)
+	}
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3+build.1", "1.2.3+build.2", 0},
+		{"v1.2.3", "1.2.3", 0},
+		{"1.2", "1.2.0", 0},
+		{"1", "1.0.1", -1},
+		{"1.0", "1.0.1", -1},
+		{"1.2.3.4", "1.2.3.5", 0},
+		{"release-1.2.3", "1.2.4", -1},
+		{"", "0.0.0", 0},
+		{"abc", "0.0.1", -1},
+		{"1.0.0-rc.1", "1.0.0-rc.1.1", -1},
+	}
+	for _, tt := range tests {
+		if got := Compare(tt.a, tt.b); got != tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := Compare(tt.b, tt.a); got != -tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+		}
+	}
+	for _, s := range []string{"", "1.2", "abc"} {
+		if _, err := CompareStrict(s, "1.0.0"); err == nil {
+			t.Errorf("CompareStrict(%q, 1.0.0) succeeded", s)
+		}
+	}
+}
+
+// TestCompareFastPath checks that the in-place path of Compare orders
+// every input it accepts the way the lenient parser does.
+func TestCompareFastPath(t *testing.T) {
+	vs := append([]string{
+		"v1.2.3", "V1.2.4", "01.02.03", "1.2.3-rc.1+build.5", "1.2.3+build",
+		"1.2.3-0", "1.2.3-00", "1.2.3-0 ", " 1.2.3", "18446744073709551616.0.0", "99999999999999999999.0.0",
+	}, precedence...)
+	for _, bm := range compareBenchmarks {
+		vs = append(vs, bm.a, bm.b)
+	}
+	for _, a := range vs {
+		for _, b := range vs {
+			if _, _, _, _, ok := splitCore(a); !ok {
+				continue
+			}
+			if _, _, _, _, ok := splitCore(b); !ok {
+				continue
+			}
+			if got, want := Compare(a, b), compareLenient(a, b); got != want {
+				t.Errorf("Compare(%q, %q) = %d, but the lenient parser gives %d", a, b, got, want)
+			}
+		}
+	}
+}
+
+func TestSort(t *testing.T) {
+	vs := make([]Version, len(precedence))
+	for i, s := range precedence {
+		vs[len(vs)-1-i] = MustParse(s)
+	}
+	Sort(vs)
+	got := make([]string, len(vs))
+	for i, v := range vs {
+		got[i] = v.String()
+	}
+	if !slices.Equal(got, precedence) {
+		t.Errorf("Sort = %q, want %q", got, precedence)
+	}
+}
+
+// compareBenchmarks are pairs for BenchmarkCompare: the first ones have
+// the [v]MAJOR.MINOR.PATCH[-PRERELEASE][+BUILD] shape that Compare
+// handles in place, the others go through the lenient parser.
+var compareBenchmarks = []struct {
+	name string
+	a, b string
+}{
+	{"fast/core", "1.2.3", "1.2.4"},
+	{"fast/prefix", "v10.20.30", "v10.20.31"},
+	{"fast/prerelease", "1.0.0-alpha.1", "1.0.0-alpha.beta"},
+	{"fast/build", "1.2.3-rc.1+build.7", "1.2.3-rc.1+build.8"},
+	{"fallback/partial", "1.2", "1.2.1"},
+	{"fallback/extra", "1.2.3.4", "1.2.3.5"},
+	{"fallback/junk", "release-1.2.3", "release-1.2.4"},
+}
+
+func BenchmarkCompare(b *testing.B) {
+	for _, bm := range compareBenchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				Compare(bm.a, bm.b)
+			}
+		})
+	}
+}
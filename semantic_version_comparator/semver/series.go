@@ -0,0 +1,68 @@
+package semver
+
+import "strconv"
+
+// SeriesOf returns the name of the release series of v at the given
+// level: "1" for CompareMajor, "1.4" for CompareMajorMinor, "1.4.5" for
+// CompareCore, which puts pre-releases in the series of their release,
+// and the canonical version without build metadata for CompareFull. A
+// non-zero epoch is kept as a prefix, as in "1:2.3".
+func SeriesOf(v Version, level CompareMode) string {
+	var s string
+	switch level {
+	case CompareMajor:
+		s = strconv.FormatUint(v.Major, 10)
+	case CompareMajorMinor:
+		s = strconv.FormatUint(v.Major, 10) + "." + strconv.FormatUint(v.Minor, 10)
+	case CompareCore:
+		s = strconv.FormatUint(v.Major, 10) + "." + strconv.FormatUint(v.Minor, 10) + "." + strconv.FormatUint(v.Patch, 10)
+	default:
+		v.Build = ""
+		return v.String()
+	}
+	if v.Epoch != 0 {
+		s = strconv.FormatUint(v.Epoch, 10) + ":" + s
+	}
+	return s
+}
+
+// GroupBySeries buckets vs by release series, named by SeriesOf: with
+// CompareMajorMinor, "1.4" maps to [1.4.0 1.4.1 1.4.5-rc.1]. Each bucket
+// is sorted in ascending precedence order; versions of equal precedence
+// keep their relative order.
+func GroupBySeries(vs []Version, level CompareMode) map[string][]Version {
+	groups := make(map[string][]Version)
+	for _, v := range vs {
+		s := SeriesOf(v, level)
+		groups[s] = append(groups[s], v)
+	}
+	for _, g := range groups {
+		Sort(g)
+	}
+	return groups
+}
+
+// LatestPerSeries returns the latest version of each release series of vs,
+// named by SeriesOf: the highest release or, in a series with only
+// pre-releases, the highest pre-release. Of versions with equal
+// precedence the first is kept.
+func LatestPerSeries(vs []Version, level CompareMode) map[string]Version {
+	latest := make(map[string]Version)
+	for _, v := range vs {
+		s := SeriesOf(v, level)
+		best, ok := latest[s]
+		if !ok || newerInSeries(v, best) {
+			latest[s] = v
+		}
+	}
+	return latest
+}
+
+// newerInSeries reports whether v should replace best as the latest of a
+// series: releases win over pre-releases, then precedence decides.
+func newerInSeries(v, best Version) bool {
+	if (v.Prerelease == "") != (best.Prerelease == "") {
+		return v.Prerelease == ""
+	}
+	return v.Compare(best) > 0
+}
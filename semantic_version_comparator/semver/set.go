@@ -0,0 +1,193 @@
+package semver
+
+import "slices"
+
+// VersionSet is a set of versions under semantic equality: versions of
+// equal precedence, such as 1.2.0 and 1.2.0+build.5, are the same
+// element, and the set keeps the first one added. Elements are held in a
+// sorted slice, so lookups take logarithmic time and Union, Intersection
+// and Difference a single merge pass. The zero value is an empty set
+// ready to use, and a nil *VersionSet is an empty set for every method
+// that does not modify it. A VersionSet is not safe for concurrent
+// modification.
+type VersionSet struct {
+	vs []Version // sorted by precedence, without duplicates
+}
+
+// NewVersionSet returns a set holding vs.
+func NewVersionSet(vs ...Version) *VersionSet {
+	sorted := slices.Clone(vs)
+	Sort(sorted)
+	return &VersionSet{vs: slices.CompactFunc(sorted, func(a, b Version) bool { return a.Compare(b) == 0 })}
+}
+
+func (s *VersionSet) list() []Version {
+	if s == nil {
+		return nil
+	}
+	return s.vs
+}
+
+// Len returns the number of versions in s.
+func (s *VersionSet) Len() int {
+	return len(s.list())
+}
+
+// Add adds v to s and reports whether it was not already present.
+func (s *VersionSet) Add(v Version) bool {
+	i, found := slices.BinarySearchFunc(s.vs, v, CompareVersions)
+	if found {
+		return false
+	}
+	s.vs = slices.Insert(s.vs, i, v)
+	return true
+}
+
+// Remove removes the version of v's precedence from s and reports
+// whether it was present.
+func (s *VersionSet) Remove(v Version) bool {
+	i, found := slices.BinarySearchFunc(s.vs, v, CompareVersions)
+	if found {
+		s.vs = slices.Delete(s.vs, i, i+1)
+	}
+	return found
+}
+
+// Contains reports whether s holds a version of v's precedence.
+func (s *VersionSet) Contains(v Version) bool {
+	_, found := slices.BinarySearchFunc(s.list(), v, CompareVersions)
+	return found
+}
+
+// Versions returns the versions of s in ascending order. The slice is a
+// copy.
+func (s *VersionSet) Versions() []Version {
+	return slices.Clone(s.list())
+}
+
+// Each calls f for each version of s in ascending order, stopping early
+// if f returns false. f must not modify s.
+func (s *VersionSet) Each(f func(Version) bool) {
+	for _, v := range s.list() {
+		if !f(v) {
+			return
+		}
+	}
+}
+
+// Union returns a new set of the versions in s or o. Where both hold a
+// version, the one from s is kept.
+func (s *VersionSet) Union(o *VersionSet) *VersionSet {
+	a, b := s.list(), o.list()
+	out := make([]Version, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch n := a[i].Compare(b[j]); {
+		case n < 0:
+			out = append(out, a[i])
+			i++
+		case n > 0:
+			out = append(out, b[j])
+			j++
+		default:
+			out = append(out, a[i])
+			i++
+			j++
+		}
+	}
+	out = append(append(out, a[i:]...), b[j:]...)
+	return &VersionSet{vs: out}
+}
+
+// Intersection returns a new set of the versions of s that o also holds.
+func (s *VersionSet) Intersection(o *VersionSet) *VersionSet {
+	a, b := s.list(), o.list()
+	var out []Version
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch n := a[i].Compare(b[j]); {
+		case n < 0:
+			i++
+		case n > 0:
+			j++
+		default:
+			out = append(out, a[i])
+			i++
+			j++
+		}
+	}
+	return &VersionSet{vs: out}
+}
+
+// Difference returns a new set of the versions of s that o does not
+// hold.
+func (s *VersionSet) Difference(o *VersionSet) *VersionSet {
+	a, b := s.list(), o.list()
+	var out []Version
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch n := a[i].Compare(b[j]); {
+		case n < 0:
+			out = append(out, a[i])
+			i++
+		case n > 0:
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+	out = append(out, a[i:]...)
+	return &VersionSet{vs: out}
+}
+
+// Page returns at most limit versions of s in ascending order, skipping
+// the first offset, for offset pagination. It returns nil past the end.
+// The slice is a copy.
+func (s *VersionSet) Page(offset, limit int) []Version {
+	vs := s.list()
+	if offset < 0 || limit <= 0 || offset >= len(vs) {
+		return nil
+	}
+	return slices.Clone(vs[offset:min(offset+limit, len(vs))])
+}
+
+// PageReverse is like Page in descending order, so PageReverse(0, 20)
+// returns the 20 highest versions.
+func (s *VersionSet) PageReverse(offset, limit int) []Version {
+	vs := s.list()
+	if offset < 0 || limit <= 0 || offset >= len(vs) {
+		return nil
+	}
+	end := len(vs) - offset
+	page := slices.Clone(vs[max(end-limit, 0):end])
+	slices.Reverse(page)
+	return page
+}
+
+// After returns at most limit versions of s above v, in ascending order,
+// for keyset pagination: pass the last version of one page to get the
+// next. Unlike offsets, the cursor stays valid as versions are added.
+func (s *VersionSet) After(v Version, limit int) []Version {
+	vs := s.list()
+	i, found := slices.BinarySearchFunc(vs, v, CompareVersions)
+	if found {
+		i++
+	}
+	if limit <= 0 || i >= len(vs) {
+		return nil
+	}
+	return slices.Clone(vs[i:min(i+limit, len(vs))])
+}
+
+// Before returns at most limit versions of s below v, in descending
+// order, for keyset pagination from the newest version down.
+func (s *VersionSet) Before(v Version, limit int) []Version {
+	vs := s.list()
+	i, _ := slices.BinarySearchFunc(vs, v, CompareVersions)
+	if limit <= 0 || i == 0 {
+		return nil
+	}
+	page := slices.Clone(vs[max(i-limit, 0):i])
+	slices.Reverse(page)
+	return page
+}
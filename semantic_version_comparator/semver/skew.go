@@ -0,0 +1,168 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SkewPolicy bounds how far a client's version may drift from the
+// server's it talks to, such as a client that may lag its server by two
+// minor versions but never run a newer major version. Each field is the
+// number of versions allowed in one direction: zero allows none and
+// SkewAny any number. The zero policy thus requires the same major and
+// minor version.
+//
+// The major limits apply to clients of another major version, whose
+// minor versions are then not compared; the minor limits apply to
+// clients of the server's major version. Epochs, patch versions,
+// pre-releases and build metadata are ignored, as in CheckSkew.
+type SkewPolicy struct {
+	MajorBehind, MajorAhead int
+	MinorBehind, MinorAhead int
+}
+
+// SkewAny is the SkewPolicy limit allowing any number of versions.
+const SkewAny = -1
+
+// SkewRule identifies the limit of a SkewPolicy that applies to a pair of
+// versions.
+type SkewRule int
+
+const (
+	SkewNone        SkewRule = iota // same major and minor version
+	SkewMajorBehind                 // client of an older major version
+	SkewMajorAhead                  // client of a newer major version
+	SkewMinorBehind                 // client of an older minor version
+	SkewMinorAhead                  // client of a newer minor version
+)
+
+var skewRuleNames = [...]string{"none", "major-behind", "major-ahead", "minor-behind", "minor-ahead"}
+
+func (r SkewRule) String() string {
+	if r < 0 || int(r) >= len(skewRuleNames) {
+		return "SkewRule(" + strconv.Itoa(int(r)) + ")"
+	}
+	return skewRuleNames[r]
+}
+
+// ParseSkewRule returns the rule with the given name, such as
+// "minor-behind".
+func ParseSkewRule(name string) (SkewRule, error) {
+	for i, n := range skewRuleNames {
+		if i > 0 && n == name {
+			return SkewRule(i), nil
+		}
+	}
+	return 0, fmt.Errorf("semver: unknown skew rule %q", name)
+}
+
+// limit returns the field of p holding the limit for r.
+func (p *SkewPolicy) limit(r SkewRule) *int {
+	switch r {
+	case SkewMajorBehind:
+		return &p.MajorBehind
+	case SkewMajorAhead:
+		return &p.MajorAhead
+	case SkewMinorBehind:
+		return &p.MinorBehind
+	case SkewMinorAhead:
+		return &p.MinorAhead
+	}
+	return nil
+}
+
+// ParseSkewPolicy parses a policy written as RULE=N settings separated by
+// commas or spaces, with N a number or "any": "minor-behind=2" allows a
+// client up to two minor versions behind its server and nothing else.
+// Rules not given allow no skew.
+func ParseSkewPolicy(s string) (SkewPolicy, error) {
+	var p SkewPolicy
+	for _, f := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' }) {
+		name, value, ok := strings.Cut(f, "=")
+		if !ok {
+			return SkewPolicy{}, fmt.Errorf("semver: invalid skew setting %q: want RULE=N", f)
+		}
+		r, err := ParseSkewRule(name)
+		if err != nil {
+			return SkewPolicy{}, err
+		}
+		n := SkewAny
+		if value != "any" {
+			if n, err = strconv.Atoi(value); err != nil || n < 0 {
+				return SkewPolicy{}, fmt.Errorf("semver: invalid skew limit %q for %v: want a number or any", value, r)
+			}
+		}
+		*p.limit(r) = n
+	}
+	return p, nil
+}
+
+// String returns p in the form ParseSkewPolicy reads.
+func (p SkewPolicy) String() string {
+	parts := make([]string, 0, len(skewRuleNames)-1)
+	for r := SkewMajorBehind; int(r) < len(skewRuleNames); r++ {
+		value := "any"
+		if n := *p.limit(r); n >= 0 {
+			value = strconv.Itoa(n)
+		}
+		parts = append(parts, r.String()+"="+value)
+	}
+	return strings.Join(parts, ",")
+}
+
+// SkewResult is the outcome of SkewCheck.
+type SkewResult struct {
+	Allowed bool
+	// Rule is the limit that decided, SkewNone for versions of the same
+	// major and minor version.
+	Rule SkewRule
+	// Distance is the number of major or minor versions, as Rule
+	// counts, between the client and the server.
+	Distance uint64
+	// Reason explains the outcome, as in "client 1.2.0 is 3 minor
+	// versions behind server 1.5.0, more than the 2 allowed".
+	Reason string
+}
+
+// SkewCheck reports whether policy allows a client at version client to
+// talk to a server at version server, and which rule decided.
+func SkewCheck(client, server Version, policy SkewPolicy) SkewResult {
+	c, s := client.Major, server.Major
+	what, behind, ahead := "major", SkewMajorBehind, SkewMajorAhead
+	if c == s {
+		c, s = client.Minor, server.Minor
+		what, behind, ahead = "minor", SkewMinorBehind, SkewMinorAhead
+	}
+	core := func(v Version) string { return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch) }
+	if c == s {
+		return SkewResult{Allowed: true, Rule: SkewNone,
+			Reason: fmt.Sprintf("client %s and server %s have the same minor version", core(client), core(server))}
+	}
+	r, d, dir := behind, s-c, "behind"
+	if c > s {
+		r, d, dir = ahead, c-s, "ahead of"
+	}
+	res := SkewResult{Rule: r, Distance: d}
+	plural := ""
+	if d != 1 {
+		plural = "s"
+	}
+	switch limit := *policy.limit(r); {
+	case limit == 0:
+		res.Reason = fmt.Sprintf("client %s is %d %s version%s %s server %s, which %v forbids",
+			core(client), d, what, plural, dir, core(server), r)
+	case limit > 0 && d > uint64(limit):
+		res.Reason = fmt.Sprintf("client %s is %d %s version%s %s server %s, more than the %d allowed",
+			core(client), d, what, plural, dir, core(server), limit)
+	case limit > 0:
+		res.Allowed = true
+		res.Reason = fmt.Sprintf("client %s is %d %s version%s %s server %s, within the %d allowed",
+			core(client), d, what, plural, dir, core(server), limit)
+	default:
+		res.Allowed = true
+		res.Reason = fmt.Sprintf("client %s is %d %s version%s %s server %s, which %v allows",
+			core(client), d, what, plural, dir, core(server), r)
+	}
+	return res
+}
@@ -0,0 +1,140 @@
+package semver
+
+import (
+	"slices"
+	"strings"
+)
+
+// Collection is a slice of versions that implements sort.Interface in
+// ascending precedence order.
+type Collection []Version
+
+func (c Collection) Len() int           { return len(c) }
+func (c Collection) Less(i, j int) bool { return c[i].Compare(c[j]) < 0 }
+func (c Collection) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
+
+// CompareVersions returns a.Compare(b). Its signature matches the
+// comparison function expected by slices.SortFunc and friends.
+func CompareVersions(a, b Version) int {
+	return a.Compare(b)
+}
+
+// Sort sorts vs in ascending precedence order. Versions of equal
+// precedence keep their relative order.
+func Sort(vs []Version) {
+	slices.SortStableFunc(vs, CompareVersions)
+}
+
+// SortStrings sorts ss in ascending precedence order, parsing each element
+// with ParseTolerant. If any element fails to parse, ss is left unchanged
+// and the first error is returned.
+func SortStrings(ss []string) error {
+	type entry struct {
+		s string
+		v Version
+	}
+	entries := make([]entry, len(ss))
+	for i, s := range ss {
+		v, err := ParseTolerant(s)
+		if err != nil {
+			return err
+		}
+		entries[i] = entry{s, v}
+	}
+	slices.SortStableFunc(entries, func(a, b entry) int {
+		return a.v.Compare(b.v)
+	})
+	for i, e := range entries {
+		ss[i] = e.s
+	}
+	return nil
+}
+
+// InvalidPolicy says where SortMixed places strings that are not valid
+// versions.
+type InvalidPolicy int
+
+const (
+	// InvalidLast sorts invalid strings after every valid version.
+	InvalidLast InvalidPolicy = iota
+	// InvalidFirst sorts invalid strings before every valid version.
+	InvalidFirst
+	// InvalidError makes SortMixed fail on the first invalid string, as
+	// SortStrings does.
+	InvalidError
+)
+
+// SortMixed sorts a list of tags that may contain non-version strings such
+// as "latest" or "nightly". Strings that ParseTolerant accepts are sorted
+// by precedence; the others are grouped according to policy and sorted in
+// natural order, comparing runs of digits numerically so that "build-9"
+// sorts before "build-10". With InvalidError, ss is left unchanged if any
+// element is invalid and the first error is returned.
+func SortMixed(ss []string, policy InvalidPolicy) error {
+	type entry struct {
+		s     string
+		v     Version
+		valid bool
+	}
+	entries := make([]entry, len(ss))
+	for i, s := range ss {
+		v, err := ParseTolerant(s)
+		if err != nil && policy == InvalidError {
+			return err
+		}
+		entries[i] = entry{s, v, err == nil}
+	}
+	invalidRank := 1
+	if policy == InvalidFirst {
+		invalidRank = -1
+	}
+	slices.SortStableFunc(entries, func(a, b entry) int {
+		switch {
+		case a.valid && b.valid:
+			return a.v.Compare(b.v)
+		case a.valid:
+			return -invalidRank
+		case b.valid:
+			return invalidRank
+		}
+		return compareNatural(a.s, b.s)
+	})
+	for i, e := range entries {
+		ss[i] = e.s
+	}
+	return nil
+}
+
+// compareNatural orders strings by alternating runs of digits, compared
+// numerically, and other bytes, compared lexically. Strings that differ
+// only in leading zeros fall back to plain lexical order.
+func compareNatural(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		da, db := isDigitByte(a[i]), isDigitByte(b[j])
+		if da && db {
+			si, sj := i, j
+			for i < len(a) && isDigitByte(a[i]) {
+				i++
+			}
+			for j < len(b) && isDigitByte(b[j]) {
+				j++
+			}
+			if c := compareDigits(a[si:i], b[sj:j]); c != 0 {
+				return c
+			}
+			continue
+		}
+		if a[i] != b[j] {
+			return compareInt(int(a[i]), int(b[j]))
+		}
+		i++
+		j++
+	}
+	if c := compareInt(len(a)-i, len(b)-j); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+func isDigitByte(c byte) bool { return c >= '0' && c <= '9' }
@@ -0,0 +1,53 @@
+package semver
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Scan implements sql.Scanner for TEXT columns holding a version string.
+func (v *Version) Scan(src any) error {
+	switch src := src.(type) {
+	case string:
+		return v.UnmarshalText([]byte(src))
+	case []byte:
+		return v.UnmarshalText(src)
+	case nil:
+		return fmt.Errorf("semver: cannot scan NULL into Version; use NullVersion")
+	}
+	return fmt.Errorf("semver: cannot scan %T into Version", src)
+}
+
+// Value implements driver.Valuer, storing v in its canonical form.
+func (v Version) Value() (driver.Value, error) {
+	return v.String(), nil
+}
+
+// NullVersion is a Version that may be NULL. It implements sql.Scanner and
+// driver.Valuer in the same way as sql.NullString.
+type NullVersion struct {
+	Version Version
+	Valid   bool // Valid is true if Version is not NULL
+}
+
+// Scan implements sql.Scanner.
+func (n *NullVersion) Scan(src any) error {
+	if src == nil {
+		n.Version, n.Valid = Version{}, false
+		return nil
+	}
+	if err := n.Version.Scan(src); err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (n NullVersion) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Version.Value()
+}
@@ -0,0 +1,81 @@
+package semver
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Status flags a published version as withdrawn or discouraged by its
+// source, such as a retract directive in a go.mod file or an npm
+// deprecation. A Status is a set of flags combined with |.
+type Status uint8
+
+const (
+	// Retracted marks a version withdrawn by a Go retract directive.
+	Retracted Status = 1 << iota
+	// Deprecated marks a version its publisher advises against, as npm
+	// deprecate does.
+	Deprecated
+	// Yanked marks a version removed from resolution by its registry, as
+	// crates.io and PyPI yank releases.
+	Yanked
+)
+
+var statusNames = [...]string{"retracted", "deprecated", "yanked"}
+
+// String returns the flags in s separated by "|", such as
+// "retracted|deprecated", or "none" if s is empty.
+func (s Status) String() string {
+	if s == 0 {
+		return "none"
+	}
+	var names []string
+	for i, name := range statusNames {
+		if s&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	if rest := s &^ (1<<len(statusNames) - 1); rest != 0 {
+		names = append(names, "Status(0x"+strconv.FormatUint(uint64(rest), 16)+")")
+	}
+	return strings.Join(names, "|")
+}
+
+// Has reports whether s includes every flag in f.
+func (s Status) Has(f Status) bool {
+	return s&f == f
+}
+
+// Status returns the flags set on v with WithStatus. Parsed versions have
+// none.
+func (v Version) Status() Status {
+	return v.status
+}
+
+// WithStatus returns a copy of v with its flags replaced by s, so that a
+// list of versions can carry what a registry knows about them:
+//
+//	for i, v := range vs {
+//		if goproxy.Retracted(rs, v) {
+//			vs[i] = v.WithStatus(semver.Retracted)
+//		}
+//	}
+//
+// MaxSatisfying and MinSatisfying skip flagged versions unless the
+// constraint allows them with AllowStatus. The flags play no part in
+// precedence, Equal or Key, but like the original string they are part of
+// the value, so == distinguishes flagged versions.
+func (v Version) WithStatus(s Status) Version {
+	v.status = s
+	return v
+}
+
+// AllowStatus makes MaxSatisfying and MinSatisfying consider versions
+// whose flags are all in s, such as AllowStatus(semver.Deprecated) to
+// pick deprecated releases but still skip retracted ones. It does not
+// affect Check, which ignores flags.
+func AllowStatus(s Status) ConstraintOption {
+	return func(c *constraintConfig) {
+		c.allowStatus |= s
+	}
+}
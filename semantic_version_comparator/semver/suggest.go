@@ -0,0 +1,180 @@
+package semver
+
+import "strings"
+
+// Suggestion is a likely correction of a version string that failed to
+// parse, for error messages such as `"1.2.3.4" is not a version; did you
+// mean 1.2.3?`.
+type Suggestion struct {
+	Version Version
+	// Confidence estimates, from 0 to 1, how likely Version is what was
+	// meant. Each correction applied lowers it: adding a missing patch
+	// component is nearly certain, dropping a fourth component is a guess.
+	Confidence float64
+	// Reason describes the corrections applied, such as "drop components
+	// after the patch".
+	Reason string
+}
+
+// String returns the suggestion as a question: "did you mean 1.2.3?".
+func (s Suggestion) String() string {
+	return "did you mean " + s.Version.String() + "?"
+}
+
+// Suggest returns the nearest valid SemVer 2.0.0 version to s, such as
+// 1.2.3 for "1.2.3.4", 1.0.2 for "1..2", 1.2.3 for "v.1.2.3", 1.2.3-rc.1
+// for "v1.2.3rc.01" or 1.2.3 for "１.２.３" written with full-width digits.
+// It reports false if s already parses with ParseStrict or cannot be
+// corrected with confidence, as with "latest" or "1.x".
+func Suggest(s string) (Suggestion, bool) {
+	if _, err := ParseStrict(s); err == nil {
+		return Suggestion{}, false
+	}
+	sg := suggester{conf: 1}
+	in := sg.fix(s, Normalize(s), 0.95, "replace non-ASCII lookalikes")
+	in = sg.fix(in, strings.TrimSpace(in), 0.99, "remove surrounding whitespace")
+	if t := strings.TrimPrefix(in, "="); t != in {
+		in = sg.fix(in, t, 0.95, `remove the leading "="`)
+	}
+	if t := trimV(in); t != in {
+		in = sg.fix(in, t, 0.95, `remove the leading "v"`)
+		in = sg.fix(in, strings.TrimLeft(in, "._,"), 0.9, `remove separators after the "v"`)
+	}
+
+	core, build, _ := strings.Cut(in, "+")
+	core, pre, hasPre := strings.Cut(core, "-")
+	if i := prereleaseStart(core); i >= 0 {
+		p := core[i:]
+		if hasPre {
+			p += "-" + pre
+		}
+		pre, hasPre = p, true
+		core = sg.fix(core, strings.TrimSuffix(core[:i], "."), 0.8, `separate the pre-release with "-"`)
+	}
+	core = sg.fix(core, strings.NewReplacer("_", ".", ",", ".").Replace(core), 0.7, `separate components with "."`)
+
+	parts := strings.Split(core, ".")
+	if core == "" || len(parts) > 3 && !isNumeric(strings.Join(parts[3:], "")) {
+		return Suggestion{}, false
+	}
+	if len(parts) > 3 {
+		parts = parts[:3]
+		sg.note(0.5, "drop components after the patch")
+	}
+	for i, p := range parts {
+		switch {
+		case p == "":
+			parts[i] = "0"
+			sg.note(0.5, "fill in empty components with 0")
+		case !isNumeric(p):
+			return Suggestion{}, false
+		case len(p) > 1 && p[0] == '0':
+			if parts[i] = strings.TrimLeft(p, "0"); parts[i] == "" {
+				parts[i] = "0"
+			}
+			sg.note(0.9, "remove leading zeros")
+		}
+	}
+	if len(parts) < 3 {
+		parts = append(parts, make([]string, 3-len(parts))...)
+		for i := range parts {
+			if parts[i] == "" {
+				parts[i] = "0"
+			}
+		}
+		sg.note(0.9, "add the missing components")
+	}
+
+	out := strings.Join(parts, ".")
+	if pre = sg.idents(pre, true); pre != "" {
+		out += "-" + pre
+	} else if hasPre {
+		sg.note(0.9, "remove the empty pre-release")
+	}
+	if build = sg.idents(build, false); build != "" {
+		out += "+" + build
+	} else if strings.Contains(in, "+") {
+		sg.note(0.9, "remove the empty build metadata")
+	}
+	v, err := ParseStrict(out)
+	if err != nil || sg.reasons == nil {
+		return Suggestion{}, false
+	}
+	return Suggestion{Version: v, Confidence: sg.conf, Reason: strings.Join(sg.reasons, "; ")}, true
+}
+
+// Suggest returns Suggest(e.Input).
+func (e *ParseError) Suggest() (Suggestion, bool) {
+	return Suggest(e.Input)
+}
+
+// suggester accumulates the corrections made by Suggest.
+type suggester struct {
+	conf    float64
+	reasons []string
+}
+
+// fix returns out, noting the correction if it differs from in.
+func (sg *suggester) fix(in, out string, conf float64, reason string) string {
+	if out != in {
+		sg.note(conf, reason)
+	}
+	return out
+}
+
+// note records a correction; repeating one does not lower the confidence
+// further.
+func (sg *suggester) note(conf float64, reason string) {
+	for _, r := range sg.reasons {
+		if r == reason {
+			return
+		}
+	}
+	sg.conf *= conf
+	sg.reasons = append(sg.reasons, reason)
+}
+
+// idents corrects the dot-separated identifier list ids, replacing invalid
+// characters with "-", dropping empty identifiers and, if numeric is set,
+// removing leading zeros from numeric identifiers.
+func (sg *suggester) idents(ids string, numeric bool) string {
+	if ids == "" {
+		return ""
+	}
+	fixed := strings.Map(func(r rune) rune {
+		if r == '.' || r < 0x80 && isIdentChar(byte(r)) {
+			return r
+		}
+		return '-'
+	}, ids)
+	ids = sg.fix(ids, fixed, 0.7, `replace invalid characters with "-"`)
+	var kept []string
+	for _, id := range strings.Split(ids, ".") {
+		if id != "" {
+			kept = append(kept, id)
+		}
+	}
+	ids = sg.fix(ids, strings.Join(kept, "."), 0.8, "drop empty identifiers")
+	if numeric {
+		ids = sg.fix(ids, trimNumericIdents(ids), 0.9, "remove leading zeros")
+	}
+	return ids
+}
+
+// prereleaseStart returns the offset of a pre-release written without its
+// "-" in core, as in "1.2.3rc1" or "1.2.3.beta", or -1. A letter must
+// follow a digit, or a "." after all three components, so that "1.x" is
+// not taken for a pre-release.
+func prereleaseStart(core string) int {
+	for i := 0; i < len(core); i++ {
+		c := core[i]
+		if c >= '0' && c <= '9' || c == '.' || c == '_' || c == ',' {
+			continue
+		}
+		if i > 0 && core[i-1] >= '0' && core[i-1] <= '9' || i > 0 && core[i-1] == '.' && strings.Count(core[:i], ".") >= 3 {
+			return i
+		}
+		return -1
+	}
+	return -1
+}
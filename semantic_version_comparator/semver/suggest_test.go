@@ -0,0 +1,45 @@
+package semver
+
+import "testing"
+
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string // "" if there is no suggestion
+	}{
+		{"1.2.3.4", "1.2.3"},
+		{"1..2", "1.0.2"},
+		{"v1.2.3rc.01", "1.2.3-rc.1"},
+		{"v.1.2.3", "1.2.3"},
+		{"v_1.2", "1.2.0"},
+		{" =v1.2.3 ", "1.2.3"},
+		{"1.2", "1.2.0"},
+		{"01.2.3", "1.2.3"},
+		{"1_2_3", "1.2.3"},
+		{"1.2.3-", "1.2.3"},
+		{"1.2.3-rc..1", "1.2.3-rc.1"},
+		{"1.2.3-rc_1", "1.2.3-rc-1"},
+		{"1.2.3", ""},
+		{"latest", ""},
+		{"1.x", ""},
+		{"1.2.3.beta.4", "1.2.3-beta.4"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		sg, ok := Suggest(tt.in)
+		if tt.want == "" {
+			if ok {
+				t.Errorf("Suggest(%q) = %v, want none", tt.in, sg)
+			}
+			continue
+		}
+		if !ok || sg.Version.String() != tt.want || sg.Confidence <= 0 || sg.Confidence >= 1 || sg.Reason == "" {
+			t.Errorf("Suggest(%q) = %v (%v, %q), %v; want %s", tt.in, sg.Version, sg.Confidence, sg.Reason, ok, tt.want)
+		}
+	}
+	a, _ := Suggest("1.2")
+	b, _ := Suggest("1.2.3.4")
+	if a.Confidence <= b.Confidence {
+		t.Errorf("Suggest(1.2) confidence %v is not above Suggest(1.2.3.4) confidence %v", a.Confidence, b.Confidence)
+	}
+}
@@ -0,0 +1,266 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TagTemplate renders tag names for release automation from a pattern
+// such as "{prefix}v{major}.{minor}.{patch}[-{channel}.{n}]", and checks
+// that every name it renders reads back as the version it was rendered
+// from, so a bot cannot push a tag that is invalid or sorts wrongly.
+//
+// Placeholders name a part of the version:
+//
+//	{major} {minor} {patch}  the numeric components
+//	{core}                   major.minor.patch
+//	{version}                the whole version, as String writes it
+//	{prerelease} {build}     the pre-release and build metadata, without "-" or "+"
+//	{channel}                the first pre-release identifier without trailing digits: rc for 1.2.3-rc.2
+//	{n}                      the pre-release number: the last numeric identifier, or the digits ending the first, as in beta2
+//	{epoch}                  the epoch
+//
+// Any other placeholder, such as {prefix}, is a context value given to
+// Render. A part in brackets is left out if any placeholder in it renders
+// empty, so the template above writes 1.4.0 as "v1.4.0" and 1.4.0-rc.2
+// as "v1.4.0-rc.2". Brackets do not nest, and neither brackets nor
+// braces can appear literally; git does not allow them in tags anyway.
+type TagTemplate struct {
+	pattern string
+	parts   []templatePart
+	build   bool // whether the template renders build metadata
+}
+
+// templatePart is literal text, a placeholder, or an optional group of
+// parts.
+type templatePart struct {
+	text, name string
+	group      []templatePart
+}
+
+// versionStart lists the placeholders that can begin the version in a
+// rendered tag.
+var versionStart = map[string]bool{"major": true, "core": true, "version": true}
+
+var builtinPlaceholders = map[string]bool{
+	"major": true, "minor": true, "patch": true, "core": true, "version": true,
+	"prerelease": true, "build": true, "channel": true, "n": true, "epoch": true,
+}
+
+// ParseTagTemplate compiles pattern. It fails if braces or brackets are
+// unbalanced, a placeholder is empty, or no placeholder renders the
+// start of the version: {major}, {core} or {version}.
+func ParseTagTemplate(pattern string) (*TagTemplate, error) {
+	t := &TagTemplate{pattern: pattern}
+	var group *[]templatePart
+	parts := &t.parts
+	hasVersion := false
+	for i := 0; i < len(pattern); {
+		switch c := pattern[i]; c {
+		case '{':
+			end := strings.IndexByte(pattern[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("semver: template %q: unclosed {", pattern)
+			}
+			name := pattern[i+1 : i+end]
+			if name == "" || strings.ContainsAny(name, "{[]") {
+				return nil, fmt.Errorf("semver: template %q: invalid placeholder {%s}", pattern, name)
+			}
+			hasVersion = hasVersion || versionStart[name]
+			t.build = t.build || name == "build" || name == "version"
+			*parts = append(*parts, templatePart{name: name})
+			i += end + 1
+		case '}':
+			return nil, fmt.Errorf("semver: template %q: unexpected } at offset %d", pattern, i)
+		case '[':
+			if group != nil {
+				return nil, fmt.Errorf("semver: template %q: nested [ at offset %d", pattern, i)
+			}
+			t.parts = append(t.parts, templatePart{})
+			group = &t.parts[len(t.parts)-1].group
+			parts = group
+			i++
+		case ']':
+			if group == nil {
+				return nil, fmt.Errorf("semver: template %q: unexpected ] at offset %d", pattern, i)
+			}
+			group, parts = nil, &t.parts
+			i++
+		default:
+			end := strings.IndexAny(pattern[i:], "{}[]")
+			if end < 0 {
+				end = len(pattern) - i
+			}
+			*parts = append(*parts, templatePart{text: pattern[i : i+end]})
+			i += end
+		}
+	}
+	switch {
+	case group != nil:
+		return nil, fmt.Errorf("semver: template %q: unclosed [", pattern)
+	case !hasVersion:
+		return nil, fmt.Errorf("semver: template %q: want {major}, {core} or {version}", pattern)
+	}
+	return t, nil
+}
+
+// MustParseTagTemplate is like ParseTagTemplate but panics on error.
+func MustParseTagTemplate(pattern string) *TagTemplate {
+	t, err := ParseTagTemplate(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func (t *TagTemplate) String() string {
+	return t.pattern
+}
+
+// Render returns the tag for v, with the context values vars filling
+// the placeholders that do not name a part of the version. It fails if a
+// placeholder has no value, vars redefines a built-in placeholder, the
+// tag is not a valid git tag name, or its version, from the first of
+// {major}, {core} or {version} (and a "v" just before it) to the end,
+// does not read back as v. Build metadata must read back only if the
+// template renders it.
+func (t *TagTemplate) Render(v Version, vars map[string]string) (string, error) {
+	for name := range vars {
+		if builtinPlaceholders[name] {
+			return "", fmt.Errorf("semver: template %q: context value {%s} is a version placeholder", t.pattern, name)
+		}
+	}
+	tag, start, _, err := t.render(t.parts, v, vars, false)
+	if err != nil {
+		return "", err
+	}
+	if err := checkTagName(tag); err != nil {
+		return "", fmt.Errorf("semver: template %q renders %q: %v", t.pattern, tag, err)
+	}
+	if start > 0 && (tag[start-1] == 'v' || tag[start-1] == 'V') {
+		start--
+	}
+	want := v
+	if !t.build {
+		want.Build = ""
+	}
+	var got Version
+	if start >= 0 {
+		got, err = Parse(tag[start:])
+	}
+	if start < 0 || err != nil || !got.Equal(want) {
+		return "", fmt.Errorf("semver: template %q renders %q, which does not read back as %s", t.pattern, tag, want)
+	}
+	return tag, nil
+}
+
+// render renders parts and returns the text, the offset in it of the
+// first placeholder that starts the version, or -1, and whether an
+// optional group is complete: false if a placeholder in it rendered
+// empty.
+func (t *TagTemplate) render(parts []templatePart, v Version, vars map[string]string, optional bool) (text string, start int, ok bool, err error) {
+	var b strings.Builder
+	start = -1
+	for _, p := range parts {
+		switch {
+		case p.group != nil:
+			sub, subStart, ok, err := t.render(p.group, v, vars, true)
+			if err != nil {
+				return "", 0, false, err
+			}
+			if !ok {
+				continue
+			}
+			if start < 0 && subStart >= 0 {
+				start = b.Len() + subStart
+			}
+			b.WriteString(sub)
+		case p.name != "":
+			value, known := templateValue(v, p.name)
+			if !known {
+				if value, known = vars[p.name]; !known {
+					return "", 0, false, fmt.Errorf("semver: template %q: no value for {%s}", t.pattern, p.name)
+				}
+			}
+			if value == "" && optional {
+				return "", -1, false, nil
+			}
+			if start < 0 && versionStart[p.name] {
+				start = b.Len()
+			}
+			b.WriteString(value)
+		default:
+			b.WriteString(p.text)
+		}
+	}
+	return b.String(), start, true, nil
+}
+
+// RenderTag compiles pattern and renders the tag for v with it.
+func RenderTag(pattern string, v Version, vars map[string]string) (string, error) {
+	t, err := ParseTagTemplate(pattern)
+	if err != nil {
+		return "", err
+	}
+	return t.Render(v, vars)
+}
+
+// templateValue returns the value of the built-in placeholder name for
+// v. known is false if name is not built in.
+func templateValue(v Version, name string) (value string, known bool) {
+	switch name {
+	case "major":
+		return strconv.FormatUint(v.Major, 10), true
+	case "minor":
+		return strconv.FormatUint(v.Minor, 10), true
+	case "patch":
+		return strconv.FormatUint(v.Patch, 10), true
+	case "core":
+		return v.Format("1.2.3"), true
+	case "version":
+		return v.String(), true
+	case "prerelease":
+		return v.Prerelease, true
+	case "build":
+		return v.Build, true
+	case "epoch":
+		if v.Epoch == 0 {
+			return "", true
+		}
+		return strconv.FormatUint(v.Epoch, 10), true
+	case "channel":
+		first, _, _ := strings.Cut(v.Prerelease, ".")
+		return strings.TrimRight(first, "0123456789"), true
+	case "n":
+		ids := strings.Split(v.Prerelease, ".")
+		for i := len(ids) - 1; i > 0; i-- {
+			if isNumeric(ids[i]) {
+				return ids[i], true
+			}
+		}
+		return ids[0][len(strings.TrimRight(ids[0], "0123456789")):], true
+	}
+	return "", false
+}
+
+// checkTagName reports why tag cannot be a git tag name, following
+// git check-ref-format.
+func checkTagName(tag string) error {
+	switch {
+	case tag == "":
+		return fmt.Errorf("empty tag")
+	case strings.HasPrefix(tag, "-"), strings.HasPrefix(tag, "/"), strings.HasSuffix(tag, "/"):
+		return fmt.Errorf("tags cannot start with - or start or end with /")
+	case strings.HasSuffix(tag, "."), strings.HasSuffix(tag, ".lock"):
+		return fmt.Errorf("tags cannot end with . or .lock")
+	case strings.Contains(tag, ".."), strings.Contains(tag, "@{"), strings.Contains(tag, "//"):
+		return fmt.Errorf("tags cannot contain .., @{ or //")
+	}
+	for _, r := range tag {
+		if r < 0x20 || r == 0x7f || strings.ContainsRune(" ~^:?*[\\", r) {
+			return fmt.Errorf("tags cannot contain %q", r)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package semver
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// Anomaly is a kind of finding of ReconstructTimeline.
+type Anomaly int
+
+const (
+	// MissingPatch is a gap in the patch releases of a series, as
+	// 1.2.0 and 1.2.2 without 1.2.1, or a series whose first release
+	// is not a .0.
+	MissingPatch Anomaly = iota
+	// OrphanPrerelease is a pre-release that was never released
+	// although a higher release of its major version was, as
+	// 1.2.0-rc.1 with 1.2.1 but no 1.2.0. Pre-releases of versions
+	// still to come are not orphans.
+	OrphanPrerelease
+	// DuplicateRelease is a release listed more than once, usually
+	// with different build metadata, as 1.2.0+a and 1.2.0+b.
+	DuplicateRelease
+)
+
+var anomalyNames = [...]string{"missing-patch", "orphan-prerelease", "duplicate-release"}
+
+func (a Anomaly) String() string {
+	if a < 0 || int(a) >= len(anomalyNames) {
+		return "Anomaly(" + strconv.Itoa(int(a)) + ")"
+	}
+	return anomalyNames[a]
+}
+
+// ParseAnomaly returns the anomaly with the given name, as returned by
+// Anomaly.String.
+func ParseAnomaly(name string) (Anomaly, error) {
+	for i, n := range anomalyNames {
+		if n == name {
+			return Anomaly(i), nil
+		}
+	}
+	return 0, fmt.Errorf("semver: unknown timeline anomaly %q", name)
+}
+
+// Timeline is the release timeline implied by a set of versions.
+type Timeline struct {
+	// Series lists the release series, named by SeriesOf with
+	// CompareMajorMinor, in ascending order.
+	Series []SeriesTimeline
+	// Findings lists the anomalies of the timeline by series and then
+	// version.
+	Findings []Finding
+}
+
+// SeriesTimeline is one release series of a Timeline.
+type SeriesTimeline struct {
+	Series string
+	// Versions holds the versions of the series, pre-releases included,
+	// in ascending order; versions of equal precedence keep their
+	// relative order.
+	Versions []Version
+}
+
+// Finding is an anomaly of a Timeline.
+type Finding struct {
+	Anomaly Anomaly
+	Series  string
+	// Version is the version concerned: the first missing version of
+	// a MissingPatch, the pre-release of an OrphanPrerelease and the
+	// second copy of a DuplicateRelease.
+	Version Version
+	// Related lists the versions the finding relates to: the releases
+	// around a gap, the release that superseded an orphan and the
+	// first copy of a duplicate.
+	Related []Version
+	Message string
+}
+
+// ReconstructTimeline sorts vs into release series and flags the
+// anomalies of the timeline they imply: missing patch releases, orphan
+// pre-releases and duplicate releases. Unlike CheckHistory, it ignores
+// the order of vs, which need not be the order of publication.
+func ReconstructTimeline(vs []Version) Timeline {
+	sorted := slices.Clone(vs)
+	Sort(sorted)
+
+	// superseded maps each orphan pre-release, by index in sorted, to the
+	// lowest release of its major version above it.
+	released := make(map[Key]bool)
+	for _, v := range sorted {
+		if v.Prerelease == "" {
+			released[v.Key()] = true
+		}
+	}
+	superseded := make(map[int]Version)
+	next := make(map[[2]uint64]Version)
+	for i := len(sorted) - 1; i >= 0; i-- {
+		v := sorted[i]
+		major := [2]uint64{v.Epoch, v.Major}
+		if v.Prerelease == "" {
+			next[major] = v
+			continue
+		}
+		core := Version{Epoch: v.Epoch, Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+		if r, ok := next[major]; ok && !released[core.Key()] {
+			superseded[i] = r
+		}
+	}
+
+	var t Timeline
+	var last *SeriesTimeline
+	var prev Version     // the last release of the series
+	var copies []Version // the copies of prev seen so far
+	hasPrev := false
+	for i, v := range sorted {
+		name := SeriesOf(v, CompareMajorMinor)
+		if last == nil || last.Series != name {
+			t.Series = append(t.Series, SeriesTimeline{Series: name})
+			last = &t.Series[len(t.Series)-1]
+			hasPrev = false
+		}
+		last.Versions = append(last.Versions, v)
+		flag := func(a Anomaly, at Version, related []Version, format string, args ...any) {
+			t.Findings = append(t.Findings, Finding{Anomaly: a, Series: name, Version: at, Related: related, Message: fmt.Sprintf(format, args...)})
+		}
+		if r, ok := superseded[i]; ok {
+			flag(OrphanPrerelease, v, []Version{r}, "pre-release %s was never released; %s followed", v, r)
+			continue
+		}
+		if v.Prerelease != "" {
+			continue
+		}
+		want := uint64(0)
+		if hasPrev {
+			want = prev.Patch + 1
+		}
+		switch {
+		case hasPrev && v.Patch == prev.Patch:
+			if slices.ContainsFunc(copies, func(c Version) bool { return c.Build == v.Build }) {
+				flag(DuplicateRelease, v, []Version{prev}, "release %s is listed twice", v)
+			} else {
+				flag(DuplicateRelease, v, []Version{prev}, "release %s duplicates %s, differing only in build metadata", v, prev)
+			}
+			copies = append(copies, v)
+			continue
+		case v.Patch > want:
+			first := Version{Epoch: v.Epoch, Major: v.Major, Minor: v.Minor, Patch: want}
+			missing := first.String()
+			if v.Patch-want > 1 {
+				missing += " through " + Version{Epoch: v.Epoch, Major: v.Major, Minor: v.Minor, Patch: v.Patch - 1}.String() + " are"
+			} else {
+				missing += " is"
+			}
+			if hasPrev {
+				flag(MissingPatch, first, []Version{prev, v}, "%s missing between %s and %s", missing, prev, v)
+			} else {
+				flag(MissingPatch, first, []Version{v}, "%s missing before %s", missing, v)
+			}
+		}
+		prev, copies, hasPrev = v, []Version{v}, true
+	}
+	return t
+}
@@ -0,0 +1,92 @@
+package semver
+
+import "slices"
+
+// TopN returns the k highest versions of vs, highest first, without
+// sorting all of vs: it takes O(n log k) time and O(k) space, for picking
+// "the 20 newest releases" out of a large tag list. Of versions of equal
+// precedence the earlier in vs comes first. vs is not modified.
+func TopN(vs []Version, k int) []Version {
+	return selectN(vs, k, 1)
+}
+
+// BottomN returns the k lowest versions of vs, lowest first, like TopN.
+func BottomN(vs []Version, k int) []Version {
+	return selectN(vs, k, -1)
+}
+
+// ranked is a version with its position in the input, which breaks ties.
+type ranked struct {
+	v Version
+	i int
+}
+
+// selectN keeps the k best versions of vs in a heap whose root is the
+// worst kept version. dir is 1 to keep the highest versions and -1 to
+// keep the lowest.
+func selectN(vs []Version, k int, dir int) []Version {
+	if k <= 0 {
+		return nil
+	}
+	// better reports whether a ranks ahead of b in the result.
+	better := func(a, b ranked) bool {
+		if n := a.v.Compare(b.v) * dir; n != 0 {
+			return n > 0
+		}
+		return a.i < b.i
+	}
+	h := make([]ranked, 0, min(k, len(vs)))
+	for i, v := range vs {
+		r := ranked{v, i}
+		if len(h) < k {
+			h = append(h, r)
+			siftUp(h, len(h)-1, better)
+			continue
+		}
+		if better(r, h[0]) {
+			h[0] = r
+			siftDown(h, 0, better)
+		}
+	}
+	slices.SortFunc(h, func(a, b ranked) int {
+		if better(a, b) {
+			return -1
+		}
+		return 1
+	})
+	out := make([]Version, len(h))
+	for i, r := range h {
+		out[i] = r.v
+	}
+	return out
+}
+
+// siftUp and siftDown maintain h as a heap whose root is the element
+// ranked last by better.
+func siftUp(h []ranked, i int, better func(a, b ranked) bool) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !better(h[parent], h[i]) {
+			return
+		}
+		h[parent], h[i] = h[i], h[parent]
+		i = parent
+	}
+}
+
+func siftDown(h []ranked, i int, better func(a, b ranked) bool) {
+	for {
+		worst, l, r := i, 2*i+1, 2*i+2
+		if l < len(h) && better(h[worst], h[l]) {
+			worst = l
+		}
+		if r < len(h) && better(h[worst], h[r]) {
+			worst = r
+		}
+		if worst == i {
+			return
+		}
+		h[i], h[worst] = h[worst], h[i]
+		i = worst
+	}
+}
@@ -0,0 +1,177 @@
+package semver
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/internal/yaml"
+)
+
+// VersionMap holds the version of each component of a monorepo whose
+// services are tagged together. It encodes to JSON as an object of
+// version strings.
+type VersionMap map[string]Version
+
+// ParseVersionMap reads a manifest mapping component names to versions,
+// written in JSON or, if data does not start with "{", in YAML:
+//
+//	api: 1.4.2
+//	worker: v2.0.0-rc.1
+//	web: "3.1.0"
+//
+// Versions are parsed with ParseTolerant.
+func ParseVersionMap(data []byte) (VersionMap, error) {
+	raw := map[string]string{}
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "{") {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("semver: version map: %v", err)
+		}
+	} else {
+		tree, err := yaml.Parse(trimmed)
+		if err != nil {
+			return nil, fmt.Errorf("semver: version map: %v", err)
+		}
+		doc, ok := tree.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("semver: version map: want a mapping of components to versions")
+		}
+		for name, v := range doc {
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("semver: version map: component %q: want a version", name)
+			}
+			raw[name] = s
+		}
+	}
+	m := make(VersionMap, len(raw))
+	for name, s := range raw {
+		v, err := ParseTolerant(s)
+		if err != nil {
+			return nil, fmt.Errorf("semver: version map: component %q: %v", name, strings.TrimPrefix(err.Error(), "semver: "))
+		}
+		m[name] = v
+	}
+	return m, nil
+}
+
+// Names returns the components of m, sorted.
+func (m VersionMap) Names() []string {
+	names := make([]string, 0, len(m))
+	for n := range m {
+		names = append(names, n)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// YAML returns m in the YAML form ParseVersionMap reads, one component
+// per line in name order.
+func (m VersionMap) YAML() string {
+	var b strings.Builder
+	for _, n := range m.Names() {
+		name := n
+		if strings.ContainsAny(n, ":#\"' ") || n == "" {
+			name = fmt.Sprintf("%q", n)
+		}
+		fmt.Fprintf(&b, "%s: %s\n", name, m[n])
+	}
+	return b.String()
+}
+
+// ComponentChange is the change of one component between two version
+// maps. Change is ListAdded or ListRemoved for a component in only one
+// of them, or ListUpgraded or ListDowngraded; From is zero for an added
+// component and To for a removed one.
+type ComponentChange struct {
+	Name     string
+	From, To Version
+	Change   ListChange
+	// Bump is the most significant component that differs between From
+	// and To, or DiffNone for an added or removed component.
+	Bump Change
+}
+
+// Diff returns the components whose version differs between m and new,
+// sorted by name. Versions that differ only in build metadata count as
+// an upgrade or downgrade by build.
+func (m VersionMap) Diff(new VersionMap) []ComponentChange {
+	var out []ComponentChange
+	for _, n := range m.Names() {
+		from := m[n]
+		to, ok := new[n]
+		switch {
+		case !ok:
+			out = append(out, ComponentChange{Name: n, From: from, Change: ListRemoved})
+		case !from.Equal(to):
+			c := ListUpgraded
+			if d := to.Compare(from); d < 0 || d == 0 && to.Build < from.Build {
+				c = ListDowngraded
+			}
+			out = append(out, ComponentChange{Name: n, From: from, To: to, Change: c, Bump: from.Diff(to)})
+		}
+	}
+	for _, n := range new.Names() {
+		if _, ok := m[n]; !ok {
+			out = append(out, ComponentChange{Name: n, To: new[n], Change: ListAdded})
+		}
+	}
+	slices.SortFunc(out, func(a, b ComponentChange) int { return strings.Compare(a.Name, b.Name) })
+	return out
+}
+
+// OverallBump returns the bump that a release tagging all components
+// together should make for changes: the most significant of the
+// components' bumps, where adding a component counts as minor and
+// removing or downgrading one as major, since either can break users.
+func OverallBump(changes []ComponentChange) Change {
+	overall := DiffNone
+	for _, c := range changes {
+		b := c.Bump
+		switch c.Change {
+		case ListAdded:
+			b = DiffMinor
+		case ListRemoved, ListDowngraded:
+			b = DiffMajor
+		}
+		overall = max(overall, b)
+	}
+	return overall
+}
+
+// ComponentResult is the outcome of checking one component of a version
+// map against its constraint.
+type ComponentResult struct {
+	Name       string
+	Constraint Constraint
+	// Version is the component's version; Present is false, and the
+	// result unsatisfied, if the map has no such component.
+	Version   Version
+	Present   bool
+	Satisfied bool
+}
+
+// Check checks each component named in cs against its constraint and
+// returns the results sorted by name. Components of m without a
+// constraint are not checked.
+func (m VersionMap) Check(cs map[string]Constraint) []ComponentResult {
+	out := make([]ComponentResult, 0, len(cs))
+	for n, c := range cs {
+		v, ok := m[n]
+		out = append(out, ComponentResult{Name: n, Constraint: c, Version: v, Present: ok, Satisfied: ok && c.Check(v)})
+	}
+	slices.SortFunc(out, func(a, b ComponentResult) int { return strings.Compare(a.Name, b.Name) })
+	return out
+}
+
+// Satisfies reports whether every component named in cs is present in m
+// and satisfies its constraint.
+func (m VersionMap) Satisfies(cs map[string]Constraint) bool {
+	for n, c := range cs {
+		if v, ok := m[n]; !ok || !c.Check(v) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxBodySize bounds the size of request bodies.
+const maxBodySize = 1 << 20
+
+// Handler returns an http.Handler serving the JSON API:
+//
+//	POST /compare    CompareRequest   -> CompareResponse
+//	POST /sort       SortRequest      -> SortResponse
+//	POST /satisfies  SatisfiesRequest -> SatisfiesResponse
+//	POST /max-satisfying  MaxSatisfyingRequest -> MaxSatisfyingResponse
+//	GET  /healthz    -> {"status": "ok"}
+//	GET  /metrics    -> DefaultMetrics in the Prometheus text format
+//
+// Invalid requests and versions get status 400 with a body of the form
+// {"error": "..."}.
+func Handler() http.Handler {
+	m := DefaultMetrics
+	mux := http.NewServeMux()
+	mux.Handle("/compare", endpoint(m, "compare", Compare))
+	mux.Handle("/sort", endpoint(m, "sort", Sort))
+	mux.Handle("/satisfies", endpoint(m, "satisfies", Satisfies))
+	mux.Handle("/max-satisfying", endpoint(m, "max-satisfying", MaxSatisfying))
+	mux.Handle("/metrics", m)
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	return mux
+}
+
+// endpoint adapts an operation to a POST handler that decodes its
+// request from and encodes its response to JSON, recording each request
+// in m under name.
+func endpoint[Req, Resp any](m *Metrics, name string, op func(Req) (Resp, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		status, invalid := http.StatusOK, false
+		defer func() {
+			m.Observe("http", name, strconv.Itoa(status), time.Since(start), invalid)
+		}()
+		if r.Method != http.MethodPost {
+			status = http.StatusMethodNotAllowed
+			w.Header().Set("Allow", "POST")
+			writeError(w, status, errors.New("method not allowed"))
+			return
+		}
+		var req Req
+		dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&req); err != nil {
+			status = http.StatusBadRequest
+			writeError(w, status, fmt.Errorf("invalid request body: %v", err))
+			return
+		}
+		resp, err := op(req)
+		if err != nil {
+			status, invalid = http.StatusBadRequest, true
+			writeError(w, status, err)
+			return
+		}
+		writeJSON(w, status, resp)
+	})
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// durationBuckets are the upper bounds, in seconds, of the request
+// latency histogram. Operations take microseconds, so the buckets are
+// finer than Prometheus' defaults.
+var durationBuckets = []float64{0.0001, 0.00025, 0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 1}
+
+// Metrics counts the requests served by Handler or, through the rpc
+// package, over gRPC, and serves them in the Prometheus text format:
+//
+//	semver_requests_total{protocol, operation, code}
+//	semver_request_duration_seconds{protocol, operation}  (histogram)
+//	semver_parse_failures_total{protocol, operation}
+//	semver_version_cache_hits_total, semver_version_cache_misses_total
+//	semver_version_cache_entries
+//
+// Parse failures are requests rejected for an invalid version,
+// constraint, scheme or dialect. The cache metrics describe the cache of
+// parsed versions shared by all requests; its hit rate is
+//
+//	rate(semver_version_cache_hits_total[5m]) /
+//	  (rate(semver_version_cache_hits_total[5m]) + rate(semver_version_cache_misses_total[5m]))
+//
+// A Metrics is safe for concurrent use.
+type Metrics struct {
+	mu        sync.Mutex
+	requests  map[requestKey]uint64
+	failures  map[opKey]uint64
+	durations map[opKey]*histogram
+}
+
+type opKey struct{ protocol, operation string }
+
+type requestKey struct {
+	opKey
+	code string
+}
+
+type histogram struct {
+	counts []uint64 // per bucket of durationBuckets, not cumulative
+	count  uint64
+	sum    float64
+}
+
+// DefaultMetrics is the Metrics that Handler records to and serves.
+var DefaultMetrics = NewMetrics()
+
+// NewMetrics returns an empty Metrics.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		requests:  make(map[requestKey]uint64),
+		failures:  make(map[opKey]uint64),
+		durations: make(map[opKey]*histogram),
+	}
+}
+
+// Observe records a request for operation over protocol, such as "http"
+// or "grpc", that completed with code after d. invalid reports whether
+// it was rejected for invalid input.
+func (m *Metrics) Observe(protocol, operation, code string, d time.Duration, invalid bool) {
+	op := opKey{protocol, operation}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[requestKey{op, code}]++
+	if invalid {
+		m.failures[op]++
+	}
+	h := m.durations[op]
+	if h == nil {
+		h = &histogram{counts: make([]uint64, len(durationBuckets))}
+		m.durations[op] = h
+	}
+	sec := d.Seconds()
+	if i, _ := slices.BinarySearch(durationBuckets, sec); i < len(durationBuckets) {
+		h.counts[i]++
+	}
+	h.count++
+	h.sum += sec
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w)
+}
+
+// WriteTo writes the metrics in the Prometheus text exposition format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	m.mu.Lock()
+	b.WriteString("# HELP semver_requests_total Requests handled, by protocol, operation and status code.\n")
+	b.WriteString("# TYPE semver_requests_total counter\n")
+	reqs := make([]requestKey, 0, len(m.requests))
+	for k := range m.requests {
+		reqs = append(reqs, k)
+	}
+	slices.SortFunc(reqs, func(a, b requestKey) int {
+		if c := compareOps(a.opKey, b.opKey); c != 0 {
+			return c
+		}
+		return strings.Compare(a.code, b.code)
+	})
+	for _, k := range reqs {
+		fmt.Fprintf(&b, "semver_requests_total{%s,code=%s} %d\n", k.labels(), quoteLabel(k.code), m.requests[k])
+	}
+	b.WriteString("# HELP semver_parse_failures_total Requests rejected for an invalid version, constraint, scheme or dialect.\n")
+	b.WriteString("# TYPE semver_parse_failures_total counter\n")
+	for _, k := range sortedOps(m.failures) {
+		fmt.Fprintf(&b, "semver_parse_failures_total{%s} %d\n", k.labels(), m.failures[k])
+	}
+	b.WriteString("# HELP semver_request_duration_seconds Time taken to handle requests.\n")
+	b.WriteString("# TYPE semver_request_duration_seconds histogram\n")
+	for _, k := range sortedOps(m.durations) {
+		h := m.durations[k]
+		var cum uint64
+		for i, le := range durationBuckets {
+			cum += h.counts[i]
+			fmt.Fprintf(&b, "semver_request_duration_seconds_bucket{%s,le=\"%s\"} %d\n", k.labels(), formatFloat(le), cum)
+		}
+		fmt.Fprintf(&b, "semver_request_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", k.labels(), h.count)
+		fmt.Fprintf(&b, "semver_request_duration_seconds_sum{%s} %s\n", k.labels(), formatFloat(h.sum))
+		fmt.Fprintf(&b, "semver_request_duration_seconds_count{%s} %d\n", k.labels(), h.count)
+	}
+	m.mu.Unlock()
+	hits, misses := versionCache.Stats()
+	b.WriteString("# HELP semver_version_cache_hits_total Versions found in the parse cache.\n")
+	b.WriteString("# TYPE semver_version_cache_hits_total counter\n")
+	fmt.Fprintf(&b, "semver_version_cache_hits_total %d\n", hits)
+	b.WriteString("# HELP semver_version_cache_misses_total Versions parsed because they were not in the parse cache.\n")
+	b.WriteString("# TYPE semver_version_cache_misses_total counter\n")
+	fmt.Fprintf(&b, "semver_version_cache_misses_total %d\n", misses)
+	b.WriteString("# HELP semver_version_cache_entries Versions held in the parse cache.\n")
+	b.WriteString("# TYPE semver_version_cache_entries gauge\n")
+	fmt.Fprintf(&b, "semver_version_cache_entries %d\n", versionCache.Len())
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+func (k opKey) labels() string {
+	return "protocol=" + quoteLabel(k.protocol) + ",operation=" + quoteLabel(k.operation)
+}
+
+func compareOps(a, b opKey) int {
+	if c := strings.Compare(a.protocol, b.protocol); c != 0 {
+		return c
+	}
+	return strings.Compare(a.operation, b.operation)
+}
+
+func sortedOps[V any](m map[opKey]V) []opKey {
+	keys := make([]opKey, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, compareOps)
+	return keys
+}
+
+// quoteLabel quotes a label value, escaping backslashes, double quotes
+// and newlines as the exposition format requires.
+func quoteLabel(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,227 @@
+// Package service exposes the version operations of the semver command
+// as request/response calls, and serves them as a JSON HTTP API, so that
+// programs not written in Go get exactly the same ordering and
+// constraint rules.
+package service
+
+import (
+	"slices"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/scheme"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+// CompareRequest asks for the order of two versions. An empty Scheme
+// means "semver". Versions must stay within semver.DefaultLimits; only
+// MaxLength applies to schemes other than semver.
+type CompareRequest struct {
+	A      string `json:"a"`
+	B      string `json:"b"`
+	Scheme string `json:"scheme,omitempty"`
+}
+
+// CompareResponse holds -1, 0 or 1 as A orders before, equal to or after
+// B.
+type CompareResponse struct {
+	Result int `json:"result"`
+}
+
+// Compare orders req.A against req.B.
+func Compare(req CompareRequest) (CompareResponse, error) {
+	sch, err := lookup(req.Scheme)
+	if err != nil {
+		return CompareResponse{}, err
+	}
+	if sch == scheme.Semver {
+		a, err := parseVersion(req.A)
+		if err != nil {
+			return CompareResponse{}, err
+		}
+		b, err := parseVersion(req.B)
+		if err != nil {
+			return CompareResponse{}, err
+		}
+		return CompareResponse{Result: a.Compare(b)}, nil
+	}
+	if err := checkLength(req.A, req.B); err != nil {
+		return CompareResponse{}, err
+	}
+	n, err := sch.Compare(req.A, req.B)
+	if err != nil {
+		return CompareResponse{}, err
+	}
+	return CompareResponse{Result: n}, nil
+}
+
+// SortRequest asks for versions to be sorted, in descending order if
+// Reverse is set. An empty Scheme means "semver". Versions are limited
+// as in CompareRequest.
+type SortRequest struct {
+	Versions []string `json:"versions"`
+	Scheme   string   `json:"scheme,omitempty"`
+	Reverse  bool     `json:"reverse,omitempty"`
+}
+
+// SortResponse holds the sorted versions, as they were written.
+type SortResponse struct {
+	Versions []string `json:"versions"`
+}
+
+// Sort sorts req.Versions; it fails if any of them is invalid.
+func Sort(req SortRequest) (SortResponse, error) {
+	sch, err := lookup(req.Scheme)
+	if err != nil {
+		return SortResponse{}, err
+	}
+	vs := slices.Clone(req.Versions)
+	if vs == nil {
+		vs = []string{}
+	}
+	if sch == scheme.Semver {
+		err = sortSemver(vs)
+	} else if err = checkLength(vs...); err == nil {
+		err = scheme.Sort(sch, vs)
+	}
+	if err != nil {
+		return SortResponse{}, err
+	}
+	if req.Reverse {
+		slices.Reverse(vs)
+	}
+	return SortResponse{Versions: vs}, nil
+}
+
+// SatisfiesRequest asks whether a version satisfies a constraint. An
+// empty Dialect means "npm". The version is parsed with
+// semver.ParseTolerant. Versions and constraints must stay within
+// semver.DefaultLimits.
+type SatisfiesRequest struct {
+	Version           string `json:"version"`
+	Constraint        string `json:"constraint"`
+	Dialect           string `json:"dialect,omitempty"`
+	IncludePrerelease bool   `json:"include_prerelease,omitempty"`
+}
+
+// SatisfiesResponse reports whether the version satisfies the constraint.
+type SatisfiesResponse struct {
+	Satisfies bool `json:"satisfies"`
+}
+
+// Satisfies checks req.Version against req.Constraint.
+func Satisfies(req SatisfiesRequest) (SatisfiesResponse, error) {
+	v, err := parseVersion(req.Version)
+	if err != nil {
+		return SatisfiesResponse{}, err
+	}
+	con, err := parseConstraint(req.Constraint, req.Dialect, req.IncludePrerelease)
+	if err != nil {
+		return SatisfiesResponse{}, err
+	}
+	return SatisfiesResponse{Satisfies: con.Check(v)}, nil
+}
+
+// MaxSatisfyingRequest asks for the highest of Versions that satisfies
+// Constraint. Dialect and IncludePrerelease are as in SatisfiesRequest.
+type MaxSatisfyingRequest struct {
+	Versions          []string `json:"versions"`
+	Constraint        string   `json:"constraint"`
+	Dialect           string   `json:"dialect,omitempty"`
+	IncludePrerelease bool     `json:"include_prerelease,omitempty"`
+}
+
+// MaxSatisfyingResponse holds the highest satisfying version, as it was
+// written. Found is false, and Version empty, if none satisfies the
+// constraint.
+type MaxSatisfyingResponse struct {
+	Version string `json:"version,omitempty"`
+	Found   bool   `json:"found"`
+}
+
+// MaxSatisfying selects the highest of req.Versions satisfying
+// req.Constraint; it fails if any version is invalid.
+func MaxSatisfying(req MaxSatisfyingRequest) (MaxSatisfyingResponse, error) {
+	con, err := parseConstraint(req.Constraint, req.Dialect, req.IncludePrerelease)
+	if err != nil {
+		return MaxSatisfyingResponse{}, err
+	}
+	var resp MaxSatisfyingResponse
+	var best semver.Version
+	for _, s := range req.Versions {
+		v, err := parseVersion(s)
+		if err != nil {
+			return MaxSatisfyingResponse{}, err
+		}
+		if con.Check(v) && (!resp.Found || v.Compare(best) > 0) {
+			resp, best = MaxSatisfyingResponse{Version: s, Found: true}, v
+		}
+	}
+	return resp, nil
+}
+
+func lookup(name string) (scheme.Scheme, error) {
+	if name == "" {
+		return scheme.Semver, nil
+	}
+	return scheme.Lookup(name)
+}
+
+// versionCache holds the versions parsed from requests, which in practice
+// repeat the same few hundred releases.
+var versionCache = semver.NewCache(10000, func(s string) (semver.Version, error) {
+	return semver.ParseWith(s, semver.Tolerant(), semver.WithLimits(semver.DefaultLimits))
+})
+
+// parseVersion parses a version received in a request, which may come
+// from anywhere.
+func parseVersion(s string) (semver.Version, error) {
+	return versionCache.Parse(s)
+}
+
+// sortSemver sorts vs as scheme.Sort does under scheme.Semver, with each
+// version parsed once by parseVersion.
+func sortSemver(vs []string) error {
+	type parsed struct {
+		s string
+		v semver.Version
+	}
+	ps := make([]parsed, len(vs))
+	for i, s := range vs {
+		v, err := parseVersion(s)
+		if err != nil {
+			return err
+		}
+		ps[i] = parsed{s, v}
+	}
+	slices.SortStableFunc(ps, func(a, b parsed) int { return a.v.Compare(b.v) })
+	for i, p := range ps {
+		vs[i] = p.s
+	}
+	return nil
+}
+
+// checkLength applies the MaxLength of semver.DefaultLimits to versions
+// of other schemes, which have no limits of their own, before they are
+// parsed.
+func checkLength(vs ...string) error {
+	for _, s := range vs {
+		if len(s) > semver.DefaultLimits.MaxLength {
+			return &semver.LimitError{Limit: "MaxLength", Max: semver.DefaultLimits.MaxLength, Got: len(s)}
+		}
+	}
+	return nil
+}
+
+func parseConstraint(s, dialect string, includePrerelease bool) (semver.Constraint, error) {
+	opts := []semver.ConstraintOption{semver.WithConstraintLimits(semver.DefaultLimits)}
+	if dialect != "" {
+		d, err := semver.ParseDialect(dialect)
+		if err != nil {
+			return semver.Constraint{}, err
+		}
+		opts = append(opts, semver.WithDialect(d))
+	}
+	if includePrerelease {
+		opts = append(opts, semver.IncludePrerelease())
+	}
+	return semver.ParseConstraint(s, opts...)
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+func TestLimits(t *testing.T) {
+	long := "1.0.0-" + strings.Repeat("a", semver.DefaultLimits.MaxLength)
+	many := "1.0.0-" + strings.Repeat("a.", semver.DefaultLimits.MaxIdentifiers) + "a"
+	for _, sch := range []string{"", "semver", "deb", "pep440"} {
+		if _, err := Compare(CompareRequest{A: long, B: "1.0.0", Scheme: sch}); !errors.Is(err, semver.ErrLimit) {
+			t.Errorf("Compare(long, scheme %q) error = %v, want ErrLimit", sch, err)
+		}
+		if _, err := Sort(SortRequest{Versions: []string{"1.0.0", long}, Scheme: sch}); !errors.Is(err, semver.ErrLimit) {
+			t.Errorf("Sort(long, scheme %q) error = %v, want ErrLimit", sch, err)
+		}
+	}
+	if _, err := Compare(CompareRequest{A: many, B: "1.0.0"}); !errors.Is(err, semver.ErrLimit) {
+		t.Errorf("Compare(%d identifiers) error = %v, want ErrLimit", semver.DefaultLimits.MaxIdentifiers+1, err)
+	}
+	if _, err := Sort(SortRequest{Versions: []string{many}}); !errors.Is(err, semver.ErrLimit) {
+		t.Errorf("Sort(%d identifiers) error = %v, want ErrLimit", semver.DefaultLimits.MaxIdentifiers+1, err)
+	}
+}
+
+func TestSort(t *testing.T) {
+	tests := []struct {
+		scheme  string
+		reverse bool
+		in      []string
+		want    []string
+	}{
+		{"", false, []string{"1.10.0", "v1.2.0", "1.2.0-rc.1"}, []string{"1.2.0-rc.1", "v1.2.0", "1.10.0"}},
+		{"", true, []string{"1.10.0", "v1.2.0", "1.2.0-rc.1"}, []string{"1.10.0", "v1.2.0", "1.2.0-rc.1"}},
+		{"deb", false, []string{"1.0", "1.0~rc1", "1:0.9"}, []string{"1.0~rc1", "1.0", "1:0.9"}},
+		{"pep440", false, []string{"1.0", "1.0rc1", "1.0.post1", "1.0.dev1"}, []string{"1.0.dev1", "1.0rc1", "1.0", "1.0.post1"}},
+	}
+	for _, tt := range tests {
+		got, err := Sort(SortRequest{Versions: tt.in, Scheme: tt.scheme, Reverse: tt.reverse})
+		if err != nil {
+			t.Errorf("Sort(%q, scheme %q): %v", tt.in, tt.scheme, err)
+			continue
+		}
+		if !slices.Equal(got.Versions, tt.want) {
+			t.Errorf("Sort(%q, scheme %q) = %q, want %q", tt.in, tt.scheme, got.Versions, tt.want)
+		}
+	}
+	if _, err := Sort(SortRequest{Versions: []string{"1.0.0", "not a version"}}); err == nil {
+		t.Error("Sort with an invalid version succeeded")
+	}
+}
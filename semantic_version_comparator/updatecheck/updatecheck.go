@@ -0,0 +1,252 @@
+// Package updatecheck tells a program whether a newer version of itself
+// has been published, on GitHub, at a JSON endpoint or on a Go module
+// proxy.
+//
+// A typical self-update check is:
+//
+//	current, _ := updatecheck.BuildVersion()
+//	src := updatecheck.GitHub(nil, "owner/tool")
+//	r, err := updatecheck.Check(ctx, src, current)
+//	if err == nil && r.Available {
+//		fmt.Fprintf(os.Stderr, "tool %s is available (you have %s)\n", r.Latest.Version, r.Current)
+//	}
+package updatecheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/ghrelease"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/goproxy"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+// Release is a published version a program could update to.
+type Release struct {
+	Version semver.Version
+	// Prerelease marks a release its source labels as a pre-release, as
+	// GitHub can, even if Version has no pre-release.
+	Prerelease bool
+	// Retracted marks a release withdrawn by its publisher, as with Go
+	// module retract directives. Retracted releases are never offered.
+	Retracted bool
+	URL       string    // where the release can be downloaded, if known
+	Published time.Time // zero if unknown
+}
+
+// Source lists the releases of a program.
+type Source interface {
+	Releases(ctx context.Context) ([]Release, error)
+}
+
+// SourceFunc adapts a function to a Source.
+type SourceFunc func(ctx context.Context) ([]Release, error)
+
+// Releases calls f.
+func (f SourceFunc) Releases(ctx context.Context) ([]Release, error) {
+	return f(ctx)
+}
+
+// GitHub returns a source listing the releases of repo ("owner/name").
+// Drafts are skipped. A nil client means the zero ghrelease.Client.
+func GitHub(c *ghrelease.Client, repo string) Source {
+	if c == nil {
+		c = &ghrelease.Client{}
+	}
+	return SourceFunc(func(ctx context.Context) ([]Release, error) {
+		rs, err := c.Releases(ctx, repo)
+		if err != nil {
+			return nil, err
+		}
+		var out []Release
+		for _, r := range rs {
+			if r.Draft {
+				continue
+			}
+			out = append(out, Release{Version: r.Version, Prerelease: r.Prerelease, URL: r.URL, Published: r.PublishedAt})
+		}
+		return out, nil
+	})
+}
+
+// GoProxy returns a source listing the tagged versions of module from a
+// Go module proxy, with the versions retracted by its latest go.mod
+// marked. A nil client means the zero goproxy.Client.
+func GoProxy(c *goproxy.Client, module string) Source {
+	if c == nil {
+		c = &goproxy.Client{}
+	}
+	return SourceFunc(func(ctx context.Context) ([]Release, error) {
+		vs, err := c.List(ctx, module)
+		if err != nil || len(vs) == 0 {
+			return nil, err
+		}
+		rs, err := c.Retractions(ctx, module)
+		if err != nil {
+			return nil, err
+		}
+		out := make([]Release, len(vs))
+		for i, v := range vs {
+			out[i] = Release{Version: v, Retracted: goproxy.Retracted(rs, v)}
+		}
+		return out, nil
+	})
+}
+
+// JSON returns a source that fetches u and reads a JSON array whose
+// elements are either version strings or objects such as
+//
+//	{"version": "1.4.0", "url": "https://...", "published": "2024-05-01T00:00:00Z", "prerelease": false}
+//
+// Versions are parsed with semver.ParseTolerant; elements that do not
+// parse are skipped. A nil client means http.DefaultClient.
+func JSON(hc *http.Client, u string) Source {
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	return SourceFunc(func(ctx context.Context) ([]Release, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, fmt.Errorf("updatecheck: %v", err)
+		}
+		req.Header.Set("Accept", "application/json")
+		resp, err := hc.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("updatecheck: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("updatecheck: GET %s: %s", u, resp.Status)
+		}
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("updatecheck: reading %s: %v", u, err)
+		}
+		rs, err := parseJSON(body)
+		if err != nil {
+			return nil, fmt.Errorf("updatecheck: %s: %v", u, err)
+		}
+		return rs, nil
+	})
+}
+
+func parseJSON(body []byte) ([]Release, error) {
+	var elems []json.RawMessage
+	if err := json.Unmarshal(body, &elems); err != nil {
+		return nil, err
+	}
+	var out []Release
+	for _, e := range elems {
+		var obj struct {
+			Version    string    `json:"version"`
+			URL        string    `json:"url"`
+			Published  time.Time `json:"published"`
+			Prerelease bool      `json:"prerelease"`
+			Retracted  bool      `json:"retracted"`
+		}
+		if err := json.Unmarshal(e, &obj.Version); err != nil {
+			if err := json.Unmarshal(e, &obj); err != nil {
+				return nil, err
+			}
+		}
+		v, err := semver.ParseTolerant(obj.Version)
+		if err != nil {
+			continue
+		}
+		out = append(out, Release{Version: v, Prerelease: obj.Prerelease, Retracted: obj.Retracted, URL: obj.URL, Published: obj.Published})
+	}
+	return out, nil
+}
+
+// Option configures Check.
+type Option func(*config)
+
+type config struct {
+	pin        *semver.Constraint
+	prerelease bool
+}
+
+// Pin restricts updates to versions satisfying c, such as "^1" to stay
+// on major version 1. Under the npm pre-release rule only constraints
+// parsed with semver.IncludePrerelease admit pre-releases, even with
+// IncludePrerelease.
+func Pin(c semver.Constraint) Option {
+	return func(cfg *config) { cfg.pin = &c }
+}
+
+// IncludePrerelease offers pre-releases as updates. Without it only
+// stable releases are considered.
+func IncludePrerelease() Option {
+	return func(cfg *config) { cfg.prerelease = true }
+}
+
+// Result is the outcome of Check.
+type Result struct {
+	Current semver.Version
+	// Latest is the highest release that qualifies as an update
+	// candidate. It is the zero Release if none does.
+	Latest Release
+	// Available reports whether Latest has higher precedence than
+	// Current.
+	Available bool
+}
+
+// ErrNoRelease is returned by Check when the source lists no release
+// that qualifies.
+var ErrNoRelease = errors.New("updatecheck: no release found")
+
+// Check lists the releases of src and reports whether one newer than
+// current qualifies: it must not be retracted, must satisfy the Pin
+// constraint if any, and must be stable unless IncludePrerelease is
+// given. A release is stable if it has no SemVer pre-release and its
+// source does not label it a pre-release.
+func Check(ctx context.Context, src Source, current semver.Version, opts ...Option) (Result, error) {
+	var cfg config
+	for _, o := range opts {
+		o(&cfg)
+	}
+	rs, err := src.Releases(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	r := Result{Current: current}
+	found := false
+	for _, rel := range rs {
+		switch {
+		case rel.Retracted:
+			continue
+		case !cfg.prerelease && (rel.Prerelease || rel.Version.Prerelease != ""):
+			continue
+		case cfg.pin != nil && !cfg.pin.Check(rel.Version):
+			continue
+		}
+		if !found || rel.Version.Compare(r.Latest.Version) > 0 {
+			r.Latest, found = rel, true
+		}
+	}
+	if !found {
+		return Result{}, ErrNoRelease
+	}
+	r.Available = current.Compare(r.Latest.Version) < 0
+	return r, nil
+}
+
+// BuildVersion returns the version of the running binary's main module as
+// recorded by the go command, such as v1.4.0 for a binary installed with
+// "go install example.com/tool@v1.4.0". ok is false for binaries built
+// from a working tree, which report "(devel)", or without build
+// information.
+func BuildVersion() (v semver.Version, ok bool) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return semver.Version{}, false
+	}
+	v, err := semver.Parse(info.Main.Version)
+	return v, err == nil
+}
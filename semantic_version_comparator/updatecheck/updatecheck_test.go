@@ -0,0 +1,166 @@
+package updatecheck
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/ghrelease"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/goproxy"
+	"github.com/ketiyohannes/sample-dataset/semantic_version_comparator/semver"
+)
+
+func releases(rs ...Release) Source {
+	return SourceFunc(func(context.Context) ([]Release, error) { return rs, nil })
+}
+
+func rel(v string) Release { return Release{Version: semver.MustParse(v)} }
+
+func TestCheck(t *testing.T) {
+	labelled := rel("1.5.0")
+	labelled.Prerelease = true
+	retracted := rel("1.6.0")
+	retracted.Retracted = true
+	src := releases(rel("1.0.0"), rel("1.2.0"), rel("2.0.0-rc.1"), labelled, retracted, rel("1.4.0"), rel("2.1.0-beta"))
+	tests := []struct {
+		name    string
+		current string
+		opts    []Option
+		latest  string // "" for ErrNoRelease
+		avail   bool
+	}{
+		{"stable", "1.0.0", nil, "1.4.0", true},
+		{"up to date", "1.4.0", nil, "1.4.0", false},
+		{"ahead", "1.9.0", nil, "1.4.0", false},
+		{"pre-releases", "1.0.0", []Option{IncludePrerelease()}, "2.1.0-beta", true},
+		{"pinned", "1.0.0", []Option{Pin(semver.MustParseConstraint("~1.2"))}, "1.2.0", true},
+		{"pinned with pre-releases", "1.0.0", []Option{
+			IncludePrerelease(), Pin(semver.MustParseConstraint(">=1.0.0 <2.0.0-0", semver.IncludePrerelease())),
+		}, "1.5.0", true},
+		{"nothing qualifies", "1.0.0", []Option{Pin(semver.MustParseConstraint(">=3.0.0"))}, "", false},
+	}
+	for _, tt := range tests {
+		r, err := Check(context.Background(), src, semver.MustParse(tt.current), tt.opts...)
+		if tt.latest == "" {
+			if !errors.Is(err, ErrNoRelease) {
+				t.Errorf("%s: Check = %+v, %v; want ErrNoRelease", tt.name, r, err)
+			}
+			continue
+		}
+		if err != nil || r.Latest.Version.String() != tt.latest || r.Available != tt.avail || r.Current.String() != tt.current {
+			t.Errorf("%s: Check(%s) = %s available=%v, %v; want %s available=%v", tt.name, tt.current, r.Latest.Version, r.Available, err, tt.latest, tt.avail)
+		}
+	}
+	if _, err := Check(context.Background(), releases(), semver.MustParse("1.0.0")); !errors.Is(err, ErrNoRelease) {
+		t.Errorf("Check of an empty source error = %v; want ErrNoRelease", err)
+	}
+	failing := SourceFunc(func(context.Context) ([]Release, error) { return nil, errors.New("offline") })
+	if _, err := Check(context.Background(), failing, semver.MustParse("1.0.0")); err == nil || err.Error() != "offline" {
+		t.Errorf("Check of a failing source error = %v; want offline", err)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "application/json" {
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+		switch r.URL.Path {
+		case "/releases.json":
+			w.Write([]byte(`["1.0.0", "v1.1", "nightly",
+				{"version": "1.2.0", "url": "https://example.com/1.2.0", "published": "2024-05-01T00:00:00Z"},
+				{"version": "1.3.0", "prerelease": true},
+				{"version": "1.4.0", "retracted": true}]`))
+		case "/bad.json":
+			w.Write([]byte(`{"version": "1.0.0"}`))
+		case "/bad-element.json":
+			w.Write([]byte(`[1]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	rs, err := JSON(nil, srv.URL+"/releases.json").Releases(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Release{
+		rel("1.0.0"), rel("1.1.0"),
+		{Version: semver.MustParse("1.2.0"), URL: "https://example.com/1.2.0"},
+		{Version: semver.MustParse("1.3.0"), Prerelease: true},
+		{Version: semver.MustParse("1.4.0"), Retracted: true},
+	}
+	if len(rs) != len(want) {
+		t.Fatalf("Releases = %+v; want %d releases", rs, len(want))
+	}
+	for i, r := range rs {
+		w := want[i]
+		if r.Version.Compare(w.Version) != 0 || r.URL != w.URL || r.Prerelease != w.Prerelease || r.Retracted != w.Retracted {
+			t.Errorf("Releases[%d] = %+v; want %+v", i, r, w)
+		}
+	}
+	if rs[2].Published.Year() != 2024 {
+		t.Errorf("Releases[2].Published = %v; want 2024-05-01", rs[2].Published)
+	}
+	for _, path := range []string{"/bad.json", "/bad-element.json", "/missing.json"} {
+		if _, err := JSON(srv.Client(), srv.URL+path).Releases(context.Background()); err == nil {
+			t.Errorf("JSON(%s) succeeded", path)
+		}
+	}
+}
+
+func TestGitHub(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/tool/releases" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`[
+			{"tag_name": "v1.0.0", "html_url": "https://github.com/owner/tool/releases/v1.0.0"},
+			{"tag_name": "v1.1.0", "draft": true},
+			{"tag_name": "v1.2.0", "prerelease": true}
+		]`))
+	}))
+	defer srv.Close()
+	src := GitHub(&ghrelease.Client{BaseURL: srv.URL}, "owner/tool")
+	rs, err := src.Releases(context.Background())
+	if err != nil || len(rs) != 2 || rs[0].URL == "" || !rs[1].Prerelease {
+		t.Fatalf("Releases = %+v, %v; want v1.0.0 and the pre-release v1.2.0", rs, err)
+	}
+	r, err := Check(context.Background(), src, semver.MustParse("0.9.0"))
+	if err != nil || r.Latest.Version.String() != "1.0.0" || !r.Available {
+		t.Errorf("Check = %+v, %v; want 1.0.0 available", r, err)
+	}
+	if _, err := GitHub(&ghrelease.Client{BaseURL: srv.URL}, "owner/missing").Releases(context.Background()); err == nil {
+		t.Error("Releases of a missing repository succeeded")
+	}
+}
+
+func TestGoProxy(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/example.com/tool/@v/list":
+			w.Write([]byte("v1.0.0\nv1.1.0\nv1.2.0\n"))
+		case "/example.com/tool/@v/v1.2.0.mod":
+			w.Write([]byte("module example.com/tool\n\nretract v1.2.0 // broken\n"))
+		case "/example.com/new/@v/list":
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	c := &goproxy.Client{Proxy: srv.URL}
+	r, err := Check(context.Background(), GoProxy(c, "example.com/tool"), semver.MustParse("v1.0.0"))
+	if err != nil || r.Latest.Version.String() != "1.1.0" || !r.Available {
+		t.Errorf("Check = %+v, %v; want 1.1.0 available", r, err)
+	}
+	if rs, err := GoProxy(c, "example.com/new").Releases(context.Background()); err != nil || len(rs) != 0 {
+		t.Errorf("Releases of a module without versions = %+v, %v; want none", rs, err)
+	}
+	if _, err := GoProxy(c, "example.com/missing").Releases(context.Background()); !errors.Is(err, goproxy.ErrNotFound) {
+		t.Errorf("Releases of a missing module error = %v; want goproxy.ErrNotFound", err)
+	}
+}